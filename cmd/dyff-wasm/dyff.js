@@ -0,0 +1,50 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// JavaScript wrapper for dyff.wasm, requires the wasm_exec.js of the Go
+// release that was used to build the module to be loaded beforehand.
+
+// load instantiates the module, source is either the URL of dyff.wasm or its
+// content as a BufferSource
+export async function load(source) {
+  const go = new Go();
+
+  const { instance } = typeof source === "string"
+    ? await WebAssembly.instantiateStreaming(fetch(source), go.importObject)
+    : await WebAssembly.instantiate(source, go.importObject);
+
+  go.run(instance);
+}
+
+// compare returns the report of the differences between the two YAML or JSON
+// documents, the JSON report is returned as an object, other output styles
+// as a string
+//
+// Supported options are output (json, human, brief), ignoreOrderChanges,
+// ignoreWhitespaceChanges, detectKubernetes, and detectRenames.
+export function compare(from, to, options = {}) {
+  const result = globalThis.dyffCompare(from, to, options);
+  if (result.error !== undefined) {
+    throw new Error(result.error);
+  }
+
+  const output = options.output ?? "json";
+  return output === "json" ? JSON.parse(result.report) : result.report;
+}
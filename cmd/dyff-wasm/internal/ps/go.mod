@@ -0,0 +1,3 @@
+module github.com/mitchellh/go-ps
+
+go 1.23.0
@@ -0,0 +1,45 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ps replaces github.com/mitchellh/go-ps in the WebAssembly build,
+// since it does not support js/wasm and is only used by the terminal detection
+// of the gonvenience packages, which does not apply to the browser anyway.
+package ps
+
+import "errors"
+
+var errUnsupported = errors.New("processes are not supported in WebAssembly")
+
+// Process is the process information of github.com/mitchellh/go-ps
+type Process interface {
+	Pid() int
+	PPid() int
+	Executable() string
+}
+
+// Processes always fails, since there are no processes in WebAssembly
+func Processes() ([]Process, error) {
+	return nil, errUnsupported
+}
+
+// FindProcess always fails, since there are no processes in WebAssembly
+func FindProcess(int) (Process, error) {
+	return nil, errUnsupported
+}
@@ -0,0 +1,145 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build js && wasm
+
+// Command dyff-wasm exposes the comparison to JavaScript, so that reports can
+// be created in the browser without a server. It registers a global function
+// dyffCompare(from, to, options), which returns an object with either the
+// rendered report or an error message, see dyff.js for the JavaScript wrapper.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"syscall/js"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/ytbx"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+func main() {
+	bunt.SetColorSettings(bunt.OFF, bunt.OFF)
+
+	js.Global().Set("dyffCompare", js.FuncOf(compare))
+
+	// Keep the module running so that the function stays callable
+	select {}
+}
+
+func compare(_ js.Value, args []js.Value) any {
+	if len(args) < 2 {
+		return result("", fmt.Errorf("expected two input documents, but got %d arguments", len(args)))
+	}
+
+	var options = js.Undefined()
+	if len(args) > 2 {
+		options = args[2]
+	}
+
+	report, err := render(args[0].String(), args[1].String(), options)
+	return result(report, err)
+}
+
+func result(report string, err error) map[string]any {
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+
+	return map[string]any{"report": report}
+}
+
+func render(fromData string, toData string, options js.Value) (string, error) {
+	from, err := loadInput("from", fromData)
+	if err != nil {
+		return "", err
+	}
+
+	to, err := loadInput("to", toData)
+	if err != nil {
+		return "", err
+	}
+
+	report, err := dyff.CompareInputFiles(from, to,
+		dyff.IgnoreOrderChanges(boolOption(options, "ignoreOrderChanges")),
+		dyff.IgnoreWhitespaceChanges(boolOption(options, "ignoreWhitespaceChanges")),
+		dyff.KubernetesEntityDetection(boolOption(options, "detectKubernetes")),
+		dyff.DetectRenames(boolOption(options, "detectRenames")),
+	)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to compare input files: %w", err)
+	}
+
+	var reportWriter dyff.ReportWriter
+	switch output := stringOption(options, "output", "json"); output {
+	case "json":
+		reportWriter = &dyff.JSONReport{Report: report}
+
+	case "human":
+		reportWriter = &dyff.HumanReport{
+			Report:               report,
+			Indent:               2,
+			MinorChangeThreshold: 0.1,
+			NoTableStyle:         true,
+			OmitHeader:           true,
+		}
+
+	case "brief":
+		reportWriter = &dyff.BriefReport{Report: report}
+
+	default:
+		return "", fmt.Errorf("unknown output style %s, supported styles are json, human, and brief", output)
+	}
+
+	var buf bytes.Buffer
+	if err := reportWriter.WriteReport(&buf); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func loadInput(location string, data string) (ytbx.InputFile, error) {
+	documents, err := ytbx.LoadDocuments([]byte(data))
+	if err != nil {
+		return ytbx.InputFile{}, fmt.Errorf("failed to load %s: %w", location, err)
+	}
+
+	return ytbx.InputFile{Location: location, Documents: documents}, nil
+}
+
+func boolOption(options js.Value, name string) bool {
+	if options.Type() != js.TypeObject {
+		return false
+	}
+
+	return options.Get(name).Truthy()
+}
+
+func stringOption(options js.Value, name string, fallback string) string {
+	if options.Type() != js.TypeObject || options.Get(name).Type() != js.TypeString {
+		return fallback
+	}
+
+	return options.Get(name).String()
+}
@@ -0,0 +1,206 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gonvenience/bunt"
+)
+
+var escapeSequence = regexp.MustCompile(`^\x1b\[[\d;]*m`)
+
+type alignBlock struct {
+	column int
+	lines  []int
+	widths []int
+}
+
+// alignKeys pads the keys within each mapping block of the provided YAML
+// text so that all values of the block start in the same column. The text
+// can contain ANSI escape sequences and indent helper lines.
+func alignKeys(text string) string {
+	lines := strings.Split(text, "\n")
+	padding := make([]int, len(lines))
+
+	var stack []*alignBlock
+	var closeBlocks = func(column int) {
+		for len(stack) > 0 && stack[len(stack)-1].column >= column {
+			block := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			var maxWidth int
+			for _, width := range block.widths {
+				maxWidth = max(maxWidth, width)
+			}
+
+			for i, idx := range block.lines {
+				padding[idx] = maxWidth - block.widths[i]
+			}
+		}
+	}
+
+	var blockScalarColumn = -1
+	for idx, line := range lines {
+		plain := bunt.RemoveAllEscapeSequences(line)
+		if strings.TrimSpace(strings.ReplaceAll(plain, "│", "")) == "" {
+			continue
+		}
+
+		column, rest := indentation(plain)
+		if blockScalarColumn >= 0 {
+			if column > blockScalarColumn {
+				continue
+			}
+
+			blockScalarColumn = -1
+		}
+
+		var newEntry bool
+		for strings.HasPrefix(rest, "- ") {
+			rest = rest[2:]
+			column += 2
+			newEntry = true
+		}
+
+		if rest == "---" || rest == "..." {
+			closeBlocks(0)
+			continue
+		}
+
+		width, value, ok := splitKey(rest)
+		if !ok {
+			closeBlocks(column + 1)
+			continue
+		}
+
+		if newEntry {
+			closeBlocks(column)
+		} else {
+			closeBlocks(column + 1)
+		}
+
+		if len(stack) == 0 || stack[len(stack)-1].column != column {
+			stack = append(stack, &alignBlock{column: column})
+		}
+
+		switch {
+		case strings.HasPrefix(value, "|"), strings.HasPrefix(value, ">"):
+			blockScalarColumn = column
+
+		case value == "":
+			continue
+		}
+
+		block := stack[len(stack)-1]
+		block.lines = append(block.lines, idx)
+		block.widths = append(block.widths, width)
+	}
+
+	closeBlocks(0)
+
+	for idx, pad := range padding {
+		if pad > 0 {
+			lines[idx] = insertAfterKey(lines[idx], pad)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// indentation returns the number of runes used for indentation (including
+// indent helper lines) and the remaining text
+func indentation(plain string) (int, string) {
+	var column int
+	for i, r := range plain {
+		if r != ' ' && r != '│' {
+			return column, plain[i:]
+		}
+
+		column++
+	}
+
+	return column, ""
+}
+
+// splitKey returns the width of the key including the colon and the value
+// following it, if the provided text is a mapping entry
+func splitKey(text string) (int, string, bool) {
+	var end int
+	switch {
+	case strings.HasPrefix(text, `"`), strings.HasPrefix(text, `'`):
+		closing := strings.IndexByte(text[1:], text[0])
+		if closing < 0 {
+			return 0, "", false
+		}
+
+		end = closing + 2
+		if !strings.HasPrefix(text[end:], ":") {
+			return 0, "", false
+		}
+
+	default:
+		end = strings.Index(text, ": ")
+		if end < 0 {
+			if !strings.HasSuffix(text, ":") {
+				return 0, "", false
+			}
+
+			end = len(text) - 1
+		}
+	}
+
+	return utf8.RuneCountInString(text[:end+1]), strings.TrimSpace(text[end+1:]), true
+}
+
+// insertAfterKey inserts the padding right after the colon that follows the
+// key, while skipping over any ANSI escape sequences in the line
+func insertAfterKey(line string, padding int) string {
+	plain := bunt.RemoveAllEscapeSequences(line)
+	column, rest := indentation(plain)
+	for strings.HasPrefix(rest, "- ") {
+		rest = rest[2:]
+		column += 2
+	}
+
+	width, _, _ := splitKey(rest)
+	target := column + width
+
+	var count int
+	for i := 0; i < len(line); {
+		if match := escapeSequence.FindString(line[i:]); match != "" {
+			i += len(match)
+			continue
+		}
+
+		if count == target {
+			return line[:i] + strings.Repeat(" ", padding) + line[i:]
+		}
+
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+		count++
+	}
+
+	return line
+}
@@ -0,0 +1,168 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gonvenience/neat"
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type benchCmdOptions struct {
+	iterations int
+}
+
+var benchCmdSettings benchCmdOptions
+
+var benchDefaults = benchCmdOptions{
+	iterations: 10,
+}
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench [flags] <from> <to>",
+	Short: "Measure how long it takes to compare input files",
+	Long: `
+Loads, compares, and renders the differences of the input files multiple
+times and reports how long each of these phases took. The report is not
+printed, but rendered with the configured output style.
+`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if benchCmdSettings.iterations < 1 {
+			return fmt.Errorf("invalid number of iterations %d, at least one iteration is required", benchCmdSettings.iterations)
+		}
+
+		options, err := reportOptions.compareOptions()
+		if err != nil {
+			return err
+		}
+
+		var phases = []*benchPhase{{name: "parse"}, {name: "compare"}, {name: "render"}}
+		var differences int
+		for i := 0; i < benchCmdSettings.iterations; i++ {
+			var from, to ytbx.InputFile
+			if err := phases[0].measure(func() (err error) {
+				from, to, err = dyff.LoadFiles(args[0], args[1])
+				return err
+			}); err != nil {
+				return fmt.Errorf("failed to load input files: %w", err)
+			}
+
+			var report dyff.Report
+			if err := phases[1].measure(func() (err error) {
+				ctx, cancel := compareContext()
+				defer cancel()
+
+				report, err = dyff.CompareInputFilesContext(ctx, from, to, options...)
+				return err
+			}); err != nil {
+				return compareError(err)
+			}
+
+			report = reportOptions.filterReport(report)
+			differences = len(report.Diffs)
+
+			if err := phases[2].measure(func() error {
+				reportWriter, err := newReportWriter(cmd, report)
+				if err != nil {
+					return err
+				}
+
+				return reportWriter.WriteReport(io.Discard)
+			}); err != nil {
+				return fmt.Errorf("failed to render report: %w", err)
+			}
+		}
+
+		var table = [][]string{{"phase", "total", "average", "minimum", "maximum"}}
+		for _, phase := range phases {
+			table = append(table, phase.row(benchCmdSettings.iterations))
+		}
+
+		out, err := neat.Table(table, neat.AlignRight(1, 2, 3, 4))
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("compared %s with %s %d times, found %d differences\n\n%s",
+			humanReadableFilename(args[0]),
+			humanReadableFilename(args[1]),
+			benchCmdSettings.iterations,
+			differences,
+			out,
+		)
+
+		return nil
+	},
+}
+
+// benchPhase collects the durations of one phase of all iterations
+type benchPhase struct {
+	name      string
+	durations []time.Duration
+}
+
+func (phase *benchPhase) measure(f func() error) error {
+	start := time.Now()
+	if err := f(); err != nil {
+		return err
+	}
+
+	phase.durations = append(phase.durations, time.Since(start))
+	return nil
+}
+
+func (phase *benchPhase) row(iterations int) []string {
+	var total, minimum, maximum time.Duration
+	for i, duration := range phase.durations {
+		total += duration
+		if i == 0 || duration < minimum {
+			minimum = duration
+		}
+
+		if duration > maximum {
+			maximum = duration
+		}
+	}
+
+	var format = func(d time.Duration) string {
+		return d.Round(time.Microsecond).String()
+	}
+
+	return []string{phase.name, format(total), format(total / time.Duration(iterations)), format(minimum), format(maximum)}
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+
+	benchCmd.Flags().SortFlags = false
+
+	benchCmd.Flags().IntVarP(&benchCmdSettings.iterations, "iterations", "n", benchDefaults.iterations, "number of times the input files are compared")
+
+	applyReportOptionsFlags(benchCmd)
+}
@@ -21,23 +21,60 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
 
 	"github.com/gonvenience/ytbx"
 	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
 
 	"github.com/homeport/dyff/pkg/dyff"
 )
 
 type betweenCmdOptions struct {
 	swap                     bool
+	stream                   bool
+	streamList               bool
+	watch                    bool
+	resolveReferences        bool
+	sops                     bool
+	placeholders             string
+	varsFiles                []string
+	placeholderCommand       string
+	spruceOperators          string
+	helmValues               bool
+	helmValuesFrom           bool
 	translateListToDocuments bool
-	chroot                   string
+	chroot                   []string
+	documents                []string
 	chrootFrom               string
 	chrootTo                 string
+	fromEmpty                bool
+	toEmpty                  bool
+	remote                   string
+	fromFormat               string
+	toFormat                 string
+	fromFD                   int
+	toFD                     int
+	git                      bool
+	includeFiles             []string
+	excludeFiles             []string
 }
 
-var betweenCmdSettings betweenCmdOptions
+var betweenDefaults = betweenCmdOptions{
+	placeholders:    "keep",
+	spruceOperators: "keep",
+	fromFD:          -1,
+	toFD:            -1,
+}
+
+var betweenCmdSettings = betweenDefaults
 
 // betweenCmd represents the between command
 var betweenCmd = &cobra.Command{
@@ -46,77 +83,120 @@ var betweenCmd = &cobra.Command{
 	Long: `
 Compares differences between files and displays the delta. Supported input file
 types are: YAML (http://yaml.org/) and JSON (http://json.org/).
+
+With --git, the arguments are the ones that Git passes to an external diff
+command, so that Git shows the differences of YAML files using dyff:
+
+  # .gitattributes
+  *.yml diff=dyff
+
+  # .git/config
+  [diff "dyff"]
+    command = dyff between --git
+
+Setting the diff.external option to "dyff between --git" uses dyff for all
+files, which only works if all files in the repository are YAML or JSON.
+
+If both from and to are directories, the YAML and JSON files of both directory
+trees are paired by their relative path and compared one by one, and files
+that only exist in one of the directories are reported as added or removed.
+The files to compare can be selected using --include-files and --exclude-files.
 `,
-	Args:    cobra.ExactArgs(2),
+	Args: func(cmd *cobra.Command, args []string) error {
+		switch {
+		case betweenCmdSettings.git:
+			// Git passes two more arguments for renamed files
+			if len(args) != 7 && len(args) != 9 {
+				return fmt.Errorf("git mode expects the 7 or 9 arguments passed by Git to external diff commands, received %d", len(args))
+			}
+
+			return nil
+
+		case betweenCmdSettings.fromEmpty && betweenCmdSettings.fromFD >= 0:
+			return fmt.Errorf("incompatible flags: cannot use from empty flag in combination with from fd flag")
+
+		case betweenCmdSettings.toEmpty && betweenCmdSettings.toFD >= 0:
+			return fmt.Errorf("incompatible flags: cannot use to empty flag in combination with to fd flag")
+
+		case betweenCmdSettings.fromEmpty && betweenCmdSettings.toEmpty:
+			// Fails with a dedicated error message when running the command
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+
+		var expected = 2
+		for _, given := range []bool{
+			betweenCmdSettings.fromEmpty || betweenCmdSettings.fromFD >= 0,
+			betweenCmdSettings.toEmpty || betweenCmdSettings.toFD >= 0,
+		} {
+			if given {
+				expected--
+			}
+		}
+
+		return cobra.ExactArgs(expected)(cmd, args)
+	},
 	Aliases: []string{"bw"},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var fromLocation, toLocation string
-		if betweenCmdSettings.swap {
-			fromLocation = args[1]
-			toLocation = args[0]
-		} else {
-			fromLocation = args[0]
-			toLocation = args[1]
+		if betweenCmdSettings.git {
+			return betweenGit(cmd, args)
 		}
 
-		from, to, err := ytbx.LoadFiles(fromLocation, toLocation)
-		if err != nil {
-			return fmt.Errorf("failed to load input files: %w", err)
+		// Inherited file descriptors are used like any other input location
+		if betweenCmdSettings.fromFD >= 0 {
+			args = append([]string{fdLocation(betweenCmdSettings.fromFD)}, args...)
 		}
 
-		// If the main change root flag is set, this (re-)sets the individual change roots of the two input files
-		if betweenCmdSettings.chroot != "" {
-			betweenCmdSettings.chrootFrom = betweenCmdSettings.chroot
-			betweenCmdSettings.chrootTo = betweenCmdSettings.chroot
+		if betweenCmdSettings.toFD >= 0 {
+			args = append(args, fdLocation(betweenCmdSettings.toFD))
 		}
 
-		// Change root of 'from' input file if change root flag for 'from' is set
-		if betweenCmdSettings.chrootFrom != "" {
-			if err = dyff.ChangeRoot(&from, betweenCmdSettings.chrootFrom, reportOptions.useGoPatchPaths, betweenCmdSettings.translateListToDocuments); err != nil {
-				return fmt.Errorf("failed to change root of %s to path %s: %w", from.Location, betweenCmdSettings.chrootFrom, err)
-			}
+		if betweenCmdSettings.remote != "" {
+			return betweenRemote(cmd, args)
 		}
 
-		// Change root of 'to' input file if change root flag for 'to' is set
-		if betweenCmdSettings.chrootTo != "" {
-			if err = dyff.ChangeRoot(&to, betweenCmdSettings.chrootTo, reportOptions.useGoPatchPaths, betweenCmdSettings.translateListToDocuments); err != nil {
-				return fmt.Errorf("failed to change root of %s to path %s: %w", to.Location, betweenCmdSettings.chrootTo, err)
-			}
+		if betweenCmdSettings.fromEmpty || betweenCmdSettings.toEmpty {
+			return betweenEmpty(cmd, args[0])
 		}
 
-		report, err := dyff.CompareInputFiles(from, to,
-			dyff.IgnoreOrderChanges(reportOptions.ignoreOrderChanges),
-			dyff.IgnoreWhitespaceChanges(reportOptions.ignoreWhitespaceChanges),
-			dyff.KubernetesEntityDetection(reportOptions.kubernetesEntityDetection),
-			dyff.AdditionalIdentifiers(reportOptions.additionalIdentifiers...),
-			dyff.DetectRenames(reportOptions.detectRenames),
-		)
+		// Directories of kubectl diff are loaded as one input file each, which
+		// matches the Kubernetes resources of all files by their name
+		if !kubectlDiff && isDirectory(args[0]) && isDirectory(args[1]) {
+			return betweenDirectories(cmd, args[0], args[1])
+		}
 
+		options, err := reportOptions.compareOptions()
 		if err != nil {
-			return fmt.Errorf("failed to compare input files: %w", err)
+			return err
 		}
 
-		if reportOptions.filters != nil {
-			report = report.Filter(reportOptions.filters...)
+		var progress *progressPrinter
+		if reportOptions.progress {
+			progress = newProgressPrinter(os.Stderr)
+			options = append(options, dyff.ProgressHandler(progress.update))
 		}
 
-		if reportOptions.filterRegexps != nil {
-			report = report.FilterRegexp(reportOptions.filterRegexps...)
+		if betweenCmdSettings.stream || betweenCmdSettings.streamList {
+			ctx, cancel := compareContext()
+			defer cancel()
+
+			return compareStreams(ctx, args[0], args[1], options, progress)
 		}
 
-		if reportOptions.excludes != nil {
-			report = report.Exclude(reportOptions.excludes...)
+		if betweenCmdSettings.watch {
+			return watch(cmd, args[0], args[1], options, progress)
 		}
 
-		if reportOptions.excludeRegexps != nil {
-			report = report.ExcludeRegexp(reportOptions.excludeRegexps...)
+		from, to, err := loadInputFiles(args[0], args[1])
+		if err != nil {
+			return fmt.Errorf("failed to load input files: %w", err)
 		}
 
-		if reportOptions.ignoreValueChanges {
-			report = report.IgnoreValueChanges()
+		report, err := compareInputFiles(from, to, options, progress)
+		if err != nil {
+			return err
 		}
 
-		return writeReport(cmd, report)
+		return writeReport(cmd, reportOptions.filterReport(report))
 	},
 }
 
@@ -128,9 +208,687 @@ func init() {
 	applyReportOptionsFlags(betweenCmd)
 
 	// Input documents modification flags
-	betweenCmd.Flags().BoolVar(&betweenCmdSettings.swap, "swap", false, "Swap 'from' and 'to' for comparison")
-	betweenCmd.Flags().StringVar(&betweenCmdSettings.chroot, "chroot", "", "change the root level of the input file to another point in the document")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.swap, "swap", false, "swap 'from' and 'to' after loading the input files")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.stream, "stream", false, "compare the documents one by one while reading the input files and print differences as soon as they are found, Kubernetes resources are matched by their identity like without streaming")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.streamList, "stream-list", false, "like --stream, but for input files with one list at the top level (e.g. a JSON array), whose entries are compared one by one")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.watch, "watch", false, "keep watching the input files and compare them again as soon as one of them changes")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.resolveReferences, "resolve-references", false, "replace JSON references ($ref) and !include tags with the content they refer to")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.sops, "sops", false, "decrypt SOPS encrypted documents in memory using the sops command before comparing them")
+	betweenCmd.Flags().StringVar(&betweenCmdSettings.placeholders, "placeholders", betweenDefaults.placeholders, "how to handle ((placeholders)) and vault: references, supported modes: keep (compare as text), symbolic (compare by name), or resolve (look up values)")
+	betweenCmd.Flags().StringArrayVar(&betweenCmdSettings.varsFiles, "vars-file", nil, "look up placeholder values in a YAML file, for example a BOSH vars store")
+	betweenCmd.Flags().StringVar(&betweenCmdSettings.placeholderCommand, "placeholder-command", "", "look up placeholder values using an external command that gets the reference as last argument and writes the value to standard output")
+	betweenCmd.Flags().StringVar(&betweenCmdSettings.spruceOperators, "spruce-operators", betweenDefaults.spruceOperators, "how to handle spruce operators like (( grab meta.name )), supported modes: keep (compare as text), symbolic (compare ignoring whitespace), or evaluate (grab, concat, join, empty, and prune)")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.helmValues, "helm-values", false, "only compare the Helm values of Flux HelmRelease and Argo CD Application resources, with embedded values parsed as YAML")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.helmValuesFrom, "helm-values-from", false, "like --helm-values, but with the values referenced in valuesFrom merged in using the ConfigMaps and Secrets of the same input file")
+	betweenCmd.Flags().StringVar(&betweenCmdSettings.fromFormat, "from-format", "", "parse the from input file in the given format instead of detecting it, supported formats: yaml, json, or toml")
+	betweenCmd.Flags().StringVar(&betweenCmdSettings.toFormat, "to-format", "", "parse the to input file in the given format instead of detecting it, supported formats: yaml, json, or toml")
+	betweenCmd.Flags().IntVar(&betweenCmdSettings.fromFD, "from-fd", betweenDefaults.fromFD, "read the from input file from the given inherited file descriptor instead of a location argument")
+	betweenCmd.Flags().IntVar(&betweenCmdSettings.toFD, "to-fd", betweenDefaults.toFD, "read the to input file from the given inherited file descriptor instead of a location argument")
+	betweenCmd.Flags().StringSliceVar(&betweenCmdSettings.documents, "documents", nil, "only load and compare the documents with the given numbers, for example 1,3-5")
+	betweenCmd.Flags().StringSliceVar(&betweenCmdSettings.chroot, "chroot", nil, "change the root level of the input file to another point in the document, use multiple times to compare multiple subtrees")
 	betweenCmd.Flags().StringVar(&betweenCmdSettings.chrootFrom, "chroot-of-from", "", "only change the root level of the from input file")
 	betweenCmd.Flags().StringVar(&betweenCmdSettings.chrootTo, "chroot-of-to", "", "only change the root level of the to input file")
 	betweenCmd.Flags().BoolVar(&betweenCmdSettings.translateListToDocuments, "chroot-list-to-documents", false, "in case the change root points to a list, treat this list as a set of documents and not as the list itself")
+	betweenCmd.Flags().StringSliceVar(&betweenCmdSettings.includeFiles, "include-files", nil, "when comparing directories, only compare the files that match the given glob patterns (default is all YAML and JSON files)")
+	betweenCmd.Flags().StringSliceVar(&betweenCmdSettings.excludeFiles, "exclude-files", nil, "when comparing directories, do not compare the files that match the given glob patterns")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.fromEmpty, "from-empty", false, "compare the only input file against an empty from document, which reports everything as added")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.toEmpty, "to-empty", false, "compare the only input file against an empty to document, which reports everything as removed")
+	betweenCmd.Flags().BoolVar(&betweenCmdSettings.git, "git", false, "accept the arguments that Git passes to external diff commands, to be used as diff driver in the Git configuration")
+	betweenCmd.Flags().StringVar(&betweenCmdSettings.remote, "remote", "", "send the input files to a dyff serve daemon at the given host:port for the comparison and render the returned report locally")
+
+	for _, name := range []string{"chroot", "chroot-of-from", "chroot-of-to"} {
+		_ = betweenCmd.RegisterFlagCompletionFunc(name, completePaths)
+	}
+}
+
+// betweenEmpty compares the input file against empty documents, either as
+// the to input file (from empty) or as the from input file (to empty)
+func betweenEmpty(cmd *cobra.Command, location string) error {
+	switch {
+	case betweenCmdSettings.fromEmpty && betweenCmdSettings.toEmpty:
+		return fmt.Errorf("incompatible flags: cannot use from empty flag in combination with to empty flag")
+
+	case betweenCmdSettings.stream || betweenCmdSettings.streamList:
+		return fmt.Errorf("incompatible flags: cannot use stream flag in combination with from empty or to empty flag")
+
+	case betweenCmdSettings.watch:
+		return fmt.Errorf("incompatible flags: cannot use watch flag in combination with from empty or to empty flag")
+	}
+
+	options, err := reportOptions.compareOptions()
+	if err != nil {
+		return err
+	}
+
+	format := betweenCmdSettings.fromFormat
+	if betweenCmdSettings.fromEmpty {
+		format = betweenCmdSettings.toFormat
+	}
+
+	inputFile, err := loadInputFile(location, format)
+	if err != nil {
+		return fmt.Errorf("failed to load input files: %w", err)
+	}
+
+	from, to := emptyInputFile(inputFile), inputFile
+	if betweenCmdSettings.toEmpty {
+		from, to = inputFile, emptyInputFile(inputFile)
+	}
+
+	report, err := compareInputFiles(from, to, options, nil)
+	if err != nil {
+		return err
+	}
+
+	return writeReport(cmd, reportOptions.filterReport(report))
+}
+
+// betweenGit compares the old and new version of a file using the arguments
+// Git passes to external diff commands: path, old-file, old-hex, old-mode,
+// new-file, new-hex, new-mode, and for renames the new path and the
+// similarity header. Added and removed files are compared against empty
+// documents, and the input files are named after the path in the repository.
+func betweenGit(cmd *cobra.Command, args []string) error {
+	switch {
+	case betweenCmdSettings.fromEmpty || betweenCmdSettings.toEmpty || betweenCmdSettings.fromFD >= 0 || betweenCmdSettings.toFD >= 0:
+		return fmt.Errorf("incompatible flags: cannot use git flag in combination with from/to empty or from/to fd flags")
+
+	case betweenCmdSettings.stream || betweenCmdSettings.streamList || betweenCmdSettings.watch || betweenCmdSettings.remote != "":
+		return fmt.Errorf("incompatible flags: cannot use git flag in combination with stream, watch, or remote flag")
+
+	case reportOptions.setsExitCode():
+		// Git stops showing differences once an external diff command fails
+		return fmt.Errorf("incompatible flags: cannot use git flag in combination with flags that set the exit code")
+	}
+
+	oldPath, newPath := args[0], args[0]
+	if len(args) == 9 {
+		newPath = args[7]
+	}
+
+	options, err := reportOptions.compareOptions()
+	if err != nil {
+		return err
+	}
+
+	var from, to ytbx.InputFile
+	if args[1] != os.DevNull {
+		if from, err = loadInputFile(args[1], betweenCmdSettings.fromFormat); err != nil {
+			return fmt.Errorf("failed to load input files: %w", err)
+		}
+	}
+
+	if args[4] != os.DevNull {
+		if to, err = loadInputFile(args[4], betweenCmdSettings.toFormat); err != nil {
+			return fmt.Errorf("failed to load input files: %w", err)
+		}
+	}
+
+	switch {
+	case args[1] == os.DevNull:
+		from = emptyInputFile(to)
+
+	case args[4] == os.DevNull:
+		to = emptyInputFile(from)
+	}
+
+	from.Location, to.Location = "a/"+oldPath, "b/"+newPath
+
+	report, err := compareInputFiles(from, to, options, nil)
+	if err != nil {
+		return err
+	}
+
+	return writeReport(cmd, reportOptions.filterReport(report))
+}
+
+// emptyDocumentLocation is the location of input files created by emptyInputFile
+const emptyDocumentLocation = "empty document"
+
+// emptyInputFile returns an input file with an empty document for each
+// document of the given input file, which is an empty map or an empty list
+// depending on the respective document
+func emptyInputFile(inputFile ytbx.InputFile) ytbx.InputFile {
+	var documents = make([]*yamlv3.Node, len(inputFile.Documents))
+	for i, document := range inputFile.Documents {
+		var kind = yamlv3.MappingNode
+		var tag = "!!map"
+		if len(document.Content) > 0 && document.Content[0].Kind == yamlv3.SequenceNode {
+			kind, tag = yamlv3.SequenceNode, "!!seq"
+		}
+
+		documents[i] = &yamlv3.Node{
+			Kind:    yamlv3.DocumentNode,
+			Content: []*yamlv3.Node{{Kind: kind, Tag: tag}},
+		}
+	}
+
+	return ytbx.InputFile{
+		Location:  emptyDocumentLocation,
+		Documents: documents,
+	}
+}
+
+// isEmptyInputFile returns whether the input file was created by
+// emptyInputFile, which has no paths that the root can be changed to
+func isEmptyInputFile(inputFile ytbx.InputFile) bool {
+	return inputFile.Location == emptyDocumentLocation
+}
+
+// matchEmptyInputFile creates the empty input file again for the documents of
+// the other input file, after the root of the other one was changed
+func matchEmptyInputFile(from *ytbx.InputFile, to *ytbx.InputFile) {
+	switch {
+	case isEmptyInputFile(*from) && !isEmptyInputFile(*to):
+		*from = emptyInputFile(*to)
+
+	case isEmptyInputFile(*to) && !isEmptyInputFile(*from):
+		*to = emptyInputFile(*from)
+	}
+}
+
+// loadInputFiles loads the input files, or only the selected documents of the
+// input files if a document selection is configured
+func loadInputFiles(fromLocation string, toLocation string) (ytbx.InputFile, ytbx.InputFile, error) {
+	from, err := loadInputFile(fromLocation, betweenCmdSettings.fromFormat)
+	if err != nil {
+		return ytbx.InputFile{}, ytbx.InputFile{}, err
+	}
+
+	to, err := loadInputFile(toLocation, betweenCmdSettings.toFormat)
+	if err != nil {
+		return ytbx.InputFile{}, ytbx.InputFile{}, err
+	}
+
+	return from, to, nil
+}
+
+// loadInputFile loads one input file, or only the selected documents of the
+// input file if a document selection is configured. Unless the format is
+// empty, the input is parsed in this format instead of detecting it.
+func loadInputFile(location string, format string) (ytbx.InputFile, error) {
+	var selection documentSelection
+	if len(betweenCmdSettings.documents) > 0 {
+		var err error
+		if selection, err = parseDocumentSelection(betweenCmdSettings.documents); err != nil {
+			return ytbx.InputFile{}, err
+		}
+	}
+
+	if format != "" || isPipe(location) || selection != nil {
+		return readInputFile(location, format, selection)
+	}
+
+	return dyff.LoadFile(location)
+}
+
+// loadWithInputLoader loads the location using the input loader, where the
+// data of the loader is parsed in the given format if one is set
+func loadWithInputLoader(loader dyff.InputLoader, location string, format string) (ytbx.InputFile, error) {
+	if format == "" {
+		return loader.Load(location)
+	}
+
+	dataLoader, ok := loader.(dyff.DataLoader)
+	if !ok {
+		return ytbx.InputFile{}, fmt.Errorf("the input loader of %s does not support to set the input format", location)
+	}
+
+	data, err := dataLoader.LoadData(location)
+	if err != nil {
+		return ytbx.InputFile{}, err
+	}
+
+	documents, err := dyff.LoadDocumentsWithFormat(data, format)
+	if err != nil {
+		return ytbx.InputFile{}, fmt.Errorf("unable to parse data from %s as %s: %w", location, format, err)
+	}
+
+	return ytbx.InputFile{Location: location, Documents: documents}, nil
+}
+
+// readInputFile reads the input file completely once and parses it in the
+// given format, or the detected format if none is given. This also works for
+// pipes, which can only be read once. Input files of registered loaders are
+// loaded by them as usual. With a document selection, only the selected
+// documents are kept, and only those are parsed for YAML input.
+func readInputFile(location string, format string, selection documentSelection) (ytbx.InputFile, error) {
+	if loader, ok := dyff.InputLoaderFor(location); ok {
+		inputFile, err := loadWithInputLoader(loader, location, format)
+		if err != nil || selection == nil {
+			return inputFile, err
+		}
+
+		return selectDocuments(inputFile, selection)
+	}
+
+	reader, err := openStream(location)
+	if err != nil {
+		return ytbx.InputFile{}, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return ytbx.InputFile{}, fmt.Errorf("failed to read %s: %w", humanReadableFilename(describeLocation(location)), err)
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 && isPipe(location) {
+		return ytbx.InputFile{}, fmt.Errorf("no data read from %s, the command writing into it might have failed", humanReadableFilename(describeLocation(location)))
+	}
+
+	var documents []*yamlv3.Node
+	switch strings.ToLower(format) {
+	case "":
+		if selection != nil {
+			return parseSelectedDocuments(location, data, selection)
+		}
+
+		documents, err = ytbx.LoadDocuments(data)
+		if err != nil {
+			return ytbx.InputFile{}, fmt.Errorf("unable to parse data from %s: %w", humanReadableFilename(describeLocation(location)), err)
+		}
+
+	case "yaml", "yml":
+		if selection != nil {
+			return parseSelectedDocuments(location, data, selection)
+		}
+
+		fallthrough
+
+	default:
+		documents, err = dyff.LoadDocumentsWithFormat(data, format)
+		if err != nil {
+			return ytbx.InputFile{}, fmt.Errorf("unable to parse data from %s as %s: %w", humanReadableFilename(describeLocation(location)), format, err)
+		}
+	}
+
+	var inputFile = ytbx.InputFile{Location: location, Documents: documents}
+	if selection == nil {
+		return inputFile, nil
+	}
+
+	return selectDocuments(inputFile, selection)
+}
+
+// compareInputFiles compares the loaded input files, which includes the
+// configured modifications like resolving references or changing the root
+func compareInputFiles(from ytbx.InputFile, to ytbx.InputFile, options []dyff.CompareOption, progress *progressPrinter) (dyff.Report, error) {
+	ctx, cancel := compareContext()
+	defer cancel()
+
+	if betweenCmdSettings.sops {
+		for _, inputFile := range []*ytbx.InputFile{&from, &to} {
+			if err := dyff.DecryptSOPS(inputFile); err != nil {
+				return dyff.Report{}, err
+			}
+		}
+	}
+
+	for _, inputFile := range []*ytbx.InputFile{&from, &to} {
+		switch strings.ToLower(betweenCmdSettings.spruceOperators) {
+		case "keep":
+			// Operators are compared as they are
+
+		case "symbolic":
+			dyff.NormalizeSpruceOperators(inputFile)
+
+		case "evaluate":
+			if err := dyff.EvaluateSpruceOperators(inputFile); err != nil {
+				return dyff.Report{}, err
+			}
+
+		default:
+			return dyff.Report{}, fmt.Errorf("unknown spruce operators mode %s, supported modes are keep, symbolic, and evaluate", betweenCmdSettings.spruceOperators)
+		}
+	}
+
+	resolver, err := placeholderResolver(betweenCmdSettings.placeholders, betweenCmdSettings.varsFiles, betweenCmdSettings.placeholderCommand)
+	if err != nil {
+		return dyff.Report{}, err
+	}
+
+	if resolver != nil {
+		for _, inputFile := range []*ytbx.InputFile{&from, &to} {
+			if err := dyff.InterpolatePlaceholders(inputFile, resolver); err != nil {
+				return dyff.Report{}, err
+			}
+		}
+	}
+
+	presets, err := lookupPresets(reportOptions.selectedPresets())
+	if err != nil {
+		return dyff.Report{}, err
+	}
+
+	resolveReferences := betweenCmdSettings.resolveReferences
+	for _, preset := range presets {
+		resolveReferences = resolveReferences || preset.resolveReferences
+	}
+
+	if resolveReferences {
+		for _, inputFile := range []*ytbx.InputFile{&from, &to} {
+			if err := dyff.ResolveReferences(inputFile); err != nil {
+				return dyff.Report{}, fmt.Errorf("failed to resolve references in %s: %w", inputFile.Location, err)
+			}
+		}
+	}
+
+	if betweenCmdSettings.helmValues || betweenCmdSettings.helmValuesFrom {
+		for _, inputFile := range []*ytbx.InputFile{&from, &to} {
+			if err := dyff.ExtractHelmValues(inputFile, betweenCmdSettings.helmValuesFrom); err != nil {
+				return dyff.Report{}, err
+			}
+		}
+	}
+
+	if len(betweenCmdSettings.chroot) > 1 {
+		if betweenCmdSettings.chrootFrom != "" || betweenCmdSettings.chrootTo != "" {
+			return dyff.Report{}, fmt.Errorf("incompatible flags: cannot use multiple change root flags in combination with change root of from or to flags")
+		}
+
+		report, changeRoots, err := compareChangeRoots(ctx, from, to, betweenCmdSettings.chroot, options)
+		progress.clear()
+		if err != nil {
+			return dyff.Report{}, err
+		}
+
+		reportOptions.changeRoots = changeRoots
+
+		return invert(report), nil
+	}
+
+	// If the main change root flag is set, this (re-)sets the individual change roots of the two input files
+	chrootFrom, chrootTo := betweenCmdSettings.chrootFrom, betweenCmdSettings.chrootTo
+	if len(betweenCmdSettings.chroot) == 1 {
+		chrootFrom, chrootTo = betweenCmdSettings.chroot[0], betweenCmdSettings.chroot[0]
+	}
+
+	// With swap, the report is inverted after the comparison, therefore the
+	// individual change roots refer to the respective other input file
+	if betweenCmdSettings.swap {
+		chrootFrom, chrootTo = chrootTo, chrootFrom
+	}
+
+	// Change root of 'from' input file if change root flag for 'from' is set
+	if chrootFrom != "" && !isEmptyInputFile(from) {
+		if err := dyff.ChangeRoot(&from, chrootFrom, reportOptions.useGoPatchPaths, betweenCmdSettings.translateListToDocuments); err != nil {
+			return dyff.Report{}, fmt.Errorf("failed to change root of %s to path %s: %w", from.Location, chrootFrom, err)
+		}
+	}
+
+	// Change root of 'to' input file if change root flag for 'to' is set
+	if chrootTo != "" && !isEmptyInputFile(to) {
+		if err := dyff.ChangeRoot(&to, chrootTo, reportOptions.useGoPatchPaths, betweenCmdSettings.translateListToDocuments); err != nil {
+			return dyff.Report{}, fmt.Errorf("failed to change root of %s to path %s: %w", to.Location, chrootTo, err)
+		}
+	}
+
+	if chrootFrom != "" || chrootTo != "" {
+		matchEmptyInputFile(&from, &to)
+	}
+
+	report, err := dyff.CompareInputFilesContext(ctx, from, to, options...)
+	progress.clear()
+	if err != nil {
+		return dyff.Report{}, compareError(err)
+	}
+
+	return invert(report), nil
+}
+
+// invert inverts the report in case the swap flag is set
+func invert(report dyff.Report) dyff.Report {
+	if betweenCmdSettings.swap {
+		return report.Invert()
+	}
+
+	return report
+}
+
+// compareChangeRoots compares each of the given change root paths separately
+// and combines the results into one report, where the paths of the
+// differences are the full paths in the input files, i.e. prefixed with the
+// respective change root path, which is returned as well so that the
+// differences can be grouped per change root
+func compareChangeRoots(ctx context.Context, from ytbx.InputFile, to ytbx.InputFile, paths []string, options []dyff.CompareOption) (dyff.Report, []ytbx.Path, error) {
+	if len(from.Documents) != 1 || len(to.Documents) != 1 {
+		return dyff.Report{}, nil, fmt.Errorf("multiple change roots are only possible if both input files contain exactly one document")
+	}
+
+	var changeRoots []ytbx.Path
+
+	var report = dyff.Report{From: from, To: to}
+	report.From.Note = fmt.Sprintf("YAML root was changed to %s", strings.Join(paths, ", "))
+	report.To.Note = report.From.Note
+
+	for _, path := range paths {
+		// Copies of the input files are used, since change root replaces the documents
+		fromRoot, toRoot := from, to
+		if !isEmptyInputFile(from) {
+			if err := dyff.ChangeRoot(&fromRoot, path, reportOptions.useGoPatchPaths, betweenCmdSettings.translateListToDocuments); err != nil {
+				return dyff.Report{}, nil, fmt.Errorf("failed to change root of %s to path %s: %w", from.Location, path, err)
+			}
+		}
+
+		if !isEmptyInputFile(to) {
+			if err := dyff.ChangeRoot(&toRoot, path, reportOptions.useGoPatchPaths, betweenCmdSettings.translateListToDocuments); err != nil {
+				return dyff.Report{}, nil, fmt.Errorf("failed to change root of %s to path %s: %w", to.Location, path, err)
+			}
+		}
+
+		matchEmptyInputFile(&fromRoot, &toRoot)
+
+		reference := from
+		if isEmptyInputFile(from) {
+			reference = to
+		}
+
+		prefix, err := ytbx.ParsePathString(path, reference.Documents[0])
+		if err != nil {
+			return dyff.Report{}, nil, err
+		}
+
+		changeRoots = append(changeRoots, prefix)
+
+		// Only a list is translated into documents, other change roots stay one document
+		translatedList := false
+		if betweenCmdSettings.translateListToDocuments {
+			if obj, err := ytbx.Grab(reference.Documents[0], path); err == nil && obj.Kind == yamlv3.SequenceNode {
+				translatedList = true
+			}
+		}
+
+		subReport, err := dyff.CompareInputFilesContext(ctx, fromRoot, toRoot, options...)
+		if err != nil {
+			return dyff.Report{}, nil, compareError(err)
+		}
+
+		for _, diff := range subReport.Diffs {
+			elements := append([]ytbx.PathElement{}, prefix.PathElements...)
+
+			// Documents created from a list refer to the respective list entry
+			if translatedList && diff.Path != nil {
+				elements = append(elements, ytbx.PathElement{Idx: diff.Path.DocumentIdx})
+			}
+
+			if diff.Path != nil {
+				elements = append(elements, diff.Path.PathElements...)
+			}
+
+			report.Diffs = append(report.Diffs, dyff.Diff{
+				Path:    &ytbx.Path{Root: &report.From, PathElements: elements},
+				Details: diff.Details,
+			})
+		}
+	}
+
+	return report, changeRoots, nil
+}
+
+// compareStreams compares the input files document by document, or list entry
+// by list entry, without loading them completely, and prints the differences
+// as soon as they are found, which is why only the human output style without
+// header is supported
+func compareStreams(ctx context.Context, fromLocation string, toLocation string, options []dyff.CompareOption, progress *progressPrinter) error {
+	switch {
+	case strings.ToLower(reportOptions.style) != "human":
+		return fmt.Errorf("stream mode only supports the human output style")
+
+	case len(betweenCmdSettings.chroot) > 0 || betweenCmdSettings.chrootFrom != "" || betweenCmdSettings.chrootTo != "":
+		return fmt.Errorf("incompatible flags: cannot use stream flag in combination with change root flags")
+
+	case betweenCmdSettings.resolveReferences:
+		return fmt.Errorf("incompatible flags: cannot use stream flag in combination with resolve references flag")
+
+	case betweenCmdSettings.sops:
+		return fmt.Errorf("incompatible flags: cannot use stream flag in combination with sops flag")
+
+	case betweenCmdSettings.placeholders != betweenDefaults.placeholders:
+		return fmt.Errorf("incompatible flags: cannot use stream flag in combination with placeholders flag")
+
+	case betweenCmdSettings.spruceOperators != betweenDefaults.spruceOperators:
+		return fmt.Errorf("incompatible flags: cannot use stream flag in combination with spruce operators flag")
+
+	case betweenCmdSettings.helmValues || betweenCmdSettings.helmValuesFrom:
+		return fmt.Errorf("incompatible flags: cannot use stream flag in combination with helm values flag")
+
+	case len(betweenCmdSettings.documents) > 0:
+		return fmt.Errorf("incompatible flags: cannot use stream flag in combination with documents flag")
+
+	case betweenCmdSettings.watch:
+		return fmt.Errorf("incompatible flags: cannot use stream flag in combination with watch flag")
+
+	case !isYAMLFormat(betweenCmdSettings.fromFormat) || !isYAMLFormat(betweenCmdSettings.toFormat):
+		return fmt.Errorf("incompatible flags: cannot use stream flag in combination with a format other than yaml")
+	}
+
+	if betweenCmdSettings.swap {
+		fromLocation, toLocation = toLocation, fromLocation
+	}
+
+	fromReader, err := openStream(fromLocation)
+	if err != nil {
+		return err
+	}
+	defer fromReader.Close()
+
+	toReader, err := openStream(toLocation)
+	if err != nil {
+		return err
+	}
+	defer toReader.Close()
+
+	hyperlinks, err := useHyperlinks(reportOptions.hyperlinks)
+	if err != nil {
+		return err
+	}
+
+	humanReport := &dyff.HumanReport{
+		Report: dyff.Report{
+			From: ytbx.InputFile{Location: fromLocation},
+			To:   ytbx.InputFile{Location: toLocation},
+		},
+		Indent:                2,
+		DoNotInspectCerts:     reportOptions.doNotInspectCerts,
+		NoTableStyle:          reportOptions.noTableStyle,
+		OmitHeader:            true,
+		UseGoPatchPaths:       reportOptions.useGoPatchPaths,
+		MinorChangeThreshold:  reportOptions.minorChangeThreshold,
+		MultilineContextLines: reportOptions.multilineContextLines,
+		Theme:                 selectedTheme(),
+	}
+
+	if hyperlinks {
+		humanReport.HyperlinkTemplate = reportOptions.hyperlinkTemplate
+	}
+
+	out := newOutput(os.Stdout)
+	defer out.Close()
+
+	// The exit codes are ordered by their precedence, so the exit code of all
+	// differences is the highest exit code of each difference
+	var exitCode int
+	compare := dyff.CompareStreamsContext
+	if betweenCmdSettings.streamList {
+		compare = dyff.CompareListStreamsContext
+	}
+
+	err = compare(ctx, fromLocation, fromReader, toLocation, toReader, func(diff dyff.Diff) error {
+		for _, diff := range reportOptions.filterReport(dyff.Report{Diffs: []dyff.Diff{diff}}).Diffs {
+			progress.clear()
+			if err := humanReport.WriteDiff(out, diff); err != nil {
+				return fmt.Errorf("failed to print difference: %w", err)
+			}
+
+			code, err := reportOptions.exitCode(dyff.Report{Diffs: []dyff.Diff{diff}})
+			if err != nil {
+				return err
+			}
+
+			exitCode = max(exitCode, code)
+		}
+
+		return nil
+	}, options...)
+
+	progress.clear()
+	if err != nil {
+		return compareError(err)
+	}
+
+	// Finish with one last newline so that we do not end next to the prompt
+	_, _ = fmt.Fprintln(out)
+
+	if reportOptions.setsExitCode() {
+		return errorWithExitCode{value: exitCode}
+	}
+
+	return nil
+}
+
+// openStream opens the input location for reading, which can be a file, a
+// URL, or standard input
+func openStream(location string) (io.ReadCloser, error) {
+	if _, ok := dyff.InputLoaderFor(location); ok {
+		return nil, fmt.Errorf("failed to open %s: input loaders cannot be streamed", location)
+	}
+
+	if ytbx.IsStdin(location) {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	if _, err := os.Stat(location); err != nil {
+		if u, urlErr := url.ParseRequestURI(location); urlErr == nil && (u.Scheme == "http" || u.Scheme == "https") {
+			response, err := http.Get(location)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open %s: %w", location, err)
+			}
+
+			if response.StatusCode != http.StatusOK {
+				response.Body.Close()
+				return nil, fmt.Errorf("failed to open %s: %s", location, response.Status)
+			}
+
+			return response.Body, nil
+		}
+	}
+
+	file, err := os.Open(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", humanReadableFilename(location), err)
+	}
+
+	return file, nil
+}
+
+// isYAMLFormat returns whether the format is either not set or YAML, which
+// are the inputs the stream mode can decode
+func isYAMLFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case "", "yaml", "yml":
+		return true
+	}
+
+	return false
 }
@@ -81,6 +81,27 @@ func assets(pathElement ...string) string {
 	return abs
 }
 
+func captureStderr(f func() error) (string, error) {
+	r, w, err := os.Pipe()
+	Expect(err).ToNot(HaveOccurred())
+
+	tmp := os.Stderr
+	defer func() {
+		os.Stderr = tmp
+	}()
+
+	os.Stderr = w
+	err = f()
+	w.Close()
+
+	var buf bytes.Buffer
+	if _, copyErr := io.Copy(&buf, r); copyErr != nil {
+		return "", copyErr
+	}
+
+	return buf.String(), err
+}
+
 func captureStdout(f func() error) (string, error) {
 	r, w, err := os.Pipe()
 	Expect(err).ToNot(HaveOccurred())
@@ -21,14 +21,22 @@
 package cmd_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	. "github.com/homeport/dyff/internal/cmd"
+	dyffpkg "github.com/homeport/dyff/pkg/dyff"
 
+	"github.com/gonvenience/bunt"
 	"github.com/gonvenience/term"
 )
 
@@ -65,6 +73,37 @@ foo:
   bar: "*"
   dash: "-"
 
+`))
+			})
+
+			It("should align keys so that values start in the same column", func() {
+				filename := createTestFile(`---
+a: 1
+longer: 2
+map:
+  k: v
+  text: |
+    not: aligned
+  kk: v
+list:
+- name: x
+  value: y
+`)
+				defer os.Remove(filename)
+
+				out, err := dyff("yaml", "--plain", "--align-keys", filename)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(out).To(BeEquivalentTo(`---
+a:      1
+longer: 2
+map:
+  k:    v
+  text: |
+    not: aligned
+  kk:   v
+list:
+  - name:  x
+    value: y
 `))
 			})
 		})
@@ -235,6 +274,525 @@ list:
 		})
 	})
 
+	Context("toml command", func() {
+		It("should write a TOML file to STDOUT while preserving the order of keys", func() {
+			filename := createTestFile(`---
+name: example
+list: [1, 2]
+server:
+  port: 8080
+  host: "localhost"
+plugins:
+- name: b
+- name: a
+`)
+			defer os.Remove(filename)
+
+			out, err := dyff("toml", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`name = "example"
+list = [1, 2]
+
+[server]
+port = 8080
+host = "localhost"
+
+[[plugins]]
+name = "b"
+
+[[plugins]]
+name = "a"
+`))
+		})
+
+		It("should write timestamps as TOML dates and date times", func() {
+			filename := createTestFile(`---
+date: 2002-12-14
+canonical: 2001-12-15T02:59:43.1Z
+spaced: 2001-12-14 21:59:43.10
+zoned: 2001-12-14 21:59:43.10 -5
+`)
+			defer os.Remove(filename)
+
+			out, err := dyff("toml", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`date = 2002-12-14
+canonical = 2001-12-15T02:59:43.1Z
+spaced = 2001-12-14T21:59:43.1Z
+zoned = "2001-12-14 21:59:43.10 -5"
+`))
+		})
+
+		It("should fail to write null values", func() {
+			filename := createTestFile("key: ~\n")
+			defer os.Remove(filename)
+
+			_, err := dyff("toml", filename)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("TOML does not support null values"))
+		})
+	})
+
+	Context("properties output", func() {
+		It("should write Java properties using a different output format", func() {
+			filename := createTestFile(`---
+server:
+  port: 8080
+  greeting: "hello wörld"
+list:
+- name: one
+- two
+`)
+			defer os.Remove(filename)
+
+			out, err := dyff("yaml", "--output", "properties", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`server.port=8080
+server.greeting=hello w\u00f6rld
+list[0].name=one
+list[1]=two
+`))
+		})
+
+		It("should fail for unknown output formats", func() {
+			_, err := dyff("json", "--output", "ini", "foobar.yml")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown output format ini"))
+		})
+	})
+
+	Context("restyle command", func() {
+		It("should write a YAML file in canonical style to STDOUT", func() {
+			filename := createTestFile(`b: "x"
+a:    1
+c:
+    - "y"
+    - z
+`)
+			defer os.Remove(filename)
+
+			out, err := dyff("restyle", "--key-order=sorted", "--quotes=double", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`a: 1
+b: "x"
+c:
+  - "y"
+  - "z"
+`))
+		})
+
+		It("should rewrite a YAML file in place", func() {
+			filename := createTestFile(`---
+list:
+- name:    one
+  aaa: 'bbb'
+`)
+			defer os.Remove(filename)
+
+			out, err := dyff("restyle", "--in-place", "--quotes=minimal", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEmpty())
+
+			data, err := os.ReadFile(filename)
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(BeEquivalentTo(`---
+list:
+  - name: one
+    aaa: bbb
+`))
+		})
+
+		It("should list files that are not in canonical style in check mode", func() {
+			styled := createTestFile("foo: bar\nlist:\n  - one\n")
+			defer os.Remove(styled)
+
+			unstyled := createTestFile("foo:   bar\nlist:\n- one\n")
+			defer os.Remove(unstyled)
+
+			out, err := dyff("restyle", "--check", styled)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEmpty())
+
+			out, err = dyff("restyle", "--check", styled, unstyled)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(ExitCode).Value()).To(Equal(1))
+			Expect(out).To(BeEquivalentTo(unstyled + "\n"))
+
+			data, err := os.ReadFile(unstyled)
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(BeEquivalentTo("foo:   bar\nlist:\n- one\n"))
+		})
+
+		It("should align keys when configured", func() {
+			filename := createTestFile("a: 1\nlonger: 2\n")
+			defer os.Remove(filename)
+
+			out, err := dyff("restyle", "--align-keys", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("a:      1\nlonger: 2\n"))
+		})
+
+		It("should fail when in place and check mode are used at the same time", func() {
+			_, err := dyff("restyle", "--in-place", "--check", "-")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(BeEquivalentTo("incompatible flags: cannot use in-place flag in combination with check flag"))
+		})
+	})
+
+	Context("merge command", func() {
+		It("should merge overlays onto the base and write the result to STDOUT", func() {
+			base := createTestFile("a: 1\nlist:\n- name: x\n  v: 1\n")
+			defer os.Remove(base)
+
+			overlay1 := createTestFile("b: 2\nlist:\n- name: x\n  v: 2\n")
+			defer os.Remove(overlay1)
+
+			overlay2 := createTestFile("list:\n- name: y\n")
+			defer os.Remove(overlay2)
+
+			out, err := dyff("merge", "--plain", base, overlay1, overlay2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`---
+a: 1
+list:
+  - name: x
+    v: 2
+  - name: y
+b: 2
+`))
+		})
+
+		It("should fail when an unknown list strategy is used", func() {
+			_, err := dyff("merge", "--list-strategy=foobar", "a.yml", "b.yml")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown list merge strategy foobar"))
+		})
+
+		It("should merge the changes of ours and theirs based on their common base", func() {
+			base := createTestFile("a: 1\nb: 2\n")
+			defer os.Remove(base)
+
+			ours := createTestFile("a: 1\nb: 20\n")
+			defer os.Remove(ours)
+
+			theirs := createTestFile("a: 10\nb: 2\nc: 3\n")
+			defer os.Remove(theirs)
+
+			out, err := dyff("merge", "--plain", "--three-way", base, ours, theirs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`---
+a: 10
+b: 20
+c: 3
+`))
+		})
+
+		It("should write the result to ours and fail with exit code 1 when there are conflicts", func() {
+			base := createTestFile("a: 1\nb: 2\n")
+			defer os.Remove(base)
+
+			ours := createTestFile("a: 2\nb: 2\n")
+			defer os.Remove(ours)
+
+			theirs := createTestFile("a: 3\nb: 3\n")
+			defer os.Remove(theirs)
+
+			_, err := dyff("merge", "--three-way", "--in-place", base, ours, theirs)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("merge has 1 conflict(s)"))
+			Expect(err.Error()).To(ContainSubstring("/a"))
+			Expect(err.(ExitCode).Value()).To(Equal(1))
+
+			data, err := os.ReadFile(ours)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal("---\na: 2\nb: 3\n"))
+		})
+
+		It("should require exactly three files for a three-way merge", func() {
+			_, err := dyff("merge", "--three-way", "a.yml", "b.yml")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("sort command", func() {
+		It("should sort map keys and selected lists", func() {
+			filename := createTestFile(`---
+spec:
+  containers:
+  - name: sidecar
+    image: b
+  - name: app
+    image: a
+  ports: [443, 80, 8080]
+kind: Pod
+`)
+			defer os.Remove(filename)
+
+			out, err := dyff("sort", "--plain", "--by-key", "--lists", "spec.containers=name", "--lists", "/spec/ports", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`---
+kind: Pod
+spec:
+  containers:
+    - image: a
+      name: app
+    - image: b
+      name: sidecar
+  ports: [80, 443, 8080]
+`))
+		})
+
+		It("should sort lists in place", func() {
+			filename := createTestFile("list:\n- b\n- a\n")
+			defer os.Remove(filename)
+
+			out, err := dyff("sort", "--in-place", "--lists", "list", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEmpty())
+
+			data, err := os.ReadFile(filename)
+			Expect(err).To(BeNil())
+			Expect(string(data)).To(BeEquivalentTo("---\nlist:\n  - a\n  - b\n"))
+		})
+
+		It("should fail when the path does not point to a list", func() {
+			filename := createTestFile("map:\n  key: value\n")
+			defer os.Remove(filename)
+
+			_, err := dyff("sort", "--lists", "map", filename)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("path map does not point to a list"))
+		})
+	})
+
+	Context("validate command", func() {
+		var schema string
+
+		BeforeEach(func() {
+			schema = createTestFile(`---
+type: object
+required: [name]
+properties:
+  name: {type: string}
+  list: {type: array, items: {type: string}}
+`)
+		})
+
+		AfterEach(func() {
+			os.Remove(schema)
+		})
+
+		It("should report valid documents", func() {
+			filename := createTestFile("name: foobar\nlist: [a, b]\n")
+			defer os.Remove(filename)
+
+			out, err := dyff("validate", "--schema", schema, filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(filename + ": valid\n"))
+		})
+
+		It("should report validation errors with their location", func() {
+			filename := createTestFile("list:\n- a\n- 3\n")
+			defer os.Remove(filename)
+
+			out, err := dyff("validate", "--schema", schema, filename)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(ExitCode).Value()).To(Equal(1))
+			Expect(err.Error()).To(BeEquivalentTo("validation failed: found two validation errors in one file"))
+			Expect(out).To(BeEquivalentTo(filename + `: two validation errors
+  (root level) (line 1, column 1)
+    missing property 'name'
+  list.1 (line 3, column 3)
+    got number, want string
+`))
+		})
+
+		It("should not interpret markup in paths and messages", func() {
+			strict := createTestFile("---\ntype: object\nadditionalProperties: {type: string}\n")
+			defer os.Remove(strict)
+
+			filename := createTestFile("\"*Red{key}* 100%\": 1\n")
+			defer os.Remove(filename)
+
+			out, err := dyff("validate", "--schema", strict, filename)
+			Expect(err).To(HaveOccurred())
+			Expect(out).To(ContainSubstring("\n  *Red{key}* 100% (line 1, column 1)\n"))
+		})
+
+		It("should fail when no schema is specified", func() {
+			_, err := dyff("validate", "foobar.yml")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no schema specified"))
+		})
+	})
+
+	Context("get command", func() {
+		var filename string
+
+		BeforeEach(func() {
+			filename = createTestFile(`---
+spec:
+  containers:
+  - name: app
+    image: app:1.0
+    ports: [8080]
+  - name: sidecar
+    image: sidecar:2.0
+    ports: [9090, 9091]
+`)
+		})
+
+		AfterEach(func() {
+			os.Remove(filename)
+		})
+
+		It("should print a scalar value using a Go-patch style path", func() {
+			out, err := dyff("get", filename, "/spec/containers/name=app/image")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("app:1.0\n"))
+		})
+
+		It("should print a scalar value using a dot-style path", func() {
+			out, err := dyff("get", filename, "spec.containers.sidecar.image")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("sidecar:2.0\n"))
+		})
+
+		It("should print all values matching a wildcard", func() {
+			out, err := dyff("get", filename, "/spec/containers/*/ports/*")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("8080\n9090\n9091\n"))
+		})
+
+		It("should print non-scalar values as documents", func() {
+			out, err := dyff("get", "--plain", filename, "/spec/containers/1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`---
+name: sidecar
+image: sidecar:2.0
+ports: [9090, 9091]
+`))
+		})
+
+		It("should support escaped slashes in Go-patch style paths like the change root flags", func() {
+			annotated := createTestFile(`---
+metadata:
+  annotations:
+    example.com/owner: team
+`)
+			defer os.Remove(annotated)
+
+			out, err := dyff("get", annotated, `/metadata/annotations/example.com\/owner`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("team\n"))
+		})
+
+		It("should fail with the path error for unknown named list entries in dot-style paths", func() {
+			_, err := dyff("get", filename, "spec.containers.foobar.image")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("available names are: app, sidecar"))
+		})
+
+		It("should fail when there is no value at the path", func() {
+			_, err := dyff("get", filename, "/spec/foobar")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no value found at path /spec/foobar"))
+		})
+	})
+
+	Context("flatten command", func() {
+		It("should convert a document into flat path=value lines", func() {
+			filename := createTestFile(`---
+some.key: value
+map:
+  int: 42
+  string: "42"
+  text: |
+    multi
+    line
+  empty: {}
+list:
+- name: one
+- two
+`)
+			defer os.Remove(filename)
+
+			out, err := dyff("flatten", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`some\.key=value
+map.int=42
+map.string="42"
+map.text="multi\nline\n"
+map.empty={}
+list.0.name=one
+list.1=two
+`))
+		})
+
+		It("should convert flat path=value lines back into a document", func() {
+			filename := createTestFile(`# comment
+some\.key=value
+map.int=42
+map.string="42"
+list.0.name=one
+list.1=two
+`)
+			defer os.Remove(filename)
+
+			out, err := dyff("flatten", "--reverse", "--plain", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`---
+some.key: value
+map:
+  int: 42
+  string: "42"
+list:
+  - name: one
+  - two
+`))
+		})
+
+		It("should keep maps with numeric keys as maps when converting back and forth", func() {
+			filename := createTestFile(`---
+ports:
+  80: http
+  "443": https
+list:
+- 0: zero
+`)
+			defer os.Remove(filename)
+
+			out, err := dyff("flatten", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`ports."80"=http
+ports."443"=https
+list.0."0"=zero
+`))
+
+			flat := createTestFile(out)
+			defer os.Remove(flat)
+
+			out, err = dyff("flatten", "--reverse", "--plain", flat)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`---
+ports:
+  "80": http
+  "443": https
+list:
+  - "0": zero
+`))
+		})
+
+		It("should fail to convert lines without a value", func() {
+			filename := createTestFile("foo.bar\n")
+			defer os.Remove(filename)
+
+			_, err := dyff("flatten", "--reverse", filename)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("expected path=value"))
+		})
+	})
+
 	Context("between command", func() {
 		It("should create the default report when there are no flags specified", func() {
 			from := createTestFile(`{"list":[{"aaa":"bbb","name":"one"}]}`)
@@ -260,6 +818,32 @@ list
 `, from, to)))
 		})
 
+		It("should create a HTML report when the html output style is used", func() {
+			from := createTestFile(`{"list":[{"aaa":"bbb","name":"one"}]}`)
+			defer os.Remove(from)
+
+			to := createTestFile(`{"list":[{"aaa":"bbb","name":"two"}]}`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--output", "html", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("<!DOCTYPE html>"))
+			Expect(out).To(ContainSubstring(`<section id="document-1/list">`))
+		})
+
+		It("should create a Markdown report when the markdown output style is used", func() {
+			from := createTestFile(`{"a": 1}`)
+			defer os.Remove(from)
+
+			to := createTestFile(`{"a": 2}`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--output", "markdown", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("| document #1 | 0 | 0 | 1 | 0 |"))
+			Expect(out).To(ContainSubstring("```diff\n- 1\n+ 2\n```"))
+		})
+
 		It("should create the same default report when swap flag is used", func() {
 			from := createTestFile(`{"list":[{"aaa":"bbb","name":"one"}]}`)
 			defer os.Remove(from)
@@ -343,11 +927,1419 @@ whitespaces
 			Expect(out).To(BeEquivalentTo(expected))
 		})
 
-		It("should fail when change root is used with files containing multiple documents", func() {
-			from, to := assets("testbed", "from.yml"), assets("testbed", "to.yml")
-			_, err := dyff("between", from, to, "--chroot", "orderchanges")
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(BeEquivalentTo(fmt.Sprintf("failed to change root of %s to path orderchanges: change root for an input file is only possible if there is only one document, but %s contains two documents", from, from)))
+		It("should compare multiple change roots in one report", func() {
+			from := createTestFile(`---
+spec:
+  a: {x: 1, y: 2}
+  b: [one, two]
+  c: same
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+spec:
+  a: {x: 1, y: 3}
+  b: [one, three]
+  c: other
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", from, to, "--chroot", "spec.a,spec.b")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+▶ spec.a  (one difference)
+
+spec.a.y
+  ± value change
+    - 2
+    + 3
+
+▶ spec.b  (one difference)
+
+spec.b
+  - one list entry removed:     + one list entry added:
+    - two                         - three
+
+`))
+
+			out, err = dyff("between", "--output", "brief", "--omit-header", from, to, "--chroot", "spec.a,spec.b", "--chroot-list-to-documents")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("two changes detected"))
+
+			out, err = dyff("between", "--output", "editor", from, to, "--chroot", "spec.a,spec.b", "--chroot-list-to-documents")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(fmt.Sprintf(`%s:3:16: spec.a.y: value change from 2 to 3
+%s:4:12: spec.b.1: value change from two to three
+`, to, to)))
+		})
+
+		It("should fail when multiple change roots are combined with the change root of one input file", func() {
+			from := createTestFile("---\nspec:\n  a: 1\n  b: 2\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nspec:\n  a: 1\n  b: 3\n")
+			defer os.Remove(to)
+
+			_, err := dyff("between", "--omit-header", from, to, "--chroot", "spec.a,spec.b", "--chroot-of-to", "spec")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot use multiple change root flags in combination with change root of from or to flags"))
+		})
+
+		It("should match list entries by the configured list key", func() {
+			from := createTestFile("---\nspec:\n  rules:\n  - {name: a, host: foo.example.com, port: 80}\n  - {name: b, host: bar.example.com, port: 80}\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nspec:\n  rules:\n  - {name: c, host: foo.example.com, port: 80}\n  - {name: d, host: bar.example.com, port: 8080}\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--list-key", "/spec/rules=host", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+spec.rules.foo.example.com.name
+  ± value change
+    - a
+    + c
+
+spec.rules.bar.example.com.name
+  ± value change
+    - b
+    + d
+
+spec.rules.bar.example.com.port
+  ± value change
+    - 80
+    + 8080
+
+`))
+
+			_, err = dyff("between", "--list-key", "/spec/rules", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid list key /spec/rules"))
+		})
+
+		It("should ignore order changes only at the configured paths", func() {
+			from := createTestFile("---\nspec:\n  tags: [a, b]\n  args: [x, y]\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nspec:\n  tags: [b, a]\n  args: [y, x]\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--ignore-order-at", "/*/tags", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+spec.args
+  ⇆ order changed
+    - x, y
+    + y, x
+
+`))
+		})
+
+		It("should ignore numbers that differ by at most the float tolerance", func() {
+			from := createTestFile("---\nratio: 0.1\ncount: 2\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nratio: 0.1000000001\ncount: 3\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--float-tolerance", "1e-6", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+count
+  ± value change
+    - 2
+    + 3
+
+`))
+		})
+
+		It("should use the flag values of a profile unless they are set explicitly", func() {
+			from := createTestFile(`---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  labels:
+    helm.sh/chart: foo-1.0.0
+data:
+  key: foo
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  labels:
+    helm.sh/chart: foo-1.1.0
+data:
+  key: bar
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--profile", "helm", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+data.key
+  ± value change
+    - foo
+    + bar
+
+`))
+
+			out, err = dyff("between", "--omit-header", "--profile", "helm", "--exclude-regexp", "^/data", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+metadata.labels.helm.sh/chart
+  ± value change
+    - foo-1.0.0
+    + foo-1.1.0
+
+`))
+		})
+
+		It("should fail with an unknown profile", func() {
+			_, err := dyff("between", "--profile", "foobar", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown profile foobar"))
+		})
+
+		It("should change the root to the common path of all differences when auto chroot is used", func() {
+			from := createTestFile(`---
+spec:
+  template:
+    spec: {replicas: 1, image: foo:1}
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+spec:
+  template:
+    spec: {replicas: 2, image: foo:2}
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--auto-chroot", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+replicas
+  ± value change
+    - 1
+    + 2
+
+image
+  ± value change
+    - foo:1
+    + foo:2
+
+`))
+
+			out, err = dyff("between", "--output", "brief", "--auto-chroot", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("YAML root was changed to spec.template.spec"))
+		})
+
+		It("should print the differences of each document when streaming is used", func() {
+			from := createTestFile("---\na: 1\n---\nb: [x, y]\n---\nc: 3\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\na: 2\n---\nb: [x, z]\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--stream", "--exclude", "b", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+a  (document #1)
+  ± value change
+    - 1
+    + 2
+
+(root level)  (document #3)
+- one document removed:
+  ---
+  c: 3
+
+`))
+		})
+
+		It("should print the differences of each list entry when list streaming is used", func() {
+			from := createTestFile(`[{"name": "a", "v": 1}, {"name": "b", "v": 1}]`)
+			defer os.Remove(from)
+
+			to := createTestFile(`[{"name": "b", "v": 2}, {"name": "a", "v": 1}]`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--stream-list", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+b.v  (document #1)
+  ± value change
+    - 1
+    + 2
+
+`))
+		})
+
+		It("should only use HTTP and HTTPS locations as URLs when streaming", func() {
+			_, err := dyff("between", "--stream", "foo:bar.yml", "foo:bar.yml")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).ToNot(ContainSubstring("unsupported protocol scheme"))
+			Expect(err.Error()).To(ContainSubstring("no such file or directory"))
+		})
+
+		It("should fail when streaming is used with an unsupported output style", func() {
+			_, err := dyff("between", "--stream", "--output", "brief", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).To(MatchError("stream mode only supports the human output style"))
+		})
+
+		It("should only compare the selected documents", func() {
+			from := createTestFile("---\na: 1\n---\nb: |\n  text\n---\nc: [x, y]\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\na: 2\n---\nb: |\n  text\n---\nc: [x, z]\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--documents", "2-3", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+c  (document #3)
+  - one list entry removed:     + one list entry added:
+    - y                           - z
+
+`))
+		})
+
+		It("should allow to change the root of a selected document in files with multiple documents", func() {
+			from := createTestFile("---\na: 1\n---\nb: {c: foo}\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\na: 2\n---\nb: {c: bar}\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--documents", "2", "--chroot", "b", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+c
+  ± value change
+    - foo
+    + bar
+
+`))
+		})
+
+		It("should support document ranges far beyond the number of documents", func() {
+			from := createTestFile("---\na: 1\n---\nb: foo\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\na: 2\n---\nb: bar\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--output", "brief", "--documents", "2-999999999", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(fmt.Sprintf("one change detected between %s and %s\n\n", from, to)))
+		})
+
+		It("should keep the directives of a selected document", func() {
+			from := createTestFile("a: 1\n...\n%TAG !e! tag:example.com,2000:\n---\nb: !e!foo bar\n")
+			defer os.Remove(from)
+
+			to := createTestFile("a: 2\n...\n%TAG !e! tag:example.com,2000:\n---\nb: !e!foo bar\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--output", "brief", "--documents", "2", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(fmt.Sprintf("no changes detected between %s and %s\n\n", from, to)))
+		})
+
+		It("should only compare the selected documents of input files with a given format", func() {
+			from := createTestFile("---\na: 1\n---\nb: foo\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\na: 2\n---\nb: foo\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--output", "brief", "--from-format", "yaml", "--to-format", "yaml", "--documents", "2", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(fmt.Sprintf("no changes detected between %s and %s\n\n", from, to)))
+		})
+
+		It("should fail when the document selection is invalid", func() {
+			_, err := dyff("between", "--documents", "3-1", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).To(MatchError("failed to load input files: invalid document selection 3-1, expected a document number (starting with 1) or a range"))
+		})
+
+		It("should parse each input file in the format given for it", func() {
+			// The content is valid TOML, which is what is detected by default
+			from := createTestFile(`version = "1.0"`)
+			defer os.Remove(from)
+
+			to := createTestFile(`version = "1.0"`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--output", "brief", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("no changes detected"))
+
+			out, err = dyff("between", "--output", "brief", "--from-format", "yaml", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("one change detected"))
+
+			out, err = dyff("between", "--output", "brief", "--from-format", "yaml", "--to-format", "yaml", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("no changes detected"))
+		})
+
+		It("should fail when an input file is not valid in the given format", func() {
+			from := createTestFile(`{"foo": "bar"}`)
+			defer os.Remove(from)
+
+			_, err := dyff("between", "--from-format", "toml", from, from)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("as toml"))
+
+			_, err = dyff("between", "--to-format", "xml", from, from)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown input format xml"))
+		})
+
+		It("should read large inputs from pipes of inherited file descriptors", func() {
+			from, fromWriter, err := os.Pipe()
+			Expect(err).ToNot(HaveOccurred())
+			defer from.Close()
+
+			to, toWriter, err := os.Pipe()
+			Expect(err).ToNot(HaveOccurred())
+			defer to.Close()
+
+			// More data than fits into the buffer of a pipe
+			go func() {
+				defer fromWriter.Close()
+				fmt.Fprintln(fromWriter, "name: foo")
+				for i := 0; i < 5000; i++ {
+					fmt.Fprintf(fromWriter, "key%d: value\n", i)
+				}
+			}()
+
+			go func() {
+				defer toWriter.Close()
+				fmt.Fprintln(toWriter, "name: bar")
+				for i := 0; i < 5000; i++ {
+					fmt.Fprintf(toWriter, "key%d: value\n", i)
+				}
+			}()
+
+			out, err := dyff("between", "--output", "brief", "--from-fd", fmt.Sprint(from.Fd()), "--to-fd", fmt.Sprint(to.Fd()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("one change detected"))
+		})
+
+		It("should show the location of a pipe in the header of the report", func() {
+			from, fromWriter, err := os.Pipe()
+			Expect(err).ToNot(HaveOccurred())
+			defer from.Close()
+
+			go func() {
+				defer fromWriter.Close()
+				fmt.Fprintln(fromWriter, "name: foo")
+			}()
+
+			out, err := dyff("between", "--from-fd", fmt.Sprint(from.Fd()), assets("examples", "to.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring(fmt.Sprintf("between /dev/fd/%d", from.Fd())))
+		})
+
+		It("should fail with a sensible error message if a pipe has no data", func() {
+			from, fromWriter, err := os.Pipe()
+			Expect(err).ToNot(HaveOccurred())
+			defer from.Close()
+			fromWriter.Close()
+
+			_, err = dyff("between", "--from-fd", fmt.Sprint(from.Fd()), assets("examples", "to.yml"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the command writing into it might have failed"))
+		})
+
+		It("should fail when the from fd flag is used in combination with the from empty flag", func() {
+			_, err := dyff("between", "--from-fd", "3", "--from-empty", assets("examples", "to.yml"))
+			Expect(err).To(MatchError("incompatible flags: cannot use from empty flag in combination with from fd flag"))
+		})
+
+		It("should accept the arguments Git passes to external diff commands", func() {
+			from := createTestFile("a: 1\n")
+			defer os.Remove(from)
+
+			to := createTestFile("a: 2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--git", "config/app.yml", from, "1234567", "100644", to, "89abcde", "100644")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("between a/config/app.yml"))
+			Expect(out).To(ContainSubstring("and b/config/app.yml"))
+			Expect(out).To(ContainSubstring("± value change"))
+		})
+
+		It("should compare added files against an empty document in git mode", func() {
+			to := createTestFile("a: 2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--git", "--omit-header", "new.yml", os.DevNull, "0000000", "0", to, "89abcde", "100644")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+(root level)
++ one map entry added:
+  a: 2
+
+`))
+		})
+
+		It("should fail when git mode does not get the arguments passed by Git", func() {
+			_, err := dyff("between", "--git", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).To(MatchError("git mode expects the 7 or 9 arguments passed by Git to external diff commands, received 2"))
+		})
+
+		It("should fail when the comparison does not finish within the timeout", func() {
+			_, err := dyff("between", "--timeout", "1ns", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).To(MatchError("failed to compare input files: comparison did not finish within 1ns"))
+		})
+
+		It("should use external commands to compare values", func() {
+			from := createTestFile("{\"blob\": \"a\", \"other\": \"a\"}")
+			defer os.Remove(from)
+
+			to := createTestFile("{\"blob\": \"b\", \"other\": \"b\"}")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--exec-comparer", "^/blob$=true", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(`
+other
+  ± value change
+    - a
+    + b
+
+`))
+		})
+
+		It("should fail for invalid exec comparers", func() {
+			_, err := dyff("between", "--exec-comparer", "^/blob$", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).To(MatchError("invalid exec comparer ^/blob$, expected format is <regexp>=<command>"))
+		})
+
+		It("should load inputs using an external input loader", func() {
+			loader := createTestFile(`echo "name: ${1#store://}"`)
+			defer os.Remove(loader)
+
+			out, err := dyff("between", "--omit-header", "--input-loader", "store=sh "+loader, "store://foo", "store://bar")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(`
+name
+  ± value change
+    - foo
+    + bar
+
+`))
+		})
+
+		It("should parse inputs of an external input loader in the given format", func() {
+			loader := createTestFile(`echo "name = \"${1#store://}\""`)
+			defer os.Remove(loader)
+
+			out, err := dyff("between", "--omit-header", "--input-loader", "store=sh "+loader, "--from-format", "toml", "--to-format", "toml", "store://foo", "store://bar")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(`
+name
+  ± value change
+    - foo
+    + bar
+
+`))
+		})
+
+		It("should keep input loaders that were not registered using the command line", func() {
+			loader := createTestFile(`echo "name: ${1#store://}"`)
+			defer os.Remove(loader)
+
+			inputLoader := dyffpkg.ExecInputLoader("store", "sh", loader)
+			dyffpkg.RegisterInputLoader(inputLoader)
+			DeferCleanup(dyffpkg.UnregisterInputLoader, inputLoader)
+
+			for i := 0; i < 2; i++ {
+				out, err := dyff("between", "--omit-header", "--output", "brief", "store://foo", "store://bar")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(out).To(ContainSubstring("one change detected"))
+			}
+		})
+
+		It("should fail for invalid input loaders", func() {
+			_, err := dyff("between", "--input-loader", "store", "store://foo", "store://bar")
+			Expect(err).To(MatchError("invalid input loader store, expected format is <scheme>=<command>"))
+		})
+
+		It("should resolve placeholders using a vars file before comparing", func() {
+			vars := createTestFile(`{"password": "secret"}`)
+			defer os.Remove(vars)
+
+			from := createTestFile(`{"password": "((password))", "user": "((user))"}`)
+			defer os.Remove(from)
+
+			to := createTestFile(`{"password": "secret", "user": "(( user ))"}`)
+			defer os.Remove(to)
+
+			_, err := dyff("between", "--placeholders", "resolve", "--vars-file", vars, from, to)
+			Expect(err).To(MatchError(ContainSubstring("no value for user")))
+
+			out, err := dyff("between", "--omit-header", "--placeholders", "symbolic", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(`
+password
+  ± value change
+    - ((password))
+    + secret
+
+`))
+		})
+
+		It("should evaluate spruce operators before comparing", func() {
+			from := createTestFile(`{"meta": {"name": "app"}, "name": "(( grab meta.name ))"}`)
+			defer os.Remove(from)
+
+			to := createTestFile(`{"meta": {"name": "app"}, "name": "app"}`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--output", "brief", "--spruce-operators", "evaluate", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("no changes detected"))
+		})
+
+		It("should show the line numbers of differences", func() {
+			from := createTestFile("---\nname: app\nversion: 1\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nname: app\n\nversion: 2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--line-numbers", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix(fmt.Sprintf("\nversion  (from: %s:3, to: %s:4)\n", from, to)))
+		})
+
+		It("should show the surrounding lines of the original input file", func() {
+			from := createTestFile("---\nname: app\nversion: 1\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nname: app\nversion: 2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--source-context", "1", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring(fmt.Sprintf("\n  %s\n    2 │ name: app\n  > 3 │ version: 2\n", to)))
+		})
+
+		It("should group the differences by their top-level key", func() {
+			from := createTestFile("---\nmetadata:\n  name: app\nspec:\n  replicas: 1\n  template:\n    image: app:1\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nmetadata:\n  name: app\nspec:\n  replicas: 2\n  template:\n    image: app:2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--group-by-depth", "1", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("\n▶ spec  (two differences)\n\nspec.replicas\n"))
+		})
+
+		It("should only show the changed parts of list entries that were removed and added", func() {
+			from := createTestFile("---\nsteps:\n- run: make lint\n- run: make test\n  timeout: 10m\n  retries: 2\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nsteps:\n- run: make lint\n- run: make test\n  timeout: 20m\n  retries: 2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--no-table-style", "--elide-unchanged", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\n    - run: \"make test\"\n      timeout: 10m\n      …\n"))
+			Expect(out).ToNot(ContainSubstring("retries"))
+		})
+
+		It("should ignore strings that only differ in trailing newlines", func() {
+			from := createTestFile("---\nscript: |\n  echo hello\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nscript: echo hello\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--ignore-trailing-newline-changes", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("\n"))
+		})
+
+		It("should report reordered map keys as a map order change", func() {
+			from := createTestFile("---\na: 1\nb: 2\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nb: 2\na: 1\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--detect-map-order-changes", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+(root level)
+⇆ map order changed
+  - a, b
+  + b, a
+
+`))
+		})
+
+		It("should show the types of changed values", func() {
+			from := createTestFile("---\nport: \"8080\"\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nport: 8080\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--value-types", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\n    - !!str 8080\n    + !!int 8080\n"))
+		})
+
+		It("should report values that only differ in their representation if configured", func() {
+			from := createTestFile("---\nreplicas: \"3\"\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nreplicas: 3\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--coerce-types", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\nreplicas\n  ≃ representation change from string to int\n"))
+
+			out, err = dyff("between", "--output", "json", "--coerce-types", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring(`"kind": "representation-change"`))
+
+			_, err = dyff("between", "--coerce-types", "--fail-on", "modification,type-change", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(ExitCode).Value()).To(Equal(0))
+
+			_, err = dyff("between", "--coerce-types", "--fail-on", "representation-change", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(ExitCode).Value()).To(Equal(1))
+		})
+
+		It("should compare values after applying the configured transformations", func() {
+			from := createTestFile("---\nmetadata:\n  name: web-1a2b3c4d\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nmetadata:\n  name: web-9f8e7d6c\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--transform", "metadata.name: s/-[a-f0-9]{8}$//", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("\n"))
+
+			_, err = dyff("between", "--omit-header", "--transform", "metadata.name", from, to)
+			Expect(err).To(MatchError("invalid transform metadata.name, expected format is <path>: s/<regexp>/<replacement>/[g]"))
+		})
+
+		It("should apply transformations at dot-style paths with named list entries", func() {
+			from := createTestFile("---\nspec:\n  containers:\n  - name: web\n    image: web@sha256:1a2b3c4d\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nspec:\n  containers:\n  - name: web\n    image: web@sha256:9f8e7d6c\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--transform", "spec.containers.web.image: s/@sha256:.*$//", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("\n"))
+		})
+
+		It("should compare a single input file against an empty document", func() {
+			filename := createTestFile("---\nname: foo\n---\n- x\n")
+			defer os.Remove(filename)
+
+			out, err := dyff("between", "--omit-header", "--from-empty", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+(root level)  (document #1)
++ one map entry added:
+  name: foo
+
+(root level)  (document #2)
++ one list entry added:
+  - x
+
+`))
+
+			out, err = dyff("between", "--omit-header", "--to-empty", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\n(root level)  (document #1)\n- one map entry removed:\n  name: foo\n"))
+
+			_, err = dyff("between", "--from-empty", filename, filename)
+			Expect(err).To(MatchError("accepts 1 arg(s), received 2"))
+
+			_, err = dyff("between", "--from-empty", "--to-empty", filename)
+			Expect(err).To(MatchError("incompatible flags: cannot use from empty flag in combination with to empty flag"))
+		})
+
+		It("should only change the root of the input file that is compared against an empty document", func() {
+			filename := createTestFile("---\nspec:\n  a: {x: 1}\n  b: [one]\n")
+			defer os.Remove(filename)
+
+			out, err := dyff("between", "--omit-header", "--from-empty", "--chroot", "spec", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+(root level)
++ two map entries added:
+  a:
+    x: 1
+  b:
+  - one
+
+`))
+
+			out, err = dyff("between", "--omit-header", "--to-empty", "--chroot", "spec.a,spec.b", filename)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\nspec.a\n  - one map entry removed:\n    x: 1\n"))
+			Expect(out).To(ContainSubstring("\nspec.b\n  - one list entry removed:\n    - one\n"))
+		})
+
+		It("should compare renamed map entries using the configured path mappings", func() {
+			from := createTestFile("---\nspec:\n  env:\n    A: \"1\"\n    B: \"2\"\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nspec:\n  environment:\n    A: \"1\"\n    B: \"3\"\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--map-path", "old: spec.env new: spec.environment", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+spec.environment.B
+  ± value change
+    - 2
+    + 3
+
+`))
+
+			_, err = dyff("between", "--omit-header", "--map-path", "spec.env", from, to)
+			Expect(err).To(MatchError("invalid path mapping spec.env, expected format is old: <path> new: <path>"))
+		})
+
+		It("should report changed subtrees at the maximum compare depth", func() {
+			from := createTestFile("---\nspec:\n  template:\n    image: x:1\nname: foo\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nspec:\n  template:\n    image: x:2\nname: foo\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--max-compare-depth", "1", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+spec
+  ± subtree changed (one leaf difference)
+
+`))
+		})
+
+		It("should exclude value changes of the given value classes", func() {
+			from := createTestFile("---\nname: \"foo \"\nport: \"8080\"\nreplicas: 1\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nname: foo\nport: 8080\nreplicas: 2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--exclude-class", "whitespace,quoting", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+replicas
+  ± value change
+    - 1
+    + 2
+
+`))
+
+			_, err = dyff("between", "--exclude-class", "noise", from, to)
+			Expect(err).To(MatchError("unknown value class noise, supported classes are: null, whitespace, quoting, or comment"))
+		})
+
+		It("should report type changes as their own kind of difference", func() {
+			from := createTestFile("---\nport: \"8080\"\nname: foo\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nport: 8080\nname: bar\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--type-changes-only", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+port
+  ≠ type change from string to int
+    - 8080
+    + 8080
+
+`))
+		})
+
+		It("should write a summary file independent of the output style", func() {
+			from := createTestFile("---\nname: foo\nold: true\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nname: bar\nnew: true\n")
+			defer os.Remove(to)
+
+			summaryFile := filepath.Join(os.TempDir(), "dyff-summary.json")
+			defer os.Remove(summaryFile)
+
+			_, err := dyff("between", "--output", "brief", "--summary-file", summaryFile, from, to)
+			Expect(err).ToNot(HaveOccurred())
+
+			data, err := os.ReadFile(summaryFile)
+			Expect(err).ToNot(HaveOccurred())
+
+			var summary struct {
+				From struct {
+					Location  string `json:"location"`
+					Documents int    `json:"documents"`
+					SHA256    string `json:"sha256"`
+				} `json:"from"`
+				Options     string         `json:"options"`
+				Differences int            `json:"differences"`
+				Kinds       map[string]int `json:"kinds"`
+				ExitStatus  int            `json:"exit_status"`
+			}
+
+			Expect(json.Unmarshal(data, &summary)).To(Succeed())
+			Expect(summary.From.Location).To(Equal(from))
+			Expect(summary.From.Documents).To(Equal(1))
+			Expect(summary.From.SHA256).To(HaveLen(64))
+			Expect(summary.Options).To(HaveLen(64))
+			Expect(summary.Differences).To(Equal(2))
+			Expect(summary.Kinds).To(Equal(map[string]int{"modification": 1, "removal": 1, "addition": 1}))
+			Expect(summary.ExitStatus).To(Equal(0))
+		})
+
+		It("should show invisible character differences in escaped notation", func() {
+			from := createTestFile("---\nname: \"foo\\u200bbar\"\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nname: foobar\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+name
+  ± invisible character change ⚠
+    - foo\u200bbar     + foobar
+
+`))
+		})
+
+		It("should show the same change at many paths only once", func() {
+			from := createTestFile("---\nname: a\nlabels: {app: x}\n---\nname: b\nlabels: {app: x}\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nname: a\nlabels: {app: x, team: y}\n---\nname: b\nlabels: {app: x, team: y}\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--collapse-repeated", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+labels  (document #1)
+  + one map entry added:
+    team: y
+  same change at one more path:
+    labels  (document #2)
+
+`))
+		})
+
+		It("should show an overview of the differences as a heatmap", func() {
+			from := createTestFile("---\nspec:\n  a: 1\n  b: 1\nname: foo\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nspec:\n  a: 2\n  b: 2\nname: bar\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--output", "heatmap", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`spec ████████████████████████████████████████ 2
+name ████████████████████                     1
+
+`))
+		})
+
+		It("should list the subtrees with the most differences before the differences", func() {
+			from := createTestFile("---\nspec:\n  a: 1\n  b: 1\nname: foo\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nspec:\n  a: 2\n  b: 2\nname: bar\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--largest-subtrees", "1", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix(`
+▶ largest changed subtrees
+  spec  (two differences)
+
+spec.a
+`))
+		})
+
+		It("should only show the first differences if the number of differences is limited", func() {
+			from := createTestFile("---\na: 1\nb: 1\nc: 1\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\na: 2\nb: 2\nc: 2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--max-diffs", "1", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+a
+  ± value change
+    - 1
+    + 2
+
+… and two more differences (rerun with --max-diffs 0)
+
+`))
+		})
+
+		It("should report purely stylistic changes as their own kind of difference", func() {
+			from := createTestFile("---\nname: \"foo\"\nlist: [1, 2]\nreplicas: 1\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nname: foo\nlist:\n- 1\n- 2\nreplicas: 2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--detect-style-changes", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+name
+  ≈ style change from double quoted to plain
+    - "foo"
+    + foo
+
+list
+  ≈ style change from flow to block
+    - [1, 2]
+    + - 1
+      - 2
+
+replicas
+  ± value change
+    - 1
+    + 2
+
+`))
+
+			out, err = dyff("between", "--omit-header", "--ignore-style-changes", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+replicas
+  ± value change
+    - 1
+    + 2
+
+`))
+		})
+
+		It("should only report additions or removals if configured", func() {
+			from := createTestFile("---\nname: foo\nold: true\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nname: bar\nnew: true\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--additions-only", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+(root level)
++ one map entry added:
+  new: true
+
+`))
+
+			out, err = dyff("between", "--omit-header", "--removals-only", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+(root level)
+- one map entry removed:
+  old: true
+
+`))
+
+			_, err = dyff("between", "--additions-only", "--removals-only", from, to)
+			Expect(err).To(MatchError("incompatible flags: cannot use additions only flag in combination with removals only flag"))
+		})
+
+		It("should show the given descriptions instead of the input file locations", func() {
+			from := createTestFile("---\nversion: 1\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nversion: 2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--output", "brief", "--from-description", "live state", "--to-description", "desired state", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal("one change detected between live state and desired state\n\n"))
+		})
+
+		It("should wrap long lines at the fixed terminal width unless disabled", func() {
+			from := createTestFile("---\ndescription: a short description\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\ndescription: a much longer description that does not fit\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--fixed-width", "30", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\n    + a much longer\n      description that does\n      not fit\n"))
+
+			out, err = dyff("between", "--omit-header", "--fixed-width", "30", "--no-wrap", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\n    + a much longer description that does not fit\n"))
+		})
+
+		It("should write the differences as editor problem lines", func() {
+			from := createTestFile("---\nname: app\nversion: 1\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nname: app\n\nversion: 2\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--output", "editor", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(fmt.Sprintf("%s:4:10: version: value change from 1 to 2\n", to)))
+		})
+
+		It("should summarize the changed resources in a table", func() {
+			from := createTestFile(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config"}, "data": {"a": "1"}}`)
+			defer os.Remove(from)
+
+			to := createTestFile(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config"}, "data": {"a": "2"}}`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--output", "table", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(`Namespace Name   Kind      Op     Changes
+-         config ConfigMap update       1
+
+Op: 0 create, 0 delete, 1 update
+`))
+		})
+
+		It("should only compare the Helm values of releases", func() {
+			from := createTestFile(`---
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: app
+spec:
+  interval: 5m
+  values:
+    replicas: 1
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: app
+spec:
+  interval: 10m
+  values:
+    replicas: 2
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--helm-values", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(`
+values.replicas
+  ± value change
+    - 1
+    + 2
+
+`))
+		})
+
+		It("should compare BOSH manifests with credential placeholders using the preset", func() {
+			from := createTestFile(`---
+name: app
+instance_groups:
+- name: web
+  properties:
+    password: ((web_password))
+    url: https://((domain))/login
+variables:
+- name: web_password
+  type: password
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+name: app
+instance_groups:
+- name: web
+  properties:
+    password: 5ecr3t
+    url: https://example.com/login
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--output", "brief", "--preset", "bosh", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("no changes detected"))
+		})
+
+		It("should show the changes of Concourse pipelines per job using the preset", func() {
+			from := createTestFile(`---
+resources:
+- name: repo
+  type: git
+  source: {branch: main}
+jobs:
+- name: test
+  plan:
+  - get: repo
+  - task: unit
+- name: build
+  plan:
+  - get: repo
+  - task: compile
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+resources:
+- name: repo
+  type: git
+  source: {branch: develop}
+jobs:
+- name: build
+  plan:
+  - get: repo
+  - task: package
+- name: test
+  plan:
+  - get: repo
+  - task: integration
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--ignore-order-changes", "--preset", "concourse", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("\nresources.repo.source.branch\n"))
+			Expect(out).To(ContainSubstring("\n▶ job test  (one difference)\n\njobs.test.plan  (jobs[0→1] name=test)\n"))
+			Expect(out).To(ContainSubstring("\n▶ job build  (one difference)\n\njobs.build.plan  (jobs[1→0] name=build)\n"))
+			Expect(strings.Index(out, "▶ job test")).To(BeNumerically("<", strings.Index(out, "▶ job build")))
+		})
+
+		It("should report document order changes of files with multiple documents using the concourse preset", func() {
+			from := createTestFile(`---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: one}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: two}
+jobs:
+- name: build
+  plan: [{get: repo}]
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: two}
+jobs:
+- name: build
+  plan: [{get: other}]
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: one}
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--preset", "concourse", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("⇆ order changed"))
+			Expect(out).To(ContainSubstring("\n▶ job build  (one difference)\n"))
+		})
+
+		It("should compare Prometheus rules by name ignoring the formatting of expressions using the preset", func() {
+			from := createTestFile(`---
+groups:
+- name: app
+  rules:
+  - record: job:errors:rate5m
+    expr: sum by (job) (rate(errors_total[5m]))
+  - alert: HighErrorRate
+    expr: |
+      job:errors:rate5m{job="app"}
+        > 0.1
+    for: 10m
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+groups:
+- name: app
+  rules:
+  - alert: HighErrorRate
+    expr: job:errors:rate5m{job="app"} > 0.1
+    for: 5m
+  - record: job:errors:rate5m
+    expr: sum by(job)(rate(errors_total[5m]))
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--ignore-order-changes", "--preset", "prometheus", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(`
+▶ group app, alert HighErrorRate  (one difference)
+
+groups.app.rules.HighErrorRate.for  (rules[1→0] alert=HighErrorRate)
+  ± value change
+    - 10m
+    + 5m
+
+`))
+		})
+
+		It("should group the changes of OpenAPI specifications by whether they are breaking using the preset", func() {
+			from := createTestFile(`---
+openapi: 3.0.0
+info: {title: Pets, version: "1.0"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Pet"}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id: {type: integer}
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+openapi: 3.0.0
+info: {title: Pets, version: "1.1"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Pet"}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id: {type: string}
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--preset", "openapi", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("\n▶ non-breaking changes  (one difference)\n\ninfo.version\n"))
+			Expect(out).To(ContainSubstring("\n▶ breaking changes  (two differences)\n\npaths./pets.get.responses.200.content.application/json.schema.properties.id.type\n"))
+			Expect(out).To(ContainSubstring("\ncomponents.schemas.Pet.properties.id.type\n"))
+		})
+
+		It("should fail for an unknown preset", func() {
+			_, err := dyff("between", "--preset", "foo", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).To(MatchError(ContainSubstring("unknown preset foo, supported presets are: bosh, concourse, helm, kubernetes, openapi, prometheus")))
+		})
+
+		It("should fail for an unknown placeholder mode", func() {
+			_, err := dyff("between", "--placeholders", "foo", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).To(MatchError(ContainSubstring("unknown placeholder mode foo")))
+		})
+
+		It("should decrypt SOPS encrypted input files before comparing them", func() {
+			bin := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(bin, "sops"), []byte("#!/bin/sh\necho 'password: decrypted'\n"), 0755)).To(Succeed())
+			GinkgoT().Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+			from := createTestFile(`{"password": "ENC[AES256_GCM,data:b3Bh,type:str]", "sops": {"mac": "ENC[AES256_GCM,data:bWFj,type:str]"}}`)
+			defer os.Remove(from)
+
+			to := createTestFile(`{"password": "plain"}`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--sops", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(`
+password
+  ± value change
+    - decrypted
+    + plain
+
+`))
+		})
+
+		It("should write the report as JSON", func() {
+			out, err := dyff("between", "--output", "json", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(HavePrefix("{\n  \"schema_version\": \"1.7\",\n"))
+		})
+
+		It("should explain the decisions of the comparison and the filters", func() {
+			from := createTestFile(`{"list": [{"name": "a", "value": 1}], "other": 1}`)
+			defer os.Remove(from)
+
+			to := createTestFile(`{"list": [{"name": "a", "value": 2}], "other": 2}`)
+			defer os.Remove(to)
+
+			out, err := captureStderr(func() error {
+				_, err := dyff("between", "--debug-compare", "--exclude", "/other", "--exclude", "/unknown", from, to)
+				return err
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("debug: /list (document #1): matching list entries by the identifier name\n"))
+			Expect(out).To(ContainSubstring("debug: --exclude /unknown does not match any difference\n"))
+			Expect(out).To(ContainSubstring("debug: /other (document #1): removing the difference, because of --exclude\n"))
+		})
+
+		It("should fail to watch input from stdin", func() {
+			_, err := dyff("between", "--watch", "-", assets("examples", "to.yml"))
+			Expect(err).To(MatchError("watch mode only supports local files, but stdin is not one"))
+		})
+
+		It("should not change the output when progress is shown", func() {
+			from, to := assets("examples", "from.yml"), assets("examples", "to.yml")
+
+			expected, err := dyff("between", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+
+			out, err := dyff("between", "--omit-header", "--progress", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal(expected))
+		})
+
+		It("should fail when change root is used with files containing multiple documents", func() {
+			from, to := assets("testbed", "from.yml"), assets("testbed", "to.yml")
+			_, err := dyff("between", from, to, "--chroot", "orderchanges")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(BeEquivalentTo(fmt.Sprintf("failed to change root of %s to path orderchanges: change root for an input file is only possible if there is only one document, but %s contains two documents", from, from)))
 		})
 
 		It("should fail when change root is used with files that do not have the specified path", func() {
@@ -461,38 +2453,314 @@ example_two
 			os.Setenv("KUBECTL_EXTERNAL_DIFF", "cmd.test between --omit-header")
 			defer os.Setenv("KUBECTL_EXTERNAL_DIFF", tmp)
 
-			_, err = dyff(from, to, "between", "--omit-header")
+			_, err = dyff(from, to, "between", "--omit-header")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should compare Kubernetes resources using the Kubernetes mode", func() {
+			from := createTestFile(`---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  creationTimestamp: "2024-01-01T00:00:00Z"
+  managedFields:
+  - manager: kubectl
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        env:
+        - name: A
+          value: "1"
+        - name: B
+          value: "2"
+status:
+  replicas: 1
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  creationTimestamp: "2025-01-01T00:00:00Z"
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        env:
+        - name: A
+          value: "1"
+        - name: B
+          value: "3"
+status:
+  replicas: 3
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", "--kubernetes", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+spec.template.spec.containers.app.env.B.value  (apps/v1/Deployment/web)
+  ± value change
+    - 2
+    + 3
+
+`))
+		})
+
+		Context("comparing directories", func() {
+			var from, to string
+
+			writeFile := func(dir string, name string, content string) {
+				location := filepath.Join(dir, name)
+				Expect(os.MkdirAll(filepath.Dir(location), os.FileMode(0755))).To(Succeed())
+				Expect(os.WriteFile(location, []byte(content), os.FileMode(0644))).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				from, to = createTestDirectory(), createTestDirectory()
+
+				writeFile(from, "config/app.yml", "name: app\nreplicas: 1\n")
+				writeFile(to, "config/app.yml", "name: app\nreplicas: 2\n")
+				writeFile(from, "config/same.json", `{"foo": "bar"}`)
+				writeFile(to, "config/same.json", `{"foo": "bar"}`)
+				writeFile(from, "removed.yaml", "removed: true\n")
+				writeFile(to, "added.json", `{"added": true}`)
+				writeFile(to, "README.md", "not compared\n")
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(from)
+				os.RemoveAll(to)
+			})
+
+			It("should report changed, added, and removed files of both directory trees", func() {
+				out, err := dyff("between", "--omit-header", from, to)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(out).To(BeEquivalentTo(fmt.Sprintf(`
+(root level)  (%s)
++ one document added:
+  ---
+  added: true
+
+replicas  (%s)
+  ± value change
+    - 1
+    + 2
+
+(root level)  (%s)
+- one document removed:
+  ---
+  removed: true
+
+`, filepath.Join(to, "added.json"), filepath.Join(from, "config", "app.yml"), filepath.Join(from, "removed.yaml"))))
+			})
+
+			It("should only compare the files that match the include and exclude patterns", func() {
+				out, err := dyff("between", "--omit-header", "--output", "brief", "--include-files", "config/*", "--exclude-files", "*.json", from, to)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(out).To(BeEquivalentTo(fmt.Sprintf("one change detected between %s and %s\n\n", from, to)))
+			})
+
+			It("should fail with flags that cannot be used for directories", func() {
+				_, err := dyff("between", "--stream", from, to)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("incompatible flags"))
+
+				_, err = dyff("between", "--include-files", "[", from, to)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid file pattern"))
+			})
+		})
+
+		It("should compare plain input files that start with a non-ASCII character", func() {
+			from := createTestFile("Übersicht:\n  name: foo\n")
+			defer os.Remove(from)
+
+			to := createTestFile("Übersicht:\n  name: bar\n")
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+Übersicht.name
+  ± value change
+    - foo
+    + bar
+
+`))
+		})
+
+		It("should create exit code zero if there are no changes", func() {
+			from := createTestFile(`{"foo": "bar"}`)
+			defer os.Remove(from)
+
+			to := createTestFile(`{"foo": "bar"}`)
+			defer os.Remove(to)
+
+			_, err := dyff("between", "--set-exit-code", from, to)
+			Expect(err).To(HaveOccurred())
+
+			exitCode, ok := err.(ExitCode)
+			Expect(ok).To(BeTrue())
+			Expect(exitCode.Value()).To(Equal(0))
+		})
+
+		It("should create exit code one if there are changes", func() {
+			from := createTestFile(`{"foo": "bar"}`)
+			defer os.Remove(from)
+
+			to := createTestFile(`{"foo": "BAR"}`)
+			defer os.Remove(to)
+
+			_, err := dyff("between", "--set-exit-code", from, to)
+			Expect(err).To(HaveOccurred())
+
+			exitCode, ok := err.(ExitCode)
+			Expect(ok).To(BeTrue())
+			Expect(exitCode.Value()).To(Equal(1))
+		})
+
+		It("should create exit code one only for differences of the configured severity", func() {
+			from := createTestFile("---\nspec: {replicas: 1}\nname: foo\n")
+			defer os.Remove(from)
+
+			to := createTestFile("---\nspec: {replicas: 2}\nname: bar\n")
+			defer os.Remove(to)
+
+			rules := createTestFile("---\n- path: ^/spec/\n  severity: warning\n")
+			defer os.Remove(rules)
+
+			_, err := dyff("between", "--severity-rules", rules, "--fail-on-severity", "critical", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(ExitCode).Value()).To(Equal(0))
+
+			out, err := dyff("between", "--omit-header", "--severity-rules", rules, "--fail-on-severity", "warning", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(ExitCode).Value()).To(Equal(1))
+			Expect(out).To(BeEquivalentTo(`
+spec.replicas  ! warning
+  ± value change
+    - 1
+    + 2
+
+name  info
+  ± value change
+    - foo
+    + bar
+
+`))
+		})
+
+		It("should label a moved Kubernetes resource as moved", func() {
+			from := createTestFile(`---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: config, namespace: old}
+data: {a: "1", b: "2", c: "3", d: "4", e: "5", f: "6", g: "7", h: "8"}
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: config, namespace: new}
+data: {a: "1", b: "2", c: "3", d: "4", e: "5", f: "6", g: "7", h: "9"}
+`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+(root level)
+→ document moved
+  - v1/ConfigMap/old/config
+  + v1/ConfigMap/new/config
+
+metadata.namespace
+  ± value change
+    - old
+    + new
+
+data.h
+  ± value change
+    - 8
+    + 9
+
+`))
+
+			out, err = dyff("between", "--output", "json", from, to)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring(`"kind": "rename"`))
+
+			_, err = dyff("between", "--fail-on", "rename", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(ExitCode).Value()).To(Equal(1))
 		})
 
-		It("should create exit code zero if there are no changes", func() {
-			from := createTestFile(`{"foo": "bar"}`)
+		It("should create an exit code only for differences of the configured kinds", func() {
+			from := createTestFile("---\nname: foo\nlist: [a, b]\n")
 			defer os.Remove(from)
 
-			to := createTestFile(`{"foo": "bar"}`)
+			to := createTestFile("---\nname: bar\nlist: [a, b, c]\n")
 			defer os.Remove(to)
 
-			_, err := dyff("between", "--set-exit-code", from, to)
+			_, err := dyff("between", "--fail-on", "removal", from, to)
 			Expect(err).To(HaveOccurred())
+			Expect(err.(ExitCode).Value()).To(Equal(0))
 
-			exitCode, ok := err.(ExitCode)
-			Expect(ok).To(BeTrue())
-			Expect(exitCode.Value()).To(Equal(0))
+			_, err = dyff("between", "--fail-on", "removal,modification", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(ExitCode).Value()).To(Equal(1))
+
+			_, err = dyff("between", "--fail-on", "copy", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown kind of difference copy"))
 		})
 
-		It("should create exit code one if there are changes", func() {
-			from := createTestFile(`{"foo": "bar"}`)
+		It("should create distinct exit codes for additions, modifications, and removals", func() {
+			from := createTestFile("---\nname: foo\nlist: [a, b]\n")
 			defer os.Remove(from)
 
-			to := createTestFile(`{"foo": "BAR"}`)
+			for to, expected := range map[string]int{
+				"---\nname: foo\nlist: [a, b]\n":    0,
+				"---\nname: foo\nlist: [a, b, c]\n": 2,
+				"---\nname: bar\nlist: [a, b, c]\n": 3,
+				"---\nname: bar\nlist: [a]\n":       4,
+			} {
+				to := createTestFile(to)
+				defer os.Remove(to)
+
+				_, err := dyff("between", "--distinct-exit-codes", from, to)
+				Expect(err).To(HaveOccurred())
+				Expect(err.(ExitCode).Value()).To(Equal(expected))
+
+				_, err = dyff("between", "--stream", "--distinct-exit-codes", from, to)
+				Expect(err).To(HaveOccurred())
+				Expect(err.(ExitCode).Value()).To(Equal(expected))
+			}
+
+			to := createTestFile("---\nname: bar\nlist: [a]\n")
 			defer os.Remove(to)
 
-			_, err := dyff("between", "--set-exit-code", from, to)
+			_, err := dyff("between", "--distinct-exit-codes", "--fail-on", "addition,modification", from, to)
 			Expect(err).To(HaveOccurred())
-
-			exitCode, ok := err.(ExitCode)
-			Expect(ok).To(BeTrue())
-			Expect(exitCode.Value()).To(Equal(1))
+			Expect(err.(ExitCode).Value()).To(Equal(3))
 		})
 
 		It("should fail with an exit code other than zero or one in case of an error", func() {
@@ -611,6 +2879,243 @@ spec.replicas  (apps/v1/Deployment/test)
 		})
 	})
 
+	Context("color themes", func() {
+		var from, to string
+
+		BeforeEach(func() {
+			from = createTestFile(`{"foo": "bar"}`)
+			to = createTestFile(`{"foo": "BAR", "new": "entry"}`)
+		})
+
+		AfterEach(func() {
+			os.Remove(from)
+			os.Remove(to)
+		})
+
+		It("should use the dark theme colors by default", func() {
+			out, err := dyff("between", "--color=on", "--truecolor=on", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("38;2;199;196;63"))
+			Expect(out).ToNot(ContainSubstring("38;2;154;103;0"))
+		})
+
+		It("should use the light theme colors when configured", func() {
+			out, err := dyff("between", "--color=on", "--truecolor=on", "--theme=light", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("38;2;154;103;0"))
+			Expect(out).ToNot(ContainSubstring("38;2;199;196;63"))
+		})
+
+		It("should pick the light theme based on the terminal background reported in COLORFGBG", func() {
+			var tmp = os.Getenv("COLORFGBG")
+			os.Setenv("COLORFGBG", "0;15")
+			defer os.Setenv("COLORFGBG", tmp)
+
+			out, err := dyff("between", "--color=on", "--truecolor=on", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("38;2;154;103;0"))
+		})
+
+		It("should translate colors to the 256 color palette when configured", func() {
+			out, err := dyff("between", "--color=on", "--color-depth=256", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\x1b[38;5;"))
+			Expect(out).ToNot(ContainSubstring("38;2;"))
+		})
+
+		It("should translate colors to the eight standard colors when configured", func() {
+			out, err := dyff("between", "--color=on", "--color-depth=8", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\x1b[33m± value change"))
+			Expect(out).ToNot(ContainSubstring("38;2;"))
+			Expect(out).ToNot(ContainSubstring("38;5;"))
+		})
+
+		It("should not enable true colors when they were turned off explicitly", func() {
+			DeferCleanup(bunt.TrueColorSetting.Set, "auto")
+
+			out, err := dyff("between", "--color=on", "--truecolor=off", "--color-depth=24bit", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\x1b[93m± value change"))
+			Expect(out).ToNot(ContainSubstring("38;2;"))
+		})
+
+		It("should fail when an unknown color depth is used", func() {
+			_, err := dyff("between", "--color-depth=42", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown color depth 42"))
+		})
+
+		It("should fail when an unknown theme is used", func() {
+			_, err := dyff("between", "--theme=neon", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown theme neon"))
+		})
+	})
+
+	Context("hyperlinks", func() {
+		var from, to string
+
+		BeforeEach(func() {
+			from = createTestFile("---\nfoo: bar\n")
+			to = createTestFile("---\nfoo: bar\nnew: entry\n")
+		})
+
+		AfterEach(func() {
+			os.Remove(from)
+			os.Remove(to)
+		})
+
+		It("should not render hyperlinks by default", func() {
+			out, err := dyff("between", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).ToNot(ContainSubstring("\x1b]8;;"))
+		})
+
+		It("should render file names and paths as hyperlinks when enabled", func() {
+			out, err := dyff("between", "--hyperlinks=on", from, to)
+			Expect(err).ToNot(HaveOccurred())
+
+			abs, _ := filepath.Abs(to)
+			host, _ := os.Hostname()
+			Expect(out).To(ContainSubstring("\x1b]8;;file://" + host + filepath.ToSlash(abs) + "\x1b\\"))
+			Expect(out).To(ContainSubstring("\x1b]8;;file://" + host + filepath.ToSlash(abs) + "#3\x1b\\(root level)\x1b]8;;\x1b\\"))
+		})
+
+		It("should use the configured hyperlink template", func() {
+			out, err := dyff("between", "--omit-header", "--hyperlinks=on", "--hyperlink-template=https://example.org/blob/main/{location}#L{line}", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("\x1b]8;;https://example.org/blob/main/" + to + "#L3\x1b\\"))
+		})
+
+		It("should fail when an unknown hyperlinks setting is used", func() {
+			_, err := dyff("between", "--hyperlinks=maybe", from, to)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unknown hyperlinks setting maybe"))
+		})
+	})
+
+	Context("path completion", func() {
+		var from, to string
+
+		BeforeEach(func() {
+			from = createTestFile("---\nspec:\n  list: [one]\n")
+			to = createTestFile("---\nspec:\n  map: {key: value}\n")
+		})
+
+		AfterEach(func() {
+			os.Remove(from)
+			os.Remove(to)
+		})
+
+		It("should complete filter arguments with the paths of the input files", func() {
+			out, err := dyff("__complete", "between", from, to, "--filter", "spec.")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal("spec.list\nspec.list.0\nspec.map\nspec.map.key\n:4\n"))
+		})
+
+		It("should complete change root arguments using Go-patch style paths", func() {
+			out, err := dyff("__complete", "between", from, to, "--chroot", "/spec/m")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal("/spec/map\n/spec/map/key\n:4\n"))
+		})
+
+		It("should complete the path argument of the get command", func() {
+			out, err := dyff("__complete", "get", from, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Equal("spec\nspec.list\nspec.list.0\n:4\n"))
+		})
+	})
+
+	Context("bench command", func() {
+		It("should report the timings of all phases", func() {
+			out, err := dyff("bench", "--iterations", "2", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).ToNot(HaveOccurred())
+
+			lines := strings.Split(out, "\n")
+			Expect(lines[0]).To(HavePrefix("compared "))
+			Expect(lines[0]).To(ContainSubstring(" 2 times, found "))
+			Expect(lines[2]).To(MatchRegexp(`^phase +total +average +minimum +maximum$`))
+			Expect(lines[3]).To(HavePrefix("parse "))
+			Expect(lines[4]).To(HavePrefix("compare "))
+			Expect(lines[5]).To(HavePrefix("render "))
+		})
+
+		It("should fail if there is not at least one iteration", func() {
+			_, err := dyff("bench", "--iterations", "0", assets("examples", "from.yml"), assets("examples", "to.yml"))
+			Expect(err).To(MatchError("invalid number of iterations 0, at least one iteration is required"))
+		})
+	})
+
+	Context("helm command", func() {
+		BeforeEach(func() {
+			dir := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(dir, "helm"), []byte(`#!/bin/sh
+case "$3" in
+old) cat <<EOF
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: release-web
+  labels:
+    helm.sh/chart: web-1.0.0
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: release-web
+spec:
+  replicas: 1
+EOF
+;;
+new) cat <<EOF
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: release-web
+spec:
+  replicas: 2
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: release-web
+  labels:
+    helm.sh/chart: web-2.0.0
+EOF
+;;
+*) echo "Error: chart not found" >&2; exit 1;;
+esac
+`), 0755)).To(Succeed())
+
+			path := os.Getenv("PATH")
+			os.Setenv("PATH", dir+string(os.PathListSeparator)+path)
+			DeferCleanup(func() { os.Setenv("PATH", path) })
+		})
+
+		It("should compare the rendered resources of both charts grouped by resource", func() {
+			out, err := dyff("helm", "--omit-header", "old", "new")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(`
+▶ apps/v1/Deployment/release-web  (one difference)
+
+spec.replicas  (apps/v1/Deployment/release-web)
+  ± value change
+    - 1
+    + 2
+
+`))
+		})
+
+		It("should fail if a chart cannot be rendered", func() {
+			_, err := dyff("helm", "old", "missing")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to render chart missing"))
+		})
+	})
+
 	Context("last-applied command", func() {
 		It("should create the default report when there are no flags specified", func() {
 			kubeYAML := createTestFile(`---
@@ -654,4 +3159,347 @@ foo: bar
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("pr command", func() {
+		var server *httptest.Server
+		var requests []string
+		var files map[string]string
+
+		BeforeEach(func() {
+			requests = nil
+			files = map[string]string{
+				"base": "name: foo\nversion: 1\n",
+				"head": "name: foo\nversion: 2\n",
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/repos/org/name/pulls/42", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"base": {"sha": "tip"}, "head": {"sha": "head"}}`))
+			})
+
+			mux.HandleFunc("/repos/org/name/compare/tip...head", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"merge_base_commit": {"sha": "base"}}`))
+			})
+
+			mux.HandleFunc("/repos/org/name/contents/deploy/values.yml", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Header.Get("Authorization"))
+				if data, ok := files[r.URL.Query().Get("ref")]; ok {
+					_, _ = w.Write([]byte(data))
+					return
+				}
+
+				http.NotFound(w, r)
+			})
+
+			mux.HandleFunc("/projects/org%2Fname/merge_requests/42", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"diff_refs": {"base_sha": "base", "head_sha": "head"}}`))
+			})
+
+			mux.HandleFunc("/projects/org%2Fname/repository/files/deploy%2Fvalues.yml/raw", func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Header.Get("PRIVATE-TOKEN"))
+				_, _ = w.Write([]byte(files[r.URL.Query().Get("ref")]))
+			})
+
+			server = httptest.NewServer(mux)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should compare the file of a GitHub pull request", func() {
+			os.Setenv("GITHUB_TOKEN", "secret")
+			defer os.Unsetenv("GITHUB_TOKEN")
+
+			out, err := dyff("pr", "--api-url", server.URL, "--repo", "org/name", "--pr", "42", "--file", "deploy/values.yml", "--output", "brief")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("one change detected between org/name@base:deploy/values.yml and org/name@head:deploy/values.yml\n\n"))
+			Expect(requests).To(Equal([]string{"Bearer secret", "Bearer secret"}))
+		})
+
+		It("should compare the file of a GitLab merge request", func() {
+			out, err := dyff("pr", "--forge", "gitlab", "--api-url", server.URL, "--repo", "org/name", "--pr", "42", "--file", "deploy/values.yml", "--output", "brief")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("one change detected between org/name@base:deploy/values.yml and org/name@head:deploy/values.yml\n\n"))
+		})
+
+		It("should compare a file added by the pull request against an empty document", func() {
+			delete(files, "base")
+
+			out, err := dyff("pr", "--api-url", server.URL, "--repo", "org/name", "--pr", "42", "--file", "deploy/values.yml", "--output", "brief")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo("one change detected between empty document and org/name@head:deploy/values.yml\n\n"))
+		})
+
+		It("should fail for a pull request that does not exist", func() {
+			_, err := dyff("pr", "--api-url", server.URL, "--repo", "org/name", "--pr", "1", "--file", "deploy/values.yml")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to look up pull request 1 of org/name"))
+		})
+	})
+
+	Context("poll and history command", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "dyff-history")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should store the snapshots that changed and show what changed between them", func() {
+			// The script returns a new version every second time it is called
+			script := filepath.Join(dir, "version.sh")
+			Expect(os.WriteFile(script, []byte(`#!/bin/sh
+count=$(cat "$0.count" 2>/dev/null || echo 0)
+echo $((count + 1)) > "$0.count"
+echo "name: foo"
+echo "version: $((count / 2))"
+`), 0755)).To(Succeed())
+
+			history := filepath.Join(dir, "history")
+			_, err := dyff("poll", "--command", script, "--interval", "10ms", "--count", "5", "--history-dir", history)
+			Expect(err).ToNot(HaveOccurred())
+
+			snapshots, err := filepath.Glob(filepath.Join(history, "*.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshots).To(HaveLen(3))
+
+			reports, err := filepath.Glob(filepath.Join(history, "*.json"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reports).To(HaveLen(2))
+
+			out, err := dyff("history", "--history-dir", history, "--output", "brief")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("one change detected between"))
+
+			_, err = dyff("history", "--history-dir", history, "--since", "2000-01-01 10:00")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no snapshot found at 2000-01-01 10:00"))
+		})
+
+		It("should run the command using the shell", func() {
+			history := filepath.Join(dir, "history")
+			_, err := dyff("poll", "--command", `printf 'name: "foo bar"\n' | cat`, "--interval", "10ms", "--count", "1", "--history-dir", history)
+			Expect(err).ToNot(HaveOccurred())
+
+			snapshots, err := filepath.Glob(filepath.Join(history, "*.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshots).To(HaveLen(1))
+
+			data, err := os.ReadFile(snapshots[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("foo bar"))
+		})
+
+		It("should stop when taking a snapshot fails repeatedly", func() {
+			stderr, err := captureStderr(func() error {
+				_, err := dyff("poll", "--command", "echo unavailable >&2; exit 1", "--interval", "1ms", "--history-dir", filepath.Join(dir, "history"))
+				return err
+			})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to take snapshot 5 times in a row"))
+			Expect(err.Error()).To(ContainSubstring("unavailable"))
+			Expect(stderr).To(ContainSubstring("failed to take snapshot: command echo unavailable >&2; exit 1 failed"))
+		})
+	})
+
+	Context("serve command", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			ResetSettings()
+			bunt.SetColorSettings(bunt.OFF, bunt.OFF)
+			server = httptest.NewServer(NewServeHandler())
+		})
+
+		AfterEach(func() {
+			server.Close()
+			bunt.SetColorSettings(bunt.AUTO, bunt.AUTO)
+		})
+
+		post := func(url string, contentType string, body string) (int, string) {
+			resp, err := http.Post(server.URL+url, contentType, strings.NewReader(body))
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+
+			data, err := io.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			return resp.StatusCode, string(data)
+		}
+
+		It("should compare documents of a JSON request", func() {
+			status, out := post("/compare", "application/json", `{"from": "foo: bar", "to": "foo: baz", "options": {"output": "brief"}}`)
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(out).To(BeEquivalentTo("one change detected between from and to\n\n"))
+		})
+
+		It("should compare documents of a multipart form request with options as query parameters", func() {
+			body := "--boundary\r\n" +
+				"Content-Disposition: form-data; name=\"from\"; filename=\"old.yml\"\r\n\r\n" +
+				"foo: bar\r\n" +
+				"--boundary\r\n" +
+				"Content-Disposition: form-data; name=\"to\"; filename=\"new.yml\"\r\n\r\n" +
+				"foo: baz\r\n" +
+				"--boundary--\r\n"
+
+			status, out := post("/compare?output=brief", "multipart/form-data; boundary=boundary", body)
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(out).To(BeEquivalentTo("one change detected between old.yml and new.yml\n\n"))
+		})
+
+		It("should reject options that are not supported by the server", func() {
+			status, out := post("/compare", "application/json", `{"from": "foo: bar", "to": "foo: baz", "options": {"exec-comparer": "foo=cat"}}`)
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(out).To(ContainSubstring("unsupported option exec-comparer"))
+		})
+
+		It("should reject invalid regular expressions", func() {
+			status, _ := post("/compare", "application/json", `{"from": "foo: bar", "to": "foo: baz", "options": {"exclude-regexp": ["("]}}`)
+			Expect(status).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should reject requests without both input documents", func() {
+			status, out := post("/compare", "application/json", `{"from": "foo: bar"}`)
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(out).To(ContainSubstring("missing input to"))
+		})
+
+		It("should return JSON Patch reports with their media type", func() {
+			resp, err := http.Post(server.URL+"/compare?output=jsonpatch", "application/json", strings.NewReader(`{"from": "foo: bar", "to": "foo: baz"}`))
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+
+			data, err := io.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Header.Get("Content-Type")).To(Equal("application/json-patch+json"))
+			Expect(string(data)).To(MatchJSON(`[{"op": "replace", "path": "/foo", "value": "baz"}]`))
+		})
+
+		It("should expose metrics of the comparisons", func() {
+			status, _ := post("/compare", "application/json", `{"from": "foo: bar\na: 1", "to": "foo: baz\nb: 2"}`)
+			Expect(status).To(Equal(http.StatusOK))
+
+			status, _ = post("/compare", "application/json", `{"from": "foo: bar"}`)
+			Expect(status).To(Equal(http.StatusBadRequest))
+
+			resp, err := http.Get(server.URL + "/metrics")
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+
+			data, err := io.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Header.Get("Content-Type")).To(HavePrefix("text/plain; version=0.0.4"))
+			Expect(string(data)).To(ContainSubstring(`dyff_comparisons_total{code="200"} 1` + "\n"))
+			Expect(string(data)).To(ContainSubstring(`dyff_comparisons_total{code="400"} 1` + "\n"))
+			Expect(string(data)).To(ContainSubstring(`dyff_comparison_duration_seconds_count 2` + "\n"))
+			Expect(string(data)).To(ContainSubstring(`dyff_input_size_bytes_bucket{input="from",le="1024"} 2` + "\n"))
+			Expect(string(data)).To(ContainSubstring(`dyff_input_size_bytes_sum{input="to"} 13` + "\n"))
+			Expect(string(data)).To(ContainSubstring(`dyff_differences_total{kind="addition"} 1` + "\n"))
+			Expect(string(data)).To(ContainSubstring(`dyff_differences_total{kind="modification"} 1` + "\n"))
+			Expect(string(data)).To(ContainSubstring(`dyff_differences_total{kind="removal"} 1` + "\n"))
+		})
+
+		It("should render the report of a remote comparison locally", func() {
+			from := createTestFile(`---
+name: foo
+list:
+- name: one
+  value: 1
+- name: two
+  value: 2
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+name: bar
+list:
+- name: one
+  value: 1
+- name: two
+  value: 3
+- name: three
+  value: 3
+`)
+			defer os.Remove(to)
+
+			expected, err := dyff("between", "--omit-header", from, to)
+			Expect(err).ToNot(HaveOccurred())
+
+			out, err := dyff("between", "--omit-header", "--remote", server.Listener.Addr().String(), from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(expected))
+		})
+
+		It("should render the report of a remote comparison of multiple documents like a local one", func() {
+			from := createTestFile(`---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: one
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: two
+data:
+  key: value
+`)
+			defer os.Remove(from)
+
+			to := createTestFile(`---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: two
+data:
+  key: value
+  other: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: one
+data: {}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: three
+`)
+			defer os.Remove(to)
+
+			expected, err := dyff("between", from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expected).To(ContainSubstring("v1/ConfigMap/two"))
+
+			out, err := dyff("between", "--remote", server.URL, from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(expected))
+		})
+
+		It("should send the comparison options to the remote", func() {
+			from := createTestFile(`{"list": [1, 2, 3]}`)
+			defer os.Remove(from)
+
+			to := createTestFile(`{"list": [3, 2, 1]}`)
+			defer os.Remove(to)
+
+			out, err := dyff("between", "--output", "brief", "--ignore-order-changes", "--remote", server.URL, from, to)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(BeEquivalentTo(fmt.Sprintf("no changes detected between %s and %s\n\n", from, to)))
+		})
+
+		It("should fail to use options with a remote that it does not support", func() {
+			_, err := dyff("between", "--sops", "--remote", server.URL, "from.yml", "to.yml")
+			Expect(err).To(MatchError("incompatible flags: cannot use sops flag in combination with remote flag"))
+		})
+	})
 })
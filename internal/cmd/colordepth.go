@@ -0,0 +1,275 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+)
+
+// Supported color depths of terminals
+const (
+	colorDepthAuto      = 0
+	colorDepth8         = 8
+	colorDepth16        = 16
+	colorDepth256       = 256
+	colorDepthTrueColor = 1 << 24
+)
+
+var sgrSequence = regexp.MustCompile("\x1b\\[([0-9;]*)m")
+
+// ansiColors are the RGB values of the eight standard terminal colors (xterm)
+var ansiColors = [8][3]int{
+	{0, 0, 0},
+	{205, 0, 0},
+	{0, 205, 0},
+	{205, 205, 0},
+	{0, 0, 238},
+	{205, 0, 205},
+	{0, 205, 205},
+	{229, 229, 229},
+}
+
+// parseColorDepth translates the color depth setting into the number of
+// supported colors, with zero meaning that it needs to be detected
+func parseColorDepth(setting string) (int, error) {
+	switch strings.ToLower(setting) {
+	case "", "auto":
+		return colorDepthAuto, nil
+
+	case "24bit", "truecolor":
+		return colorDepthTrueColor, nil
+
+	case "256":
+		return colorDepth256, nil
+
+	case "16":
+		return colorDepth16, nil
+
+	case "8":
+		return colorDepth8, nil
+	}
+
+	return 0, fmt.Errorf("unknown color depth %s, supported values are: auto, 24bit, 256, 16, or 8", setting)
+}
+
+// detectColorDepth returns the color depth of the terminal based on the
+// configured true color setting and the well-known environment variables
+func detectColorDepth() int {
+	if bunt.UseTrueColor() {
+		return colorDepthTrueColor
+	}
+
+	terminal := os.Getenv("TERM")
+	switch {
+	case strings.Contains(terminal, "256color"):
+		return colorDepth256
+
+	case terminal == "linux", terminal == "ansi", terminal == "vt100", terminal == "vt220", terminal == "cons25":
+		return colorDepth8
+	}
+
+	return colorDepth16
+}
+
+// applyColorDepth configures the color rendering so that it matches the given
+// color depth. Since colors are rendered with either 24 bit, or 16 colors,
+// all other color depths render with 24 bit colors, which are translated to
+// the respective color depth when the output is written. An explicitly
+// configured true color setting is kept as it is.
+func applyColorDepth(setting string, trueColorConfigured bool) (int, error) {
+	depth, err := parseColorDepth(setting)
+	if err != nil {
+		return 0, err
+	}
+
+	if depth == colorDepthAuto {
+		depth = detectColorDepth()
+	}
+
+	if trueColorConfigured {
+		return depth, nil
+	}
+
+	switch depth {
+	case colorDepth16:
+		return depth, bunt.TrueColorSetting.Set("off")
+
+	default:
+		return depth, bunt.TrueColorSetting.Set("on")
+	}
+}
+
+// colorDepthWriter translates 24 bit color escape sequences into the closest
+// color of the configured color depth
+type colorDepthWriter struct {
+	out     io.Writer
+	depth   int
+	pending []byte
+}
+
+// newOutput returns a writer for the command output that makes sure the
+// colors used match the color depth of the terminal
+func newOutput(out io.Writer) io.WriteCloser {
+	switch rootCmdSettings.depth {
+	case colorDepth256, colorDepth8:
+		return &colorDepthWriter{out: out, depth: rootCmdSettings.depth}
+	}
+
+	return nopWriteCloser{out}
+}
+
+func (w *colorDepthWriter) Write(p []byte) (int, error) {
+	data := append(w.pending, p...)
+	w.pending = nil
+
+	// Hold back an incomplete escape sequence at the end, since it could be
+	// completed with the next write call
+	if idx := bytes.LastIndexByte(data, '\x1b'); idx >= 0 && bytes.IndexByte(data[idx:], 'm') < 0 {
+		w.pending = append([]byte{}, data[idx:]...)
+		data = data[:idx]
+	}
+
+	if _, err := w.out.Write([]byte(translateColors(string(data), w.depth))); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (w *colorDepthWriter) Close() error {
+	if len(w.pending) > 0 {
+		_, err := w.out.Write(w.pending)
+		w.pending = nil
+		return err
+	}
+
+	return nil
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// translateColors replaces all 24 bit foreground and background color
+// parameters of SGR escape sequences with the closest color of the given
+// color depth
+func translateColors(input string, depth int) string {
+	return sgrSequence.ReplaceAllStringFunc(input, func(sequence string) string {
+		params := strings.Split(sgrSequence.FindStringSubmatch(sequence)[1], ";")
+
+		var result []string
+		for i := 0; i < len(params); i++ {
+			if (params[i] == "38" || params[i] == "48") && i+4 < len(params) && params[i+1] == "2" {
+				r, _ := strconv.Atoi(params[i+2])
+				g, _ := strconv.Atoi(params[i+3])
+				b, _ := strconv.Atoi(params[i+4])
+
+				switch depth {
+				case colorDepth256:
+					result = append(result, params[i], "5", strconv.Itoa(closest256Color(r, g, b)))
+
+				default:
+					offset := 30
+					if params[i] == "48" {
+						offset = 40
+					}
+
+					result = append(result, strconv.Itoa(offset+closest8Color(r, g, b)))
+				}
+
+				i += 4
+				continue
+			}
+
+			result = append(result, params[i])
+		}
+
+		return fmt.Sprintf("\x1b[%sm", strings.Join(result, ";"))
+	})
+}
+
+// closest256Color returns the index of the closest color in the xterm 256
+// color palette, using either the 6x6x6 color cube or the grayscale ramp
+func closest256Color(r, g, b int) int {
+	cubeIndex := func(v int) int {
+		switch {
+		case v < 48:
+			return 0
+
+		case v < 115:
+			return 1
+		}
+
+		return (v - 35) / 40
+	}
+
+	cubeValue := func(idx int) int {
+		if idx == 0 {
+			return 0
+		}
+
+		return 55 + idx*40
+	}
+
+	ri, gi, bi := cubeIndex(r), cubeIndex(g), cubeIndex(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDistance := distance(r, g, b, cubeValue(ri), cubeValue(gi), cubeValue(bi))
+
+	avg := (r + g + b) / 3
+	grayIndex := 23
+	if avg < 238 {
+		grayIndex = max(0, (avg-3)/10)
+	}
+
+	grayValue := 8 + grayIndex*10
+	if distance(r, g, b, grayValue, grayValue, grayValue) < cubeDistance {
+		return 232 + grayIndex
+	}
+
+	return cube
+}
+
+// closest8Color returns the offset of the closest color of the eight standard
+// terminal colors
+func closest8Color(r, g, b int) int {
+	result, min := 0, math.MaxFloat64
+	for i, c := range ansiColors {
+		if d := distance(r, g, b, c[0], c[1], c[2]); d < min {
+			result, min = i, d
+		}
+	}
+
+	return result
+}
+
+func distance(r1, g1, b1, r2, g2, b2 int) float64 {
+	dr, dg, db := float64(r1-r2), float64(g1-g2), float64(b1-b2)
+	return dr*dr + dg*dg + db*db
+}
@@ -23,15 +23,21 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/gonvenience/bunt"
 	"github.com/gonvenience/neat"
+	"github.com/gonvenience/term"
 	"github.com/gonvenience/ytbx"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	yamlv3 "gopkg.in/yaml.v3"
 
 	"github.com/homeport/dyff/pkg/dyff"
@@ -40,75 +46,227 @@ import (
 type reportConfig struct {
 	style                     string
 	ignoreOrderChanges        bool
+	ignoreOrderAt             []string
 	ignoreWhitespaceChanges   bool
+	ignoreNewlineChanges      bool
+	detectMapOrderChanges     bool
+	maxCompareDepth           int
 	kubernetesEntityDetection bool
 	noTableStyle              bool
 	doNotInspectCerts         bool
 	exitWithCode              bool
+	summaryFile               string
+	severityRules             string
+	failOnSeverity            string
+	failOn                    []string
+	distinctExitCodes         bool
 	omitHeader                bool
+	tableDetails              bool
 	useGoPatchPaths           bool
+	lineNumbers               bool
+	sourceContextLines        int
+	valueTypes                bool
+	coerceTypes               bool
+	elideUnchanged            bool
+	fromDescription           string
+	toDescription             string
+	groupByDepth              int
+	maxDiffs                  int
+	largestSubtrees           int
+	collapseRepeated          bool
+	noWrap                    bool
 	ignoreValueChanges        bool
+	additionsOnly             bool
+	removalsOnly              bool
+	detectTypeChanges         bool
+	floatTolerance            float64
+	typeChangesOnly           bool
+	detectStyleChanges        bool
+	ignoreStyleChanges        bool
+	excludeClasses            []string
+	autoChroot                bool
+	changeRoots               []ytbx.Path
 	detectRenames             bool
+	renameThreshold           int
 	minorChangeThreshold      float64
 	multilineContextLines     int
+	concurrency               int
+	listAlgorithm             string
+	timeout                   time.Duration
+	progress                  bool
+	hyperlinks                string
+	hyperlinkTemplate         string
 	additionalIdentifiers     []string
+	listKeys                  []string
 	filters                   []string
 	excludes                  []string
 	filterRegexps             []string
 	excludeRegexps            []string
+	execComparers             []string
+	transforms                []string
+	pathMappings              []string
+	presets                   []string
+	kubernetes                bool
+	profile                   string
+	debugCompare              bool
 }
 
 var defaults = reportConfig{
 	style:                     "human",
 	ignoreOrderChanges:        false,
+	ignoreOrderAt:             nil,
 	ignoreWhitespaceChanges:   false,
+	ignoreNewlineChanges:      false,
+	detectMapOrderChanges:     false,
+	maxCompareDepth:           0,
 	kubernetesEntityDetection: true,
 	noTableStyle:              false,
 	doNotInspectCerts:         false,
 	exitWithCode:              false,
+	summaryFile:               "",
+	severityRules:             "",
+	failOnSeverity:            "",
+	failOn:                    nil,
+	distinctExitCodes:         false,
 	omitHeader:                false,
+	tableDetails:              false,
 	useGoPatchPaths:           false,
+	lineNumbers:               false,
+	sourceContextLines:        0,
+	valueTypes:                false,
+	coerceTypes:               false,
+	elideUnchanged:            false,
+	fromDescription:           "",
+	toDescription:             "",
+	groupByDepth:              0,
+	maxDiffs:                  0,
+	largestSubtrees:           0,
+	collapseRepeated:          false,
+	noWrap:                    false,
 	ignoreValueChanges:        false,
+	additionsOnly:             false,
+	removalsOnly:              false,
+	detectTypeChanges:         false,
+	floatTolerance:            0,
+	typeChangesOnly:           false,
+	detectStyleChanges:        false,
+	ignoreStyleChanges:        false,
+	autoChroot:                false,
+	excludeClasses:            nil,
 	detectRenames:             true,
+	renameThreshold:           60,
 	minorChangeThreshold:      0.1,
 	multilineContextLines:     4,
+	concurrency:               0,
+	listAlgorithm:             string(dyff.ListDiffMultiset),
+	timeout:                   0,
+	progress:                  false,
+	hyperlinks:                "auto",
+	hyperlinkTemplate:         dyff.DefaultHyperlinkTemplate,
 	additionalIdentifiers:     nil,
+	listKeys:                  nil,
 	filters:                   nil,
 	excludes:                  nil,
 	filterRegexps:             nil,
 	excludeRegexps:            nil,
+	execComparers:             nil,
+	transforms:                nil,
+	pathMappings:              nil,
+	presets:                   nil,
+	kubernetes:                false,
+	profile:                   "",
+	debugCompare:              false,
 }
 
 var reportOptions reportConfig
 
 func applyReportOptionsFlags(cmd *cobra.Command) {
+	bindReportOptionsFlags(cmd.Flags(), &reportOptions)
+
+	for _, name := range []string{"filter", "exclude"} {
+		_ = cmd.RegisterFlagCompletionFunc(name, completePaths)
+	}
+
+	_ = cmd.Flags().MarkDeprecated("set-exit-status", "use --set-exit-code instead")
+}
+
+// bindReportOptionsFlags defines the flags for the report options in the flag
+// set, so that the flags can also be used for other sources of options
+func bindReportOptionsFlags(flags *pflag.FlagSet, config *reportConfig) {
 	// Compare options
-	cmd.Flags().BoolVarP(&reportOptions.ignoreOrderChanges, "ignore-order-changes", "i", defaults.ignoreOrderChanges, "ignore order changes in lists")
-	cmd.Flags().BoolVar(&reportOptions.ignoreWhitespaceChanges, "ignore-whitespace-changes", defaults.ignoreWhitespaceChanges, "ignore leading or trailing whitespace changes")
-	cmd.Flags().BoolVarP(&reportOptions.kubernetesEntityDetection, "detect-kubernetes", "", defaults.kubernetesEntityDetection, "detect kubernetes entities")
-	cmd.Flags().StringArrayVar(&reportOptions.additionalIdentifiers, "additional-identifier", defaults.additionalIdentifiers, "use additional identifier candidates in named entry lists")
-	cmd.Flags().StringSliceVar(&reportOptions.filters, "filter", defaults.filters, "filter reports to a subset of differences based on supplied arguments")
-	cmd.Flags().StringSliceVar(&reportOptions.excludes, "exclude", defaults.excludes, "exclude reports from a set of differences based on supplied arguments")
-	cmd.Flags().StringSliceVar(&reportOptions.filterRegexps, "filter-regexp", defaults.filterRegexps, "filter reports to a subset of differences based on supplied regular expressions")
-	cmd.Flags().StringSliceVar(&reportOptions.excludeRegexps, "exclude-regexp", defaults.excludeRegexps, "exclude reports from a set of differences based on supplied regular expressions")
-	cmd.Flags().BoolVarP(&reportOptions.ignoreValueChanges, "ignore-value-changes", "v", defaults.ignoreValueChanges, "exclude changes in values")
-	cmd.Flags().BoolVar(&reportOptions.detectRenames, "detect-renames", defaults.detectRenames, "enable detection for renames (document level for Kubernetes resources)")
+	flags.BoolVarP(&config.ignoreOrderChanges, "ignore-order-changes", "i", defaults.ignoreOrderChanges, "ignore order changes in lists")
+	flags.StringSliceVar(&config.ignoreOrderAt, "ignore-order-at", defaults.ignoreOrderAt, "ignore order changes only in the lists at the given paths and compare their entries as unordered sets, where * matches any one path element, e.g. /spec/containers/*/env")
+	flags.BoolVar(&config.ignoreWhitespaceChanges, "ignore-whitespace-changes", defaults.ignoreWhitespaceChanges, "ignore leading or trailing whitespace changes")
+	flags.BoolVar(&config.ignoreNewlineChanges, "ignore-trailing-newline-changes", defaults.ignoreNewlineChanges, "ignore strings that only differ in trailing newlines")
+	flags.Float64Var(&config.floatTolerance, "float-tolerance", defaults.floatTolerance, "ignore numbers that differ by at most the given tolerance, e.g. 1e-9 to ignore floating point noise")
+	flags.BoolVar(&config.detectMapOrderChanges, "detect-map-order-changes", defaults.detectMapOrderChanges, "report maps with the same keys in a different order as a map order change")
+	flags.IntVar(&config.maxCompareDepth, "max-compare-depth", defaults.maxCompareDepth, "report changed maps and lists at the given depth as one changed subtree instead of comparing them in detail, zero means no limit")
+	flags.BoolVarP(&config.kubernetesEntityDetection, "detect-kubernetes", "", defaults.kubernetesEntityDetection, "detect kubernetes entities")
+	flags.StringArrayVar(&config.additionalIdentifiers, "additional-identifier", defaults.additionalIdentifiers, "use additional identifier candidates in named entry lists")
+	flags.StringArrayVar(&config.listKeys, "list-key", defaults.listKeys, "match the entries of the list at a path by the given field instead of detecting the identifier, format is <path>=<field>, e.g. /spec/rules=host")
+	flags.StringSliceVar(&config.filters, "filter", defaults.filters, "filter reports to a subset of differences based on supplied arguments")
+	flags.StringSliceVar(&config.excludes, "exclude", defaults.excludes, "exclude reports from a set of differences based on supplied arguments")
+	flags.StringSliceVar(&config.filterRegexps, "filter-regexp", defaults.filterRegexps, "filter reports to a subset of differences based on supplied regular expressions")
+	flags.StringSliceVar(&config.excludeRegexps, "exclude-regexp", defaults.excludeRegexps, "exclude reports from a set of differences based on supplied regular expressions")
+	flags.BoolVarP(&config.ignoreValueChanges, "ignore-value-changes", "v", defaults.ignoreValueChanges, "exclude changes in values")
+	flags.BoolVar(&config.additionsOnly, "additions-only", defaults.additionsOnly, "only report additions, i.e. what is new in the to input file")
+	flags.BoolVar(&config.removalsOnly, "removals-only", defaults.removalsOnly, "only report removals, i.e. what would be lost going from the from input file to the to input file")
+	flags.BoolVar(&config.detectTypeChanges, "detect-type-changes", defaults.detectTypeChanges, "report values whose type changed, e.g. from string to int, as a type change instead of a modification")
+	flags.BoolVar(&config.typeChangesOnly, "type-changes-only", defaults.typeChangesOnly, "only report type changes, implies --detect-type-changes")
+	flags.BoolVar(&config.detectStyleChanges, "detect-style-changes", defaults.detectStyleChanges, "report purely stylistic changes of the same value, e.g. quoting or flow style, as a low severity style change instead of a modification")
+	flags.BoolVar(&config.ignoreStyleChanges, "ignore-style-changes", defaults.ignoreStyleChanges, "do not report purely stylistic changes, implies --detect-style-changes")
+	flags.StringSliceVar(&config.excludeClasses, "exclude-class", defaults.excludeClasses, "exclude value changes of the given classes, supported classes: null, whitespace, quoting, or comment")
+	flags.BoolVar(&config.autoChroot, "auto-chroot", defaults.autoChroot, "change the root of the report to the path that all differences have in common, so that it is not repeated in every path")
+	flags.BoolVar(&config.detectRenames, "detect-renames", defaults.detectRenames, "enable detection for renames (document level for Kubernetes resources)")
+	flags.IntVar(&config.renameThreshold, "rename-threshold", defaults.renameThreshold, "minimum similarity in percent of a removed and an added document to report them as renamed or moved")
+	flags.StringVar(&config.listAlgorithm, "list-algorithm", defaults.listAlgorithm, "algorithm to align the entries of lists without identifier, supported algorithms: multiset, lcs, patience, histogram, or greedy")
+	flags.IntVar(&config.concurrency, "concurrency", defaults.concurrency, "number of documents that are compared at the same time, zero means to use the number of CPUs")
+	flags.DurationVar(&config.timeout, "timeout", defaults.timeout, "maximum duration of the comparison, for example 30s, zero means no limit")
+	flags.BoolVar(&config.progress, "progress", defaults.progress, "show the progress of the comparison on standard error")
+	flags.StringArrayVar(&config.execComparers, "exec-comparer", defaults.execComparers, "compare values at paths matching a regular expression using an external command, format is <regexp>=<command>")
+	flags.StringArrayVar(&config.transforms, "transform", defaults.transforms, "compare values at a path after applying a sed-like substitution to them, format is <path>: s/<regexp>/<replacement>/[g]")
+	flags.StringArrayVar(&config.pathMappings, "map-path", defaults.pathMappings, "compare a renamed map entry with its new name instead of reporting a removal and an addition, format is old: <path> new: <path>")
+	flags.StringSliceVar(&config.presets, "preset", defaults.presets, fmt.Sprintf("use options tailored to specific input files, supported presets: %s", presetNames()))
+	flags.BoolVar(&config.kubernetes, "kubernetes", defaults.kubernetes, "compare Kubernetes resources by their identity and the entries of containers, env, and ports by their name, and ignore managed fields, creation timestamps, and status (same as --preset kubernetes)")
+	flags.StringVar(&config.profile, "profile", defaults.profile, fmt.Sprintf("use the flag values of a profile for all flags that are not set explicitly, supported profiles: %s", profileNames()))
+	flags.BoolVar(&config.debugCompare, "debug-compare", defaults.debugCompare, "explain on standard error why differences are (not) reported, e.g. which identifier is used for a list")
 
 	// Main output preferences
-	cmd.Flags().StringVarP(&reportOptions.style, "output", "o", defaults.style, "specify the output style, supported styles: human, brief, github, gitlab, gitea")
-	cmd.Flags().BoolVarP(&reportOptions.omitHeader, "omit-header", "b", defaults.omitHeader, "omit the dyff summary header")
-	cmd.Flags().BoolVarP(&reportOptions.exitWithCode, "set-exit-code", "s", defaults.exitWithCode, "set program exit code, with 0 meaning no difference, 1 for differences detected, and 255 for program error")
+	flags.StringVarP(&config.style, "output", "o", defaults.style, "specify the output style, supported styles: human, brief, table, editor, annotated, heatmap, github, gitlab, gitea, json, jsonpatch, html, markdown")
+	flags.BoolVarP(&config.omitHeader, "omit-header", "b", defaults.omitHeader, "omit the dyff summary header")
+	flags.StringVar(&config.fromDescription, "from-description", defaults.fromDescription, "describe the from input in the report instead of using its location, e.g. when it is a temporary file")
+	flags.StringVar(&config.toDescription, "to-description", defaults.toDescription, "describe the to input in the report instead of using its location, e.g. when it is a temporary file")
+	flags.BoolVarP(&config.exitWithCode, "set-exit-code", "s", defaults.exitWithCode, "set program exit code, with 0 meaning no difference, 1 for differences detected, and 255 for program error")
+	flags.StringVar(&config.summaryFile, "summary-file", defaults.summaryFile, "write a JSON summary of the run with the input files, a fingerprint of the options, the number of differences per kind, and the exit code to the given file")
+	flags.StringVar(&config.severityRules, "severity-rules", defaults.severityRules, "assign severities (info, warning, or critical) to differences using the rules of the given YAML file, which is a list of entries with path (regular expression), optional kind, and severity")
+	flags.StringVar(&config.failOnSeverity, "fail-on-severity", defaults.failOnSeverity, "set the exit code to 1 only if there is a difference of at least the given severity (info, warning, or critical), implies --set-exit-code")
+	flags.StringSliceVar(&config.failOn, "fail-on", defaults.failOn, "set the exit code only if there is a difference of the given kinds (addition, removal, modification, order-change, type-change, style-change, representation-change, or rename), implies --set-exit-code")
+	flags.BoolVar(&config.distinctExitCodes, "distinct-exit-codes", defaults.distinctExitCodes, "set the exit code to 2 if there are only additions, 3 if there are modifications, but no removals, and 4 if there are removals, implies --set-exit-code")
+
+	// Table output related flags
+	flags.BoolVar(&config.tableDetails, "table-details", defaults.tableDetails, "show the detailed differences below the change table of the table output style")
 
 	// Human/BOSH output related flags
-	cmd.Flags().BoolVarP(&reportOptions.noTableStyle, "no-table-style", "l", defaults.noTableStyle, "do not place blocks next to each other, always use one row per text block")
-	cmd.Flags().BoolVarP(&reportOptions.doNotInspectCerts, "no-cert-inspection", "x", defaults.doNotInspectCerts, "disable x509 certificate inspection, compare as raw text")
-	cmd.Flags().BoolVarP(&reportOptions.useGoPatchPaths, "use-go-patch-style", "g", defaults.useGoPatchPaths, "use Go-Patch style paths in outputs")
-	cmd.Flags().Float64VarP(&reportOptions.minorChangeThreshold, "minor-change-threshold", "", defaults.minorChangeThreshold, "minor change threshold")
-	cmd.Flags().IntVarP(&reportOptions.multilineContextLines, "multi-line-context-lines", "", defaults.multilineContextLines, "multi-line context lines")
+	flags.BoolVarP(&config.noTableStyle, "no-table-style", "l", defaults.noTableStyle, "do not place blocks next to each other, always use one row per text block")
+	flags.BoolVarP(&config.doNotInspectCerts, "no-cert-inspection", "x", defaults.doNotInspectCerts, "disable x509 certificate inspection, compare as raw text")
+	flags.BoolVarP(&config.useGoPatchPaths, "use-go-patch-style", "g", defaults.useGoPatchPaths, "use Go-Patch style paths in outputs")
+	flags.BoolVar(&config.lineNumbers, "line-numbers", defaults.lineNumbers, "show the line numbers of each difference in the from and to input file")
+	flags.BoolVar(&config.valueTypes, "value-types", defaults.valueTypes, "show the YAML types of changed values, e.g. !!str or !!int")
+	flags.BoolVar(&config.coerceTypes, "coerce-types", defaults.coerceTypes, "report values of different types that are the same after coercion, e.g. \"10\" and 10, as a representation change")
+	flags.BoolVar(&config.elideUnchanged, "elide-unchanged", defaults.elideUnchanged, "only show the changed parts of list entries that are reported as removed and added")
+	flags.IntVar(&config.sourceContextLines, "source-context", defaults.sourceContextLines, "show the given number of lines of the original input file around each difference")
+	flags.IntVar(&config.groupByDepth, "group-by-depth", defaults.groupByDepth, "group the differences by the given number of path elements, e.g. 1 to group by the top-level key")
+	flags.IntVar(&config.maxDiffs, "max-diffs", defaults.maxDiffs, "only show the first given number of differences per document in human readable output, 0 shows all")
+	flags.IntVar(&config.largestSubtrees, "largest-subtrees", defaults.largestSubtrees, "list the given number of subtrees with the most differences before the differences in human readable output")
+	flags.BoolVar(&config.collapseRepeated, "collapse-repeated", defaults.collapseRepeated, "show the same change at many paths, e.g. the same label added to many resources, only once together with the list of paths in human readable output")
+	flags.BoolVar(&config.noWrap, "no-wrap", defaults.noWrap, "do not wrap lines that are longer than the terminal width")
+	flags.Float64VarP(&config.minorChangeThreshold, "minor-change-threshold", "", defaults.minorChangeThreshold, "minor change threshold")
+	flags.IntVarP(&config.multilineContextLines, "multi-line-context-lines", "", defaults.multilineContextLines, "multi-line context lines")
+	flags.StringVar(&config.hyperlinks, "hyperlinks", defaults.hyperlinks, "render file names and paths as terminal hyperlinks, supported values: auto, on, or off")
+	flags.StringVar(&config.hyperlinkTemplate, "hyperlink-template", defaults.hyperlinkTemplate, "URL template for hyperlinks, supported placeholders: {host}, {file}, {location}, and {line}")
 
 	// Deprecated
-	cmd.Flags().BoolVar(&reportOptions.exitWithCode, "set-exit-status", defaults.exitWithCode, "set program exit code, with 0 meaning no difference, 1 for differences detected, and 255 for program error")
-	_ = cmd.Flags().MarkDeprecated("set-exit-status", "use --set-exit-code instead")
+	flags.BoolVar(&config.exitWithCode, "set-exit-status", defaults.exitWithCode, "set program exit code, with 0 meaning no difference, 1 for differences detected, and 255 for program error")
 }
 
 // OutputWriter encapsulates the required fields to define the look and feel of
@@ -117,6 +275,7 @@ type OutputWriter struct {
 	PlainMode        bool
 	Restructure      bool
 	OmitIndentHelper bool
+	AlignKeys        bool
 	OutputStyle      string
 }
 
@@ -131,8 +290,11 @@ func humanReadableFilename(filename string) string {
 // WriteToStdout is a convenience function to write the content of the documents
 // stored in the provided input file to the standard output
 func (w *OutputWriter) WriteToStdout(filename string) error {
-	if err := w.write(os.Stdout, filename); err != nil {
-		return bunt.Errorf("failed to write output to _*stdout*_: %w", err)
+	out := newOutput(os.Stdout)
+	defer out.Close()
+
+	if err := w.write(out, filename); err != nil {
+		return fmt.Errorf("failed to write output to %s: %w", bunt.Sprint("_*stdout*_"), err)
 	}
 
 	return nil
@@ -141,12 +303,24 @@ func (w *OutputWriter) WriteToStdout(filename string) error {
 // WriteInplace writes the content of the documents stored in the provided input
 // file to the file itself overwriting the content in place.
 func (w *OutputWriter) WriteInplace(filename string) error {
+	inputFile, err := ytbx.LoadFile(filename)
+	if err != nil {
+		err = fmt.Errorf("failed to load input from %s: %w", humanReadableFilename(filename), err)
+		return fmt.Errorf("failed to write output to %s: %w", humanReadableFilename(filename), err)
+	}
+
+	return w.WriteDocumentsInplace(filename, inputFile.Documents)
+}
+
+// WriteDocumentsInplace writes the provided documents to the file with the
+// given name overwriting its content in place.
+func (w *OutputWriter) WriteDocumentsInplace(filename string, documents []*yamlv3.Node) error {
 	var buf bytes.Buffer
 	bufWriter := bufio.NewWriter(&buf)
 
 	// Force plain mode to make sure there are no ANSI sequences
 	w.PlainMode = true
-	if err := w.write(bufWriter, filename); err != nil {
+	if err := w.writeDocuments(bufWriter, documents); err != nil {
 		return fmt.Errorf("failed to write output to %s: %w", humanReadableFilename(filename), err)
 	}
 
@@ -159,28 +333,52 @@ func (w *OutputWriter) WriteInplace(filename string) error {
 	return nil
 }
 
+// WriteDocumentsToStdout is a convenience function to write the provided
+// documents to the standard output
+func (w *OutputWriter) WriteDocumentsToStdout(documents []*yamlv3.Node) error {
+	out := newOutput(os.Stdout)
+	defer out.Close()
+
+	if err := w.writeDocuments(out, documents); err != nil {
+		return fmt.Errorf("failed to write output to %s: %w", bunt.Sprint("_*stdout*_"), err)
+	}
+
+	return nil
+}
+
 func (w *OutputWriter) write(writer io.Writer, filename string) error {
-	inputFile, err := ytbx.LoadFile(filename)
+	inputFile, err := dyff.LoadFile(filename)
 	if err != nil {
 		return fmt.Errorf("failed to load input from %s: %w", humanReadableFilename(filename), err)
 	}
 
-	for _, document := range inputFile.Documents {
+	return w.writeDocuments(writer, inputFile.Documents)
+}
+
+func (w *OutputWriter) writeDocuments(writer io.Writer, documents []*yamlv3.Node) error {
+	switch w.OutputStyle {
+	case "toml", "properties":
+		if len(documents) > 1 {
+			return fmt.Errorf("output style %s does not support multiple documents", w.OutputStyle)
+		}
+	}
+
+	for _, document := range documents {
 		if w.Restructure {
 			ytbx.RestructureObject(document)
 		}
 
 		switch {
 		case w.PlainMode && w.OutputStyle == "json":
-			output, err := neat.NewOutputProcessor(false, false, &neat.DefaultColorSchema).ToCompactJSON(document)
+			output, err := neat.NewOutputProcessor(false, false, &selectedTheme().DocumentSchema).ToCompactJSON(document)
 			if err != nil {
 				return err
 			}
 			fmt.Fprintf(writer, "%s\n", output)
 
 		case w.PlainMode && w.OutputStyle == "yaml":
-			fmt.Fprintln(writer, "---")
-			encoder := yamlv3.NewEncoder(writer)
+			var buf bytes.Buffer
+			encoder := yamlv3.NewEncoder(&buf)
 			encoder.SetIndent(2)
 
 			if err := encoder.Encode(document); err != nil {
@@ -191,18 +389,39 @@ func (w *OutputWriter) write(writer io.Writer, filename string) error {
 				return err
 			}
 
+			output := buf.String()
+			if w.AlignKeys {
+				output = alignKeys(output)
+			}
+
+			fmt.Fprintf(writer, "---\n%s", output)
+
 		case w.OutputStyle == "json":
-			output, err := neat.NewOutputProcessor(!w.OmitIndentHelper, true, &neat.DefaultColorSchema).ToJSON(document)
+			output, err := neat.NewOutputProcessor(!w.OmitIndentHelper, true, &selectedTheme().DocumentSchema).ToJSON(document)
 			if err != nil {
 				return err
 			}
 			fmt.Fprintf(writer, "%s\n", output)
 
+		case w.OutputStyle == "toml":
+			if err := writeTOML(writer, document); err != nil {
+				return err
+			}
+
+		case w.OutputStyle == "properties":
+			if err := writeProperties(writer, document); err != nil {
+				return err
+			}
+
 		case w.OutputStyle == "yaml":
-			output, err := neat.NewOutputProcessor(!w.OmitIndentHelper, true, &neat.DefaultColorSchema).ToYAML(document)
+			output, err := neat.NewOutputProcessor(!w.OmitIndentHelper, true, &selectedTheme().DocumentSchema).ToYAML(document)
 			if err != nil {
 				return err
 			}
+
+			if w.AlignKeys {
+				output = alignKeys(output)
+			}
 			fmt.Fprintf(writer, "%s\n", output)
 		}
 	}
@@ -210,22 +429,350 @@ func (w *OutputWriter) write(writer io.Writer, filename string) error {
 	return nil
 }
 
+var pathMappingRegexp = regexp.MustCompile(`^\s*old:\s*(\S+)\s+new:\s*(\S+)\s*$`)
+
+// compareOptions returns the compare options based on the report options
+func (config reportConfig) compareOptions() ([]dyff.CompareOption, error) {
+	if config.additionsOnly && config.removalsOnly {
+		return nil, fmt.Errorf("incompatible flags: cannot use additions only flag in combination with removals only flag")
+	}
+
+	if config.typeChangesOnly && (config.additionsOnly || config.removalsOnly) {
+		return nil, fmt.Errorf("incompatible flags: cannot use type changes only flag in combination with additions only or removals only flag")
+	}
+
+	for _, class := range config.excludeClasses {
+		if _, err := dyff.ParseValueClass(class); err != nil {
+			return nil, err
+		}
+	}
+
+	listAlgorithm, err := dyff.ParseListDiffAlgorithm(config.listAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []dyff.CompareOption{
+		dyff.IgnoreOrderChanges(config.ignoreOrderChanges),
+		dyff.IgnoreOrderChangesAt(config.ignoreOrderAt...),
+		dyff.IgnoreWhitespaceChanges(config.ignoreWhitespaceChanges),
+		dyff.IgnoreTrailingNewlineChanges(config.ignoreNewlineChanges),
+		dyff.DetectMapOrderChanges(config.detectMapOrderChanges),
+		dyff.MaxCompareDepth(config.maxCompareDepth),
+		dyff.DetectTypeChanges(config.detectTypeChanges || config.typeChangesOnly),
+		dyff.WithFloatTolerance(config.floatTolerance),
+		dyff.DetectStyleChanges(config.detectStyleChanges || config.ignoreStyleChanges),
+		dyff.CoerceTypes(config.coerceTypes),
+		dyff.KubernetesEntityDetection(config.kubernetesEntityDetection),
+		dyff.AdditionalIdentifiers(config.additionalIdentifiers...),
+		dyff.DetectRenames(config.detectRenames),
+		dyff.RenameThreshold(config.renameThreshold),
+		dyff.Concurrency(config.concurrency),
+		dyff.ListAlgorithm(listAlgorithm),
+	}
+
+	presets, err := lookupPresets(config.selectedPresets())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, preset := range presets {
+		options = append(options, preset.compareOptions...)
+	}
+
+	for _, execComparer := range config.execComparers {
+		pattern, command, found := strings.Cut(execComparer, "=")
+		args := strings.Fields(command)
+		if !found || len(args) == 0 {
+			return nil, fmt.Errorf("invalid exec comparer %s, expected format is <regexp>=<command>", execComparer)
+		}
+
+		expr, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exec comparer %s: %w", execComparer, err)
+		}
+
+		options = append(options, dyff.ComparerForPathRegexp(expr, dyff.ExecComparer(args[0], args[1:]...)))
+	}
+
+	var transformPaths []string
+	var transformations = map[string][]dyff.Transformation{}
+	for _, transform := range config.transforms {
+		path, expression, found := strings.Cut(transform, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid transform %s, expected format is <path>: s/<regexp>/<replacement>/[g]", transform)
+		}
+
+		transformation, err := dyff.ParseTransformation(strings.TrimSpace(expression))
+		if err != nil {
+			return nil, err
+		}
+
+		path = strings.TrimSpace(path)
+		if _, ok := transformations[path]; !ok {
+			transformPaths = append(transformPaths, path)
+		}
+
+		transformations[path] = append(transformations[path], transformation)
+	}
+
+	for _, path := range transformPaths {
+		options = append(options, dyff.ComparerForPath(path, dyff.TransformComparer(transformations[path]...)))
+	}
+
+	for _, listKey := range config.listKeys {
+		idx := strings.LastIndex(listKey, "=")
+		if idx <= 0 || idx == len(listKey)-1 {
+			return nil, fmt.Errorf("invalid list key %s, expected format is <path>=<field>", listKey)
+		}
+
+		options = append(options, dyff.ListIdentifierForPath(listKey[:idx], listKey[idx+1:]))
+	}
+
+	for _, pathMapping := range config.pathMappings {
+		matches := pathMappingRegexp.FindStringSubmatch(pathMapping)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid path mapping %s, expected format is old: <path> new: <path>", pathMapping)
+		}
+
+		options = append(options, dyff.MapPath(matches[1], matches[2]))
+	}
+
+	if config.debugCompare {
+		options = append(options, dyff.TraceHandler(func(path ytbx.Path, message string) {
+			debugCompare(&path, message)
+		}))
+	}
+
+	return options, nil
+}
+
+// compareContext returns the context for the comparison, which is done once
+// the configured timeout is reached
+func compareContext() (context.Context, context.CancelFunc) {
+	if reportOptions.timeout > 0 {
+		return context.WithTimeout(context.Background(), reportOptions.timeout)
+	}
+
+	return context.WithCancel(context.Background())
+}
+
+// compareError returns the error of a failed comparison, with a timeout
+// being explained in more detail
+func compareError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("failed to compare input files: comparison did not finish within %s", reportOptions.timeout)
+	}
+
+	return fmt.Errorf("failed to compare input files: %w", err)
+}
+
+// filterReport applies the filter and exclude options to the report
+func (config reportConfig) filterReport(report dyff.Report) dyff.Report {
+	if config.filters != nil {
+		report = debugFilter(config.debugCompare, report, "filter", false, config.filters, dyff.Report.Filter)
+	}
+
+	if config.filterRegexps != nil {
+		report = debugFilter(config.debugCompare, report, "filter-regexp", false, config.filterRegexps, dyff.Report.FilterRegexp)
+	}
+
+	if config.excludes != nil {
+		report = debugFilter(config.debugCompare, report, "exclude", true, config.excludes, dyff.Report.Exclude)
+	}
+
+	if config.excludeRegexps != nil {
+		report = debugFilter(config.debugCompare, report, "exclude-regexp", true, config.excludeRegexps, dyff.Report.ExcludeRegexp)
+	}
+
+	if config.ignoreValueChanges {
+		report = debugFilter(config.debugCompare, report, "ignore-value-changes", true, nil, func(report dyff.Report, _ ...string) dyff.Report {
+			return report.IgnoreValueChanges()
+		})
+	}
+
+	if config.excludeClasses != nil {
+		report = debugFilter(config.debugCompare, report, "exclude-class", true, config.excludeClasses, func(report dyff.Report, classes ...string) dyff.Report {
+			var valueClasses = make([]dyff.ValueClass, len(classes))
+			for i, class := range classes {
+				valueClasses[i] = dyff.ValueClass(class)
+			}
+
+			return report.ExcludeValueClasses(valueClasses...)
+		})
+	}
+
+	if config.additionsOnly {
+		report = debugFilter(config.debugCompare, report, "additions-only", true, nil, func(report dyff.Report, _ ...string) dyff.Report {
+			return report.AdditionsOnly()
+		})
+	}
+
+	if config.removalsOnly {
+		report = debugFilter(config.debugCompare, report, "removals-only", true, nil, func(report dyff.Report, _ ...string) dyff.Report {
+			return report.RemovalsOnly()
+		})
+	}
+
+	if config.typeChangesOnly {
+		report = debugFilter(config.debugCompare, report, "type-changes-only", true, nil, func(report dyff.Report, _ ...string) dyff.Report {
+			return report.TypeChangesOnly()
+		})
+	}
+
+	if config.ignoreStyleChanges {
+		report = debugFilter(config.debugCompare, report, "ignore-style-changes", true, nil, func(report dyff.Report, _ ...string) dyff.Report {
+			return report.IgnoreStyleChanges()
+		})
+	}
+
+	// The common path is only known once all other filters are applied
+	if config.autoChroot {
+		report = report.AutoChangeRoot(config.useGoPatchPaths)
+	}
+
+	return report
+}
+
 func writeReport(cmd *cobra.Command, report dyff.Report) error {
+	reportWriter, err := newReportWriter(cmd, report)
+	if err != nil {
+		return err
+	}
+
+	out := newOutput(os.Stdout)
+	defer out.Close()
+
+	if err := reportWriter.WriteReport(out); err != nil {
+		return fmt.Errorf("failed to print report: %w", err)
+	}
+
+	exitCode, err := reportOptions.exitCode(report)
+	if err != nil {
+		return err
+	}
+
+	if reportOptions.summaryFile != "" {
+		if err := writeSummaryFile(cmd, reportOptions.summaryFile, report, exitCode); err != nil {
+			return err
+		}
+	}
+
+	// If configured, make sure `dyff` exists with an exit status
+	if reportOptions.setsExitCode() {
+		return errorWithExitCode{value: exitCode}
+	}
+
+	return nil
+}
+
+// loadSeverityRules loads the configured severity rules, which are nil if
+// there is no severity rules file
+func (config reportConfig) loadSeverityRules() (dyff.SeverityRules, error) {
+	if config.severityRules == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(config.severityRules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read severity rules: %w", err)
+	}
+
+	return dyff.LoadSeverityRules(data)
+}
+
+// newReportWriter returns the report writer for the configured output style
+func newReportWriter(cmd *cobra.Command, report dyff.Report) (dyff.ReportWriter, error) {
+	reportWriter, err := reportOptions.reportWriter(report)
+	if errors.Is(err, errUnknownOutputStyle) {
+		return nil, fmt.Errorf("%w: %w", err, fmt.Errorf(cmd.UsageString()))
+	}
+
+	return reportWriter, err
+}
+
+var errUnknownOutputStyle = errors.New("unknown output style")
+
+// wrapLines returns whether long lines of the human report are wrapped, which
+// is the case for terminals or a configured fixed terminal width
+func (config reportConfig) wrapLines() bool {
+	return !config.noWrap && (term.FixedTerminalWidth > 0 || term.IsTerminal())
+}
+
+// reportWriter returns the report writer for the configured output style
+func (config reportConfig) reportWriter(report dyff.Report) (dyff.ReportWriter, error) {
+	hyperlinks, err := useHyperlinks(config.hyperlinks)
+	if err != nil {
+		return nil, err
+	}
+
+	severityRules, err := config.loadSeverityRules()
+	if err != nil {
+		return nil, err
+	}
+
 	var reportWriter dyff.ReportWriter
-	switch strings.ToLower(reportOptions.style) {
+	switch strings.ToLower(config.style) {
 	case "human", "bosh":
-		reportWriter = &dyff.HumanReport{
+		humanReport := &dyff.HumanReport{
 			Report:                report,
 			Indent:                2,
-			DoNotInspectCerts:     reportOptions.doNotInspectCerts,
-			NoTableStyle:          reportOptions.noTableStyle,
-			OmitHeader:            reportOptions.omitHeader,
-			UseGoPatchPaths:       reportOptions.useGoPatchPaths,
-			MinorChangeThreshold:  reportOptions.minorChangeThreshold,
-			MultilineContextLines: reportOptions.multilineContextLines,
+			DoNotInspectCerts:     config.doNotInspectCerts,
+			NoTableStyle:          config.noTableStyle,
+			OmitHeader:            config.omitHeader,
+			UseGoPatchPaths:       config.useGoPatchPaths,
+			MinorChangeThreshold:  config.minorChangeThreshold,
+			MultilineContextLines: config.multilineContextLines,
 			PrefixMultiline:       false,
+			ShowLineNumbers:       config.lineNumbers,
+			SourceContextLines:    config.sourceContextLines,
+			ShowValueTypes:        config.valueTypes,
+			ElideUnchanged:        config.elideUnchanged,
+			WrapLines:             config.wrapLines(),
+			FromDescription:       config.fromDescription,
+			ToDescription:         config.toDescription,
+			MaxDiffs:              config.maxDiffs,
+			MaxDiffsHint:          "rerun with --max-diffs 0",
+			LargestSubtrees:       config.largestSubtrees,
+			SeverityRules:         severityRules,
+			CollapseRepeated:      config.collapseRepeated,
+			Theme:                 selectedTheme(),
+		}
+
+		// Looking up the command writing into a pipe scans the proc file
+		// system, which is only worth it if the header is actually rendered
+		if !config.omitHeader {
+			humanReport.FromDescription = describeHeaderLocation(config.fromDescription, report.From.Location)
+			humanReport.ToDescription = describeHeaderLocation(config.toDescription, report.To.Location)
+		}
+
+		if hyperlinks {
+			humanReport.HyperlinkTemplate = config.hyperlinkTemplate
 		}
 
+		presets, err := lookupPresets(config.selectedPresets())
+		if err != nil {
+			return nil, err
+		}
+
+		// Differences of multiple change roots are shown below one heading
+		// per change root, unless they are explicitly grouped otherwise
+		if len(config.changeRoots) > 1 {
+			humanReport.GroupBy = dyff.GroupByPathPrefix(config.changeRoots, config.useGoPatchPaths)
+		}
+
+		for _, preset := range presets {
+			if preset.groupBy != nil {
+				humanReport.GroupBy = preset.groupBy
+			}
+		}
+
+		if config.groupByDepth > 0 {
+			humanReport.GroupBy = dyff.GroupByPath(config.groupByDepth, config.useGoPatchPaths)
+		}
+
+		reportWriter = humanReport
+
 	case "github", "linguist":
 		reportWriter = &dyff.DiffSyntaxReport{
 			PathPrefix:            "@@",
@@ -234,12 +781,12 @@ func writeReport(cmd *cobra.Command, report dyff.Report) error {
 			HumanReport: dyff.HumanReport{
 				Report:                report,
 				Indent:                0,
-				DoNotInspectCerts:     reportOptions.doNotInspectCerts,
+				DoNotInspectCerts:     config.doNotInspectCerts,
 				NoTableStyle:          true,
 				OmitHeader:            true,
-				UseGoPatchPaths:       reportOptions.useGoPatchPaths,
-				MinorChangeThreshold:  reportOptions.minorChangeThreshold,
-				MultilineContextLines: reportOptions.multilineContextLines,
+				UseGoPatchPaths:       config.useGoPatchPaths,
+				MinorChangeThreshold:  config.minorChangeThreshold,
+				MultilineContextLines: config.multilineContextLines,
 				PrefixMultiline:       true,
 			},
 		}
@@ -252,12 +799,12 @@ func writeReport(cmd *cobra.Command, report dyff.Report) error {
 			HumanReport: dyff.HumanReport{
 				Report:                report,
 				Indent:                0,
-				DoNotInspectCerts:     reportOptions.doNotInspectCerts,
+				DoNotInspectCerts:     config.doNotInspectCerts,
 				NoTableStyle:          true,
 				OmitHeader:            true,
-				UseGoPatchPaths:       reportOptions.useGoPatchPaths,
-				MinorChangeThreshold:  reportOptions.minorChangeThreshold,
-				MultilineContextLines: reportOptions.multilineContextLines,
+				UseGoPatchPaths:       config.useGoPatchPaths,
+				MinorChangeThreshold:  config.minorChangeThreshold,
+				MultilineContextLines: config.multilineContextLines,
 				PrefixMultiline:       true,
 			},
 		}
@@ -270,39 +817,93 @@ func writeReport(cmd *cobra.Command, report dyff.Report) error {
 			HumanReport: dyff.HumanReport{
 				Report:                report,
 				Indent:                0,
-				DoNotInspectCerts:     reportOptions.doNotInspectCerts,
+				DoNotInspectCerts:     config.doNotInspectCerts,
 				NoTableStyle:          true,
 				OmitHeader:            true,
-				UseGoPatchPaths:       reportOptions.useGoPatchPaths,
-				MinorChangeThreshold:  reportOptions.minorChangeThreshold,
-				MultilineContextLines: reportOptions.multilineContextLines,
+				UseGoPatchPaths:       config.useGoPatchPaths,
+				MinorChangeThreshold:  config.minorChangeThreshold,
+				MultilineContextLines: config.multilineContextLines,
 				PrefixMultiline:       true,
 			},
 		}
 
+	case "table", "kapp":
+		reportWriter = &dyff.ChangeTableReport{
+			ShowDetails: config.tableDetails,
+			HumanReport: dyff.HumanReport{
+				Report:                report,
+				Indent:                2,
+				DoNotInspectCerts:     config.doNotInspectCerts,
+				NoTableStyle:          config.noTableStyle,
+				OmitHeader:            true,
+				UseGoPatchPaths:       config.useGoPatchPaths,
+				MinorChangeThreshold:  config.minorChangeThreshold,
+				MultilineContextLines: config.multilineContextLines,
+				PrefixMultiline:       false,
+				ShowLineNumbers:       config.lineNumbers,
+				SourceContextLines:    config.sourceContextLines,
+				ShowValueTypes:        config.valueTypes,
+				ElideUnchanged:        config.elideUnchanged,
+				FromDescription:       config.fromDescription,
+				ToDescription:         config.toDescription,
+				Theme:                 selectedTheme(),
+			},
+		}
+
+	case "editor", "problems":
+		reportWriter = &dyff.EditorReport{
+			Report:          report,
+			UseGoPatchPaths: config.useGoPatchPaths,
+			SeverityRules:   severityRules,
+		}
+
+	case "annotated", "document":
+		reportWriter = &dyff.AnnotatedReport{
+			Report: report,
+			Indent: 2,
+			Theme:  selectedTheme(),
+		}
+
 	case "brief", "short", "summary":
 		reportWriter = &dyff.BriefReport{
-			Report: report,
+			Report:          report,
+			FromDescription: config.fromDescription,
+			ToDescription:   config.toDescription,
 		}
 
-	default:
-		return fmt.Errorf("unknown output style %s: %w", reportOptions.style, fmt.Errorf(cmd.UsageString()))
-	}
+	case "heatmap", "overview":
+		reportWriter = &dyff.HeatmapReport{
+			Report:          report,
+			UseGoPatchPaths: config.useGoPatchPaths,
+			Theme:           selectedTheme(),
+		}
 
-	if err := reportWriter.WriteReport(os.Stdout); err != nil {
-		return fmt.Errorf("failed to print report: %w", err)
-	}
+	case "json":
+		reportWriter = &dyff.JSONReport{
+			Report:        report,
+			SeverityRules: severityRules,
+		}
 
-	// If configured, make sure `dyff` exists with an exit status
-	if reportOptions.exitWithCode {
-		switch len(report.Diffs) {
-		case 0:
-			return errorWithExitCode{value: 0}
+	case "jsonpatch", "json-patch":
+		reportWriter = &dyff.PatchReport{
+			Report: report,
+		}
+
+	case "html":
+		reportWriter = &dyff.HTMLReport{
+			Report:          report,
+			UseGoPatchPaths: config.useGoPatchPaths,
+		}
 
-		default:
-			return errorWithExitCode{value: 1}
+	case "markdown", "md":
+		reportWriter = &dyff.MarkdownReport{
+			Report:          report,
+			UseGoPatchPaths: config.useGoPatchPaths,
 		}
+
+	default:
+		return nil, fmt.Errorf("%w %s", errUnknownOutputStyle, config.style)
 	}
 
-	return nil
+	return reportWriter, nil
 }
@@ -0,0 +1,91 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+)
+
+// completePaths suggests the paths found in the input files that are already
+// provided as arguments, using Go-patch style paths if the value to complete
+// starts with a slash and dot-style paths otherwise
+func completePaths(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return pathCompletions(args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFileThenPath is the completion of commands with a file location as
+// the first argument and a path in that file as the second argument
+func completeFileThenPath(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return nil, cobra.ShellCompDirectiveDefault
+
+	case 1:
+		return pathCompletions(args, toComplete), cobra.ShellCompDirectiveNoFileComp
+
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func pathCompletions(locations []string, toComplete string) []string {
+	var goPatchStyle = strings.HasPrefix(toComplete, "/")
+
+	var result []string
+	var known = map[string]struct{}{}
+	for _, location := range locations {
+		// Completion must not block on reading from standard input
+		if ytbx.IsStdin(location) {
+			continue
+		}
+
+		paths, err := ytbx.ListPaths(location)
+		if err != nil {
+			continue
+		}
+
+		for _, path := range paths {
+			// Besides the leaf paths, all parent paths are valid suggestions
+			for i := 1; i <= len(path.PathElements); i++ {
+				var candidate = ytbx.Path{PathElements: path.PathElements[:i]}
+
+				var value string
+				if goPatchStyle {
+					value = candidate.ToGoPatchStyle()
+				} else {
+					value = candidate.ToDotStyle()
+				}
+
+				if _, ok := known[value]; ok || !strings.HasPrefix(value, toComplete) {
+					continue
+				}
+
+				known[value] = struct{}{}
+				result = append(result, value)
+			}
+		}
+	}
+
+	return result
+}
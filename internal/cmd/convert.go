@@ -0,0 +1,359 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf16"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var bareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// conversionOutputStyle returns the output style to be used by a conversion
+// command, which is the format of the command itself unless configured
+func conversionOutputStyle(value string, commandFormat string) (string, error) {
+	switch value {
+	case "":
+		return commandFormat, nil
+
+	case "yaml", "json", "toml", "properties":
+		return value, nil
+	}
+
+	return "", fmt.Errorf("unknown output format %s, supported formats are: yaml, json, toml, or properties", value)
+}
+
+// writeTOML writes the document in TOML format while preserving the order of
+// all keys, which requires the document to be a map
+func writeTOML(writer io.Writer, document *yamlv3.Node) error {
+	if len(document.Content) == 0 {
+		return nil
+	}
+
+	root := followAlias(document.Content[0])
+	if root.Kind != yamlv3.MappingNode {
+		return fmt.Errorf("TOML only supports a map as the top-level structure")
+	}
+
+	var sb strings.Builder
+	if err := writeTOMLTable(&sb, nil, root); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(writer, strings.TrimPrefix(sb.String(), "\n"))
+	return err
+}
+
+func writeTOMLTable(sb *strings.Builder, path []string, node *yamlv3.Node) error {
+	type entry struct {
+		key   string
+		value *yamlv3.Node
+	}
+
+	var tables, arraysOfTables []entry
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := tomlKey(node.Content[i].Value), followAlias(node.Content[i+1])
+
+		switch {
+		case value.Kind == yamlv3.MappingNode && len(value.Content) > 0:
+			tables = append(tables, entry{key, value})
+
+		case isArrayOfTables(value):
+			arraysOfTables = append(arraysOfTables, entry{key, value})
+
+		default:
+			text, err := tomlValue(value)
+			if err != nil {
+				return fmt.Errorf("failed to convert %s: %w", strings.Join(append(path, key), "."), err)
+			}
+
+			fmt.Fprintf(sb, "%s = %s\n", key, text)
+		}
+	}
+
+	for _, table := range tables {
+		fmt.Fprintf(sb, "\n[%s]\n", strings.Join(append(path, table.key), "."))
+		if err := writeTOMLTable(sb, append(path, table.key), table.value); err != nil {
+			return err
+		}
+	}
+
+	for _, array := range arraysOfTables {
+		for _, table := range array.value.Content {
+			fmt.Fprintf(sb, "\n[[%s]]\n", strings.Join(append(path, array.key), "."))
+			if err := writeTOMLTable(sb, append(path, array.key), followAlias(table)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func isArrayOfTables(node *yamlv3.Node) bool {
+	if node.Kind != yamlv3.SequenceNode || len(node.Content) == 0 {
+		return false
+	}
+
+	for _, entry := range node.Content {
+		if followAlias(entry).Kind != yamlv3.MappingNode {
+			return false
+		}
+	}
+
+	return true
+}
+
+func tomlKey(key string) string {
+	if bareKey.MatchString(key) {
+		return key
+	}
+
+	return tomlString(key)
+}
+
+func tomlValue(node *yamlv3.Node) (string, error) {
+	node = followAlias(node)
+
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		var entries []string
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := tomlValue(node.Content[i+1])
+			if err != nil {
+				return "", err
+			}
+
+			entries = append(entries, fmt.Sprintf("%s = %s", tomlKey(node.Content[i].Value), value))
+		}
+
+		if len(entries) == 0 {
+			return "{}", nil
+		}
+
+		return "{ " + strings.Join(entries, ", ") + " }", nil
+
+	case yamlv3.SequenceNode:
+		var entries []string
+		for _, entry := range node.Content {
+			value, err := tomlValue(entry)
+			if err != nil {
+				return "", err
+			}
+
+			entries = append(entries, value)
+		}
+
+		return "[" + strings.Join(entries, ", ") + "]", nil
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return "", fmt.Errorf("TOML does not support null values")
+
+	case "!!bool", "!!int":
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprint(value), nil
+
+	case "!!timestamp":
+		return tomlDateTime(node), nil
+
+	case "!!float":
+		switch strings.ToLower(node.Value) {
+		case ".inf", "+.inf":
+			return "inf", nil
+
+		case "-.inf":
+			return "-inf", nil
+
+		case ".nan":
+			return "nan", nil
+		}
+
+		var value float64
+		if err := node.Decode(&value); err != nil {
+			return "", err
+		}
+
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	}
+
+	return tomlString(node.Value), nil
+}
+
+// tomlDateTime returns the YAML timestamp as a TOML local date or offset date
+// time, since the YAML timestamp formats with a space or a short time zone are
+// not valid in TOML. Timestamps that cannot be parsed are written as strings.
+func tomlDateTime(node *yamlv3.Node) string {
+	if _, err := time.Parse(time.DateOnly, node.Value); err == nil {
+		return node.Value
+	}
+
+	var value time.Time
+	if err := node.Decode(&value); err != nil {
+		return tomlString(node.Value)
+	}
+
+	return value.Format(time.RFC3339Nano)
+}
+
+// tomlString returns the text as a TOML basic string
+func tomlString(text string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range text {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+
+		case r == '\b':
+			sb.WriteString(`\b`)
+
+		case r == '\t':
+			sb.WriteString(`\t`)
+
+		case r == '\n':
+			sb.WriteString(`\n`)
+
+		case r == '\f':
+			sb.WriteString(`\f`)
+
+		case r == '\r':
+			sb.WriteString(`\r`)
+
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// writeProperties writes the document in Java properties format, where list
+// entries are referenced by their index in square brackets
+func writeProperties(writer io.Writer, document *yamlv3.Node) error {
+	if len(document.Content) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	writePropertiesNode(&sb, "", document.Content[0])
+
+	_, err := io.WriteString(writer, sb.String())
+	return err
+}
+
+func writePropertiesNode(sb *strings.Builder, path string, node *yamlv3.Node) {
+	node = followAlias(node)
+
+	switch {
+	case node.Kind == yamlv3.MappingNode && len(node.Content) > 0:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if path != "" {
+				key = path + "." + key
+			}
+
+			writePropertiesNode(sb, key, node.Content[i+1])
+		}
+
+	case node.Kind == yamlv3.SequenceNode && len(node.Content) > 0:
+		for i, entry := range node.Content {
+			writePropertiesNode(sb, fmt.Sprintf("%s[%d]", path, i), entry)
+		}
+
+	case node.Kind == yamlv3.ScalarNode && node.ShortTag() == "!!null":
+		fmt.Fprintf(sb, "%s=\n", escapeProperty(path, true))
+
+	case node.Kind == yamlv3.ScalarNode:
+		fmt.Fprintf(sb, "%s=%s\n", escapeProperty(path, true), escapeProperty(node.Value, false))
+	}
+}
+
+// escapeProperty escapes the text according to the Java properties format,
+// with non-ASCII characters being written as Unicode escape sequences
+func escapeProperty(text string, isKey bool) string {
+	var sb strings.Builder
+	for i, r := range text {
+		switch {
+		case r == '\\':
+			sb.WriteString(`\\`)
+
+		case r == '\n':
+			sb.WriteString(`\n`)
+
+		case r == '\r':
+			sb.WriteString(`\r`)
+
+		case r == '\t':
+			sb.WriteString(`\t`)
+
+		case r == '\f':
+			sb.WriteString(`\f`)
+
+		case r == ' ' && (isKey || i == 0):
+			sb.WriteString(`\ `)
+
+		case (r == '=' || r == ':') && isKey,
+			(r == '#' || r == '!') && i == 0:
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+
+		case r < 0x20 || r > 0x7e:
+			if r1, r2 := utf16.EncodeRune(r); r1 != unicode.ReplacementChar {
+				fmt.Fprintf(&sb, `\u%04x\u%04x`, r1, r2)
+				continue
+			}
+
+			fmt.Fprintf(&sb, `\u%04x`, r)
+
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+func followAlias(node *yamlv3.Node) *yamlv3.Node {
+	if node != nil && node.Kind == yamlv3.AliasNode {
+		return node.Alias
+	}
+
+	return node
+}
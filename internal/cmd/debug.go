@@ -0,0 +1,81 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/gonvenience/ytbx"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var debugMutex sync.Mutex
+
+// debugCompare writes an explanation of a decision for the given path to
+// standard error, which is used to find out why a difference is (not) shown
+func debugCompare(path *ytbx.Path, message string) {
+	debugMutex.Lock()
+	defer debugMutex.Unlock()
+
+	if path == nil {
+		fmt.Fprintf(os.Stderr, "debug: %s\n", message)
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "debug: %s (%s): %s\n", path.String(), path.RootDescription(), message)
+}
+
+// debugFilter applies a filter or exclude function to the report, and in
+// case the comparison is debugged, explains which differences are removed
+// and which of the patterns do not match any difference at all
+func debugFilter(debug bool, report dyff.Report, flag string, exclude bool, patterns []string, apply func(dyff.Report, ...string) dyff.Report) dyff.Report {
+	result := apply(report, patterns...)
+	if !debug {
+		return result
+	}
+
+	for _, pattern := range patterns {
+		matches := len(apply(report, pattern).Diffs)
+		if exclude {
+			matches = len(report.Diffs) - matches
+		}
+
+		if matches == 0 {
+			debugCompare(nil, fmt.Sprintf("--%s %s does not match any difference", flag, pattern))
+		}
+	}
+
+	var kept = make(map[*ytbx.Path]struct{}, len(result.Diffs))
+	for _, diff := range result.Diffs {
+		kept[diff.Path] = struct{}{}
+	}
+
+	for _, diff := range report.Diffs {
+		if _, ok := kept[diff.Path]; !ok {
+			debugCompare(diff.Path, fmt.Sprintf("removing the difference, because of --%s", flag))
+		}
+	}
+
+	return result
+}
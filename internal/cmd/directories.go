@@ -0,0 +1,241 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+// directoryFileExtensions are the extensions of the files that are compared
+// when comparing directories, unless include patterns are configured
+var directoryFileExtensions = map[string]struct{}{
+	".yml":  {},
+	".yaml": {},
+	".json": {},
+}
+
+// isDirectory returns whether the location is an existing directory
+func isDirectory(location string) bool {
+	info, err := os.Stat(location)
+	return err == nil && info.IsDir()
+}
+
+// betweenDirectories compares the files of two directory trees, which are
+// paired by their path relative to the respective directory. Files that only
+// exist in one of the directories are reported as added or removed documents,
+// and the reports of all files are combined into one report.
+func betweenDirectories(cmd *cobra.Command, fromDir string, toDir string) error {
+	switch {
+	case len(betweenCmdSettings.chroot) > 0 || betweenCmdSettings.chrootFrom != "" || betweenCmdSettings.chrootTo != "":
+		return fmt.Errorf("incompatible flags: cannot use change root flags when comparing directories")
+
+	case betweenCmdSettings.stream || betweenCmdSettings.streamList || betweenCmdSettings.watch:
+		return fmt.Errorf("incompatible flags: cannot use stream or watch flag when comparing directories")
+
+	case betweenCmdSettings.fromFormat != "" || betweenCmdSettings.toFormat != "":
+		return fmt.Errorf("incompatible flags: cannot use format flags when comparing directories")
+	}
+
+	for _, pattern := range append(append([]string{}, betweenCmdSettings.includeFiles...), betweenCmdSettings.excludeFiles...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid file pattern %s: %w", pattern, err)
+		}
+	}
+
+	options, err := reportOptions.compareOptions()
+	if err != nil {
+		return err
+	}
+
+	fromFiles, err := directoryFiles(fromDir)
+	if err != nil {
+		return err
+	}
+
+	toFiles, err := directoryFiles(toDir)
+	if err != nil {
+		return err
+	}
+
+	var reports []dyff.Report
+	for _, name := range unionOfKeys(fromFiles, toFiles) {
+		var fromLocation, toLocation = filepath.Join(fromDir, name), filepath.Join(toDir, name)
+		_, inFrom := fromFiles[name]
+		_, inTo := toFiles[name]
+
+		var report dyff.Report
+		switch {
+		case inFrom && inTo:
+			from, to, err := loadInputFiles(fromLocation, toLocation)
+			if err != nil {
+				return fmt.Errorf("failed to load input files: %w", err)
+			}
+
+			if report, err = compareInputFiles(from, to, options, nil); err != nil {
+				return err
+			}
+
+		case inFrom:
+			from, err := loadInputFile(fromLocation, "")
+			if err != nil {
+				return fmt.Errorf("failed to load input files: %w", err)
+			}
+
+			report = documentChanges(from, ytbx.InputFile{Location: toLocation}, dyff.REMOVAL)
+
+		default:
+			to, err := loadInputFile(toLocation, "")
+			if err != nil {
+				return fmt.Errorf("failed to load input files: %w", err)
+			}
+
+			report = documentChanges(ytbx.InputFile{Location: fromLocation}, to, dyff.ADDITION)
+		}
+
+		reports = append(reports, report)
+	}
+
+	report := dyff.MergeReports(reports...)
+	report.From.Location, report.To.Location = fromDir, toDir
+	if betweenCmdSettings.swap {
+		report.From.Location, report.To.Location = toDir, fromDir
+	}
+
+	return writeReport(cmd, reportOptions.filterReport(report))
+}
+
+// documentChanges returns a report of a file that only exists in one of the
+// directories, where each document of the file is added or removed
+func documentChanges(from ytbx.InputFile, to ytbx.InputFile, kind rune) dyff.Report {
+	var report = dyff.Report{From: from, To: to}
+
+	inputFile := &report.From
+	if kind == dyff.ADDITION {
+		inputFile = &report.To
+	}
+
+	for idx, document := range inputFile.Documents {
+		if len(document.Content) == 0 || (document.Content[0].Kind == yamlv3.ScalarNode && document.Content[0].Tag == "!!null") {
+			continue
+		}
+
+		var detail = dyff.Detail{Kind: kind, From: document}
+		if kind == dyff.ADDITION {
+			detail = dyff.Detail{Kind: kind, To: document}
+		}
+
+		report.Diffs = append(report.Diffs, dyff.Diff{
+			Path:    &ytbx.Path{Root: inputFile, DocumentIdx: idx},
+			Details: []dyff.Detail{detail},
+		})
+	}
+
+	return invert(report)
+}
+
+// directoryFiles returns the files of the directory tree that are compared,
+// by their slash separated path relative to the directory
+func directoryFiles(dir string) (map[string]struct{}, error) {
+	var result = map[string]struct{}{}
+	err := filepath.WalkDir(dir, func(location string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, location)
+		if err != nil {
+			return err
+		}
+
+		if name := filepath.ToSlash(rel); isComparedFile(name) {
+			result[name] = struct{}{}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read files in directory %s: %w", humanReadableFilename(dir), err)
+	}
+
+	return result, nil
+}
+
+// isComparedFile returns whether the file with the given relative path is
+// compared, which are YAML and JSON files unless include patterns are set.
+// Patterns match either the relative path or the name of the file.
+func isComparedFile(name string) bool {
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+
+			if ok, _ := path.Match(pattern, path.Base(name)); ok {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if matches(betweenCmdSettings.excludeFiles) {
+		return false
+	}
+
+	if len(betweenCmdSettings.includeFiles) > 0 {
+		return matches(betweenCmdSettings.includeFiles)
+	}
+
+	_, ok := directoryFileExtensions[path.Ext(name)]
+	return ok
+}
+
+func unionOfKeys(a map[string]struct{}, b map[string]struct{}) []string {
+	var result = make([]string, 0, len(a)+len(b))
+	for key := range a {
+		result = append(result, key)
+	}
+
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			result = append(result, key)
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,193 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// documentSelection is a list of (zero based) document index ranges, each
+// with its first and last index, so that large ranges need no extra memory
+type documentSelection [][2]int
+
+// contains returns whether the document with the given index is selected
+func (selection documentSelection) contains(idx int) bool {
+	for _, r := range selection {
+		if r[0] <= idx && idx <= r[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseDocumentSelection parses a list of document numbers and ranges, for
+// example `1,3-5`, into the selected ranges of (zero based) document indices
+func parseDocumentSelection(selection []string) (documentSelection, error) {
+	var result documentSelection
+	for _, entry := range selection {
+		first, last, isRange := strings.Cut(strings.TrimSpace(entry), "-")
+		if !isRange {
+			last = first
+		}
+
+		start, err := strconv.Atoi(first)
+		if err != nil || start < 1 {
+			return nil, fmt.Errorf("invalid document selection %s, expected a document number (starting with 1) or a range", entry)
+		}
+
+		end, err := strconv.Atoi(last)
+		if err != nil || end < start {
+			return nil, fmt.Errorf("invalid document selection %s, expected a document number (starting with 1) or a range", entry)
+		}
+
+		result = append(result, [2]int{start - 1, end - 1})
+	}
+
+	return result, nil
+}
+
+// parseSelectedDocuments parses only the selected documents of the input
+// data. YAML input is split at the document markers first, so that only the
+// selected documents need to be parsed. The names of the documents refer to
+// their original position in the input.
+func parseSelectedDocuments(location string, data []byte, selection documentSelection) (ytbx.InputFile, error) {
+	var result = ytbx.InputFile{Location: location}
+	var add = func(idx int, document *yamlv3.Node) {
+		result.Documents = append(result.Documents, document)
+		result.Names = append(result.Names, fmt.Sprintf("document #%d", idx+1))
+	}
+
+	chunks := splitDocuments(data)
+
+	// JSON or TOML input cannot be split, only YAML has document markers
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] == '{' || trimmed[0] == '[' || len(chunks) < 2 {
+		documents, err := ytbx.LoadDocuments(data)
+		if err != nil {
+			return ytbx.InputFile{}, fmt.Errorf("unable to parse data from %s: %w", humanReadableFilename(location), err)
+		}
+
+		return selectDocuments(ytbx.InputFile{Location: location, Documents: documents}, selection)
+	}
+
+	for idx, chunk := range chunks {
+		if !selection.contains(idx) {
+			continue
+		}
+
+		documents, err := ytbx.LoadYAMLDocuments(chunk)
+		if err != nil {
+			return ytbx.InputFile{}, fmt.Errorf("unable to parse document #%d of %s: %w", idx+1, humanReadableFilename(location), err)
+		}
+
+		for _, document := range documents {
+			add(idx, document)
+		}
+	}
+
+	if len(result.Documents) == 0 {
+		return ytbx.InputFile{}, fmt.Errorf("none of the selected documents exist in %s", humanReadableFilename(location))
+	}
+
+	return result, nil
+}
+
+// selectDocuments keeps only the selected documents of an already loaded input
+func selectDocuments(inputFile ytbx.InputFile, selection documentSelection) (ytbx.InputFile, error) {
+	var result = ytbx.InputFile{Location: inputFile.Location}
+	for idx, document := range inputFile.Documents {
+		if selection.contains(idx) {
+			result.Documents = append(result.Documents, document)
+			result.Names = append(result.Names, fmt.Sprintf("document #%d", idx+1))
+		}
+	}
+
+	if len(result.Documents) == 0 {
+		return ytbx.InputFile{}, fmt.Errorf("none of the selected documents exist in %s", humanReadableFilename(inputFile.Location))
+	}
+
+	return result, nil
+}
+
+// splitDocuments splits YAML input at the document start and end markers
+// without parsing the documents, which works since a document marker at the
+// beginning of a line always ends the current document, even inside of block
+// scalars. Directives like `%YAML` or `%TAG` belong to the document started
+// by the next marker. Leading content without any data is not considered a
+// document.
+func splitDocuments(data []byte) [][]byte {
+	var (
+		result     [][]byte
+		current    []byte
+		directives []byte
+		hasData    bool
+		started    bool
+	)
+
+	var flush = func() {
+		if started || hasData {
+			result = append(result, current)
+		}
+
+		current, hasData, started = nil, false, false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "---" || strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "---\t"):
+			flush()
+			started = true
+			current, directives = directives, nil
+
+		case line == "..." || strings.HasPrefix(line, "... "):
+			current = append(current, line...)
+			current = append(current, '\n')
+			flush()
+			continue
+
+		case strings.HasPrefix(line, "%") && !started && !hasData:
+			directives = append(directives, line...)
+			directives = append(directives, '\n')
+			continue
+
+		case !hasData:
+			trimmed := strings.TrimSpace(line)
+			hasData = trimmed != "" && !strings.HasPrefix(trimmed, "#")
+		}
+
+		current = append(current, line...)
+		current = append(current, '\n')
+	}
+
+	flush()
+	return result
+}
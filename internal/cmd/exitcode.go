@@ -20,6 +20,12 @@
 
 package cmd
 
+import (
+	"slices"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
 // ExitCode is an error interface that has exit code (value) details
 type ExitCode interface {
 	Value() int
@@ -50,3 +56,93 @@ func (e errorWithExitCode) Error() string {
 
 	return ""
 }
+
+// Exit codes of the report, the distinct exit codes are only used if they are
+// configured, otherwise all differences result in the same exit code
+const (
+	exitCodeNoDifferences = 0
+	exitCodeDifferences   = 1
+	exitCodeAdditions     = 2
+	exitCodeModifications = 3
+	exitCodeRemovals      = 4
+)
+
+// setsExitCode returns whether the exit code depends on the differences
+func (config reportConfig) setsExitCode() bool {
+	return config.exitWithCode ||
+		config.failOnSeverity != "" ||
+		len(config.failOn) > 0 ||
+		config.distinctExitCodes
+}
+
+// exitCode returns the exit code for the differences of the report, where
+// only the details of the configured kinds and differences of at least the
+// configured severity are considered
+func (config reportConfig) exitCode(report dyff.Report) (int, error) {
+	if !config.setsExitCode() {
+		return exitCodeNoDifferences, nil
+	}
+
+	if len(config.failOn) > 0 {
+		var kinds []rune
+		for _, name := range config.failOn {
+			kind, err := dyff.ParseKindName(name)
+			if err != nil {
+				return 0, err
+			}
+
+			kinds = append(kinds, kind)
+		}
+
+		var diffs []dyff.Diff
+		for _, diff := range report.Diffs {
+			var details []dyff.Detail
+			for _, detail := range diff.Details {
+				if slices.Contains(kinds, detail.Kind) {
+					details = append(details, detail)
+				}
+			}
+
+			if len(details) > 0 {
+				diffs = append(diffs, dyff.Diff{Path: diff.Path, Details: details})
+			}
+		}
+
+		report = dyff.Report{From: report.From, To: report.To, Diffs: diffs}
+	}
+
+	if len(report.Diffs) == 0 {
+		return exitCodeNoDifferences, nil
+	}
+
+	if config.failOnSeverity != "" {
+		threshold, err := dyff.ParseSeverity(config.failOnSeverity)
+		if err != nil {
+			return 0, err
+		}
+
+		rules, err := config.loadSeverityRules()
+		if err != nil {
+			return 0, err
+		}
+
+		if rules.Highest(report) < threshold {
+			return exitCodeNoDifferences, nil
+		}
+	}
+
+	if !config.distinctExitCodes {
+		return exitCodeDifferences, nil
+	}
+
+	switch summary := report.Summary(); {
+	case summary.Count(dyff.REMOVAL) > 0:
+		return exitCodeRemovals, nil
+
+	case summary.OnlyKinds(dyff.ADDITION):
+		return exitCodeAdditions, nil
+
+	default:
+		return exitCodeModifications, nil
+	}
+}
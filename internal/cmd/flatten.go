@@ -0,0 +1,371 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type flattenCmdOptions struct {
+	reverse          bool
+	plainMode        bool
+	omitIndentHelper bool
+}
+
+var flattenCmdSettings flattenCmdOptions
+
+// flattenCmd represents the flatten command
+var flattenCmd = &cobra.Command{
+	Use:   "flatten [flags] <file-location> ...",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Converts documents into flat path=value lines (and back)",
+	Long: `
+Converts documents into flat 'path=value' lines, with one line for each value
+in the document. Path elements are separated by dots, list entries are
+referenced by their index. Dots, equal signs, and backslashes in map keys are
+escaped using a backslash, numeric map keys are written in double quotes so
+that they are not read as list indices. String values that would be read as a
+different type, or that contain special characters, are written in double
+quotes.
+
+Use --reverse to convert flat 'path=value' lines back into a document.
+`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var errs []error
+		for _, location := range args {
+			var err error
+			switch {
+			case flattenCmdSettings.reverse:
+				err = unflattenFile(location)
+
+			default:
+				err = flattenFile(location)
+			}
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to process input files: %w", errors.Join(errs...))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(flattenCmd)
+
+	flattenCmd.Flags().SortFlags = false
+
+	flattenCmd.Flags().BoolVarP(&flattenCmdSettings.reverse, "reverse", "r", false, "convert flat path=value lines back into a document")
+	flattenCmd.Flags().BoolVarP(&flattenCmdSettings.plainMode, "plain", "p", false, "output in plain style without any highlighting (reverse only)")
+	flattenCmd.Flags().BoolVarP(&flattenCmdSettings.omitIndentHelper, "omit-indent-helper", "O", false, "omit indent helper lines in highlighted output (reverse only)")
+}
+
+func flattenFile(location string) error {
+	inputFile, err := dyff.LoadFile(location)
+	if err != nil {
+		return fmt.Errorf("failed to load input from %s: %w", humanReadableFilename(location), err)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	for i, document := range inputFile.Documents {
+		if len(inputFile.Documents) > 1 && i > 0 {
+			fmt.Fprintln(out, "---")
+		}
+
+		if len(document.Content) > 0 {
+			flatten(out, nil, document.Content[0])
+		}
+	}
+
+	return nil
+}
+
+func flatten(out io.Writer, path []string, node *yamlv3.Node) {
+	if node.Kind == yamlv3.AliasNode {
+		node = node.Alias
+	}
+
+	switch {
+	case node.Kind == yamlv3.MappingNode && len(node.Content) > 0:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			flatten(out, append(path, escapeFlatKey(node.Content[i].Value)), node.Content[i+1])
+		}
+
+	case node.Kind == yamlv3.SequenceNode && len(node.Content) > 0:
+		for i, entry := range node.Content {
+			flatten(out, append(path, strconv.Itoa(i)), entry)
+		}
+
+	case node.Kind == yamlv3.MappingNode:
+		fmt.Fprintf(out, "%s={}\n", strings.Join(path, "."))
+
+	case node.Kind == yamlv3.SequenceNode:
+		fmt.Fprintf(out, "%s=[]\n", strings.Join(path, "."))
+
+	default:
+		fmt.Fprintf(out, "%s=%s\n", strings.Join(path, "."), flatValue(node))
+	}
+}
+
+func escapeFlatKey(key string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, ".", `\.`, "=", `\=`, `"`, `\"`).Replace(key)
+	if isFlatIndex(key) {
+		return `"` + escaped + `"`
+	}
+
+	return escaped
+}
+
+// isFlatIndex returns whether the path element would be read as a list index
+func isFlatIndex(element string) bool {
+	idx, err := strconv.Atoi(element)
+	return err == nil && idx >= 0
+}
+
+// flatPathElement is an element of a flat path, where quoted elements are
+// always map keys, even if they are numeric
+type flatPathElement struct {
+	name   string
+	quoted bool
+}
+
+// flatValue returns the scalar value as it is written in the flat line, which
+// is the value as-is if parsing it again results in the same value
+func flatValue(node *yamlv3.Node) string {
+	if node.ShortTag() != "!!str" {
+		return node.Value
+	}
+
+	var reparsed yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(node.Value), &reparsed); err == nil &&
+		len(reparsed.Content) == 1 &&
+		reparsed.Content[0].Kind == yamlv3.ScalarNode &&
+		reparsed.Content[0].ShortTag() == "!!str" &&
+		reparsed.Content[0].Value == node.Value &&
+		!strings.ContainsAny(node.Value, "\"'#\n") {
+		return node.Value
+	}
+
+	data, _ := json.Marshal(node.Value)
+	return string(data)
+}
+
+func unflattenFile(location string) error {
+	var input []byte
+	var err error
+	switch {
+	case ytbx.IsStdin(location):
+		input, err = io.ReadAll(os.Stdin)
+
+	default:
+		input, err = os.ReadFile(location)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to load input from %s: %w", humanReadableFilename(location), err)
+	}
+
+	var documents []*yamlv3.Node
+	var root *yamlv3.Node
+	for no, line := range strings.Split(string(input), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+
+		case line == "---":
+			if root != nil {
+				documents = append(documents, &yamlv3.Node{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{root}})
+				root = nil
+			}
+
+			continue
+		}
+
+		path, value, err := splitFlatLine(line)
+		if err != nil {
+			return fmt.Errorf("failed to parse line %d of %s: %w", no+1, humanReadableFilename(location), err)
+		}
+
+		root, err = unflatten(root, path, value)
+		if err != nil {
+			return fmt.Errorf("failed to parse line %d of %s: %w", no+1, humanReadableFilename(location), err)
+		}
+	}
+
+	if root != nil {
+		documents = append(documents, &yamlv3.Node{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{root}})
+	}
+
+	writer := &OutputWriter{
+		OutputStyle:      "yaml",
+		PlainMode:        flattenCmdSettings.plainMode,
+		OmitIndentHelper: flattenCmdSettings.omitIndentHelper,
+	}
+
+	return writer.WriteDocumentsToStdout(documents)
+}
+
+// splitFlatLine splits the line into the unescaped path elements and the
+// value node
+func splitFlatLine(line string) ([]flatPathElement, *yamlv3.Node, error) {
+	var path []flatPathElement
+	var element bytes.Buffer
+	var inQuotes, quoted bool
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\':
+			if i+1 < len(line) {
+				i++
+				element.WriteByte(line[i])
+			}
+
+		case line[i] == '"' && inQuotes:
+			inQuotes = false
+
+		case line[i] == '"' && element.Len() == 0 && !quoted:
+			inQuotes, quoted = true, true
+
+		case inQuotes:
+			element.WriteByte(line[i])
+
+		case line[i] == '.':
+			path = append(path, flatPathElement{element.String(), quoted})
+			element.Reset()
+			quoted = false
+
+		case line[i] == '=':
+			path = append(path, flatPathElement{element.String(), quoted})
+
+			var value yamlv3.Node
+			if err := yamlv3.Unmarshal([]byte(line[i+1:]), &value); err != nil {
+				return nil, nil, err
+			}
+
+			if len(value.Content) == 0 {
+				return path, &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!null", Value: "null"}, nil
+			}
+
+			value.Content[0].Style = value.Content[0].Style &^ yamlv3.FlowStyle
+			return path, value.Content[0], nil
+
+		default:
+			element.WriteByte(line[i])
+		}
+	}
+
+	if inQuotes {
+		return nil, nil, fmt.Errorf("expected path=value, but found an unterminated quote")
+	}
+
+	return nil, nil, fmt.Errorf("expected path=value, but found no equal sign")
+}
+
+// unflatten sets the value at the path in the given node, which is created
+// if it does not exist yet, numeric path elements are used as list indices
+// unless they are quoted
+func unflatten(node *yamlv3.Node, path []flatPathElement, value *yamlv3.Node) (*yamlv3.Node, error) {
+	if len(path) == 0 || (len(path) == 1 && path[0] == flatPathElement{}) {
+		return value, nil
+	}
+
+	element, rest := path[0].name, path[1:]
+	isIndex := !path[0].quoted && isFlatIndex(element)
+	idx, _ := strconv.Atoi(element)
+
+	if node == nil {
+		switch {
+		case isIndex:
+			node = &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq"}
+
+		default:
+			node = &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+		}
+	}
+
+	switch node.Kind {
+	case yamlv3.SequenceNode:
+		if !isIndex || idx > len(node.Content) {
+			return nil, fmt.Errorf("invalid list index %s", element)
+		}
+
+		if idx == len(node.Content) {
+			node.Content = append(node.Content, nil)
+		}
+
+		child, err := unflatten(node.Content[idx], rest, value)
+		if err != nil {
+			return nil, err
+		}
+
+		node.Content[idx] = child
+
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == element {
+				child, err := unflatten(node.Content[i+1], rest, value)
+				if err != nil {
+					return nil, err
+				}
+
+				node.Content[i+1] = child
+				return node, nil
+			}
+		}
+
+		child, err := unflatten(nil, rest, value)
+		if err != nil {
+			return nil, err
+		}
+
+		node.Content = append(node.Content,
+			&yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: element},
+			child,
+		)
+
+	default:
+		return nil, fmt.Errorf("cannot set %s on a scalar value", element)
+	}
+
+	return node, nil
+}
@@ -0,0 +1,211 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type getCmdOptions struct {
+	plainMode        bool
+	omitIndentHelper bool
+	outputStyle      string
+}
+
+var getDefaults = getCmdOptions{
+	plainMode:        false,
+	omitIndentHelper: false,
+	outputStyle:      "yaml",
+}
+
+var getCmdSettings = getDefaults
+
+// getCmd represents the get command
+var getCmd = &cobra.Command{
+	Use:     "get [flags] <file-location> <path>",
+	Aliases: []string{"query"},
+	Args:    cobra.ExactArgs(2),
+	Short:   "Prints the value(s) at the given path",
+	Long: `
+Prints the value(s) at the given path, which can be a Go-patch style path
+(for example '/spec/containers/name=app/image') or a dot-style path (for
+example 'spec.containers.app.image'), which are parsed the same way as the
+paths of the change root flags. A '*' matches all entries of a map, and in
+Go-patch style paths also all entries of a list. Scalar values are printed
+as-is, all other values are printed as documents.
+`,
+
+	ValidArgsFunction: completeFileThenPath,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		location, pathString := args[0], args[1]
+
+		switch getCmdSettings.outputStyle {
+		case "yaml", "json":
+		default:
+			return fmt.Errorf("unknown output style %s, supported styles are: yaml, or json", getCmdSettings.outputStyle)
+		}
+
+		inputFile, err := dyff.LoadFile(location)
+		if err != nil {
+			return fmt.Errorf("failed to load input from %s: %w", humanReadableFilename(location), err)
+		}
+
+		var results []*yamlv3.Node
+		var parseErr error
+		for _, document := range inputFile.Documents {
+			if len(document.Content) == 0 {
+				continue
+			}
+
+			// Dot-style paths are parsed using the document, so a path that
+			// does not exist in one document can still exist in another one
+			path, err := ytbx.ParsePathString(pathString, document)
+			if err != nil {
+				parseErr = err
+				continue
+			}
+
+			results = append(results, query(document.Content[0], path.PathElements)...)
+		}
+
+		if len(results) == 0 && parseErr != nil {
+			return fmt.Errorf("failed to get value: %w", parseErr)
+		}
+
+		if len(results) == 0 {
+			return fmt.Errorf("failed to get value: %w", bunt.Errorf("no value found at path _*%s*_ in %s", pathString, humanReadableFilename(location)))
+		}
+
+		writer := &OutputWriter{
+			OutputStyle:      getCmdSettings.outputStyle,
+			PlainMode:        getCmdSettings.plainMode,
+			OmitIndentHelper: getCmdSettings.omitIndentHelper,
+		}
+
+		for _, result := range results {
+			if result.Kind == yamlv3.ScalarNode {
+				fmt.Fprintln(os.Stdout, result.Value)
+				continue
+			}
+
+			if err := writer.WriteDocumentsToStdout([]*yamlv3.Node{{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{result}}}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(getCmd)
+
+	getCmd.Flags().SortFlags = false
+
+	getCmd.Flags().StringVarP(&getCmdSettings.outputStyle, "output", "o", getDefaults.outputStyle, "specify the output style for non-scalar values, supported styles: yaml, or json")
+	getCmd.Flags().BoolVarP(&getCmdSettings.plainMode, "plain", "p", getDefaults.plainMode, "output in plain style without any highlighting")
+	getCmd.Flags().BoolVarP(&getCmdSettings.omitIndentHelper, "omit-indent-helper", "O", getDefaults.omitIndentHelper, "omit indent helper lines in highlighted output")
+}
+
+// query returns all nodes that match the path elements, where `*` matches
+// all map values or list entries, elements with a key match list entries with
+// the respective field, and other elements match map keys, list indices, or
+// the name of list entries (using the usual identifier fields name, key, or id)
+func query(node *yamlv3.Node, elements []ytbx.PathElement) []*yamlv3.Node {
+	if node.Kind == yamlv3.AliasNode {
+		node = node.Alias
+	}
+
+	if len(elements) == 0 {
+		return []*yamlv3.Node{node}
+	}
+
+	element, rest := elements[0], elements[1:]
+
+	var candidates []*yamlv3.Node
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		// Go-patch style paths are parsed without the document, so numeric
+		// map keys end up as list indices
+		name := element.Name
+		if element.Key == "" && name == "" {
+			name = strconv.Itoa(element.Idx)
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if element.Key == "" && (name == "*" || node.Content[i].Value == name) {
+				candidates = append(candidates, node.Content[i+1])
+			}
+		}
+
+	case yamlv3.SequenceNode:
+		for i, entry := range node.Content {
+			switch {
+			case element.Key == "" && element.Name == "*",
+				element.Key != "" && fieldValue(entry, element.Key) == element.Name,
+				element.Key == "" && element.Name == "" && i == element.Idx,
+				element.Key == "" && element.Name != "" && entryName(entry) == element.Name:
+				candidates = append(candidates, entry)
+			}
+		}
+	}
+
+	var result []*yamlv3.Node
+	for _, candidate := range candidates {
+		result = append(result, query(candidate, rest)...)
+	}
+
+	return result
+}
+
+func fieldValue(node *yamlv3.Node, field string) string {
+	if node.Kind != yamlv3.MappingNode {
+		return ""
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == field && node.Content[i+1].Kind == yamlv3.ScalarNode {
+			return node.Content[i+1].Value
+		}
+	}
+
+	return ""
+}
+
+func entryName(node *yamlv3.Node) string {
+	for _, field := range []string{"name", "key", "id"} {
+		if value := fieldValue(node, field); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
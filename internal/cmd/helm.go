@@ -0,0 +1,134 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type helmCmdOptions struct {
+	releaseName string
+	namespace   string
+	values      []string
+	fromValues  []string
+	toValues    []string
+	set         []string
+	fromVersion string
+	toVersion   string
+}
+
+var helmDefaults = helmCmdOptions{
+	releaseName: "release",
+}
+
+var helmCmdSettings = helmDefaults
+
+// helmCmd represents the helm command
+var helmCmd = &cobra.Command{
+	Use:   "helm [flags] <from-chart> <to-chart>",
+	Short: "Compare the manifests of two Helm charts",
+	Long: `
+Renders both charts with 'helm template' using the same release name and
+values, and compares the rendered Kubernetes resources, which are matched by
+their identity and shown grouped by resource. This shows what changes in a
+cluster when upgrading a release from one chart (version) to the other.
+
+Charts can be chart directories, chart archives, or charts in a repository,
+e.g. for an upgrade to a new version of a chart in a repository:
+
+  dyff helm bitnami/nginx bitnami/nginx --from-version 15.0.0 --to-version 16.0.0 --values values.yml
+
+Unless a profile is set, the helm profile is used, which for example leaves
+out the chart version labels and checksum annotations.
+`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("profile") {
+			if err := cmd.Flags().Set("profile", "helm"); err != nil {
+				return err
+			}
+
+			if err := applyProfile(cmd.Flags()); err != nil {
+				return err
+			}
+		}
+
+		if !cmd.Flags().Changed("preset") {
+			reportOptions.presets = []string{"helm"}
+		}
+
+		options, err := reportOptions.compareOptions()
+		if err != nil {
+			return err
+		}
+
+		from, err := dyff.RenderHelmChart(args[0], helmTemplateOptions(helmCmdSettings.fromVersion, helmCmdSettings.fromValues))
+		if err != nil {
+			return err
+		}
+
+		to, err := dyff.RenderHelmChart(args[1], helmTemplateOptions(helmCmdSettings.toVersion, helmCmdSettings.toValues))
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := compareContext()
+		defer cancel()
+
+		report, err := dyff.CompareInputFilesContext(ctx, from, to, options...)
+		if err != nil {
+			return compareError(err)
+		}
+
+		return writeReport(cmd, reportOptions.filterReport(report))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(helmCmd)
+
+	helmCmd.Flags().SortFlags = false
+
+	helmCmd.Flags().StringVar(&helmCmdSettings.releaseName, "release-name", helmDefaults.releaseName, "name of the release that both charts are rendered with")
+	helmCmd.Flags().StringVar(&helmCmdSettings.namespace, "namespace", helmDefaults.namespace, "namespace of the release that both charts are rendered with")
+	helmCmd.Flags().StringArrayVar(&helmCmdSettings.values, "values", helmDefaults.values, "values file that is used for both charts, can be used multiple times")
+	helmCmd.Flags().StringArrayVar(&helmCmdSettings.fromValues, "from-values", helmDefaults.fromValues, "values file that is only used for the from chart, applied after --values")
+	helmCmd.Flags().StringArrayVar(&helmCmdSettings.toValues, "to-values", helmDefaults.toValues, "values file that is only used for the to chart, applied after --values")
+	helmCmd.Flags().StringArrayVar(&helmCmdSettings.set, "set", helmDefaults.set, "value that is used for both charts, format is key=value like for helm")
+	helmCmd.Flags().StringVar(&helmCmdSettings.fromVersion, "from-version", helmDefaults.fromVersion, "version of the from chart, if it is a chart in a repository")
+	helmCmd.Flags().StringVar(&helmCmdSettings.toVersion, "to-version", helmDefaults.toVersion, "version of the to chart, if it is a chart in a repository")
+
+	applyReportOptionsFlags(helmCmd)
+}
+
+// helmTemplateOptions returns the options to render one of the charts, with
+// the values files of the chart applied after the common values files
+func helmTemplateOptions(version string, valueFiles []string) dyff.HelmTemplateOptions {
+	return dyff.HelmTemplateOptions{
+		ReleaseName: helmCmdSettings.releaseName,
+		Namespace:   helmCmdSettings.namespace,
+		Version:     version,
+		ValueFiles:  append(append([]string{}, helmCmdSettings.values...), valueFiles...),
+		Values:      helmCmdSettings.set,
+	}
+}
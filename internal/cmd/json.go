@@ -33,6 +33,7 @@ type jsonCmdOptions struct {
 	restructure      bool
 	omitIndentHelper bool
 	inplace          bool
+	outputStyle      string
 }
 
 var jsonCmdSettings jsonCmdOptions
@@ -47,8 +48,13 @@ Converts input document into JSON format while preserving the order of all keys.
 `,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
+		outputStyle, err := conversionOutputStyle(jsonCmdSettings.outputStyle, "json")
+		if err != nil {
+			return err
+		}
+
 		writer := &OutputWriter{
-			OutputStyle:      "json",
+			OutputStyle:      outputStyle,
 			PlainMode:        jsonCmdSettings.plainMode,
 			Restructure:      jsonCmdSettings.restructure,
 			OmitIndentHelper: jsonCmdSettings.omitIndentHelper,
@@ -88,4 +94,5 @@ func init() {
 	jsonCmd.Flags().BoolVarP(&jsonCmdSettings.restructure, "restructure", "r", false, "restructure map keys in reasonable order")
 	jsonCmd.Flags().BoolVarP(&jsonCmdSettings.omitIndentHelper, "omit-indent-helper", "O", false, "omit indent helper lines in highlighted output")
 	jsonCmd.Flags().BoolVarP(&jsonCmdSettings.inplace, "in-place", "i", false, "overwrite input file with output of this command")
+	jsonCmd.Flags().StringVarP(&jsonCmdSettings.outputStyle, "output", "o", "", "use a different output format, supported formats: yaml, json, toml, or properties")
 }
@@ -42,7 +42,7 @@ to compare it against the current configuration.
 	Args:    cobra.ExactArgs(1),
 	Aliases: []string{"la"},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		inputFile, err := ytbx.LoadFile(args[0])
+		inputFile, err := dyff.LoadFile(args[0])
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,77 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+// registeredInputLoaders are the input loaders registered by the command
+// line, which are removed before the next run, while loaders registered by
+// programs that embed the commands are kept
+var registeredInputLoaders []dyff.InputLoader
+
+// registerInputLoader registers the input loader until the next run
+func registerInputLoader(loader dyff.InputLoader) {
+	dyff.RegisterInputLoader(loader)
+	registeredInputLoaders = append(registeredInputLoaders, loader)
+}
+
+// applyInputLoaders registers the input loaders configured on the command
+// line, replacing the loaders of previous runs
+func applyInputLoaders(inputLoaders []string) error {
+	for _, loader := range registeredInputLoaders {
+		dyff.UnregisterInputLoader(loader)
+	}
+
+	registeredInputLoaders = nil
+
+	for _, inputLoader := range inputLoaders {
+		scheme, command, found := strings.Cut(inputLoader, "=")
+		args := strings.Fields(command)
+		if !found || scheme == "" || len(args) == 0 {
+			return fmt.Errorf("invalid input loader %s, expected format is <scheme>=<command>", inputLoader)
+		}
+
+		registerInputLoader(dyff.ExecInputLoader(scheme, args[0], args[1:]...))
+	}
+
+	return nil
+}
+
+// applyDecryption registers the loader for age and PGP encrypted input files,
+// with the keys of the environment unless they are configured as flags
+func applyDecryption(keys dyff.DecryptionKeys) {
+	if len(keys.AgeIdentities) == 0 {
+		keys.AgeIdentities = filepath.SplitList(os.Getenv("DYFF_AGE_IDENTITY"))
+	}
+
+	if keys.PGPPassphraseFile == "" {
+		keys.PGPPassphraseFile = os.Getenv("DYFF_PGP_PASSPHRASE_FILE")
+	}
+
+	registerInputLoader(dyff.DecryptingInputLoader(keys))
+}
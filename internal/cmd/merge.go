@@ -0,0 +1,214 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type mergeCmdOptions struct {
+	listStrategy     string
+	listKey          string
+	plainMode        bool
+	omitIndentHelper bool
+	outputStyle      string
+	threeWay         bool
+	inPlace          bool
+}
+
+var mergeDefaults = mergeCmdOptions{
+	listStrategy:     string(dyff.ListMergeByKey),
+	listKey:          "",
+	plainMode:        false,
+	omitIndentHelper: false,
+	outputStyle:      "yaml",
+	threeWay:         false,
+	inPlace:          false,
+}
+
+var mergeCmdSettings = mergeDefaults
+
+// mergeCmd represents the merge command
+var mergeCmd = &cobra.Command{
+	Use: "merge [flags] <base> <overlay> ...",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if mergeCmdSettings.threeWay {
+			return cobra.ExactArgs(3)(cmd, args)
+		}
+
+		return cobra.MinimumNArgs(2)(cmd, args)
+	},
+	Short: "Merges overlay documents onto a base document",
+	Long: `
+Performs a deep merge of one or more overlay files onto a base file, where
+the overlays are applied in the given order. Maps are merged recursively while
+preserving the order of keys. Lists are merged depending on the list strategy:
+
+- replace: the list of the overlay replaces the list of the base
+- append: the entries of the overlay are appended to the list of the base
+- merge-by-key: entries with the same identifier (e.g. name) are merged, and
+  new entries are appended. If there is no identifier, the list is replaced.
+
+With --three-way, the arguments are a common base followed by two files that
+were both changed based on it (ours and theirs). The changes of both files are
+merged, and changes that were made in different ways are reported as conflicts,
+in which case the value of ours is kept and the exit code is 1. This can be
+used as a merge driver for YAML files in Git:
+
+  # .gitattributes
+  *.yml merge=dyff
+
+  # .git/config
+  [merge "dyff"]
+    name = dyff three-way merge
+    driver = dyff merge --three-way --in-place %O %A %B
+`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		strategy, err := dyff.ParseListMergeStrategy(mergeCmdSettings.listStrategy)
+		if err != nil {
+			return err
+		}
+
+		if mergeCmdSettings.threeWay && len(mergeCmdSettings.listKey) > 0 {
+			return fmt.Errorf("the list key cannot be used with a three-way merge, lists are merged by their detected identifier")
+		}
+
+		var inputFiles []ytbx.InputFile
+		for _, location := range args {
+			inputFile, err := dyff.LoadFile(location)
+			if err != nil {
+				return fmt.Errorf("failed to load input from %s: %w", humanReadableFilename(location), err)
+			}
+
+			inputFiles = append(inputFiles, inputFile)
+		}
+
+		var result ytbx.InputFile
+		var conflicts []dyff.Conflict
+		if mergeCmdSettings.threeWay {
+			threeWayResult, err := dyff.ThreeWayCompare(inputFiles[0], inputFiles[1], inputFiles[2])
+			if err != nil {
+				return fmt.Errorf("failed to merge input files: %w", err)
+			}
+
+			result, conflicts = threeWayResult.Merged, threeWayResult.Conflicts
+
+		} else {
+			result, err = dyff.MergeInputFiles(
+				inputFiles[0],
+				inputFiles[1:],
+				dyff.ListStrategy(strategy),
+				dyff.ListKey(mergeCmdSettings.listKey),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to merge input files: %w", err)
+			}
+		}
+
+		writer := &OutputWriter{
+			OutputStyle:      mergeCmdSettings.outputStyle,
+			PlainMode:        mergeCmdSettings.plainMode,
+			OmitIndentHelper: mergeCmdSettings.omitIndentHelper,
+		}
+
+		switch mergeCmdSettings.outputStyle {
+		case "yaml", "json":
+			// the merged result replaces the file that was merged into, which
+			// is ours for a three-way merge
+			if mergeCmdSettings.inPlace {
+				target := args[len(args)-1]
+				if mergeCmdSettings.threeWay {
+					target = args[1]
+				}
+
+				err = writer.WriteDocumentsInplace(target, result.Documents)
+			} else {
+				err = writer.WriteDocumentsToStdout(result.Documents)
+			}
+
+		default:
+			return fmt.Errorf("unknown output style %s, supported styles are: yaml, or json", mergeCmdSettings.outputStyle)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) > 0 {
+			return errorWithExitCode{
+				value: 1,
+				cause: fmt.Errorf("merge has %d conflict(s): %w", len(conflicts), errors.New(conflictsText(conflicts))),
+			}
+		}
+
+		return nil
+	},
+}
+
+// conflictsText returns a description of each conflict of a three-way merge
+func conflictsText(conflicts []dyff.Conflict) string {
+	value := func(node *yamlv3.Node) string {
+		if node == nil {
+			return "<none>"
+		}
+
+		text, err := yamlv3.Marshal(node)
+		if err != nil {
+			return node.Value
+		}
+
+		return strings.TrimSpace(string(text))
+	}
+
+	var lines []string
+	for _, conflict := range conflicts {
+		lines = append(lines,
+			conflict.Path.String(),
+			"  ours:   "+strings.ReplaceAll(value(conflict.Ours), "\n", "\n          "),
+			"  theirs: "+strings.ReplaceAll(value(conflict.Theirs), "\n", "\n          "),
+		)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+
+	mergeCmd.Flags().SortFlags = false
+
+	mergeCmd.Flags().StringVar(&mergeCmdSettings.listStrategy, "list-strategy", mergeDefaults.listStrategy, "how to merge lists, supported strategies: replace, append, or merge-by-key")
+	mergeCmd.Flags().StringVar(&mergeCmdSettings.listKey, "list-key", mergeDefaults.listKey, "field to be used as the identifier when merging lists by key")
+	mergeCmd.Flags().BoolVar(&mergeCmdSettings.threeWay, "three-way", mergeDefaults.threeWay, "merge the changes of two files (ours and theirs) based on their common base")
+	mergeCmd.Flags().BoolVarP(&mergeCmdSettings.inPlace, "in-place", "i", mergeDefaults.inPlace, "overwrite the last input file (ours for a three-way merge) with the merged result")
+	mergeCmd.Flags().StringVarP(&mergeCmdSettings.outputStyle, "output", "o", mergeDefaults.outputStyle, "specify the output style, supported styles: yaml, or json")
+	mergeCmd.Flags().BoolVarP(&mergeCmdSettings.plainMode, "plain", "p", mergeDefaults.plainMode, "output in plain style without any highlighting")
+	mergeCmd.Flags().BoolVarP(&mergeCmdSettings.omitIndentHelper, "omit-indent-helper", "O", mergeDefaults.omitIndentHelper, "omit indent helper lines in highlighted output")
+}
@@ -0,0 +1,175 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var (
+	durationBuckets  = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+	inputSizeBuckets = []float64{1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20}
+)
+
+// serveMetrics are the metrics of the serve command, which are exposed in the
+// Prometheus text format so that the server can be monitored
+type serveMetrics struct {
+	sync.Mutex
+
+	comparisons map[string]uint64
+	differences map[string]uint64
+	duration    *histogram
+	inputSize   map[string]*histogram
+}
+
+// histogram counts observations in cumulative buckets like a Prometheus
+// histogram, where the last bucket is the implicit +Inf bucket
+type histogram struct {
+	bounds []float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+func (h *histogram) observe(value float64) {
+	for i, bound := range h.bounds {
+		if value <= bound {
+			h.counts[i]++
+		}
+	}
+
+	h.sum += value
+	h.count++
+}
+
+func newServeMetrics() *serveMetrics {
+	return &serveMetrics{
+		comparisons: map[string]uint64{},
+		differences: map[string]uint64{},
+		duration:    newHistogram(durationBuckets),
+		inputSize: map[string]*histogram{
+			"from": newHistogram(inputSizeBuckets),
+			"to":   newHistogram(inputSizeBuckets),
+		},
+	}
+}
+
+// observeInput records the size of an input file of a comparison request
+func (m *serveMetrics) observeInput(name string, size int) {
+	m.Lock()
+	defer m.Unlock()
+
+	if h, ok := m.inputSize[name]; ok {
+		h.observe(float64(size))
+	}
+}
+
+// observeComparison records a finished comparison request with the status
+// code of the reply
+func (m *serveMetrics) observeComparison(status int, duration time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.comparisons[strconv.Itoa(status)]++
+	m.duration.observe(duration.Seconds())
+}
+
+// observeDifferences records the differences of a report by their kind
+func (m *serveMetrics) observeDifferences(report dyff.Report) {
+	m.Lock()
+	defer m.Unlock()
+
+	for kind, count := range report.Summary().Kinds {
+		m.differences[dyff.KindName(kind)] += uint64(count)
+	}
+}
+
+// ServeHTTP writes the current metrics in the Prometheus text format
+func (m *serveMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	m.Lock()
+	defer m.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	writeMetricHeader(w, "dyff_comparisons_total", "counter", "Number of comparison requests by status code of the reply.")
+	for _, code := range sortedKeys(m.comparisons) {
+		fmt.Fprintf(w, "dyff_comparisons_total{code=%q} %d\n", code, m.comparisons[code])
+	}
+
+	writeMetricHeader(w, "dyff_comparison_duration_seconds", "histogram", "Duration of comparison requests in seconds.")
+	m.duration.write(w, "dyff_comparison_duration_seconds", "")
+
+	writeMetricHeader(w, "dyff_input_size_bytes", "histogram", "Size of the input files of comparison requests in bytes.")
+	for _, name := range []string{"from", "to"} {
+		m.inputSize[name].write(w, "dyff_input_size_bytes", fmt.Sprintf("input=%q,", name))
+	}
+
+	writeMetricHeader(w, "dyff_differences_total", "counter", "Number of reported differences by kind.")
+	for _, kind := range sortedKeys(m.differences) {
+		fmt.Fprintf(w, "dyff_differences_total{kind=%q} %d\n", kind, m.differences[kind])
+	}
+}
+
+func (h *histogram) write(w io.Writer, name string, labels string) {
+	for i, bound := range h.bounds {
+		fmt.Fprintf(w, "%s_bucket{%sle=%q} %d\n", name, labels, formatFloat(bound), h.counts[i])
+	}
+
+	fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", name, labels, h.count)
+
+	if labels != "" {
+		labels = "{" + labels[:len(labels)-1] + "}"
+	}
+
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, labels, formatFloat(h.sum))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labels, h.count)
+}
+
+func writeMetricHeader(w io.Writer, name string, kind string, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedKeys(m map[string]uint64) []string {
+	var keys = make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
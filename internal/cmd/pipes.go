@@ -0,0 +1,122 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// isPipe returns whether the location is a pipe, e.g. a named pipe (FIFO) or
+// the file descriptor of a process substitution like <(helm template ...)
+func isPipe(location string) bool {
+	info, err := os.Stat(location)
+	return err == nil && info.Mode()&os.ModeNamedPipe != 0
+}
+
+// fdLocation returns the location of an inherited file descriptor
+func fdLocation(fd int) string {
+	return fmt.Sprintf("/dev/fd/%d", fd)
+}
+
+// describeLocation returns the location with the command that writes into
+// it, in case the location is a pipe and the command can be determined
+func describeLocation(location string) string {
+	if command := pipeCommand(location); command != "" {
+		return fmt.Sprintf("%s (output of %s)", location, command)
+	}
+
+	return location
+}
+
+// describeHeaderLocation returns the description of the location for the
+// report header, which is the configured description if there is one
+func describeHeaderLocation(description string, location string) string {
+	if description != "" {
+		return description
+	}
+
+	return describeLocation(location)
+}
+
+// pipeCommand returns the command line of the process that writes into the
+// pipe at the location. This is a best effort look-up based on the proc file
+// system, so an empty string is returned if it is not available.
+func pipeCommand(location string) string {
+	if !isPipe(location) {
+		return ""
+	}
+
+	target, err := os.Readlink(location)
+	if err != nil || !strings.HasPrefix(target, "pipe:") {
+		return ""
+	}
+
+	fds, err := filepath.Glob("/proc/[0-9]*/fd/*")
+	if err != nil {
+		return ""
+	}
+
+	var self = strconv.Itoa(os.Getpid())
+	var command string
+	for _, fd := range fds {
+		pid := strings.Split(fd, "/")[2]
+		if pid == self {
+			continue
+		}
+
+		if link, err := os.Readlink(fd); err != nil || link != target || !isWriteOnly(fd) {
+			continue
+		}
+
+		// The last writer is usually the actual command, since the shell that
+		// started it for the process substitution has a lower process ID
+		if data, err := os.ReadFile(filepath.Join("/proc", pid, "cmdline")); err == nil && len(data) > 0 {
+			command = strings.Join(strings.Split(strings.TrimRight(string(data), "\x00"), "\x00"), " ")
+		}
+	}
+
+	return command
+}
+
+// isWriteOnly returns whether the file descriptor in the proc file system is
+// opened for writing only, which is the write end of a pipe
+func isWriteOnly(fd string) bool {
+	file, err := os.Open(strings.Replace(fd, "/fd/", "/fdinfo/", 1))
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "flags:"); ok {
+			flags, err := strconv.ParseInt(strings.TrimSpace(value), 8, 64)
+			return err == nil && int(flags)&(os.O_WRONLY|os.O_RDWR) == os.O_WRONLY
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+// placeholderResolver returns the resolver for the configured placeholder
+// mode, or nil if placeholders are compared as they are
+func placeholderResolver(mode string, varsFiles []string, command string) (dyff.PlaceholderResolver, error) {
+	switch strings.ToLower(mode) {
+	case "keep":
+		if len(varsFiles) > 0 || command != "" {
+			return nil, fmt.Errorf("incompatible flags: vars file and placeholder command flags require placeholders to be resolved, use --placeholders=resolve")
+		}
+
+		return nil, nil
+
+	case "symbolic":
+		return dyff.SymbolicPlaceholders, nil
+
+	case "resolve":
+		var resolvers []dyff.PlaceholderResolver
+		for _, varsFile := range varsFiles {
+			resolver, err := dyff.VarsFileResolver(varsFile)
+			if err != nil {
+				return nil, err
+			}
+
+			resolvers = append(resolvers, resolver)
+		}
+
+		if args := strings.Fields(command); len(args) > 0 {
+			resolvers = append(resolvers, dyff.ExecPlaceholderResolver(args[0], args[1:]...))
+		}
+
+		if len(resolvers) == 0 {
+			return nil, fmt.Errorf("resolving placeholders requires a vars file or a placeholder command")
+		}
+
+		// The first resolver that knows the placeholder wins, the error of
+		// the last one is reported if none of them does
+		return dyff.PlaceholderResolverFunc(func(reference string) (value *yamlv3.Node, err error) {
+			for _, resolver := range resolvers {
+				if value, err = resolver.Resolve(reference); err == nil {
+					return value, nil
+				}
+			}
+
+			return nil, err
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("unknown placeholder mode %s, supported modes are keep, symbolic, and resolve", mode)
+	}
+}
@@ -0,0 +1,438 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type pollCmdOptions struct {
+	command    string
+	interval   time.Duration
+	historyDir string
+	count      int
+}
+
+var pollDefaults = pollCmdOptions{
+	interval:   time.Minute,
+	historyDir: ".dyff-history",
+}
+
+var pollCmdSettings = pollDefaults
+
+type historyCmdOptions struct {
+	historyDir string
+	since      string
+	until      string
+}
+
+var historyDefaults = historyCmdOptions{
+	historyDir: pollDefaults.historyDir,
+}
+
+var historyCmdSettings = historyDefaults
+
+// maxPollFailures is the number of failed snapshots in a row after which
+// polling is stopped, since the URL or command is most likely not temporarily
+// unavailable, but wrong
+const maxPollFailures = 5
+
+// historyTimeFormat is the format of the timestamps used as file names in the
+// history directory, which sort in chronological order
+const historyTimeFormat = "20060102T150405.000Z"
+
+// pollCmd represents the poll command
+var pollCmd = &cobra.Command{
+	Use:   "poll [flags] [<url>]",
+	Short: "Periodically fetch a URL or run a command and record what changed",
+	Long: `
+Fetches the URL (or runs the command) in the given interval and compares the
+result with the previous snapshot. Snapshots that differ from the previous one
+are stored with their timestamp in the history directory, together with the
+report of the differences in JSON format. The history command renders what
+changed between two points in time using the stored snapshots.
+
+  dyff poll --interval 5m https://example.com/config.json
+  dyff poll --command "kubectl get configmap app --output yaml"
+
+`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if pollCmdSettings.command != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if pollCmdSettings.interval <= 0 {
+			return fmt.Errorf("invalid interval %s, it needs to be greater than zero", pollCmdSettings.interval)
+		}
+
+		var fetch func() ([]byte, error)
+		switch {
+		case pollCmdSettings.command != "":
+			fetch = func() ([]byte, error) { return runPollCommand(pollCmdSettings.command) }
+
+		default:
+			fetch = func() ([]byte, error) { return fetchURL(args[0]) }
+		}
+
+		options, err := reportOptions.compareOptions()
+		if err != nil {
+			return err
+		}
+
+		return poll(cmd, fetch, options)
+	},
+}
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history [flags]",
+	Short: "Show what changed between two points in time recorded by the poll command",
+	Long: `
+Compares the snapshots of the history directory that were current at the
+given points in time. Times can be a time of today like 14:00, a date and time
+like 2006-01-02 15:04, or RFC 3339 timestamps. Without a time, the oldest and
+the latest snapshot are used.
+
+  dyff history --since 10:00 --until 14:00
+
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		snapshots, err := listSnapshots(historyCmdSettings.historyDir)
+		if err != nil {
+			return err
+		}
+
+		if len(snapshots) == 0 {
+			return fmt.Errorf("no snapshots found in %s", historyCmdSettings.historyDir)
+		}
+
+		from, err := snapshotAt(snapshots, historyCmdSettings.since, snapshots[0])
+		if err != nil {
+			return err
+		}
+
+		to, err := snapshotAt(snapshots, historyCmdSettings.until, snapshots[len(snapshots)-1])
+		if err != nil {
+			return err
+		}
+
+		options, err := reportOptions.compareOptions()
+		if err != nil {
+			return err
+		}
+
+		fromFile, err := from.load()
+		if err != nil {
+			return err
+		}
+
+		toFile, err := to.load()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := compareContext()
+		defer cancel()
+
+		report, err := dyff.CompareInputFilesContext(ctx, fromFile, toFile, options...)
+		if err != nil {
+			return compareError(err)
+		}
+
+		return writeReport(cmd, reportOptions.filterReport(report))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pollCmd)
+	rootCmd.AddCommand(historyCmd)
+
+	pollCmd.Flags().SortFlags = false
+	historyCmd.Flags().SortFlags = false
+
+	pollCmd.Flags().StringVar(&pollCmdSettings.command, "command", "", "run the command using sh instead of fetching a URL, its standard output is the snapshot")
+	pollCmd.Flags().DurationVar(&pollCmdSettings.interval, "interval", pollDefaults.interval, "time between two snapshots")
+	pollCmd.Flags().StringVar(&pollCmdSettings.historyDir, "history-dir", pollDefaults.historyDir, "directory to store the snapshots and reports in")
+	pollCmd.Flags().IntVar(&pollCmdSettings.count, "count", 0, "stop after the given number of snapshots, zero means to poll until the program is stopped")
+
+	historyCmd.Flags().StringVar(&historyCmdSettings.historyDir, "history-dir", historyDefaults.historyDir, "directory with the snapshots stored by the poll command")
+	historyCmd.Flags().StringVar(&historyCmdSettings.since, "since", "", "point in time of the from snapshot (default is the oldest snapshot)")
+	historyCmd.Flags().StringVar(&historyCmdSettings.until, "until", "", "point in time of the to snapshot (default is the latest snapshot)")
+
+	applyReportOptionsFlags(pollCmd)
+	applyReportOptionsFlags(historyCmd)
+}
+
+// poll takes a snapshot in each interval and stores it in the history with
+// the report of the differences, if it differs from the previous snapshot
+func poll(cmd *cobra.Command, fetch func() ([]byte, error), options []dyff.CompareOption) error {
+	if err := os.MkdirAll(pollCmdSettings.historyDir, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	snapshots, err := listSnapshots(pollCmdSettings.historyDir)
+	if err != nil {
+		return err
+	}
+
+	// Continue with the latest snapshot of an earlier run
+	var previous *ytbx.InputFile
+	if len(snapshots) > 0 {
+		inputFile, err := snapshots[len(snapshots)-1].load()
+		if err != nil {
+			return err
+		}
+
+		previous = &inputFile
+	}
+
+	var failures int
+	for i := 0; pollCmdSettings.count == 0 || i < pollCmdSettings.count; i++ {
+		if i > 0 {
+			time.Sleep(pollCmdSettings.interval)
+		}
+
+		current, err := takeSnapshot(cmd, fetch, previous, options)
+		if err != nil {
+			if failures++; failures >= maxPollFailures {
+				return fmt.Errorf("failed to take snapshot %d times in a row: %w", failures, err)
+			}
+
+			// A failed fetch can be temporary, the next one is tried anyway
+			fmt.Fprintf(cmd.ErrOrStderr(), "failed to take snapshot: %v\n", err)
+			continue
+		}
+
+		failures = 0
+		previous = current
+	}
+
+	return nil
+}
+
+// takeSnapshot fetches a snapshot and compares it with the previous one, the
+// snapshot is only stored if it differs, and the new latest one is returned
+func takeSnapshot(cmd *cobra.Command, fetch func() ([]byte, error), previous *ytbx.InputFile, options []dyff.CompareOption) (*ytbx.InputFile, error) {
+	data, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := ytbx.LoadDocuments(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var now = time.Now()
+	var current = ytbx.InputFile{Location: now.Format(time.DateTime), Documents: documents}
+	var name = filepath.Join(pollCmdSettings.historyDir, now.UTC().Format(historyTimeFormat))
+
+	if previous == nil {
+		return &current, os.WriteFile(name+".yml", data, 0644)
+	}
+
+	ctx, cancel := compareContext()
+	defer cancel()
+
+	report, err := dyff.CompareInputFilesContext(ctx, *previous, current, options...)
+	if err != nil {
+		return nil, compareError(err)
+	}
+
+	if len(report.Diffs) == 0 {
+		return previous, nil
+	}
+
+	if err := os.WriteFile(name+".yml", data, 0644); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := (&dyff.JSONReport{Report: report}).WriteReport(&buf); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(name+".json", buf.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+
+	if err := writeReport(cmd, reportOptions.filterReport(report)); err != nil && !errors.As(err, &errorWithExitCode{}) {
+		return nil, err
+	}
+
+	return &current, nil
+}
+
+func fetchURL(location string) ([]byte, error) {
+	response, err := http.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", location, response.Status)
+	}
+
+	return io.ReadAll(response.Body)
+}
+
+func runPollCommand(command string) ([]byte, error) {
+	if strings.TrimSpace(command) == "" {
+		return nil, fmt.Errorf("empty command")
+	}
+
+	// The command is run by the shell, so that quoted arguments and pipes
+	// work like they do on the command line
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("command %s failed: %w: %s", command, err, msg)
+		}
+
+		return nil, fmt.Errorf("command %s failed: %w", command, err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// snapshot is a snapshot stored in the history directory
+type snapshot struct {
+	time time.Time
+	path string
+}
+
+func (s snapshot) load() (ytbx.InputFile, error) {
+	inputFile, err := dyff.LoadFile(s.path)
+	if err != nil {
+		return ytbx.InputFile{}, err
+	}
+
+	inputFile.Location = s.time.Local().Format(time.DateTime)
+	return inputFile, nil
+}
+
+// listSnapshots returns the snapshots of the history directory in
+// chronological order, other files are ignored
+func listSnapshots(dir string) ([]snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	var snapshots []snapshot
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".yml")
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		timestamp, err := time.Parse(historyTimeFormat, name)
+		if err != nil {
+			continue
+		}
+
+		snapshots = append(snapshots, snapshot{time: timestamp, path: filepath.Join(dir, entry.Name())})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].time.Before(snapshots[j].time)
+	})
+
+	return snapshots, nil
+}
+
+// snapshotAt returns the snapshot that was the latest at the given point in
+// time, or the fallback if no time is given
+func snapshotAt(snapshots []snapshot, value string, fallback snapshot) (snapshot, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	pointInTime, err := parsePointInTime(value, time.Now())
+	if err != nil {
+		return snapshot{}, err
+	}
+
+	var result *snapshot
+	for i := range snapshots {
+		if snapshots[i].time.After(pointInTime) {
+			break
+		}
+
+		result = &snapshots[i]
+	}
+
+	if result == nil {
+		return snapshot{}, fmt.Errorf("no snapshot found at %s, the oldest one is from %s", value, snapshots[0].time.Local().Format(time.DateTime))
+	}
+
+	return *result, nil
+}
+
+// parsePointInTime parses a time of today, a date with time, or an RFC 3339
+// timestamp, where the local time zone is used unless one is specified
+func parsePointInTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	for _, layout := range []string{time.DateTime, "2006-01-02 15:04", time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	for _, layout := range []string{time.TimeOnly, "15:04"} {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location()), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid point in time %s, use for example 14:00, 2006-01-02 15:04, or an RFC 3339 timestamp", value)
+}
@@ -0,0 +1,342 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type prCmdOptions struct {
+	forge  string
+	apiURL string
+	repo   string
+	pr     int
+	file   string
+}
+
+var prDefaults = prCmdOptions{
+	forge: "github",
+}
+
+var prCmdSettings = prDefaults
+
+// errNotFound is returned by forges for anything that does not exist, for
+// example a file in a version before it was added by the pull request
+var errNotFound = errors.New("not found")
+
+// forge fetches the versions of a file that are compared for a pull request
+type forge interface {
+	// revisions returns the base and the head revision of a pull request
+	revisions(ctx context.Context, repo string, number int) (string, string, error)
+
+	// file returns the content of a file in the given revision
+	file(ctx context.Context, repo string, path string, revision string) ([]byte, error)
+}
+
+// prCmd represents the pr command
+var prCmd = &cobra.Command{
+	Use:   "pr [flags]",
+	Short: "Compare the base and head version of a file in a pull request",
+	Long: `
+Fetches the base and the head version of a file of a pull request (GitHub) or
+merge request (GitLab) using the API of the forge, and compares them without
+the need to clone the repository. The base version is the merge base, which
+means only the changes of the pull request itself are shown.
+
+A file that is added or removed by the pull request is compared against an
+empty document. The API token is read from the GITHUB_TOKEN or GITLAB_TOKEN
+environment variable, depending on the forge.
+
+  dyff pr --repo homeport/dyff --pr 123 --file deploy/values.yml
+
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		switch {
+		case prCmdSettings.repo == "":
+			return fmt.Errorf("missing repository, use --repo to specify it")
+
+		case prCmdSettings.pr <= 0:
+			return fmt.Errorf("missing pull request number, use --pr to specify it")
+
+		case prCmdSettings.file == "":
+			return fmt.Errorf("missing file, use --file to specify it")
+		}
+
+		api, err := lookupForge(prCmdSettings.forge, prCmdSettings.apiURL)
+		if err != nil {
+			return err
+		}
+
+		options, err := reportOptions.compareOptions()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := compareContext()
+		defer cancel()
+
+		from, to, err := loadPullRequestFiles(ctx, api, prCmdSettings.repo, prCmdSettings.pr, prCmdSettings.file)
+		if err != nil {
+			return err
+		}
+
+		report, err := dyff.CompareInputFilesContext(ctx, from, to, options...)
+		if err != nil {
+			return compareError(err)
+		}
+
+		return writeReport(cmd, reportOptions.filterReport(report))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(prCmd)
+
+	prCmd.Flags().SortFlags = false
+
+	prCmd.Flags().StringVar(&prCmdSettings.forge, "forge", prDefaults.forge, "forge that hosts the repository, supported forges: github, or gitlab")
+	prCmd.Flags().StringVar(&prCmdSettings.apiURL, "api-url", "", "base URL of the API of the forge, for example of a self-hosted instance (default is the public API of the forge)")
+	prCmd.Flags().StringVar(&prCmdSettings.repo, "repo", "", "repository of the pull request in the form org/name")
+	prCmd.Flags().IntVar(&prCmdSettings.pr, "pr", 0, "number of the pull request or merge request")
+	prCmd.Flags().StringVar(&prCmdSettings.file, "file", "", "path of the file in the repository to compare")
+
+	applyReportOptionsFlags(prCmd)
+}
+
+func lookupForge(name string, apiURL string) (forge, error) {
+	switch strings.ToLower(name) {
+	case "github":
+		if apiURL == "" {
+			apiURL = "https://api.github.com"
+		}
+
+		return &gitHub{api: strings.TrimSuffix(apiURL, "/"), token: os.Getenv("GITHUB_TOKEN")}, nil
+
+	case "gitlab":
+		if apiURL == "" {
+			apiURL = "https://gitlab.com/api/v4"
+		}
+
+		return &gitLab{api: strings.TrimSuffix(apiURL, "/"), token: os.Getenv("GITLAB_TOKEN")}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown forge %s, supported forges are github, or gitlab", name)
+	}
+}
+
+// loadPullRequestFiles fetches the base and head version of the file, where
+// a missing version is replaced with empty documents
+func loadPullRequestFiles(ctx context.Context, api forge, repo string, number int, path string) (ytbx.InputFile, ytbx.InputFile, error) {
+	base, head, err := api.revisions(ctx, repo, number)
+	if err != nil {
+		return ytbx.InputFile{}, ytbx.InputFile{}, fmt.Errorf("failed to look up pull request %d of %s: %w", number, repo, err)
+	}
+
+	var inputFiles = make([]*ytbx.InputFile, 2)
+	for i, revision := range []string{base, head} {
+		data, err := api.file(ctx, repo, path, revision)
+		if errors.Is(err, errNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return ytbx.InputFile{}, ytbx.InputFile{}, fmt.Errorf("failed to fetch %s of revision %s: %w", path, shortRevision(revision), err)
+		}
+
+		documents, err := ytbx.LoadDocuments(data)
+		if err != nil {
+			return ytbx.InputFile{}, ytbx.InputFile{}, fmt.Errorf("failed to load %s of revision %s: %w", path, shortRevision(revision), err)
+		}
+
+		inputFiles[i] = &ytbx.InputFile{
+			Location:  fmt.Sprintf("%s@%s:%s", repo, shortRevision(revision), path),
+			Documents: documents,
+		}
+	}
+
+	switch {
+	case inputFiles[0] == nil && inputFiles[1] == nil:
+		return ytbx.InputFile{}, ytbx.InputFile{}, fmt.Errorf("file %s does not exist in pull request %d of %s", path, number, repo)
+
+	case inputFiles[0] == nil:
+		return emptyInputFile(*inputFiles[1]), *inputFiles[1], nil
+
+	case inputFiles[1] == nil:
+		return *inputFiles[0], emptyInputFile(*inputFiles[0]), nil
+	}
+
+	return *inputFiles[0], *inputFiles[1], nil
+}
+
+func shortRevision(revision string) string {
+	if len(revision) > 7 {
+		return revision[:7]
+	}
+
+	return revision
+}
+
+// gitHub uses the REST API of GitHub
+type gitHub struct {
+	api   string
+	token string
+}
+
+func (g *gitHub) revisions(ctx context.Context, repo string, number int) (string, string, error) {
+	var pullRequest struct {
+		Base struct {
+			SHA string `json:"sha"`
+		} `json:"base"`
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	}
+
+	if err := g.get(ctx, fmt.Sprintf("/repos/%s/pulls/%d", repo, number), "application/vnd.github+json", &pullRequest); err != nil {
+		return "", "", err
+	}
+
+	// The base of a pull request is the tip of the target branch, which can
+	// contain newer commits, the merge base is what the pull request is based on
+	var comparison struct {
+		MergeBaseCommit struct {
+			SHA string `json:"sha"`
+		} `json:"merge_base_commit"`
+	}
+
+	if err := g.get(ctx, fmt.Sprintf("/repos/%s/compare/%s...%s", repo, pullRequest.Base.SHA, pullRequest.Head.SHA), "application/vnd.github+json", &comparison); err != nil {
+		return "", "", err
+	}
+
+	return comparison.MergeBaseCommit.SHA, pullRequest.Head.SHA, nil
+}
+
+func (g *gitHub) file(ctx context.Context, repo string, path string, revision string) ([]byte, error) {
+	var data []byte
+	err := g.get(ctx, fmt.Sprintf("/repos/%s/contents/%s?ref=%s", repo, escapePath(path), url.QueryEscape(revision)), "application/vnd.github.raw", &data)
+	return data, err
+}
+
+func (g *gitHub) get(ctx context.Context, path string, accept string, result any) error {
+	var header = http.Header{"Accept": {accept}}
+	if g.token != "" {
+		header.Set("Authorization", "Bearer "+g.token)
+	}
+
+	return forgeRequest(ctx, g.api+path, header, result)
+}
+
+// gitLab uses the REST API of GitLab
+type gitLab struct {
+	api   string
+	token string
+}
+
+func (g *gitLab) revisions(ctx context.Context, repo string, number int) (string, string, error) {
+	var mergeRequest struct {
+		DiffRefs struct {
+			BaseSHA string `json:"base_sha"`
+			HeadSHA string `json:"head_sha"`
+		} `json:"diff_refs"`
+	}
+
+	if err := g.get(ctx, fmt.Sprintf("/projects/%s/merge_requests/%d", url.PathEscape(repo), number), &mergeRequest); err != nil {
+		return "", "", err
+	}
+
+	return mergeRequest.DiffRefs.BaseSHA, mergeRequest.DiffRefs.HeadSHA, nil
+}
+
+func (g *gitLab) file(ctx context.Context, repo string, path string, revision string) ([]byte, error) {
+	var data []byte
+	err := g.get(ctx, fmt.Sprintf("/projects/%s/repository/files/%s/raw?ref=%s", url.PathEscape(repo), url.PathEscape(path), url.QueryEscape(revision)), &data)
+	return data, err
+}
+
+func (g *gitLab) get(ctx context.Context, path string, result any) error {
+	var header = http.Header{}
+	if g.token != "" {
+		header.Set("PRIVATE-TOKEN", g.token)
+	}
+
+	return forgeRequest(ctx, g.api+path, header, result)
+}
+
+// forgeRequest sends a GET request to the API of a forge, the response is
+// either decoded as JSON into the result, or used as is for a byte slice
+func forgeRequest(ctx context.Context, location string, header http.Header, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header = header
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("request to %s failed: %w", location, errNotFound)
+
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("request to %s failed: %s: %s", location, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	if raw, ok := result.(*[]byte); ok {
+		*raw = data
+		return nil
+	}
+
+	return json.Unmarshal(data, result)
+}
+
+// escapePath escapes the elements of a path, but keeps the separators
+func escapePath(path string) string {
+	elements := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i := range elements {
+		elements[i] = url.PathEscape(elements[i])
+	}
+
+	return strings.Join(elements, "/")
+}
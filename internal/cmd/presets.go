@@ -0,0 +1,196 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+// preset is a named set of options for a specific type of input files
+type preset struct {
+	compareOptions []dyff.CompareOption
+
+	// groupBy optionally groups the differences in the human output
+	groupBy func(diff dyff.Diff) string
+
+	// resolveReferences replaces JSON references in the input files with the
+	// content they refer to before comparing them (not done by serve)
+	resolveReferences bool
+}
+
+// kubernetesCompareOptions are the options for Kubernetes resources: The
+// entries of the lists of a pod specification are identified by their name,
+// and fields that are maintained by the cluster instead of the author of a
+// resource are not compared. Resources are matched by their identity, so the
+// order of the documents is irrelevant.
+var kubernetesCompareOptions = []dyff.CompareOption{
+	dyff.KubernetesEntityDetection(true),
+	dyff.IgnoreDocumentOrderChanges(true),
+	dyff.ListIdentifierForPathRegexp(regexp.MustCompile(`/(containers|initContainers|ephemeralContainers|volumes)$`), "name"),
+	dyff.ListIdentifierForPathRegexp(regexp.MustCompile(`/(containers|initContainers|ephemeralContainers)/name=[^/]+/env$`), "name"),
+	dyff.ListIdentifierForPathRegexp(regexp.MustCompile(`/ports$`), "name", "containerPort", "port"),
+	dyff.IgnorePaths(
+		"/metadata/managedFields",
+		"/metadata/creationTimestamp",
+		"/spec/template/metadata/creationTimestamp",
+		"/status",
+	),
+}
+
+var presets = map[string]preset{
+	// BOSH deployment manifests: Placeholders for credentials are resolved
+	// during the deployment, so they are equal to any concrete value, and the
+	// variable definitions only describe how the credentials are generated
+	"bosh": {
+		compareOptions: []dyff.CompareOption{
+			dyff.ComparerForPathRegexp(regexp.MustCompile(`^/`), dyff.PlaceholderWildcards),
+			dyff.IgnorePaths("/variables"),
+		},
+	},
+
+	// Concourse pipelines: The entities of a pipeline are identified by their
+	// name, and changes are shown per job
+	"concourse": {
+		compareOptions: []dyff.CompareOption{
+			dyff.ListIdentifierForPath("/jobs", "name"),
+			dyff.ListIdentifierForPath("/resources", "name"),
+			dyff.ListIdentifierForPath("/resource_types", "name"),
+			dyff.ListIdentifierForPath("/groups", "name"),
+			dyff.ListIdentifierForPath("/var_sources", "name"),
+		},
+		groupBy: func(diff dyff.Diff) string {
+			// Differences on file level, e.g. document order changes, have no path
+			if diff.Path == nil {
+				return ""
+			}
+
+			if elements := diff.Path.PathElements; len(elements) > 1 && elements[0].Name == "jobs" && elements[1].Key == "name" {
+				return "job " + elements[1].Name
+			}
+
+			return ""
+		},
+	},
+
+	// Prometheus and Thanos rule files, as well as PrometheusRule resources:
+	// Groups and rules are identified by their name, alert, or record, PromQL
+	// expressions are compared ignoring their formatting, and changes are
+	// shown per rule
+	"prometheus": {
+		compareOptions: []dyff.CompareOption{
+			dyff.ListIdentifierForPathRegexp(regexp.MustCompile(`^(/spec)?/groups$`), "name"),
+			dyff.ListIdentifierForPathRegexp(regexp.MustCompile(`^(/spec)?/groups/name=[^/]+/rules$`), "alert", "record"),
+			dyff.ComparerForPathRegexp(regexp.MustCompile(`^(/spec)?/groups/name=[^/]+/rules/(alert|record)=[^/]+/expr$`), dyff.PromQLWhitespace),
+		},
+		groupBy: func(diff dyff.Diff) string {
+			elements := diff.Path.PathElements
+			if len(elements) > 0 && elements[0].Name == "spec" {
+				elements = elements[1:]
+			}
+
+			switch {
+			case len(elements) > 3 && elements[0].Name == "groups" && elements[2].Name == "rules" && elements[3].Key != "":
+				return fmt.Sprintf("group %s, %s %s", elements[1].Name, elements[3].Key, elements[3].Name)
+
+			case len(elements) > 1 && elements[0].Name == "groups" && elements[1].Key == "name":
+				return "group " + elements[1].Name
+			}
+
+			return ""
+		},
+	},
+
+	// Kubernetes resources: See kubernetesCompareOptions
+	"kubernetes": {
+		compareOptions: kubernetesCompareOptions,
+	},
+
+	// Rendered Helm charts: Like Kubernetes resources, but changes are shown
+	// per resource, which is the default of the helm command
+	"helm": {
+		compareOptions: kubernetesCompareOptions,
+		groupBy:        dyff.GroupByDocument,
+	},
+
+	// OpenAPI and Swagger specifications: References are resolved, so that
+	// changes in shared schemas show up where they are used, and changes are
+	// shown grouped by whether they are likely to break existing clients
+	"openapi": {
+		compareOptions: []dyff.CompareOption{
+			dyff.ListIdentifierForPathRegexp(regexp.MustCompile(`/parameters$`), "name", "$ref"),
+			dyff.ListIdentifierForPathRegexp(regexp.MustCompile(`/servers$`), "url"),
+			dyff.ListIdentifierForPathRegexp(regexp.MustCompile(`^/tags$`), "name"),
+		},
+		groupBy: func(diff dyff.Diff) string {
+			if dyff.IsBreakingOpenAPIChange(diff) {
+				return "breaking changes"
+			}
+
+			return "non-breaking changes"
+		},
+		resolveReferences: true,
+	},
+}
+
+func presetNames() string {
+	var names []string
+	for name := range presets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// selectedPresets returns the names of the presets to use, which includes
+// the kubernetes preset if the kubernetes flag is set
+func (config reportConfig) selectedPresets() []string {
+	if !config.kubernetes {
+		return config.presets
+	}
+
+	for _, name := range config.presets {
+		if strings.EqualFold(name, "kubernetes") {
+			return config.presets
+		}
+	}
+
+	return append(append([]string{}, config.presets...), "kubernetes")
+}
+
+func lookupPresets(names []string) ([]preset, error) {
+	var result []preset
+	for _, name := range names {
+		preset, ok := presets[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown preset %s, supported presets are: %s", name, presetNames())
+		}
+
+		result = append(result, preset)
+	}
+
+	return result, nil
+}
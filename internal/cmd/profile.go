@@ -0,0 +1,82 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+var cpuProfile *os.File
+
+// startProfiling starts the CPU profiling in case a CPU profile is configured
+func startProfiling() error {
+	if rootCmdSettings.cpuProfile == "" {
+		return nil
+	}
+
+	file, err := os.Create(rootCmdSettings.cpuProfile)
+	if err != nil {
+		return fmt.Errorf("failed to create CPU profile: %w", err)
+	}
+
+	if err := pprof.StartCPUProfile(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to start CPU profile: %w", err)
+	}
+
+	cpuProfile = file
+	return nil
+}
+
+// stopProfiling stops the CPU profiling if it was started, and writes the
+// memory profile in case one is configured
+func stopProfiling() error {
+	if cpuProfile != nil {
+		pprof.StopCPUProfile()
+		if err := cpuProfile.Close(); err != nil {
+			return fmt.Errorf("failed to write CPU profile: %w", err)
+		}
+
+		cpuProfile = nil
+	}
+
+	if rootCmdSettings.memProfile == "" {
+		return nil
+	}
+
+	file, err := os.Create(rootCmdSettings.memProfile)
+	if err != nil {
+		return fmt.Errorf("failed to create memory profile: %w", err)
+	}
+	defer file.Close()
+
+	// Make sure the profile contains up-to-date statistics
+	runtime.GC()
+
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		return fmt.Errorf("failed to write memory profile: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// profiles are named sets of flag values for the input files of an
+// ecosystem, which are used for all flags that are not set explicitly
+var profiles = map[string]map[string][]string{
+	// Rendered Helm charts: Resources are paired by their Kubernetes identity,
+	// and the chart version label and the checksum annotations are left out,
+	// since they change with every release or with the referenced content
+	"helm": {
+		"detect-kubernetes":         {"true"},
+		"detect-renames":            {"true"},
+		"ignore-whitespace-changes": {"true"},
+		"exclude-regexp": {
+			`/labels/helm\.sh/chart$`,
+			`/annotations/checksum/[^/]+$`,
+		},
+	},
+
+	// Kustomize builds: Resources are paired by their Kubernetes identity, and
+	// generated resources with a new name suffix are reported as renamed
+	"kustomize": {
+		"detect-kubernetes":         {"true"},
+		"detect-renames":            {"true"},
+		"ignore-whitespace-changes": {"true"},
+	},
+
+	// Plain YAML files: Documents are compared in their order, and the
+	// differences are grouped by their top-level key
+	"plain-yaml": {
+		"detect-kubernetes": {"false"},
+		"detect-renames":    {"false"},
+		"group-by-depth":    {"1"},
+	},
+
+	// OpenAPI and Swagger specifications: See the openapi preset
+	"openapi": {
+		"detect-kubernetes": {"false"},
+		"detect-renames":    {"false"},
+		"preset":            {"openapi"},
+	},
+}
+
+func profileNames() string {
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// applyProfile sets the flags of the profile selected in the given flags,
+// unless they were set explicitly
+func applyProfile(flags *pflag.FlagSet) error {
+	flag := flags.Lookup("profile")
+	if flag == nil || flag.Value.String() == "" {
+		return nil
+	}
+
+	profile, ok := profiles[strings.ToLower(flag.Value.String())]
+	if !ok {
+		return fmt.Errorf("unknown profile %s, supported profiles are: %s", flag.Value.String(), profileNames())
+	}
+
+	var names []string
+	for name := range profile {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		if flags.Changed(name) || flags.Lookup(name) == nil {
+			continue
+		}
+
+		for _, value := range profile[name] {
+			if err := flags.Set(name, value); err != nil {
+				return fmt.Errorf("failed to apply profile %s: %w", flag.Value.String(), err)
+			}
+		}
+	}
+
+	return nil
+}
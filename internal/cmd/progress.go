@@ -0,0 +1,91 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	xterm "golang.org/x/term"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+// progressPrinter shows the progress of a comparison, which is one line that
+// is continuously updated on a terminal, and a line every now and then if
+// the output is redirected into a file
+type progressPrinter struct {
+	out      io.Writer
+	terminal bool
+	interval time.Duration
+	last     time.Time
+	visible  bool
+}
+
+func newProgressPrinter(out *os.File) *progressPrinter {
+	terminal := xterm.IsTerminal(int(out.Fd()))
+
+	interval := time.Second
+	if terminal {
+		interval = 100 * time.Millisecond
+	}
+
+	return &progressPrinter{out: out, terminal: terminal, interval: interval}
+}
+
+func (p *progressPrinter) update(progress dyff.Progress) {
+	var final = progress.Total > 0 && progress.Documents == progress.Total
+	if !final && time.Since(p.last) < p.interval {
+		return
+	}
+
+	p.last = time.Now()
+
+	var message = fmt.Sprintf("compared %d documents", progress.Documents)
+	if progress.Total > 0 {
+		message = fmt.Sprintf("compared %d of %d documents", progress.Documents, progress.Total)
+	}
+
+	if progress.Path != nil {
+		message += fmt.Sprintf(", last one was %s", progress.Path.RootDescription())
+	}
+
+	if p.terminal {
+		_, _ = fmt.Fprintf(p.out, "\r\x1b[K%s", message)
+		p.visible = true
+		return
+	}
+
+	_, _ = fmt.Fprintln(p.out, message)
+}
+
+// clear removes the progress line from the terminal, so that other output
+// does not end up in the same line
+func (p *progressPrinter) clear() {
+	if p == nil || !p.visible {
+		return
+	}
+
+	_, _ = fmt.Fprint(p.out, "\r\x1b[K")
+	p.visible = false
+}
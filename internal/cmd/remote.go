@@ -0,0 +1,203 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+// remoteLocalOptions are the supported options of the serve command that
+// are applied when rendering the report locally, and therefore not sent
+var remoteLocalOptions = map[string]struct{}{
+	"output":                   {},
+	"omit-header":              {},
+	"table-details":            {},
+	"no-table-style":           {},
+	"no-cert-inspection":       {},
+	"use-go-patch-style":       {},
+	"line-numbers":             {},
+	"minor-change-threshold":   {},
+	"multi-line-context-lines": {},
+}
+
+// remoteUnsupportedOptions are the options that change how the input files
+// are loaded or compared, but which the serve command does not support
+var remoteUnsupportedOptions = []string{
+	"stream",
+	"stream-list",
+	"watch",
+	"resolve-references",
+	"sops",
+	"placeholders",
+	"vars-file",
+	"placeholder-command",
+	"spruce-operators",
+	"helm-values",
+	"helm-values-from",
+	"chroot",
+	"chroot-of-from",
+	"chroot-of-to",
+	"chroot-list-to-documents",
+	"from-empty",
+	"to-empty",
+	"ignore-trailing-newline-changes",
+	"detect-map-order-changes",
+	"max-compare-depth",
+	"detect-type-changes",
+	"detect-style-changes",
+	"exec-comparer",
+	"transform",
+	"map-path",
+	"coerce-types",
+	"concurrency",
+	"progress",
+	"debug-compare",
+}
+
+// betweenRemote sends the input files to a dyff serve daemon and renders the
+// returned report locally, as if the comparison was done by this process
+func betweenRemote(cmd *cobra.Command, args []string) error {
+	for _, name := range remoteUnsupportedOptions {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return fmt.Errorf("incompatible flags: cannot use %s flag in combination with remote flag", name)
+		}
+	}
+
+	from, to, err := loadInputFiles(args[0], args[1])
+	if err != nil {
+		return fmt.Errorf("failed to load input files: %w", err)
+	}
+
+	report, err := compareRemote(cmd, betweenCmdSettings.remote, from, to)
+	if err != nil {
+		return err
+	}
+
+	// The daemon only knows the content, so the differences have to refer to
+	// the documents of the locally loaded input files
+	report = report.WithInputFiles(from, to)
+
+	return writeReport(cmd, reportOptions.filterReport(invert(report)))
+}
+
+// compareRemote posts the input files with the changed comparison options to
+// the compare endpoint of the daemon and parses the JSON report it returns
+func compareRemote(cmd *cobra.Command, address string, from ytbx.InputFile, to ytbx.InputFile) (dyff.Report, error) {
+	var options = map[string]any{"output": "json"}
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if !flag.Changed {
+			return
+		}
+
+		if _, ok := serveOptions[flag.Name]; !ok {
+			return
+		}
+
+		if _, ok := remoteLocalOptions[flag.Name]; ok {
+			return
+		}
+
+		if value, ok := flag.Value.(pflag.SliceValue); ok {
+			options[flag.Name] = value.GetSlice()
+			return
+		}
+
+		options[flag.Name] = flag.Value.String()
+	})
+
+	var request = map[string]any{"options": options}
+	for name, inputFile := range map[string]ytbx.InputFile{"from": from, "to": to} {
+		data, err := marshalDocuments(inputFile.Documents)
+		if err != nil {
+			return dyff.Report{}, fmt.Errorf("failed to send %s: %w", inputFile.Location, err)
+		}
+
+		request[name] = data
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return dyff.Report{}, err
+	}
+
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+
+	ctx, cancel := compareContext()
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(address, "/")+"/compare", bytes.NewReader(body))
+	if err != nil {
+		return dyff.Report{}, fmt.Errorf("failed to create request for remote %s: %w", address, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return dyff.Report{}, compareError(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return dyff.Report{}, fmt.Errorf("failed to read response of remote %s: %w", address, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return dyff.Report{}, fmt.Errorf("failed to compare input files using remote %s: %s: %s", address, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	return dyff.ParseJSONReport(data)
+}
+
+// marshalDocuments returns the documents as one YAML stream, which keeps the
+// comments and order of keys of the documents
+func marshalDocuments(documents []*yamlv3.Node) (string, error) {
+	var buf bytes.Buffer
+	encoder := yamlv3.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	for _, document := range documents {
+		if err := encoder.Encode(document); err != nil {
+			return "", err
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
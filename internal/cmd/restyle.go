@@ -0,0 +1,280 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type restyleCmdOptions struct {
+	inplace   bool
+	check     bool
+	indent    int
+	keyOrder  string
+	quotes    string
+	alignKeys bool
+}
+
+var restyleDefaults = restyleCmdOptions{
+	inplace:   false,
+	check:     false,
+	indent:    2,
+	keyOrder:  "preserve",
+	quotes:    "preserve",
+	alignKeys: false,
+}
+
+var restyleCmdSettings = restyleDefaults
+
+// restyleCmd represents the restyle command
+var restyleCmd = &cobra.Command{
+	Use:     "restyle [flags] <file-location> ...",
+	Aliases: []string{"fmt"},
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Rewrites YAML files in a canonical style",
+	Long: `
+Rewrites YAML files in a canonical style with a consistent indentation, key
+order, and quoting of string values. By default, the result is written to
+standard output. Use --in-place to overwrite the input files, or --check to
+only list the files that are not in canonical style, which fails with exit
+code 1 if there is at least one such file.
+`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if restyleCmdSettings.inplace && restyleCmdSettings.check {
+			return fmt.Errorf("incompatible flags: %w", bunt.Errorf("cannot use in-place flag in combination with check flag"))
+		}
+
+		var unstyled []string
+		var errs []error
+		for _, filename := range args {
+			if ytbx.IsStdin(filename) && restyleCmdSettings.inplace {
+				return fmt.Errorf("incompatible flags: %w", bunt.Errorf("cannot use in-place flag in combination with input from _*stdin*_"))
+			}
+
+			input, output, err := restyle(filename)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			switch {
+			case restyleCmdSettings.check:
+				if !bytes.Equal(input, output) {
+					unstyled = append(unstyled, filename)
+				}
+
+			case restyleCmdSettings.inplace:
+				if bytes.Equal(input, output) {
+					continue
+				}
+
+				if err := os.WriteFile(filename, output, 0644); err != nil {
+					errs = append(errs, fmt.Errorf("failed to overwrite %s in place: %w", humanReadableFilename(filename), err))
+				}
+
+			default:
+				if _, err := os.Stdout.Write(output); err != nil {
+					errs = append(errs, fmt.Errorf("failed to write output to %s: %w", bunt.Sprint("_*stdout*_"), err))
+				}
+			}
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to process input files: %w", errors.Join(errs...))
+		}
+
+		if len(unstyled) > 0 {
+			for _, filename := range unstyled {
+				fmt.Println(filename)
+			}
+
+			return errorWithExitCode{value: 1}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restyleCmd)
+
+	restyleCmd.Flags().SortFlags = false
+
+	restyleCmd.Flags().BoolVarP(&restyleCmdSettings.inplace, "in-place", "i", restyleDefaults.inplace, "overwrite input file with output of this command")
+	restyleCmd.Flags().BoolVar(&restyleCmdSettings.check, "check", restyleDefaults.check, "only list files that are not in canonical style and fail if there are any")
+	restyleCmd.Flags().IntVar(&restyleCmdSettings.indent, "indent", restyleDefaults.indent, "number of spaces used for indentation")
+	restyleCmd.Flags().StringVar(&restyleCmdSettings.keyOrder, "key-order", restyleDefaults.keyOrder, "order of map keys, supported values: preserve, restructure, or sorted")
+	restyleCmd.Flags().StringVar(&restyleCmdSettings.quotes, "quotes", restyleDefaults.quotes, "quoting of string values, supported values: preserve, minimal, double, or single")
+	restyleCmd.Flags().BoolVar(&restyleCmdSettings.alignKeys, "align-keys", restyleDefaults.alignKeys, "pad keys so that values of a mapping start in the same column")
+}
+
+// restyle loads the input from the given location and returns both the
+// original input and the input rewritten in canonical style
+func restyle(filename string) ([]byte, []byte, error) {
+	var input []byte
+	var err error
+	switch {
+	case ytbx.IsStdin(filename):
+		input, err = io.ReadAll(os.Stdin)
+
+	default:
+		input, err = os.ReadFile(filename)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load input from %s: %w", humanReadableFilename(filename), err)
+	}
+
+	documents, err := ytbx.LoadYAMLDocuments(input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse input from %s: %w", humanReadableFilename(filename), err)
+	}
+
+	var buf bytes.Buffer
+	for i, document := range documents {
+		if err := restyleNode(document, restyleCmdSettings.keyOrder, restyleCmdSettings.quotes); err != nil {
+			return nil, nil, err
+		}
+
+		if i > 0 || len(documents) > 1 || bytes.HasPrefix(input, []byte("---")) {
+			buf.WriteString("---\n")
+		}
+
+		if len(document.Content) == 0 {
+			continue
+		}
+
+		encoder := yamlv3.NewEncoder(&buf)
+		encoder.SetIndent(restyleCmdSettings.indent)
+		if err := encoder.Encode(document); err != nil {
+			return nil, nil, fmt.Errorf("failed to restyle %s: %w", humanReadableFilename(filename), err)
+		}
+
+		if err := encoder.Close(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if restyleCmdSettings.alignKeys {
+		return input, []byte(alignKeys(buf.String())), nil
+	}
+
+	return input, buf.Bytes(), nil
+}
+
+// restyleNode applies the key order and quoting policy to the node and all
+// of its child nodes
+func restyleNode(node *yamlv3.Node, keyOrder string, quotes string) error {
+	switch strings.ToLower(keyOrder) {
+	case "preserve", "":
+
+	case "restructure":
+		ytbx.RestructureObject(node)
+
+	case "sorted":
+		sortMappingKeys(node)
+
+	default:
+		return fmt.Errorf("unknown key order %s, supported values are: preserve, restructure, or sorted", keyOrder)
+	}
+
+	var style yamlv3.Style
+	switch strings.ToLower(quotes) {
+	case "preserve", "":
+		return nil
+
+	case "minimal":
+		style = 0
+
+	case "double":
+		style = yamlv3.DoubleQuotedStyle
+
+	case "single":
+		style = yamlv3.SingleQuotedStyle
+
+	default:
+		return fmt.Errorf("unknown quoting %s, supported values are: preserve, minimal, double, or single", quotes)
+	}
+
+	applyQuoteStyle(node, style)
+	return nil
+}
+
+func sortMappingKeys(node *yamlv3.Node) {
+	if node.Kind == yamlv3.MappingNode {
+		type pair struct{ key, value *yamlv3.Node }
+
+		pairs := make([]pair, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			pairs = append(pairs, pair{node.Content[i], node.Content[i+1]})
+		}
+
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return pairs[i].key.Value < pairs[j].key.Value
+		})
+
+		for i, p := range pairs {
+			node.Content[2*i], node.Content[2*i+1] = p.key, p.value
+		}
+	}
+
+	for _, child := range node.Content {
+		sortMappingKeys(child)
+	}
+}
+
+// applyQuoteStyle sets the style of all string values that are not written
+// as a block scalar, map keys are not changed
+func applyQuoteStyle(node *yamlv3.Node, style yamlv3.Style) {
+	var apply = func(value *yamlv3.Node) {
+		if value.Kind == yamlv3.ScalarNode && value.Tag == "!!str" && value.Style&(yamlv3.LiteralStyle|yamlv3.FoldedStyle) == 0 {
+			value.Style = style
+		}
+	}
+
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			apply(node.Content[i])
+		}
+
+	case yamlv3.SequenceNode:
+		for _, entry := range node.Content {
+			apply(entry)
+		}
+	}
+
+	for _, child := range node.Content {
+		applyQuoteStyle(child, style)
+	}
+}
@@ -29,6 +29,9 @@ import (
 	"github.com/gonvenience/term"
 	"github.com/gonvenience/ytbx"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/dyff/pkg/dyff"
 )
 
 var name = func() string {
@@ -40,6 +43,22 @@ var name = func() string {
 	return filepath.Base(ep)
 }()
 
+type rootCmdOptions struct {
+	theme        string
+	themeLookup  func() *dyff.Theme
+	colorDepth   string
+	depth        int
+	cpuProfile   string
+	memProfile   string
+	inputLoaders []string
+	decryption   dyff.DecryptionKeys
+}
+
+var rootCmdSettings rootCmdOptions
+
+// kubectlDiff is set if dyff is used as the external diff tool of kubectl
+var kubectlDiff bool
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:           name,
@@ -50,6 +69,31 @@ var rootCmd = &cobra.Command{
 can transform YAML to JSON, and vice versa. The order of keys in hashes
 is preserved during the conversion.
 `,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+		if err := startProfiling(); err != nil {
+			return err
+		}
+
+		if err := applyProfile(cmd.Flags()); err != nil {
+			return err
+		}
+
+		if err := applyInputLoaders(rootCmdSettings.inputLoaders); err != nil {
+			return err
+		}
+
+		applyDecryption(rootCmdSettings.decryption)
+
+		if rootCmdSettings.depth, err = applyColorDepth(rootCmdSettings.colorDepth, cmd.Flags().Changed("truecolor")); err != nil {
+			return err
+		}
+
+		if rootCmdSettings.themeLookup, err = lookupTheme(rootCmdSettings.theme); err != nil {
+			return err
+		}
+
+		return nil
+	},
 }
 
 // NewRootCmd returns the root command (for generating documentation)
@@ -61,9 +105,37 @@ func NewRootCmd() *cobra.Command {
 // the test suite to make sure that the flag parsing works correctly.
 func ResetSettings() {
 	reportOptions = defaults
-	betweenCmdSettings = betweenCmdOptions{}
+	rootCmdSettings = rootCmdOptions{}
+	betweenCmdSettings = betweenDefaults
 	yamlCmdSettings = yamlCmdOptions{}
 	jsonCmdSettings = jsonCmdOptions{}
+	tomlCmdSettings = tomlCmdOptions{}
+	restyleCmdSettings = restyleDefaults
+	mergeCmdSettings = mergeDefaults
+	sortCmdSettings = sortCmdOptions{}
+	validateCmdSettings = validateCmdOptions{}
+	getCmdSettings = getDefaults
+	flattenCmdSettings = flattenCmdOptions{}
+	benchCmdSettings = benchDefaults
+	serveCmdSettings = serveDefaults
+	prCmdSettings = prDefaults
+	pollCmdSettings = pollDefaults
+	historyCmdSettings = historyDefaults
+	helmCmdSettings = helmDefaults
+
+	resetChangedFlags(rootCmd)
+}
+
+// resetChangedFlags marks the flags of the command and its sub-commands as
+// not set, so that settings are not considered as changed in the next run
+func resetChangedFlags(cmd *cobra.Command) {
+	for _, flags := range []*pflag.FlagSet{cmd.Flags(), cmd.PersistentFlags()} {
+		flags.VisitAll(func(flag *pflag.Flag) { flag.Changed = false })
+	}
+
+	for _, subCmd := range cmd.Commands() {
+		resetChangedFlags(subCmd)
+	}
 }
 
 // rearrange will rearrange the OS args to match `dyff between --flags from to`
@@ -89,7 +161,8 @@ func Execute() error {
 	// In case `KUBECTL_EXTERNAL_DIFF` is set with `dyff`, it is very likely
 	// that `kubectl` intends to use `dyff` for its `diff` command. Therefore,
 	// enable Kubernetes specific entity detection and fix the order issue.
-	if strings.Contains(os.Getenv("KUBECTL_EXTERNAL_DIFF"), name) {
+	kubectlDiff = strings.Contains(os.Getenv("KUBECTL_EXTERNAL_DIFF"), name)
+	if kubectlDiff {
 		// Make sure the OS args are in a supported order
 		os.Args = rearrange()
 
@@ -103,7 +176,12 @@ func Execute() error {
 		reportOptions.excludeRegexps = append(reportOptions.excludeRegexps, "^/metadata/managedFields")
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if profilingErr := stopProfiling(); err == nil {
+		err = profilingErr
+	}
+
+	if err != nil {
 		// Special case ExitCode, which means that we will exit immediately
 		// with the given exit code
 		if _, ok := err.(errorWithExitCode); ok {
@@ -126,6 +204,17 @@ func init() {
 
 	rootCmd.PersistentFlags().VarP(&bunt.ColorSetting, "color", "c", "specify color usage: on, off, or auto")
 	rootCmd.PersistentFlags().VarP(&bunt.TrueColorSetting, "truecolor", "t", "specify true color usage: on, off, or auto")
-	rootCmd.PersistentFlags().IntVarP(&term.FixedTerminalWidth, "fixed-width", "w", -1, "disable terminal width detection and use provided fixed value")
+	rootCmd.PersistentFlags().StringVar(&rootCmdSettings.colorDepth, "color-depth", "auto", "specify the number of colors supported by the terminal: auto, 24bit, 256, 16, or 8")
+	rootCmd.PersistentFlags().StringVar(&rootCmdSettings.theme, "theme", "auto", "specify color theme: auto (based on terminal background), dark, or light")
+	rootCmd.PersistentFlags().IntVarP(&term.FixedTerminalWidth, "fixed-width", "w", -1, "disable terminal width detection and use provided fixed value, which is also used to lay out and wrap the human readable report")
 	rootCmd.PersistentFlags().BoolVarP(&ytbx.PreserveKeyOrderInJSON, "preserve-key-order-in-json", "k", false, "use ordered keys during JSON decoding (non standard behavior)")
+	rootCmd.PersistentFlags().StringArrayVar(&rootCmdSettings.inputLoaders, "input-loader", nil, "load inputs with locations starting with <scheme>:// using an external command that writes the documents to standard output, format is <scheme>=<command>")
+	rootCmd.PersistentFlags().StringArrayVar(&rootCmdSettings.decryption.AgeIdentities, "age-identity", nil, "identity file to decrypt age encrypted input files with, defaults to the files listed in DYFF_AGE_IDENTITY")
+	rootCmd.PersistentFlags().StringVar(&rootCmdSettings.decryption.PGPPassphraseFile, "pgp-passphrase-file", "", "file with the passphrase to decrypt PGP encrypted input files with, defaults to DYFF_PGP_PASSPHRASE_FILE or otherwise the gpg-agent")
+
+	// Profiling flags, which are only meant for the development of dyff itself
+	rootCmd.PersistentFlags().StringVar(&rootCmdSettings.cpuProfile, "cpuprofile", "", "write a CPU profile to the given file")
+	rootCmd.PersistentFlags().StringVar(&rootCmdSettings.memProfile, "memprofile", "", "write a memory profile to the given file")
+	_ = rootCmd.PersistentFlags().MarkHidden("cpuprofile")
+	_ = rootCmd.PersistentFlags().MarkHidden("memprofile")
 }
@@ -0,0 +1,384 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type serveCmdOptions struct {
+	listen      string
+	maxBodySize int64
+}
+
+var serveCmdSettings serveCmdOptions
+
+var serveDefaults = serveCmdOptions{
+	listen:      "localhost:8080",
+	maxBodySize: 32 << 20,
+}
+
+// serveOptions are the report options that can be used in requests, options
+// that access the local system or are meant for the terminal are left out
+var serveOptions = map[string]struct{}{
+	"ignore-order-changes":      {},
+	"ignore-order-at":           {},
+	"ignore-whitespace-changes": {},
+	"float-tolerance":           {},
+	"detect-kubernetes":         {},
+	"additional-identifier":     {},
+	"list-key":                  {},
+	"filter":                    {},
+	"exclude":                   {},
+	"filter-regexp":             {},
+	"exclude-regexp":            {},
+	"ignore-value-changes":      {},
+	"detect-renames":            {},
+	"rename-threshold":          {},
+	"preset":                    {},
+	"kubernetes":                {},
+	"profile":                   {},
+	"list-algorithm":            {},
+	"timeout":                   {},
+	"output":                    {},
+	"omit-header":               {},
+	"table-details":             {},
+	"no-table-style":            {},
+	"no-cert-inspection":        {},
+	"use-go-patch-style":        {},
+	"line-numbers":              {},
+	"minor-change-threshold":    {},
+	"multi-line-context-lines":  {},
+}
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve [flags]",
+	Short: "Offer the comparison of input files as an HTTP API",
+	Long: `
+Starts an HTTP server that compares two input files and returns the report.
+
+Send the input files with a POST request to the /compare endpoint, either as
+a multipart form with the fields 'from' and 'to', or as a JSON object with
+the fields 'from', 'to', and 'options'. Options use the names of the flags
+of the between command, e.g. 'output' or 'exclude', and can also be passed
+as query parameters or additional form fields.
+
+Metrics of the comparisons, e.g. their number, durations, input sizes, and
+differences, are available in the Prometheus format at the /metrics endpoint.
+
+  curl --form from=@old.yml --form to=@new.yml 'http://localhost:8080/compare?output=json'
+
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// The report is not written to a terminal, so do not use colors unless
+		// they are explicitly requested
+		if !cmd.Flags().Changed("color") {
+			bunt.SetColorSettings(bunt.OFF, bunt.OFF)
+		}
+
+		server := &http.Server{
+			Addr:              serveCmdSettings.listen,
+			Handler:           NewServeHandler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		fmt.Fprintf(cmd.ErrOrStderr(), "listening on %s\n", serveCmdSettings.listen)
+		return server.ListenAndServe()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().SortFlags = false
+
+	serveCmd.Flags().StringVar(&serveCmdSettings.listen, "listen", serveDefaults.listen, "address to listen on")
+	serveCmd.Flags().Int64Var(&serveCmdSettings.maxBodySize, "max-body-size", serveDefaults.maxBodySize, "maximum size of a request body in bytes")
+}
+
+// NewServeHandler returns the HTTP handler of the serve command
+func NewServeHandler() http.Handler {
+	mux := http.NewServeMux()
+	metrics := newServeMetrics()
+
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "ok\n")
+	})
+
+	mux.Handle("GET /metrics", metrics)
+	mux.HandleFunc("POST /compare", func(w http.ResponseWriter, r *http.Request) {
+		serveCompare(w, r, metrics)
+	})
+
+	return mux
+}
+
+// httpError is an error with the HTTP status code that is used to reply
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e httpError) Error() string { return e.err.Error() }
+
+func badRequest(format string, a ...any) error {
+	return httpError{http.StatusBadRequest, fmt.Errorf(format, a...)}
+}
+
+func serveCompare(w http.ResponseWriter, r *http.Request, metrics *serveMetrics) {
+	var start = time.Now()
+	contentType, data, err := compareRequest(r, metrics)
+	if err != nil {
+		var status = http.StatusInternalServerError
+		if httpErr, ok := err.(httpError); ok {
+			status = httpErr.status
+		}
+
+		metrics.observeComparison(status, time.Since(start))
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	metrics.observeComparison(http.StatusOK, time.Since(start))
+
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(data)
+}
+
+// compareRequest compares the input files of the request and returns the
+// rendered report and its content type
+func compareRequest(r *http.Request, metrics *serveMetrics) (string, []byte, error) {
+	r.Body = http.MaxBytesReader(nil, r.Body, serveCmdSettings.maxBodySize)
+
+	from, to, options, err := parseCompareRequest(r, metrics)
+	if err != nil {
+		return "", nil, err
+	}
+
+	config, err := serveConfig(options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	compareOptions, err := config.compareOptions()
+	if err != nil {
+		return "", nil, badRequest("%w", err)
+	}
+
+	var ctx, cancel = context.WithCancel(r.Context())
+	if config.timeout > 0 {
+		ctx, cancel = context.WithTimeout(r.Context(), config.timeout)
+	}
+	defer cancel()
+
+	report, err := dyff.CompareInputFilesContext(ctx, from, to, compareOptions...)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("comparison did not finish within %s", config.timeout)
+		}
+
+		return "", nil, httpError{http.StatusUnprocessableEntity, fmt.Errorf("failed to compare input files: %w", err)}
+	}
+
+	report = config.filterReport(report)
+	metrics.observeDifferences(report)
+
+	reportWriter, err := config.reportWriter(report)
+	if err != nil {
+		return "", nil, badRequest("%w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := reportWriter.WriteReport(&buf); err != nil {
+		return "", nil, fmt.Errorf("failed to render report: %w", err)
+	}
+
+	switch strings.ToLower(config.style) {
+	case "json":
+		return "application/json", buf.Bytes(), nil
+
+	case "jsonpatch", "json-patch":
+		return "application/json-patch+json", buf.Bytes(), nil
+
+	case "html":
+		return "text/html; charset=utf-8", buf.Bytes(), nil
+
+	case "markdown", "md":
+		return "text/markdown; charset=utf-8", buf.Bytes(), nil
+	}
+
+	return "text/plain; charset=utf-8", buf.Bytes(), nil
+}
+
+// parseCompareRequest reads the input files and the options from either a
+// multipart form or a JSON object, options can also be query parameters
+func parseCompareRequest(r *http.Request, metrics *serveMetrics) (ytbx.InputFile, ytbx.InputFile, map[string][]string, error) {
+	var inputs = map[string]ytbx.InputFile{}
+	var options = map[string][]string(r.URL.Query())
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(serveCmdSettings.maxBodySize); err != nil {
+			return ytbx.InputFile{}, ytbx.InputFile{}, nil, badRequest("failed to parse multipart form: %w", err)
+		}
+
+		for name, values := range r.MultipartForm.Value {
+			if name == "from" || name == "to" {
+				if err := loadRequestInput(metrics, inputs, name, name, []byte(values[0])); err != nil {
+					return ytbx.InputFile{}, ytbx.InputFile{}, nil, err
+				}
+
+				continue
+			}
+
+			options[name] = append(options[name], values...)
+		}
+
+		for _, name := range []string{"from", "to"} {
+			if headers := r.MultipartForm.File[name]; len(headers) > 0 {
+				file, err := headers[0].Open()
+				if err != nil {
+					return ytbx.InputFile{}, ytbx.InputFile{}, nil, badRequest("failed to read %s: %w", name, err)
+				}
+
+				data, err := io.ReadAll(file)
+				file.Close()
+				if err != nil {
+					return ytbx.InputFile{}, ytbx.InputFile{}, nil, badRequest("failed to read %s: %w", name, err)
+				}
+
+				if err := loadRequestInput(metrics, inputs, name, headers[0].Filename, data); err != nil {
+					return ytbx.InputFile{}, ytbx.InputFile{}, nil, err
+				}
+			}
+		}
+
+	case "application/json":
+		var body struct {
+			From    *string        `json:"from"`
+			To      *string        `json:"to"`
+			Options map[string]any `json:"options"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return ytbx.InputFile{}, ytbx.InputFile{}, nil, badRequest("failed to parse JSON body: %w", err)
+		}
+
+		for name, data := range map[string]*string{"from": body.From, "to": body.To} {
+			if data == nil {
+				continue
+			}
+
+			if err := loadRequestInput(metrics, inputs, name, name, []byte(*data)); err != nil {
+				return ytbx.InputFile{}, ytbx.InputFile{}, nil, err
+			}
+		}
+
+		for name, value := range body.Options {
+			switch value := value.(type) {
+			case []any:
+				for _, entry := range value {
+					options[name] = append(options[name], fmt.Sprint(entry))
+				}
+
+			default:
+				options[name] = append(options[name], fmt.Sprint(value))
+			}
+		}
+
+	default:
+		return ytbx.InputFile{}, ytbx.InputFile{}, nil, httpError{http.StatusUnsupportedMediaType, fmt.Errorf("unsupported content type %q, use multipart/form-data or application/json", mediaType)}
+	}
+
+	for _, name := range []string{"from", "to"} {
+		if _, ok := inputs[name]; !ok {
+			return ytbx.InputFile{}, ytbx.InputFile{}, nil, badRequest("missing input %s", name)
+		}
+	}
+
+	return inputs["from"], inputs["to"], options, nil
+}
+
+func loadRequestInput(metrics *serveMetrics, inputs map[string]ytbx.InputFile, name string, location string, data []byte) error {
+	metrics.observeInput(name, len(data))
+
+	documents, err := ytbx.LoadDocuments(data)
+	if err != nil {
+		return badRequest("failed to load %s: %w", name, err)
+	}
+
+	inputs[name] = ytbx.InputFile{Location: location, Documents: documents}
+	return nil
+}
+
+// serveConfig creates the report options for a request using the same flag
+// definitions as the command line, but only for the supported options
+func serveConfig(options map[string][]string) (reportConfig, error) {
+	var config reportConfig
+	flags := pflag.NewFlagSet("options", pflag.ContinueOnError)
+	bindReportOptionsFlags(flags, &config)
+	config.hyperlinks = "off"
+
+	for name, values := range options {
+		if _, ok := serveOptions[name]; !ok {
+			return reportConfig{}, badRequest("unsupported option %s", name)
+		}
+
+		for _, value := range values {
+			if err := flags.Set(name, value); err != nil {
+				return reportConfig{}, badRequest("invalid value %q for option %s: %w", value, name, err)
+			}
+		}
+	}
+
+	if err := applyProfile(flags); err != nil {
+		return reportConfig{}, badRequest("%w", err)
+	}
+
+	// Make sure invalid regular expressions are reported instead of panicking
+	for _, pattern := range append(append([]string{}, config.filterRegexps...), config.excludeRegexps...) {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return reportConfig{}, badRequest("invalid regular expression %s: %w", pattern, err)
+		}
+	}
+
+	return config, nil
+}
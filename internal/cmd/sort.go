@@ -0,0 +1,202 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type sortCmdOptions struct {
+	byKey            bool
+	lists            []string
+	plainMode        bool
+	omitIndentHelper bool
+	inplace          bool
+}
+
+var sortCmdSettings sortCmdOptions
+
+// sortCmd represents the sort command
+var sortCmd = &cobra.Command{
+	Use:   "sort [flags] <file-location> ...",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Sorts map keys and lists to normalize documents",
+	Long: `
+Sorts map keys alphabetically and sorts selected lists to create normalized
+documents, which keeps subsequent comparisons minimal.
+
+Lists are selected using their path (dot-style or Go-patch style), optionally
+followed by the field that is used to sort the list entries, for example
+'spec.template.spec.containers=name'. Without a field, entries are sorted by
+their value.
+`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lists, err := parseListSortSpecs(sortCmdSettings.lists)
+		if err != nil {
+			return err
+		}
+
+		writer := &OutputWriter{
+			OutputStyle:      "yaml",
+			PlainMode:        sortCmdSettings.plainMode,
+			OmitIndentHelper: sortCmdSettings.omitIndentHelper,
+		}
+
+		var errs []error
+		for _, filename := range args {
+			if ytbx.IsStdin(filename) && sortCmdSettings.inplace {
+				return fmt.Errorf("incompatible flags: %w", bunt.Errorf("cannot use in-place flag in combination with input from _*stdin*_"))
+			}
+
+			inputFile, err := dyff.LoadFile(filename)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to load input from %s: %w", humanReadableFilename(filename), err))
+				continue
+			}
+
+			for _, document := range inputFile.Documents {
+				if err := sortDocument(document, sortCmdSettings.byKey, lists); err != nil {
+					errs = append(errs, fmt.Errorf("failed to sort %s: %w", humanReadableFilename(filename), err))
+				}
+			}
+
+			if sortCmdSettings.inplace {
+				err = writer.WriteDocumentsInplace(filename, inputFile.Documents)
+			} else {
+				err = writer.WriteDocumentsToStdout(inputFile.Documents)
+			}
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to process input files: %w", errors.Join(errs...))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sortCmd)
+
+	sortCmd.Flags().SortFlags = false
+
+	sortCmd.Flags().BoolVar(&sortCmdSettings.byKey, "by-key", false, "sort map keys alphabetically")
+	sortCmd.Flags().StringArrayVar(&sortCmdSettings.lists, "lists", nil, "sort the list at the given path, optionally by a field, e.g. spec.containers=name")
+	sortCmd.Flags().BoolVarP(&sortCmdSettings.plainMode, "plain", "p", false, "output in plain style without any highlighting")
+	sortCmd.Flags().BoolVarP(&sortCmdSettings.omitIndentHelper, "omit-indent-helper", "O", false, "omit indent helper lines in highlighted output")
+	sortCmd.Flags().BoolVarP(&sortCmdSettings.inplace, "in-place", "i", false, "overwrite input file with output of this command")
+}
+
+type listSortSpec struct {
+	path  string
+	field string
+}
+
+func parseListSortSpecs(specs []string) ([]listSortSpec, error) {
+	var result []listSortSpec
+	for _, spec := range specs {
+		path, field, _ := strings.Cut(spec, "=")
+		if path == "" {
+			return nil, fmt.Errorf("invalid list sort specification %q, expected <path>[=<field>]", spec)
+		}
+
+		if _, err := ytbx.ParsePathStringUnsafe(path); err != nil {
+			return nil, fmt.Errorf("invalid path in list sort specification %q: %w", spec, err)
+		}
+
+		result = append(result, listSortSpec{path: path, field: field})
+	}
+
+	return result, nil
+}
+
+// sortDocument sorts the map keys of the document if configured and the lists
+// matching the specifications, lists that do not exist in the document are
+// skipped
+func sortDocument(document *yamlv3.Node, byKey bool, lists []listSortSpec) error {
+	if byKey {
+		sortMappingKeys(document)
+	}
+
+	for _, spec := range lists {
+		node, err := ytbx.Grab(document, spec.path)
+		if err != nil {
+			continue
+		}
+
+		if node.Kind != yamlv3.SequenceNode {
+			return fmt.Errorf("path %s does not point to a list", spec.path)
+		}
+
+		sortKey := func(entry *yamlv3.Node) (string, bool) {
+			if spec.field == "" {
+				return entry.Value, entry.Kind == yamlv3.ScalarNode
+			}
+
+			value, err := ytbx.Grab(&yamlv3.Node{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{entry}}, spec.field)
+			if err != nil || value.Kind != yamlv3.ScalarNode {
+				return "", false
+			}
+
+			return value.Value, true
+		}
+
+		sort.SliceStable(node.Content, func(i, j int) bool {
+			a, okA := sortKey(node.Content[i])
+			b, okB := sortKey(node.Content[j])
+
+			switch {
+			case okA != okB:
+				// entries without a sort key are moved to the end
+				return okA
+
+			case !okA:
+				return false
+			}
+
+			if numA, err := strconv.ParseFloat(a, 64); err == nil {
+				if numB, err := strconv.ParseFloat(b, 64); err == nil {
+					return numA < numB
+				}
+			}
+
+			return a < b
+		})
+	}
+
+	return nil
+}
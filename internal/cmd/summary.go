@@ -0,0 +1,133 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+// runSummary is the machine readable summary of a run, which is written
+// independent of the output style
+type runSummary struct {
+	From        summaryInput   `json:"from"`
+	To          summaryInput   `json:"to"`
+	Options     string         `json:"options"`
+	Differences int            `json:"differences"`
+	Kinds       map[string]int `json:"kinds"`
+	ExitStatus  int            `json:"exit_status"`
+}
+
+// summaryInput identifies an input file by its location and a hash of the
+// content of its documents
+type summaryInput struct {
+	dyff.SerializedInput
+	SHA256 string `json:"sha256"`
+}
+
+// writeSummaryFile writes the summary of the report to the given file
+func writeSummaryFile(cmd *cobra.Command, filename string, report dyff.Report, exitStatus int) error {
+	from, err := summarizeInput(report.From)
+	if err != nil {
+		return err
+	}
+
+	to, err := summarizeInput(report.To)
+	if err != nil {
+		return err
+	}
+
+	var summary = runSummary{
+		From:        from,
+		To:          to,
+		Options:     optionsFingerprint(cmd.Flags()),
+		Differences: len(report.Diffs),
+		Kinds:       map[string]int{},
+		ExitStatus:  exitStatus,
+	}
+
+	for kind, count := range report.Summary().Kinds {
+		summary.Kinds[dyff.KindName(kind)] = count
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filename, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write summary file: %w", err)
+	}
+
+	return nil
+}
+
+func summarizeInput(inputFile ytbx.InputFile) (summaryInput, error) {
+	hash := sha256.New()
+	for _, document := range inputFile.Documents {
+		data, err := yamlv3.Marshal(document)
+		if err != nil {
+			return summaryInput{}, fmt.Errorf("failed to hash %s: %w", inputFile.Location, err)
+		}
+
+		_, _ = hash.Write(data)
+	}
+
+	return summaryInput{
+		SerializedInput: dyff.SerializedInput{
+			Location:  inputFile.Location,
+			Note:      inputFile.Note,
+			Documents: len(inputFile.Documents),
+		},
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// optionsFingerprint returns a hash of the flags that were set, so that runs
+// with the same options can be told apart from runs with other options
+func optionsFingerprint(flags *pflag.FlagSet) string {
+	var options []string
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed && flag.Name != "summary-file" {
+			options = append(options, fmt.Sprintf("%s=%s", flag.Name, flag.Value.String()))
+		}
+	})
+
+	sort.Strings(options)
+
+	hash := sha256.New()
+	for _, option := range options {
+		_, _ = fmt.Fprintln(hash, option)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
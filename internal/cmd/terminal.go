@@ -0,0 +1,239 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/term"
+	"github.com/lucasb-eyer/go-colorful"
+	xterm "golang.org/x/term"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+// oscBackgroundColorResponse matches the reply of a terminal to the OSC 11
+// query, e.g. `ESC ] 11 ; rgb:1e1e/1e1e/1e1e BEL`
+var oscBackgroundColorResponse = regexp.MustCompile(`\]11;rgb:([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})`)
+
+// lookupTheme returns a function that returns the theme to be used for the
+// output based on the provided theme name, with `auto` meaning that the
+// terminal background is checked once the theme is needed for the first time
+func lookupTheme(name string) (func() *dyff.Theme, error) {
+	switch strings.ToLower(name) {
+	case "", "auto":
+		return sync.OnceValue(detectTheme), nil
+
+	case "dark":
+		return func() *dyff.Theme { return &dyff.DarkTheme }, nil
+
+	case "light":
+		return func() *dyff.Theme { return &dyff.LightTheme }, nil
+	}
+
+	return nil, fmt.Errorf("unknown theme %s, supported themes are: auto, dark, or light", name)
+}
+
+// detectTheme returns the theme that matches the terminal background, which
+// is only checked if the output is colored, since the theme does not matter
+// otherwise
+func detectTheme() *dyff.Theme {
+	if bunt.UseColors() && isLightTerminalBackground() {
+		return &dyff.LightTheme
+	}
+
+	return &dyff.DarkTheme
+}
+
+// selectedTheme returns the theme configured using the theme flag, which is
+// passed to the report writers, or the dark theme in case the flag was not
+// evaluated yet
+func selectedTheme() *dyff.Theme {
+	if rootCmdSettings.themeLookup != nil {
+		return rootCmdSettings.themeLookup()
+	}
+
+	return &dyff.DarkTheme
+}
+
+// isLightTerminalBackground returns whether the terminal most likely uses a
+// light background color. The `COLORFGBG` environment variable is checked
+// first, and if it is not set, the terminal itself is asked using OSC 11.
+// In case nothing can be determined, a dark background is assumed.
+func isLightTerminalBackground() bool {
+	if light, ok := lightBackgroundFromColorFgBg(os.Getenv("COLORFGBG")); ok {
+		return light
+	}
+
+	// Only ask the terminal if the colored output is actually written to it
+	if term.IsTerminal() && !term.IsDumbTerminal() {
+		if bg, err := queryTerminalBackgroundColor(100 * time.Millisecond); err == nil {
+			_, _, l := bg.Hsl()
+			return l > 0.5
+		}
+	}
+
+	return false
+}
+
+// lightBackgroundFromColorFgBg interprets the `COLORFGBG` environment variable
+// (format `fg;bg` or `fg;default;bg`) that is set by some terminals, where the
+// background is one of the 16 standard ANSI colors
+func lightBackgroundFromColorFgBg(value string) (light bool, ok bool) {
+	if value == "" {
+		return false, false
+	}
+
+	fields := strings.Split(value, ";")
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return false, false
+	}
+
+	// Only white (7) and the bright colors except bright black (8) are
+	// considered to be light background colors
+	return bg == 7 || bg > 8, true
+}
+
+// queryTerminalBackgroundColor sends the OSC 11 query to the controlling
+// terminal and parses the reported background color. Terminals that do not
+// support the query do not reply, which is covered by the timeout.
+func queryTerminalBackgroundColor(timeout time.Duration) (colorful.Color, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return colorful.Color{}, err
+	}
+	defer tty.Close()
+
+	// Do not use the Fd function, since it would switch the file into
+	// blocking mode and therefore render the read deadline useless
+	conn, err := tty.SyscallConn()
+	if err != nil {
+		return colorful.Color{}, err
+	}
+
+	var state *xterm.State
+	var rawErr error
+	if err := conn.Control(func(fd uintptr) { state, rawErr = xterm.MakeRaw(int(fd)) }); err != nil {
+		return colorful.Color{}, err
+	}
+
+	if rawErr != nil {
+		return colorful.Color{}, rawErr
+	}
+
+	defer func() { _ = conn.Control(func(fd uintptr) { _ = xterm.Restore(int(fd), state) }) }()
+
+	if _, err := tty.WriteString("\x1b]11;?\x1b\\"); err != nil {
+		return colorful.Color{}, err
+	}
+
+	if err := tty.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return colorful.Color{}, err
+	}
+
+	var response []byte
+	var buf = make([]byte, 64)
+	for !bytes.HasSuffix(response, []byte("\a")) && !bytes.HasSuffix(response, []byte("\x1b\\")) {
+		n, err := tty.Read(buf)
+		if err != nil {
+			return colorful.Color{}, fmt.Errorf("terminal did not report its background color: %w", err)
+		}
+
+		response = append(response, buf[:n]...)
+	}
+
+	return parseBackgroundColorResponse(string(response))
+}
+
+func parseBackgroundColorResponse(response string) (colorful.Color, error) {
+	matches := oscBackgroundColorResponse.FindStringSubmatch(response)
+	if matches == nil {
+		return colorful.Color{}, fmt.Errorf("unsupported background color response %q", response)
+	}
+
+	var rgb [3]float64
+	for i, hex := range matches[1:] {
+		value, err := strconv.ParseUint(hex, 16, 16)
+		if err != nil {
+			return colorful.Color{}, err
+		}
+
+		// The number of hex digits defines the scale of the value
+		rgb[i] = float64(value) / float64(uint64(1)<<(4*len(hex))-1)
+	}
+
+	return colorful.Color{R: rgb[0], G: rgb[1], B: rgb[2]}, nil
+}
+
+// useHyperlinks returns whether hyperlinks should be rendered based on the
+// provided setting, with `auto` meaning that hyperlinks are only used for
+// terminals that are known to support OSC 8 hyperlinks
+func useHyperlinks(setting string) (bool, error) {
+	switch strings.ToLower(setting) {
+	case "", "auto":
+		return bunt.UseColors() && term.IsTerminal() && supportsHyperlinks(), nil
+
+	case "on", "true", "always":
+		return true, nil
+
+	case "off", "false", "never":
+		return false, nil
+
+	default:
+		return false, fmt.Errorf("unknown hyperlinks setting %s, supported values are: auto, on, or off", setting)
+	}
+}
+
+// supportsHyperlinks uses well-known environment variables to check whether
+// the terminal supports OSC 8 hyperlinks, since there is no way to query it
+func supportsHyperlinks() bool {
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "Hyper", "ghostty":
+		return true
+	}
+
+	for _, name := range []string{"WT_SESSION", "KONSOLE_VERSION", "DOMTERM"} {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+
+	if version, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && version >= 5000 {
+		return true
+	}
+
+	for _, name := range []string{"kitty", "foot", "wezterm", "ghostty"} {
+		if strings.Contains(os.Getenv("TERM"), name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,352 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/text"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type validateCmdOptions struct {
+	schema string
+}
+
+var validateCmdSettings validateCmdOptions
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate [flags] --schema <schema-location> <file-location> ...",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Validates documents against a JSON Schema",
+	Long: `
+Validates all documents of the provided input files against a JSON Schema. The
+schema itself can be written in JSON or YAML. Each validation error is reported
+with the path and the line in the input file where the error was found. The
+command fails with exit code 1 if at least one document is invalid.
+`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if validateCmdSettings.schema == "" {
+			return fmt.Errorf("no schema specified, use the --schema flag to provide one")
+		}
+
+		schema, err := compileSchema(validateCmdSettings.schema)
+		if err != nil {
+			return err
+		}
+
+		var numberOfErrors, numberOfFiles int
+		for _, location := range args {
+			inputFile, err := dyff.LoadFile(location)
+			if err != nil {
+				return fmt.Errorf("failed to load input from %s: %w", humanReadableFilename(location), err)
+			}
+
+			var errs []validationError
+			for idx, document := range inputFile.Documents {
+				if len(document.Content) == 0 {
+					continue
+				}
+
+				result, err := validateDocument(schema, idx, document.Content[0])
+				if err != nil {
+					return fmt.Errorf("failed to validate %s: %w", humanReadableFilename(location), err)
+				}
+
+				errs = append(errs, result...)
+			}
+
+			if len(errs) > 0 {
+				numberOfErrors += len(errs)
+				numberOfFiles++
+			}
+
+			writeValidationResult(location, len(inputFile.Documents), errs)
+		}
+
+		if numberOfErrors > 0 {
+			return errorWithExitCode{
+				value: 1,
+				cause: fmt.Errorf("validation failed: %w", fmt.Errorf("found %s in %s",
+					text.Plural(numberOfErrors, "validation error"),
+					text.Plural(numberOfFiles, "file"),
+				)),
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().SortFlags = false
+
+	validateCmd.Flags().StringVar(&validateCmdSettings.schema, "schema", "", "location of the JSON Schema (JSON or YAML) to validate against")
+}
+
+type validationError struct {
+	documentIdx int
+	path        string
+	line        int
+	column      int
+	message     string
+}
+
+// compileSchema loads the schema from the provided location using the same
+// loader that is used for input files, so that schemas in YAML are supported
+func compileSchema(location string) (*jsonschema.Schema, error) {
+	inputFile, err := dyff.LoadFile(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema from %s: %w", humanReadableFilename(location), err)
+	}
+
+	if len(inputFile.Documents) != 1 || len(inputFile.Documents[0].Content) == 0 {
+		return nil, fmt.Errorf("failed to load schema from %s: expected exactly one document", humanReadableFilename(location))
+	}
+
+	schemaURL := location
+	if _, err := os.Stat(location); err == nil {
+		abs, err := filepath.Abs(location)
+		if err != nil {
+			return nil, err
+		}
+
+		schemaURL = (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String()
+	}
+
+	value, err := jsonValue(inputFile.Documents[0].Content[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema from %s: %w", humanReadableFilename(location), err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, value); err != nil {
+		return nil, fmt.Errorf("failed to load schema from %s: %w", humanReadableFilename(location), err)
+	}
+
+	schema, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema from %s: %w", humanReadableFilename(location), err)
+	}
+
+	return schema, nil
+}
+
+// validateDocument validates the provided node against the schema and returns
+// the list of validation errors including their location in the document
+func validateDocument(schema *jsonschema.Schema, documentIdx int, node *yamlv3.Node) ([]validationError, error) {
+	value, err := jsonValue(node)
+	if err != nil {
+		return nil, err
+	}
+
+	err = schema.Validate(value)
+	if err == nil {
+		return nil, nil
+	}
+
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, err
+	}
+
+	var result []validationError
+	var collect func(unit jsonschema.OutputUnit)
+	collect = func(unit jsonschema.OutputUnit) {
+		if unit.Error != nil && len(unit.Errors) == 0 {
+			line, column := locate(node, unit.InstanceLocation)
+			result = append(result, validationError{
+				documentIdx: documentIdx,
+				path:        humanReadableInstanceLocation(unit.InstanceLocation),
+				line:        line,
+				column:      column,
+				message:     unit.Error.String(),
+			})
+		}
+
+		for _, cause := range unit.Errors {
+			collect(cause)
+		}
+	}
+
+	collect(*validationErr.BasicOutput())
+	return result, nil
+}
+
+// jsonValue converts the YAML node into a value as it would be created when
+// parsing the respective JSON, which is what the schema validator expects
+func jsonValue(node *yamlv3.Node) (any, error) {
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+
+		return jsonValue(node.Content[0])
+
+	case yamlv3.AliasNode:
+		return jsonValue(node.Alias)
+
+	case yamlv3.MappingNode:
+		result := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := jsonValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+
+			result[node.Content[i].Value] = value
+		}
+
+		return result, nil
+
+	case yamlv3.SequenceNode:
+		result := make([]any, 0, len(node.Content))
+		for _, entry := range node.Content {
+			value, err := jsonValue(entry)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, value)
+		}
+
+		return result, nil
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+
+	case "!!bool":
+		var value bool
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+
+	case "!!int", "!!float":
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported number %s in line %d", node.Value, node.Line)
+		}
+
+		return json.Number(data), nil
+	}
+
+	return node.Value, nil
+}
+
+// locate returns the line and column of the node that the JSON pointer
+// references, or of the closest parent node if it cannot be found
+func locate(node *yamlv3.Node, pointer string) (int, int) {
+	line, column := node.Line, node.Column
+	if pointer == "" {
+		return line, column
+	}
+
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			var found bool
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					line, column = node.Content[i].Line, node.Content[i].Column
+					node, found = node.Content[i+1], true
+					break
+				}
+			}
+
+			if !found {
+				return line, column
+			}
+
+		case yamlv3.SequenceNode:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(node.Content) {
+				return line, column
+			}
+
+			node = node.Content[idx]
+			line, column = node.Line, node.Column
+
+		default:
+			return line, column
+		}
+
+		if node.Kind == yamlv3.AliasNode {
+			node = node.Alias
+		}
+	}
+
+	return line, column
+}
+
+func humanReadableInstanceLocation(pointer string) string {
+	if pointer == "" {
+		return "(root level)"
+	}
+
+	return strings.Join(strings.Split(strings.TrimPrefix(pointer, "/"), "/"), ".")
+}
+
+func writeValidationResult(location string, numberOfDocuments int, errs []validationError) {
+	// Paths and messages are styled directly rather than being part of a bunt
+	// format string, so that text in them cannot be taken for markup
+	if len(errs) == 0 {
+		fmt.Printf("%s: %s\n", humanReadableFilename(location), bunt.Style("valid", bunt.Foreground(bunt.LimeGreen)))
+		return
+	}
+
+	fmt.Printf("%s: %s\n", humanReadableFilename(location), bunt.Style(text.Plural(len(errs), "validation error"), bunt.Foreground(bunt.Coral)))
+	for _, err := range errs {
+		var position = fmt.Sprintf("line %d, column %d", err.line, err.column)
+		if numberOfDocuments > 1 {
+			position = fmt.Sprintf("document #%d, %s", err.documentIdx+1, position)
+		}
+
+		fmt.Printf("  %s %s\n", bunt.Style(err.path, bunt.Bold()), bunt.Style("("+position+")", bunt.Foreground(bunt.DimGray)))
+		fmt.Printf("    %s\n", err.message)
+	}
+}
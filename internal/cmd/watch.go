@@ -0,0 +1,122 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gonvenience/ytbx"
+	"github.com/spf13/cobra"
+	xterm "golang.org/x/term"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+// watchInterval is the time between two checks whether an input file changed
+var watchInterval = 500 * time.Millisecond
+
+// watchedFile is an input file in watch mode, which is only loaded again if
+// it changed, so that the cached subtree hashes of its nodes stay valid
+type watchedFile struct {
+	location string
+	format   string
+	modTime  time.Time
+	size     int64
+	input    ytbx.InputFile
+}
+
+// update loads the input file again in case it was modified since it was
+// loaded the last time, and returns whether it was loaded again
+func (f *watchedFile) update() (bool, error) {
+	info, err := os.Stat(f.location)
+	if err != nil {
+		return false, err
+	}
+
+	if info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return false, nil
+	}
+
+	f.modTime, f.size = info.ModTime(), info.Size()
+
+	input, err := loadInputFile(f.location, f.format)
+	if err != nil {
+		return false, err
+	}
+
+	f.input = input
+	return true, nil
+}
+
+// watch compares the input files every time one of them changes until the
+// program is stopped. The comparison results of documents that did not change
+// are cached, so that only the changed documents need to be compared again.
+func watch(cmd *cobra.Command, fromLocation string, toLocation string, options []dyff.CompareOption, progress *progressPrinter) error {
+	var files = []*watchedFile{
+		{location: fromLocation, format: betweenCmdSettings.fromFormat},
+		{location: toLocation, format: betweenCmdSettings.toFormat},
+	}
+
+	for _, file := range files {
+		if info, err := os.Stat(file.location); err != nil || !info.Mode().IsRegular() {
+			return fmt.Errorf("watch mode only supports local files, but %s is not one", humanReadableFilename(file.location))
+		}
+
+		if _, err := file.update(); err != nil {
+			return fmt.Errorf("failed to load input files: %w", err)
+		}
+	}
+
+	options = append(options, dyff.WithCache(dyff.NewCache()))
+
+	var clearScreen = xterm.IsTerminal(int(os.Stdout.Fd()))
+	for changed := true; ; changed = false {
+		for _, file := range files {
+			updated, err := file.update()
+			if err != nil {
+				// Files are often invalid for a moment while they are written,
+				// so only show the error and keep the last version of the file
+				fmt.Fprintf(os.Stderr, "failed to load %s: %v\n", humanReadableFilename(file.location), err)
+			}
+
+			changed = changed || updated
+		}
+
+		if changed {
+			if clearScreen {
+				fmt.Print("\x1b[H\x1b[2J")
+			}
+
+			report, err := compareInputFiles(files[0].input, files[1].input, options, progress)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+
+			} else if err := writeReport(cmd, reportOptions.filterReport(report)); err != nil && !errors.As(err, &errorWithExitCode{}) {
+				return err
+			}
+		}
+
+		time.Sleep(watchInterval)
+	}
+}
@@ -34,6 +34,8 @@ type yamlCmdOptions struct {
 	restructure      bool
 	omitIndentHelper bool
 	inplace          bool
+	outputStyle      string
+	alignKeys        bool
 }
 
 var yamlCmdSettings yamlCmdOptions
@@ -49,11 +51,17 @@ Converts input document into YAML format while preserving the order of all keys.
 `,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
+		outputStyle, err := conversionOutputStyle(yamlCmdSettings.outputStyle, "yaml")
+		if err != nil {
+			return err
+		}
+
 		writer := &OutputWriter{
-			OutputStyle:      "yaml",
+			OutputStyle:      outputStyle,
 			PlainMode:        yamlCmdSettings.plainMode,
 			Restructure:      yamlCmdSettings.restructure,
 			OmitIndentHelper: yamlCmdSettings.omitIndentHelper,
+			AlignKeys:        yamlCmdSettings.alignKeys,
 		}
 
 		var errs []error
@@ -90,4 +98,6 @@ func init() {
 	yamlCmd.Flags().BoolVarP(&yamlCmdSettings.restructure, "restructure", "r", false, "restructure map keys in reasonable order")
 	yamlCmd.Flags().BoolVarP(&yamlCmdSettings.omitIndentHelper, "omit-indent-helper", "O", false, "omit indent helper lines in highlighted output")
 	yamlCmd.Flags().BoolVarP(&yamlCmdSettings.inplace, "in-place", "i", false, "overwrite input file with output of this command")
+	yamlCmd.Flags().StringVarP(&yamlCmdSettings.outputStyle, "output", "o", "", "use a different output format, supported formats: yaml, json, toml, or properties")
+	yamlCmd.Flags().BoolVar(&yamlCmdSettings.alignKeys, "align-keys", false, "pad keys so that values of a mapping start in the same column")
 }
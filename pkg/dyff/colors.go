@@ -24,14 +24,141 @@ import (
 	"fmt"
 
 	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/neat"
 	"github.com/lucasb-eyer/go-colorful"
 )
 
-var (
-	additionGreen      = color("#58BF38")
-	modificationYellow = color("#C7C43F")
-	removalRed         = color("#B9311B")
-)
+// Theme defines the set of colors that is used to highlight the different
+// parts of a report, for example additions, removals, or the header
+type Theme struct {
+	Addition      colorful.Color
+	Modification  colorful.Color
+	Removal       colorful.Color
+	LightAddition colorful.Color
+	LightRemoval  colorful.Color
+	Dimmed        colorful.Color
+	Header        [3]colorful.Color
+
+	// AdditionSchema and RemovalSchema are the neat color schemas used to
+	// render added or removed YAML structures
+	AdditionSchema map[string]colorful.Color
+	RemovalSchema  map[string]colorful.Color
+
+	// DocumentSchema is the neat color schema used to render complete
+	// documents, for example in the YAML or JSON conversion commands
+	DocumentSchema map[string]colorful.Color
+}
+
+// DarkTheme is the default theme, which works best on terminals with a dark
+// background color
+var DarkTheme = Theme{
+	Addition:      color("#58BF38"),
+	Modification:  color("#C7C43F"),
+	Removal:       color("#B9311B"),
+	LightAddition: bunt.LightGreen,
+	LightRemoval:  bunt.LightSalmon,
+	Dimmed:        bunt.DimGray,
+	Header: [3]colorful.Color{
+		{R: .45, G: .71, B: .30},
+		{R: .79, G: .76, B: .38},
+		{R: .65, G: .17, B: .17},
+	},
+
+	AdditionSchema: map[string]colorful.Color{
+		"keyColor":           bunt.Green,
+		"indentLineColor":    {R: 0, G: 0.2, B: 0},
+		"scalarDefaultColor": bunt.LimeGreen,
+		"boolColor":          bunt.LimeGreen,
+		"floatColor":         bunt.LimeGreen,
+		"intColor":           bunt.LimeGreen,
+		"multiLineTextColor": bunt.OliveDrab,
+		"nullColor":          bunt.Olive,
+		"emptyStructures":    bunt.DarkOliveGreen,
+		"dashColor":          bunt.Green,
+	},
+
+	RemovalSchema: map[string]colorful.Color{
+		"keyColor":           bunt.FireBrick,
+		"indentLineColor":    {R: 0.2, G: 0, B: 0},
+		"scalarDefaultColor": bunt.LightCoral,
+		"boolColor":          bunt.LightCoral,
+		"floatColor":         bunt.LightCoral,
+		"intColor":           bunt.LightCoral,
+		"multiLineTextColor": bunt.DarkSalmon,
+		"nullColor":          bunt.Salmon,
+		"emptyStructures":    bunt.LightSalmon,
+		"dashColor":          bunt.FireBrick,
+	},
+
+	DocumentSchema: neat.DefaultColorSchema,
+}
+
+// LightTheme is a theme with darker and more saturated colors, which works
+// best on terminals with a light background color
+var LightTheme = Theme{
+	Addition:      color("#2E7D32"),
+	Modification:  color("#9A6700"),
+	Removal:       color("#B71C1C"),
+	LightAddition: color("#6E9F5A"),
+	LightRemoval:  color("#C9735F"),
+	Dimmed:        color("#8A8A8A"),
+	Header: [3]colorful.Color{
+		{R: .18, G: .49, B: .20},
+		{R: .60, G: .40, B: .00},
+		{R: .72, G: .11, B: .11},
+	},
+
+	AdditionSchema: map[string]colorful.Color{
+		"keyColor":           bunt.DarkGreen,
+		"indentLineColor":    {R: 0.8, G: 0.9, B: 0.8},
+		"scalarDefaultColor": bunt.ForestGreen,
+		"boolColor":          bunt.ForestGreen,
+		"floatColor":         bunt.ForestGreen,
+		"intColor":           bunt.ForestGreen,
+		"multiLineTextColor": bunt.OliveDrab,
+		"nullColor":          bunt.Olive,
+		"emptyStructures":    bunt.DarkOliveGreen,
+		"dashColor":          bunt.DarkGreen,
+	},
+
+	RemovalSchema: map[string]colorful.Color{
+		"keyColor":           bunt.DarkRed,
+		"indentLineColor":    {R: 0.9, G: 0.8, B: 0.8},
+		"scalarDefaultColor": bunt.FireBrick,
+		"boolColor":          bunt.FireBrick,
+		"floatColor":         bunt.FireBrick,
+		"intColor":           bunt.FireBrick,
+		"multiLineTextColor": bunt.Brown,
+		"nullColor":          bunt.Sienna,
+		"emptyStructures":    bunt.IndianRed,
+		"dashColor":          bunt.DarkRed,
+	},
+
+	DocumentSchema: map[string]colorful.Color{
+		"documentStart":      bunt.SlateGray,
+		"keyColor":           color("#0451A5"),
+		"indentLineColor":    {R: 0.86, G: 0.86, B: 0.86},
+		"scalarDefaultColor": color("#A31515"),
+		"boolColor":          color("#0000FF"),
+		"floatColor":         color("#098658"),
+		"intColor":           color("#098658"),
+		"multiLineTextColor": color("#795E26"),
+		"nullColor":          color("#0000FF"),
+		"binaryColor":        bunt.Teal,
+		"emptyStructures":    bunt.DarkGoldenrod,
+		"commentColor":       bunt.Gray,
+		"anchorColor":        bunt.RoyalBlue,
+	},
+}
+
+// orDefaultTheme returns the given theme, or the dark theme if it is nil
+func orDefaultTheme(theme *Theme) *Theme {
+	if theme != nil {
+		return theme
+	}
+
+	return &DarkTheme
+}
 
 func color(hex string) colorful.Color {
 	color, _ := colorful.Hex(hex)
@@ -46,28 +173,28 @@ func render(format string, a ...interface{}) string {
 	return fmt.Sprintf(format, a...)
 }
 
-func green(format string, a ...interface{}) string {
-	return colored(additionGreen, render(format, a...))
+func (theme *Theme) green(format string, a ...interface{}) string {
+	return colored(theme.Addition, render(format, a...))
 }
 
-func red(format string, a ...interface{}) string {
-	return colored(removalRed, render(format, a...))
+func (theme *Theme) red(format string, a ...interface{}) string {
+	return colored(theme.Removal, render(format, a...))
 }
 
-func yellow(format string, a ...interface{}) string {
-	return colored(modificationYellow, render(format, a...))
+func (theme *Theme) yellow(format string, a ...interface{}) string {
+	return colored(theme.Modification, render(format, a...))
 }
 
-func lightgreen(format string, a ...interface{}) string {
-	return colored(bunt.LightGreen, render(format, a...))
+func (theme *Theme) lightgreen(format string, a ...interface{}) string {
+	return colored(theme.LightAddition, render(format, a...))
 }
 
-func lightred(format string, a ...interface{}) string {
-	return colored(bunt.LightSalmon, render(format, a...))
+func (theme *Theme) lightred(format string, a ...interface{}) string {
+	return colored(theme.LightRemoval, render(format, a...))
 }
 
-func dimgray(format string, a ...interface{}) string {
-	return colored(bunt.DimGray, render(format, a...))
+func (theme *Theme) dimgray(format string, a ...interface{}) string {
+	return colored(theme.Dimmed, render(format, a...))
 }
 
 func bold(format string, a ...interface{}) string {
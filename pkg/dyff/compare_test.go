@@ -21,6 +21,12 @@
 package dyff_test
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -64,6 +70,29 @@ some:
 				Expect(result[0]).To(BeSameDiffAs(singleDiff("/some/yaml/structure/name", dyff.MODIFICATION, "foobar", "fOObAr")))
 			})
 
+			It("should return the differences next to identical subtrees", func() {
+				from := yml(`---
+identical:
+  list: [{name: one, value: foo}, {name: two, value: foo}]
+  map: {key: value}
+changed:
+  list: [{name: one, value: foo}, {name: two, value: foo}]
+`)
+
+				to := yml(`---
+identical:
+  list: [{name: one, value: foo}, {name: two, value: foo}]
+  map: {key: value}
+changed:
+  list: [{name: one, value: bar}, {name: two, value: foo}]
+`)
+
+				result, err := compare(from, to)
+				Expect(err).To(BeNil())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(singleDiff("/changed/list/name=one/value", dyff.MODIFICATION, "foo", "bar")))
+			})
+
 			It("should return that an integer was modified", func() {
 				from := yml(`---
 some:
@@ -226,6 +255,112 @@ some:
 				Expect(err).To(BeNil())
 				Expect(diffs).To(BeNil())
 			})
+
+			It("should ignore changes of trailing newlines only if configured", func() {
+				from := yml("---\nscript: |\n  echo hello\nname: foo\n")
+				to := yml("---\nscript: echo hello\nname: \"foo \"\n")
+
+				diffs, err := compare(from, to, dyff.IgnoreTrailingNewlineChanges(true))
+				Expect(err).To(BeNil())
+				Expect(diffs).To(HaveLen(1))
+				Expect(diffs[0].Path.String()).To(Equal("/name"))
+			})
+
+			It("should report type changes as their own kind of difference if configured", func() {
+				from := yml("---\nport: \"8080\"\nspec: x\nname: foo\n")
+				to := yml("---\nport: 8080\nspec: {a: 1}\nname: bar\n")
+
+				diffs, err := compare(from, to)
+				Expect(err).To(BeNil())
+				Expect(diffs).To(HaveLen(3))
+				for _, diff := range diffs {
+					Expect(diff.Details[0].Kind).To(Equal(dyff.MODIFICATION))
+				}
+
+				diffs, err = compare(from, to, dyff.DetectTypeChanges(true))
+				Expect(err).To(BeNil())
+				Expect(diffs).To(HaveLen(3))
+				Expect(diffs[0].Details[0].Kind).To(Equal(dyff.TYPECHANGE))
+				Expect(diffs[1].Details[0].Kind).To(Equal(dyff.TYPECHANGE))
+				Expect(diffs[2].Details[0].Kind).To(Equal(dyff.MODIFICATION))
+
+				report := dyff.Report{Diffs: diffs}
+				Expect(report.TypeChangesOnly().Diffs).To(HaveLen(2))
+				Expect(report.IgnoreValueChanges().Diffs).To(BeEmpty())
+			})
+
+			It("should report purely stylistic changes as their own kind of difference if configured", func() {
+				from := yml("---\nname: \"foo\"\nlist: [1, 2]\ntext: \"a\\n  b\\n\"\nother: x\n")
+				to := yml("---\nname: foo\nlist:\n- 1\n- 2\ntext: \"a\\n    b\\n\"\nother: y\n")
+
+				diffs, err := compare(from, to)
+				Expect(err).To(BeNil())
+				Expect(diffs).To(HaveLen(2))
+
+				diffs, err = compare(from, to, dyff.DetectStyleChanges(true))
+				Expect(err).To(BeNil())
+				Expect(diffs).To(HaveLen(4))
+				Expect(diffs[0].Details[0].Kind).To(Equal(dyff.STYLECHANGE))
+				Expect(diffs[1].Details[0].Kind).To(Equal(dyff.STYLECHANGE))
+				Expect(diffs[2].Details[0].Kind).To(Equal(dyff.MODIFICATION))
+				Expect(diffs[3].Details[0].Kind).To(Equal(dyff.MODIFICATION))
+
+				report := dyff.Report{Diffs: diffs}
+				Expect(report.IgnoreStyleChanges().Diffs).To(HaveLen(2))
+				Expect(report.IgnoreStyleChanges().Diffs[0].Path.String()).To(Equal("/text"))
+				Expect(report.IgnoreStyleChanges().Diffs[1].Path.String()).To(Equal("/other"))
+			})
+
+			It("should not report strings with a different content as a style change", func() {
+				from := yml("---\ntext: \"a\\n  b\\n\"\ntrailing: \"foo \"\nscript: |\n  echo a\n  echo b\n")
+				to := yml("---\ntext: \"a\\n    b\\n\"\ntrailing: foo\nscript: >\n  echo a\n  echo b\n")
+
+				diffs, err := compare(from, to, dyff.DetectStyleChanges(true))
+				Expect(err).To(BeNil())
+				Expect(diffs).To(HaveLen(3))
+				for _, diff := range diffs {
+					Expect(diff.Details[0].Kind).To(Equal(dyff.MODIFICATION))
+				}
+			})
+
+			It("should report changed subtrees at the maximum depth as one difference", func() {
+				from := yml("---\nspec:\n  template:\n    image: x:1\n    env: {A: \"1\"}\nname: foo\n")
+				to := yml("---\nspec:\n  template:\n    image: x:2\n    env: {A: \"2\"}\nname: foo\n")
+
+				diffs, err := compare(from, to, dyff.MaxCompareDepth(1))
+				Expect(err).To(BeNil())
+				Expect(diffs).To(HaveLen(1))
+				Expect(diffs[0].Path.String()).To(Equal("/spec"))
+				Expect(diffs[0].Details).To(HaveLen(1))
+				Expect(diffs[0].Details[0].Kind).To(Equal(dyff.MODIFICATION))
+				Expect(diffs[0].Details[0].From.Kind).To(Equal(yamlv3.MappingNode))
+
+				diffs, err = compare(from, to)
+				Expect(err).To(BeNil())
+				Expect(diffs).To(HaveLen(2))
+			})
+
+			It("should report reordered map keys only if configured", func() {
+				from := yml("---\nfoo:\n  a: 1\n  b: 2\n")
+				to := yml("---\nfoo:\n  b: 2\n  a: 1\n")
+
+				diffs, err := compare(from, to)
+				Expect(err).To(BeNil())
+				Expect(diffs).To(BeNil())
+
+				diffs, err = compare(from, to, dyff.DetectMapOrderChanges(true))
+				Expect(err).To(BeNil())
+				Expect(diffs).To(HaveLen(1))
+				Expect(diffs[0].Path.String()).To(Equal("/foo"))
+				Expect(diffs[0].Details).To(HaveLen(1))
+				Expect(diffs[0].Details[0].Kind).To(Equal(dyff.ORDERCHANGE))
+				Expect(diffs[0].Details[0].From).To(Equal(dyff.AsSequenceNode("a", "b")))
+				Expect(diffs[0].Details[0].To).To(Equal(dyff.AsSequenceNode("b", "a")))
+
+				diffs, err = compare(from, to, dyff.DetectMapOrderChanges(true), dyff.IgnoreOrderChanges(true))
+				Expect(err).To(BeNil())
+				Expect(diffs).To(BeNil())
+			})
 		})
 
 		Context("Given two YAML structures with simple lists", func() {
@@ -358,6 +493,102 @@ list:
 			})
 		})
 
+		Context("Given two YAML structures with simple lists and a list diff algorithm", func() {
+			from := func() *yamlv3.Node { return yml(`list: [a, b, c, d]`) }
+			to := func() *yamlv3.Node { return yml(`list: [b, c, d, a]`) }
+
+			It("should report order changes using the default multiset algorithm", func() {
+				result, err := compare(from(), to(), dyff.ListAlgorithm(dyff.ListDiffMultiset))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0].Details).To(HaveLen(1))
+				Expect(result[0].Details[0].Kind).To(Equal(dyff.ORDERCHANGE))
+			})
+
+			for _, algorithm := range []dyff.ListDiffAlgorithm{dyff.ListDiffLCS, dyff.ListDiffPatience, dyff.ListDiffHistogram} {
+				algorithm := algorithm
+				It(fmt.Sprintf("should report moved entries as removal and addition using the %s algorithm", algorithm), func() {
+					result, err := compare(from(), to(), dyff.ListAlgorithm(algorithm))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(HaveLen(1))
+					Expect(result[0]).To(BeSameDiffAs(doubleDiff("/list",
+						dyff.REMOVAL, list(`[a]`), nil,
+						dyff.ADDITION, nil, list(`[a]`),
+					)))
+				})
+			}
+
+			It("should align each entry with the next matching entry using the greedy algorithm", func() {
+				result, err := compare(from(), to(), dyff.ListAlgorithm(dyff.ListDiffGreedy))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(doubleDiff("/list",
+					dyff.REMOVAL, list(`[b, c, d]`), nil,
+					dyff.ADDITION, nil, list(`[b, c, d]`),
+				)))
+			})
+
+			It("should align lists with many duplicate entries", func() {
+				result, err := compare(
+					yml(`list: [x, a, a, b, a, a, y]`),
+					yml(`list: [a, a, b, a, z, a, y]`),
+					dyff.ListAlgorithm(dyff.ListDiffHistogram),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(doubleDiff("/list",
+					dyff.REMOVAL, list(`[x]`), nil,
+					dyff.ADDITION, nil, list(`[z]`),
+				)))
+			})
+
+			It("should align very large lists using the lcs algorithm", func() {
+				var fromEntries, toEntries []string
+				for i := 0; i < 5000; i++ {
+					fromEntries = append(fromEntries, fmt.Sprintf("entry-%d", i%100))
+					toEntries = append(toEntries, fmt.Sprintf("entry-%d", i%100))
+				}
+
+				fromEntries[10] = "removed"
+				toEntries[4990] = "added"
+
+				result, err := compare(
+					yml(fmt.Sprintf("list: [%s]", strings.Join(fromEntries, ", "))),
+					yml(fmt.Sprintf("list: [%s]", strings.Join(toEntries, ", "))),
+					dyff.ListAlgorithm(dyff.ListDiffLCS),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(doubleDiff("/list",
+					dyff.REMOVAL, list(`[removed, entry-90]`), nil,
+					dyff.ADDITION, nil, list(`[entry-10, added]`),
+				)))
+			})
+
+			It("should not align list entries using a list diff algorithm if order changes are ignored", func() {
+				result, err := compare(
+					yml("list: [one, two, three, four]"),
+					yml("list: [four, three, two, five]"),
+					dyff.ListAlgorithm(dyff.ListDiffLCS),
+					dyff.IgnoreOrderChanges(true),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(doubleDiff("/list",
+					dyff.REMOVAL, list(`[one]`), nil,
+					dyff.ADDITION, nil, list(`[five]`),
+				)))
+			})
+
+			It("should fail to parse an unknown list diff algorithm", func() {
+				_, err := dyff.ParseListDiffAlgorithm("quantum")
+				Expect(err).To(MatchError("unknown list diff algorithm quantum, supported algorithms are: multiset, lcs, patience, histogram, or greedy"))
+			})
+		})
+
 		Context("Given two YAML structures with complex content", func() {
 			It("should return all differences in there", func() {
 				from := yml(`---
@@ -844,6 +1075,834 @@ listY: [ Yo, Yo, Yo ]
 					singleDiff("/yaml/map/removed", dyff.REMOVAL, nil, "removed"),
 				}}))
 			})
+
+			It("should exclude value changes of the given value classes", func() {
+				report, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Documents: multiDoc("---\nname: \"foo \"\nport: \"8080\"\nscript: |\n  # install\n  make\nreplicas: 1\n")},
+					ytbx.InputFile{Documents: multiDoc("---\nname: foo\nport: 8080\nscript: |\n  # build and install\n  make\nreplicas: 2\n")},
+				)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(report.Diffs).To(HaveLen(4))
+
+				Expect(report.ExcludeValueClasses()).To(BeEquivalentTo(report))
+
+				var paths = func(report dyff.Report) (result []string) {
+					for _, diff := range report.Diffs {
+						result = append(result, diff.Path.String())
+					}
+
+					return result
+				}
+
+				Expect(paths(report.ExcludeValueClasses(dyff.ValueClassWhitespace))).To(Equal([]string{"/port", "/script", "/replicas"}))
+				Expect(paths(report.ExcludeValueClasses(dyff.ValueClassQuoting, dyff.ValueClassComment))).To(Equal([]string{"/name", "/replicas"}))
+				Expect(paths(report.ExcludeValueClasses(dyff.ValueClassWhitespace, dyff.ValueClassQuoting, dyff.ValueClassComment, dyff.ValueClassNull))).To(Equal([]string{"/replicas"}))
+			})
+
+			It("should fail to parse an unknown value class", func() {
+				_, err := dyff.ParseValueClass("noise")
+				Expect(err).To(MatchError("unknown value class noise, supported classes are: null, whitespace, quoting, or comment"))
+			})
+
+			It("should only keep additions or removals", func() {
+				report := dyff.Report{Diffs: []dyff.Diff{
+					singleDiff("/yaml/map/add", dyff.ADDITION, nil, "added"),
+					singleDiff("/yaml/map/removed", dyff.REMOVAL, "removed", nil),
+					singleDiff("/yaml/map/changed", dyff.MODIFICATION, "foobar", "barfoo"),
+					doubleDiff("/yaml/list",
+						dyff.REMOVAL, "one", nil,
+						dyff.ADDITION, nil, "two",
+					),
+				}}
+
+				Expect(report.AdditionsOnly()).To(BeEquivalentTo(dyff.Report{Diffs: []dyff.Diff{
+					singleDiff("/yaml/map/add", dyff.ADDITION, nil, "added"),
+					singleDiff("/yaml/list", dyff.ADDITION, nil, "two"),
+				}}))
+
+				Expect(report.RemovalsOnly()).To(BeEquivalentTo(dyff.Report{Diffs: []dyff.Diff{
+					singleDiff("/yaml/map/removed", dyff.REMOVAL, "removed", nil),
+					singleDiff("/yaml/list", dyff.REMOVAL, "one", nil),
+				}}))
+			})
+		})
+
+		Context("comparing with a context", func() {
+			It("should stop the comparison when the context is cancelled", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				_, err := dyff.CompareInputFilesContext(ctx,
+					ytbx.InputFile{Documents: multiDoc(`{"list": [1, 2]}`, `{"foo": "bar"}`)},
+					ytbx.InputFile{Documents: multiDoc(`{"list": [1, 3]}`, `{"foo": "baz"}`)},
+					dyff.KubernetesEntityDetection(false),
+				)
+
+				Expect(err).To(MatchError(context.Canceled))
+			})
+
+			It("should compare as usual if the context is not done", func() {
+				report, err := dyff.CompareInputFilesContext(context.Background(),
+					ytbx.InputFile{Documents: multiDoc(`{"foo": "bar"}`)},
+					ytbx.InputFile{Documents: multiDoc(`{"foo": "baz"}`)},
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(report.Diffs).To(HaveLen(1))
+			})
+		})
+
+		Context("reporting progress", func() {
+			It("should report each compared document", func() {
+				var progress []dyff.Progress
+				_, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Documents: multiDoc(`{"foo": "bar"}`, `{"foo": "bar"}`, `{"foo": "bar"}`)},
+					ytbx.InputFile{Documents: multiDoc(`{"foo": "baz"}`, `{"foo": "bar"}`, `{"foo": "baz"}`)},
+					dyff.KubernetesEntityDetection(false),
+					dyff.ProgressHandler(func(p dyff.Progress) { progress = append(progress, p) }),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(progress).To(HaveLen(3))
+				for i, p := range progress {
+					Expect(p.Documents).To(Equal(i + 1))
+					Expect(p.Total).To(Equal(3))
+					Expect(p.Path).ToNot(BeNil())
+				}
+			})
+		})
+
+		Context("handling differences as soon as they are found", func() {
+			It("should pass each difference in the order of the report", func() {
+				var diffs []dyff.Diff
+				report, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Documents: multiDoc(`{"foo": "bar"}`, `{"foo": "bar"}`, `{"foo": "bar", "list": [1, 2]}`)},
+					ytbx.InputFile{Documents: multiDoc(`{"foo": "baz"}`, `{"foo": "bar"}`, `{"foo": "baz", "list": [1, 3]}`)},
+					dyff.KubernetesEntityDetection(false),
+					dyff.Concurrency(3),
+					dyff.DifferenceHandler(func(diff dyff.Diff) error {
+						diffs = append(diffs, diff)
+						return nil
+					}),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(diffs).To(HaveLen(3))
+				Expect(diffs).To(Equal(report.Diffs))
+			})
+
+			It("should pass the additions and removals of Kubernetes resources", func() {
+				var diffs []dyff.Diff
+				report, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Documents: multiDoc(
+						`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "one"}, "data": {"foo": "bar"}}`,
+						`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "two"}}`,
+					)},
+					ytbx.InputFile{Documents: multiDoc(
+						`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "one"}, "data": {"foo": "baz"}}`,
+						`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "three"}}`,
+					)},
+					dyff.DifferenceHandler(func(diff dyff.Diff) error {
+						diffs = append(diffs, diff)
+						return nil
+					}),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(diffs).To(HaveLen(4))
+				Expect(diffs).To(Equal(report.Diffs))
+			})
+
+			It("should stop the comparison with the error of the handler", func() {
+				var calls int
+				_, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Documents: multiDoc(`{"foo": "bar"}`, `{"foo": "bar"}`)},
+					ytbx.InputFile{Documents: multiDoc(`{"foo": "baz"}`, `{"foo": "baz"}`)},
+					dyff.KubernetesEntityDetection(false),
+					dyff.Concurrency(1),
+					dyff.DifferenceHandler(func(diff dyff.Diff) error {
+						calls++
+						return fmt.Errorf("stop")
+					}),
+				)
+
+				Expect(err).To(MatchError("stop"))
+				Expect(calls).To(Equal(1))
+			})
+		})
+
+		Context("custom comparers", func() {
+			semver := dyff.ComparerFunc(func(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]dyff.Detail, bool, error) {
+				if strings.TrimPrefix(from.Value, "v") == strings.TrimPrefix(to.Value, "v") {
+					return nil, true, nil
+				}
+
+				return nil, false, nil
+			})
+
+			It("should use a comparer registered for a YAML tag", func() {
+				from := yml(`---
+version: !semver v1.2.0
+other: v1.2.0
+`)
+				to := yml(`---
+version: !semver 1.2.0
+other: 1.2.0
+`)
+
+				result, err := compare(from, to, dyff.ComparerForTag("!semver", semver))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(singleDiff("/other", dyff.MODIFICATION, "v1.2.0", "1.2.0")))
+			})
+
+			It("should use a comparer registered for a path", func() {
+				from := yml(`---
+spec:
+  version: v1.2.0
+`)
+				to := yml(`---
+spec:
+  version: 1.2.0
+`)
+
+				result, err := compare(from, to, dyff.ComparerForPath("spec.version", semver))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeEmpty())
+
+				result, err = compare(from, to, dyff.ComparerForPath("/spec/version", semver))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeEmpty())
+			})
+
+			It("should use the default comparison if the comparer does not handle the nodes", func() {
+				from := yml(`{"spec": {"version": "v1.2.0"}}`)
+				to := yml(`{"spec": {"version": "1.3.0"}}`)
+
+				result, err := compare(from, to, dyff.ComparerForPath("/spec/version", semver))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(singleDiff("/spec/version", dyff.MODIFICATION, "v1.2.0", "1.3.0")))
+			})
+
+			It("should use a comparer registered for a path pattern", func() {
+				from := yml(`{"spec": {"image": "v1.2.0", "version": "v1.2.0"}}`)
+				to := yml(`{"spec": {"image": "1.2.0", "version": "1.2.0"}}`)
+
+				result, err := compare(from, to, dyff.ComparerForPathRegexp(regexp.MustCompile(`^/spec/v`), semver))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(singleDiff("/spec/image", dyff.MODIFICATION, "v1.2.0", "1.2.0")))
+			})
+
+			It("should fail if a comparer fails", func() {
+				failing := dyff.ComparerFunc(func(ytbx.Path, *yamlv3.Node, *yamlv3.Node) ([]dyff.Detail, bool, error) {
+					return nil, false, fmt.Errorf("failed to decrypt")
+				})
+
+				_, err := compare(yml(`{"secret": "a"}`), yml(`{"secret": "b"}`), dyff.ComparerForPath("/secret", failing))
+				Expect(err).To(MatchError("failed to decrypt"))
+			})
+
+			It("should compare values after applying transformations", func() {
+				suffix, err := dyff.ParseTransformation(`s/-[a-f0-9]{8}$//`)
+				Expect(err).ToNot(HaveOccurred())
+
+				from := yml(`{"metadata": {"name": "web-1a2b3c4d"}}`)
+				to := yml(`{"metadata": {"name": "web-9f8e7d6c"}}`)
+
+				result, err := compare(from, to, dyff.ComparerForPath("metadata.name", dyff.TransformComparer(suffix)))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeEmpty())
+
+				to = yml(`{"metadata": {"name": "api-9f8e7d6c"}}`)
+				result, err = compare(from, to, dyff.ComparerForPath("metadata.name", dyff.TransformComparer(suffix)))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(singleDiff("/metadata/name", dyff.MODIFICATION, "web-1a2b3c4d", "api-9f8e7d6c")))
+			})
+
+			It("should parse sed-like transformations", func() {
+				for expression, expected := range map[string]string{
+					`s/o/0/`:               "f0o-bar",
+					`s/o/0/g`:              "f00-bar",
+					`s|(\w+)-(\w+)|\2-\1|`: "bar-foo",
+					`s/\//_/`:              "foo-bar",
+					`s/bar/[&]/`:           "foo-[bar]",
+					`s/-/$/`:               "foo$bar",
+				} {
+					transformation, err := dyff.ParseTransformation(expression)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(transformation.Apply("foo-bar")).To(Equal(expected), expression)
+				}
+
+				_, err := dyff.ParseTransformation("x/a/b/")
+				Expect(err).To(MatchError("invalid transformation x/a/b/, expected format is s/<regexp>/<replacement>/[g]"))
+
+				_, err = dyff.ParseTransformation("s/a/b")
+				Expect(err).To(MatchError("invalid transformation s/a/b, expected format is s/<regexp>/<replacement>/[g]"))
+			})
+		})
+
+		Context("ignoring paths", func() {
+			It("should ignore changed values and map entries that only exist on one side", func() {
+				result, err := compare(
+					yml(`{"name": "app", "variables": [{"name": "a"}], "meta": {"x": 1}}`),
+					yml(`{"name": "app", "meta": {"x": 2}, "added": true}`),
+					dyff.IgnorePaths("/variables", "meta.x", "/added"),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeEmpty())
+			})
+
+			It("should ignore dot-style paths that refer to named list entries", func() {
+				result, err := compare(
+					yml(`{"spec": {"containers": [{"name": "web", "image": "web:1"}, {"name": "db", "image": "db:1"}]}}`),
+					yml(`{"spec": {"containers": [{"name": "web", "image": "web:2"}, {"name": "db", "image": "db:2"}]}}`),
+					dyff.IgnorePaths("spec.containers.web.image"),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0].Path.String()).To(Equal("/spec/containers/name=db/image"))
+			})
+		})
+
+		Context("mapping paths", func() {
+			It("should compare a renamed map entry with its new name", func() {
+				result, err := compare(
+					yml(`{"spec": {"env": {"A": "1", "B": "2"}, "old": true}}`),
+					yml(`{"spec": {"environment": {"A": "1", "B": "3"}, "new": true}}`),
+					dyff.MapPath("spec.env", "/spec/environment"),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(2))
+				Expect(result[0].Path.String()).To(Equal("/spec"))
+				Expect(result[0].Details).To(HaveLen(2))
+				Expect(result[0].Details[0].Kind).To(Equal(dyff.REMOVAL))
+				Expect(result[0].Details[0].From.Content).To(HaveLen(2))
+				Expect(result[0].Details[0].From.Content[0].Value).To(Equal("old"))
+				Expect(result[0].Details[1].Kind).To(Equal(dyff.ADDITION))
+				Expect(result[0].Details[1].To.Content).To(HaveLen(2))
+				Expect(result[0].Details[1].To.Content[0].Value).To(Equal("new"))
+				Expect(result[1]).To(BeSameDiffAs(singleDiff("/spec/environment/B", dyff.MODIFICATION, "2", "3")))
+			})
+
+			It("should not map paths that exist on both sides", func() {
+				result, err := compare(
+					yml(`{"spec": {"env": {"A": "1"}, "environment": {"A": "1"}}}`),
+					yml(`{"spec": {"environment": {"A": "2"}}}`),
+					dyff.MapPath("spec.env", "spec.environment"),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(2))
+				Expect(result[0].Path.String()).To(Equal("/spec"))
+				Expect(result[1]).To(BeSameDiffAs(singleDiff("/spec/environment/A", dyff.MODIFICATION, "1", "2")))
+			})
+		})
+
+		Context("configured list identifiers", func() {
+			It("should use the identifier configured for the path", func() {
+				result, err := compare(
+					yml(`{"list": [{"id": "a", "ref": "x", "value": 1}, {"id": "b", "ref": "y", "value": 2}]}`),
+					yml(`{"list": [{"id": "c", "ref": "x", "value": 1}, {"id": "d", "ref": "y", "value": 3}]}`),
+					dyff.ListIdentifierForPath("list", "ref"),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				var paths []string
+				for _, diff := range result {
+					paths = append(paths, diff.Path.String())
+				}
+
+				Expect(paths).To(ConsistOf("/list/ref=x/id", "/list/ref=y/id", "/list/ref=y/value"))
+			})
+
+			It("should identify entries by the first of the fields configured for a path pattern", func() {
+				result, err := compare(
+					yml(`{"groups": [{"name": "a", "rules": [{"record": "x", "expr": "1"}, {"alert": "y", "expr": "2"}]}]}`),
+					yml(`{"groups": [{"name": "a", "rules": [{"alert": "y", "expr": "3"}, {"record": "x", "expr": "1"}]}]}`),
+					dyff.ListIdentifierForPathRegexp(regexp.MustCompile(`^/groups/name=[^/]+/rules$`), "alert", "record"),
+					dyff.IgnoreOrderChanges(true),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0].Path.String()).To(Equal("/groups/name=a/rules/alert=y/expr"))
+			})
+
+			It("should use the identifier configured for a path with placeholders", func() {
+				result, err := compare(
+					yml(`{"spec": {"containers": [{"name": "app", "ports": [{"port": 80, "id": "a"}, {"port": 443, "id": "b"}]}]}}`),
+					yml(`{"spec": {"containers": [{"name": "app", "ports": [{"port": 80, "id": "c"}, {"port": 443, "id": "b"}]}]}}`),
+					dyff.ListIdentifierForPath("/spec/containers/*/ports", "port"),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0].Path.String()).To(Equal("/spec/containers/name=app/ports/port=80/id"))
+			})
+
+			It("should fall back to the detected identifier if the configured one is not unique", func() {
+				result, err := compare(
+					yml(`{"list": [{"name": "a", "ref": "x"}, {"name": "b", "ref": "x"}]}`),
+					yml(`{"list": [{"name": "a", "ref": "x"}, {"name": "b", "ref": "y"}]}`),
+					dyff.ListIdentifierForPath("list", "ref"),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0].Path.String()).To(Equal("/list/name=b/ref"))
+			})
+		})
+
+		Context("renamed documents", func() {
+			from := func() ytbx.InputFile {
+				return ytbx.InputFile{Location: "from", Documents: multiDoc(`---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: config, namespace: old}
+data: {a: "1", b: "2", c: "3", d: "4", e: "5", f: "6", g: "7", h: "8"}
+`)}
+			}
+
+			to := func() ytbx.InputFile {
+				return ytbx.InputFile{Location: "to", Documents: multiDoc(`---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: config, namespace: new}
+data: {a: "1", b: "2", c: "3", d: "4", e: "5", f: "6", g: "7", h: "9"}
+`)}
+			}
+
+			paths := func(report dyff.Report) []string {
+				var result []string
+				for _, diff := range report.Diffs {
+					result = append(result, diff.Path.String())
+				}
+
+				return result
+			}
+
+			It("should report a moved resource with the remaining differences", func() {
+				report, err := dyff.CompareInputFiles(from(), to(), dyff.DetectRenames(true))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(paths(report)).To(Equal([]string{"/", "/metadata/namespace", "/data/h"}))
+
+				rename := report.Diffs[0].Details[0]
+				Expect(rename.Kind).To(Equal(dyff.RENAME))
+				Expect(rename.From.Value).To(Equal("v1/ConfigMap/old/config"))
+				Expect(rename.To.Value).To(Equal("v1/ConfigMap/new/config"))
+			})
+
+			It("should report a removal and addition if the documents are less similar than the threshold", func() {
+				report, err := dyff.CompareInputFiles(from(), to(), dyff.DetectRenames(true), dyff.RenameThreshold(100))
+				Expect(err).ToNot(HaveOccurred())
+				var kinds []rune
+				for _, diff := range report.Diffs {
+					kinds = append(kinds, diff.Details[0].Kind)
+				}
+
+				Expect(kinds).To(ContainElements(dyff.REMOVAL, dyff.ADDITION))
+				Expect(kinds).ToNot(ContainElement(dyff.MODIFICATION))
+			})
+
+			It("should fail for invalid thresholds", func() {
+				_, err := dyff.CompareInputFiles(from(), to(), dyff.DetectRenames(true), dyff.RenameThreshold(101))
+				Expect(err).To(MatchError(ContainSubstring("invalid rename threshold 101, expected a percentage between 0 and 100")))
+			})
+		})
+
+		Context("external comparers", func() {
+			It("should consider values equal if the command succeeds", func() {
+				result, err := compare(yml(`{"blob": "a"}`), yml(`{"blob": "b"}`),
+					dyff.ComparerForPath("/blob", dyff.ExecComparer("sh", "-c", `grep -q "path: /blob"`)),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeEmpty())
+			})
+
+			It("should use the rendered values of the command if the values are not equal", func() {
+				result, err := compare(yml(`{"blob": "a"}`), yml(`{"blob": "b"}`),
+					dyff.ComparerForPath("/blob", dyff.ExecComparer("sh", "-c", `printf 'from: decoded a\nto: decoded b\n'; exit 1`)),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(singleDiff("/blob", dyff.MODIFICATION, "decoded a", "decoded b")))
+			})
+
+			It("should use the original values if the command does not render them", func() {
+				result, err := compare(yml(`{"blob": "a"}`), yml(`{"blob": "b"}`),
+					dyff.ComparerForPath("/blob", dyff.ExecComparer("sh", "-c", "exit 1")),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+				Expect(result[0]).To(BeSameDiffAs(singleDiff("/blob", dyff.MODIFICATION, "a", "b")))
+			})
+
+			It("should fail if the command fails", func() {
+				_, err := compare(yml(`{"blob": "a"}`), yml(`{"blob": "b"}`),
+					dyff.ComparerForPath("/blob", dyff.ExecComparer("sh", "-c", "echo unsupported format >&2; exit 2")),
+				)
+
+				Expect(err).To(MatchError("comparer sh failed for path /blob: exit status 2: unsupported format"))
+			})
+		})
+
+		Context("tracing the comparison", func() {
+			It("should explain which identifier is used for a list", func() {
+				var messages []string
+				_, err := compare(
+					yml(`{"list": [{"id": "a", "value": 1}, {"id": "b", "value": 1}]}`),
+					yml(`{"list": [{"id": "a", "value": 2}, {"id": "b", "value": 1}]}`),
+					dyff.TraceHandler(func(path ytbx.Path, message string) {
+						messages = append(messages, fmt.Sprintf("%s: %s", path.String(), message))
+					}),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(messages).To(ContainElements(
+					"/list: matching list entries by the identifier id",
+					"/list/id=b: skipping the subtree, because it is identical in both files",
+				))
+			})
+
+			It("should explain ignored whitespace changes", func() {
+				var messages []string
+				result, err := compare(yml(`{"key": "value "}`), yml(`{"key": "value"}`),
+					dyff.IgnoreWhitespaceChanges(true),
+					dyff.TraceHandler(func(path ytbx.Path, message string) {
+						messages = append(messages, fmt.Sprintf("%s: %s", path.String(), message))
+					}),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeEmpty())
+				Expect(messages).To(ContainElement("/key: ignoring the change, because it only differs in leading or trailing whitespace"))
+			})
+		})
+
+		Context("comparing with a cache", func() {
+			It("should return the same differences as without cache for repeated comparisons", func() {
+				from := ytbx.InputFile{Location: "/ginkgo/compare/test/from", Documents: multiDoc(`{"foo": "bar"}`, `{"list": [1, 2]}`, `{"foo": "bar"}`)}
+				to := ytbx.InputFile{Location: "/ginkgo/compare/test/to", Documents: multiDoc(`{"foo": "baz"}`, `{"list": [1, 3]}`, `{"foo": "bar"}`)}
+				changed := ytbx.InputFile{Location: "/ginkgo/compare/test/to", Documents: append(to.Documents[:2:2], multiDoc(`{"foo": "qux"}`)...)}
+
+				cache := dyff.NewCache()
+				for _, input := range []ytbx.InputFile{to, changed, to} {
+					expected, err := dyff.CompareInputFiles(from, input, dyff.KubernetesEntityDetection(false))
+					Expect(err).ToNot(HaveOccurred())
+
+					report, err := dyff.CompareInputFiles(from, input, dyff.KubernetesEntityDetection(false), dyff.WithCache(cache))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(report.Diffs).To(HaveLen(len(expected.Diffs)))
+					for i := range expected.Diffs {
+						Expect(report.Diffs[i]).To(BeSameDiffAs(expected.Diffs[i]))
+						Expect(report.Diffs[i].Path.DocumentIdx).To(Equal(expected.Diffs[i].Path.DocumentIdx))
+					}
+				}
+			})
+		})
+
+		Context("inverting reports", func() {
+			It("should exchange from and to, as well as additions and removals", func() {
+				from := ytbx.InputFile{Location: "/ginkgo/compare/test/from", Documents: multiDoc(`---
+list: [a, b]
+map: {key: value, old: entry}
+`)}
+
+				to := ytbx.InputFile{Location: "/ginkgo/compare/test/to", Documents: multiDoc(`---
+list: [a, c]
+map: {key: other, new: entry}
+`)}
+
+				report, err := dyff.CompareInputFiles(from, to)
+				Expect(err).ToNot(HaveOccurred())
+
+				expected, err := dyff.CompareInputFiles(to, from)
+				Expect(err).ToNot(HaveOccurred())
+
+				inverted := report.Invert()
+				Expect(inverted.From.Location).To(Equal(to.Location))
+				Expect(inverted.To.Location).To(Equal(from.Location))
+				Expect(inverted.Diffs).To(HaveLen(len(expected.Diffs)))
+				for i := range expected.Diffs {
+					Expect(inverted.Diffs[i]).To(BeSameDiffAs(expected.Diffs[i]))
+				}
+			})
+			It("should refer to the documents of the new from input file", func() {
+				from := ytbx.InputFile{Location: "/ginkgo/compare/test/from", Documents: multiDoc(`---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: one}
+data: {key: foo}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: two}
+data: {key: bar}
+`)}
+
+				to := ytbx.InputFile{Location: "/ginkgo/compare/test/to", Documents: multiDoc(`---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: three}
+data: {key: baz}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: two}
+data: {key: other}
+`)}
+
+				report, err := dyff.CompareInputFiles(from, to)
+				Expect(err).ToNot(HaveOccurred())
+
+				expected, err := dyff.CompareInputFiles(to, from)
+				Expect(err).ToNot(HaveOccurred())
+
+				inverted := report.Invert()
+				Expect(inverted.Diffs).To(HaveLen(len(expected.Diffs)))
+
+				var descriptions, expectedDescriptions []string
+				for i := range inverted.Diffs {
+					if path := inverted.Diffs[i].Path; path != nil {
+						descriptions = append(descriptions, path.Root.Location+" "+path.RootDescription())
+					}
+
+					if path := expected.Diffs[i].Path; path != nil {
+						expectedDescriptions = append(expectedDescriptions, path.Root.Location+" "+path.RootDescription())
+					}
+				}
+
+				Expect(descriptions).To(ConsistOf(expectedDescriptions))
+				Expect(inverted.Diffs[0].Path.Root.Documents[inverted.Diffs[0].Path.DocumentIdx]).To(BeIdenticalTo(to.Documents[1]))
+			})
+		})
+
+		Context("severity rules", func() {
+			It("should use the severity of the first matching rule", func() {
+				rules, err := dyff.LoadSeverityRules([]byte(`---
+- path: ^/spec/replicas$
+  kind: modification
+  severity: critical
+- path: ^/spec/
+  severity: warning
+`))
+				Expect(err).ToNot(HaveOccurred())
+
+				report, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Documents: multiDoc("---\nspec: {replicas: 1, image: a}\nname: foo\n")},
+					ytbx.InputFile{Documents: multiDoc("---\nspec: {replicas: 2, image: b}\nname: bar\n")},
+				)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(report.Diffs).To(HaveLen(3))
+
+				Expect(rules.Severity(report.Diffs[0])).To(Equal(dyff.SeverityCritical))
+				Expect(rules.Severity(report.Diffs[1])).To(Equal(dyff.SeverityWarning))
+				Expect(rules.Severity(report.Diffs[2])).To(Equal(dyff.SeverityInfo))
+				Expect(rules.Highest(report)).To(Equal(dyff.SeverityCritical))
+			})
+
+			It("should fail to load invalid severity rules", func() {
+				_, err := dyff.LoadSeverityRules([]byte("---\n- path: ^/spec\n  severity: fatal\n"))
+				Expect(err).To(MatchError("unknown severity fatal, supported severities are: info, warning, or critical"))
+
+				_, err = dyff.LoadSeverityRules([]byte("---\n- path: ^/spec\n  kind: change\n  severity: info\n"))
+				Expect(err).To(MatchError(ContainSubstring("unknown kind of difference change")))
+			})
+		})
+
+		Context("building reports", func() {
+			It("should build the same report as a comparison", func() {
+				from := ytbx.InputFile{Location: "/ginkgo/compare/test/from", Documents: multiDoc(`---
+list:
+- name: one
+  value: 1
+map: {key: value}
+`)}
+
+				to := ytbx.InputFile{Location: "/ginkgo/compare/test/to", Documents: multiDoc(`---
+list:
+- name: one
+  value: 2
+map: {key: value, new: entry}
+`)}
+
+				expected, err := dyff.CompareInputFiles(from, to)
+				Expect(err).ToNot(HaveOccurred())
+
+				modification, err := dyff.NewDetail(dyff.MODIFICATION, yml("1"), yml("2"))
+				Expect(err).ToNot(HaveOccurred())
+
+				addition, err := dyff.NewDetail(dyff.ADDITION, nil, yml("new: entry"))
+				Expect(err).ToNot(HaveOccurred())
+
+				report, err := dyff.NewReportBuilder(from, to).
+					AddDiff(0, "list.one.value", modification).
+					AddDiff(0, "/map", addition).
+					Build()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(report.Diffs).To(HaveLen(len(expected.Diffs)))
+				for i := range expected.Diffs {
+					Expect(report.Diffs[i]).To(BeSameDiffAs(expected.Diffs[i]))
+				}
+
+				var expectedOut, out bytes.Buffer
+				Expect((&dyff.HumanReport{Report: expected, OmitHeader: true}).WriteReport(&expectedOut)).To(Succeed())
+				Expect((&dyff.HumanReport{Report: report, OmitHeader: true}).WriteReport(&out)).To(Succeed())
+				Expect(out.String()).To(Equal(expectedOut.String()))
+			})
+
+			It("should fail for invalid details", func() {
+				_, err := dyff.NewDetail(dyff.ADDITION, yml("foo: bar"), nil)
+				Expect(err).To(MatchError("an addition requires a to value, but no from value"))
+
+				_, err = dyff.NewDetail('?', nil, nil)
+				Expect(err).To(MatchError(`unknown kind of difference '?'`))
+			})
+
+			It("should fail for invalid paths and documents", func() {
+				from := ytbx.InputFile{Documents: multiDoc(`{"foo": "bar"}`)}
+				detail := dyff.Detail{Kind: dyff.MODIFICATION, From: yml("1")}
+
+				_, err := dyff.NewReportBuilder(from, from).AddDiff(1, "/foo", detail).Build()
+				Expect(err).To(MatchError("invalid difference at /foo: there is no document with index 1"))
+
+				_, err = dyff.NewReportBuilder(from, from).AddDiff(0, "/foo").Build()
+				Expect(err).To(MatchError("invalid difference at /foo: at least one detail is required"))
+			})
+		})
+
+		Context("ignoring order changes at specific paths", func() {
+			It("should compare the lists at matching paths as unordered sets", func() {
+				from := yml(`---
+spec:
+  containers:
+  - name: app
+    env: [A=1, B=2, C=3]
+    args: [--foo, --bar]
+`)
+				to := yml(`---
+spec:
+  containers:
+  - name: app
+    env: [C=3, A=1, B=3]
+    args: [--bar, --foo]
+`)
+
+				result, err := compare(from, to, dyff.IgnoreOrderChangesAt("/spec/containers/*/env"), dyff.ListAlgorithm(dyff.ListDiffLCS))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(2))
+				Expect(result[0].Path.String()).To(Equal("/spec/containers/name=app/env"))
+				Expect(result[0].Details).To(HaveLen(2))
+				for _, detail := range result[0].Details {
+					Expect(detail.Kind).To(BeElementOf(dyff.ADDITION, dyff.REMOVAL))
+				}
+
+				Expect(result[1].Path.String()).To(Equal("/spec/containers/name=app/args"))
+			})
+		})
+
+		Context("comparing numbers with a tolerance", func() {
+			It("should ignore numbers that differ by at most the tolerance", func() {
+				from := yml("---\nratio: 0.30000000000000004\nlimit: 1\nscale: 1.5\nname: foo\n")
+				to := yml("---\nratio: 0.3\nlimit: 1.0000001\nscale: 1.6\nname: bar\n")
+
+				result, err := compare(from, to, dyff.WithFloatTolerance(1e-6))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(2))
+				Expect(result[0]).To(BeSameDiffAs(singleDiff("/scale", dyff.MODIFICATION, 1.5, 1.6)))
+				Expect(result[1]).To(BeSameDiffAs(singleDiff("/name", dyff.MODIFICATION, "foo", "bar")))
+			})
+
+			It("should compare numbers exactly without a tolerance", func() {
+				from := yml("---\nratio: 0.30000000000000004\n")
+				to := yml("---\nratio: 0.3\n")
+
+				result, err := compare(from, to)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1))
+			})
+		})
+
+		Context("summarizing reports", func() {
+			It("should count the details of the differences per kind", func() {
+				report, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Documents: multiDoc("---\nname: foo\nlist: [a, b]\nmap: {a: 1}\n")},
+					ytbx.InputFile{Documents: multiDoc("---\nname: bar\nlist: [a, c]\nmap: {b: 1}\n")},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				summary := report.Summary()
+				Expect(summary.Differences).To(Equal(3))
+				Expect(summary.Count(dyff.ADDITION)).To(Equal(2))
+				Expect(summary.Count(dyff.REMOVAL)).To(Equal(2))
+				Expect(summary.Count(dyff.MODIFICATION)).To(Equal(1))
+				Expect(summary.Count(dyff.ORDERCHANGE)).To(Equal(0))
+				Expect(summary.OnlyKinds(dyff.ADDITION, dyff.REMOVAL)).To(BeFalse())
+				Expect(summary.OnlyKinds(dyff.ADDITION, dyff.REMOVAL, dyff.MODIFICATION)).To(BeTrue())
+			})
+		})
+
+		Context("merging reports", func() {
+			It("should combine the differences of multiple pairs of input files", func() {
+				first, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Location: "a/app.yml", Documents: multiDoc("---\nname: foo\n")},
+					ytbx.InputFile{Location: "b/app.yml", Documents: multiDoc("---\nname: bar\n")},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				second, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Location: "a/db.yml", Documents: multiDoc("---\nsize: 1\n---\nsize: 2\n")},
+					ytbx.InputFile{Location: "b/db.yml", Documents: multiDoc("---\nsize: 1\n---\nsize: 3\n")},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				report := first.Merge(second)
+				Expect(report.From.Location).To(Equal("a/app.yml, a/db.yml"))
+				Expect(report.To.Location).To(Equal("b/app.yml, b/db.yml"))
+				Expect(report.From.Documents).To(HaveLen(3))
+				Expect(report.To.Documents).To(HaveLen(3))
+
+				Expect(report.Diffs).To(HaveLen(2))
+				Expect(report.Diffs[0].Path.String()).To(Equal("/name"))
+				Expect(report.Diffs[0].Path.DocumentIdx).To(Equal(0))
+				Expect(report.Diffs[0].Path.RootDescription()).To(Equal("a/app.yml"))
+				Expect(report.Diffs[1].Path.String()).To(Equal("/size"))
+				Expect(report.Diffs[1].Path.Root).To(BeIdenticalTo(second.Diffs[0].Path.Root))
+				Expect(report.Diffs[1].Path.DocumentIdx).To(Equal(1))
+				Expect(report.Diffs[1].Path.RootDescription()).To(Equal("a/db.yml: document #2"))
+			})
+
+			It("should refer to added documents in their original to input file", func() {
+				first, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Location: "a/app.yml", Documents: multiDoc("---\nname: foo\n")},
+					ytbx.InputFile{Location: "b/app.yml", Documents: multiDoc("---\nname: foo\n")},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				second, err := dyff.CompareInputFiles(
+					ytbx.InputFile{Location: "a/k8s.yml", Documents: multiDoc("---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: one\n")},
+					ytbx.InputFile{Location: "b/k8s.yml", Documents: multiDoc("---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: one\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: two\n")},
+				)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(second.Diffs).To(HaveLen(1))
+
+				report := dyff.MergeReports(first, second)
+				Expect(report.Diffs).To(HaveLen(1))
+				Expect(report.Diffs[0].Path.Root).To(BeIdenticalTo(second.Diffs[0].Path.Root))
+				Expect(report.Diffs[0].Path.Root.Location).To(Equal("b/k8s.yml"))
+				Expect(report.Diffs[0].Path.DocumentIdx).To(Equal(1))
+				Expect(report.Diffs[0].Path.RootDescription()).To(Equal("b/k8s.yml: v1/ConfigMap/two"))
+			})
 		})
 
 		Context("change root for comparison", func() {
@@ -879,6 +1938,50 @@ b: bar
 					Expect(result).To(BeSameDiffAs(expected[i]))
 				}
 			})
+
+			It("should change the root to the common path of all differences", func() {
+				from := ytbx.InputFile{Location: "/ginkgo/compare/test/from", Documents: multiDoc(`---
+spec:
+  template:
+    metadata: {name: foo}
+    spec: {replicas: 1, image: foo:1}
+`)}
+
+				to := ytbx.InputFile{Location: "/ginkgo/compare/test/to", Documents: multiDoc(`---
+spec:
+  template:
+    metadata: {name: foo}
+    spec: {replicas: 2, image: foo:2}
+`)}
+
+				report, err := dyff.CompareInputFiles(from, to)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(report.CommonPath().String()).To(Equal("/spec/template/spec"))
+
+				report = report.AutoChangeRoot(false)
+				Expect(report.From.Note).To(Equal("YAML root was changed to spec.template.spec"))
+				Expect(report.To.Note).To(Equal("YAML root was changed to spec.template.spec"))
+
+				expected := []dyff.Diff{
+					singleDiff("/replicas", dyff.MODIFICATION, 1, 2),
+					singleDiff("/image", dyff.MODIFICATION, "foo:1", "foo:2"),
+				}
+
+				Expect(report.Diffs).To(HaveLen(len(expected)))
+				for i, result := range report.Diffs {
+					Expect(result).To(BeSameDiffAs(expected[i]))
+				}
+			})
+
+			It("should keep the report if the differences have no common path", func() {
+				from := ytbx.InputFile{Location: "/ginkgo/compare/test/from", Documents: multiDoc("---\na: {x: 1}\nb: {y: 1}\n")}
+				to := ytbx.InputFile{Location: "/ginkgo/compare/test/to", Documents: multiDoc("---\na: {x: 2}\nb: {y: 2}\n")}
+
+				report, err := dyff.CompareInputFiles(from, to)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(report.CommonPath()).To(BeNil())
+				Expect(report.AutoChangeRoot(false)).To(Equal(report))
+			})
 		})
 
 		Context("two YAML structures with Kubernetes lists", func() {
@@ -1027,6 +2130,40 @@ b: bar
 				Expect(results.Diffs).To(HaveLen(1))
 			})
 
+			It("should not return order change differences of the documents if document order changes are ignored", func() {
+				from, to, err := ytbx.LoadFiles(assets("issues", "issue-184", "from.yml"), assets("issues", "issue-184", "to.yml"))
+				Expect(err).To(BeNil())
+
+				results, err := dyff.CompareInputFiles(from, to, dyff.IgnoreDocumentOrderChanges(true))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(results.Diffs).To(BeEmpty())
+			})
+
+			It("should return the differences in document order regardless of the number of concurrent workers", func() {
+				var fromDocs, toDocs []string
+				for i := 0; i < 50; i++ {
+					fromDocs = append(fromDocs, fmt.Sprintf(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm-%d"}, "data": {"key": "foo"}}`, i))
+					toDocs = append(toDocs, fmt.Sprintf(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm-%d"}, "data": {"key": "bar"}}`, i))
+				}
+
+				from := ytbx.InputFile{Location: "/ginkgo/compare/test/from", Documents: multiDoc(fromDocs...)}
+				to := ytbx.InputFile{Location: "/ginkgo/compare/test/to", Documents: multiDoc(toDocs...)}
+
+				sequential, err := dyff.CompareInputFiles(from, to, dyff.Concurrency(1))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sequential.Diffs).To(HaveLen(50))
+
+				for _, kubernetes := range []bool{true, false} {
+					concurrent, err := dyff.CompareInputFiles(from, to, dyff.Concurrency(8), dyff.KubernetesEntityDetection(kubernetes))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(concurrent.Diffs).To(HaveLen(50))
+					for i := range concurrent.Diffs {
+						Expect(concurrent.Diffs[i].Path.DocumentIdx).To(Equal(i))
+						Expect(concurrent.Diffs[i]).To(BeSameDiffAs(sequential.Diffs[i]))
+					}
+				}
+			})
+
 			It("should report that a document was added", func() {
 				from := ytbx.InputFile{
 					Location: "/ginkgo/compare/test/from",
@@ -21,9 +21,16 @@
 package dyff
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
+	"math"
+	"regexp"
+	"runtime"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/gonvenience/bunt"
 	"github.com/gonvenience/idem"
@@ -40,14 +47,51 @@ type CompareOption func(*compareSettings)
 type compareSettings struct {
 	NonStandardIdentifierGuessCountThreshold int
 	IgnoreOrderChanges                       bool
+	IgnoreOrderPaths                         []*regexp.Regexp
 	IgnoreWhitespaceChanges                  bool
+	IgnoreTrailingNewlineChanges             bool
+	FloatTolerance                           float64
+	DetectMapOrderChanges                    bool
+	MaxCompareDepth                          int
+	DetectTypeChanges                        bool
+	DetectStyleChanges                       bool
+	CoerceTypes                              bool
+	PathMappings                             []pathMapping
 	KubernetesEntityDetection                bool
+	IgnoreDocumentOrderChanges               bool
 	DetectRenames                            bool
+	RenameThreshold                          int
+	Concurrency                              int
+	ListAlgorithm                            ListDiffAlgorithm
+	ProgressHandler                          func(Progress)
+	DifferenceHandler                        func(Diff) error
+	Cache                                    *Cache
+	PathComparers                            map[string]Comparer
+	PathRegexpComparers                      []regexpComparer
+	TagComparers                             map[string]Comparer
+	Visitor                                  Visitor
+	TraceHandler                             func(path ytbx.Path, message string)
 	AdditionalIdentifiers                    []string
+	IgnoredPaths                             map[string]struct{}
+	PathListIdentifiers                      map[string]string
+	PathRegexpListIdentifiers                []regexpListIdentifier
+	ListStreamWindow                         int
+
+	// pathResolvers register dot-style paths once the documents are known,
+	// since they can refer to named list entries
+	pathResolvers []func(settings *compareSettings, from []*yamlv3.Node, to []*yamlv3.Node)
 }
 
 type compare struct {
-	settings compareSettings
+	ctx       context.Context
+	settings  compareSettings
+	hashes    sync.Map
+	documents sync.Map
+
+	// subtree hashes and document differences of the previous comparison
+	// that used the same cache
+	previousHashes    *sync.Map
+	previousDocuments *sync.Map
 }
 
 // AdditionalIdentifiers specifies additional identifiers that will be
@@ -68,13 +112,52 @@ func NonStandardIdentifierGuessCountThreshold(nonStandardIdentifierGuessCountThr
 	}
 }
 
-// IgnoreOrderChanges disables the detection for changes of the order in lists
+// IgnoreOrderChanges disables the detection for changes of the order in lists,
+// which also skips the order preserving list diff algorithms and the lookup of
+// the common entries of both lists, since these are only needed for ordering
 func IgnoreOrderChanges(value bool) CompareOption {
 	return func(settings *compareSettings) {
 		settings.IgnoreOrderChanges = value
 	}
 }
 
+// IgnoreDocumentOrderChanges disables the detection for changes of the order
+// of the documents, which are matched as Kubernetes resources by their kind
+// and name, so that only the order of lists is compared
+func IgnoreDocumentOrderChanges(value bool) CompareOption {
+	return func(settings *compareSettings) {
+		settings.IgnoreDocumentOrderChanges = value
+	}
+}
+
+// IgnoreOrderChangesAt disables the detection for changes of the order only
+// for the lists and maps at the given go-patch style paths, where `*` matches
+// any one path element, e.g. `/spec/containers/*/env`. Lists at these paths
+// are compared as unordered sets, even if a list diff algorithm is configured.
+func IgnoreOrderChangesAt(pathPatterns ...string) CompareOption {
+	return func(settings *compareSettings) {
+		for _, pattern := range pathPatterns {
+			settings.IgnoreOrderPaths = append(settings.IgnoreOrderPaths, pathPatternRegexp(pattern))
+		}
+	}
+}
+
+// pathPatternRegexp returns the regular expression for a go-patch style path
+// with `*` as a placeholder for any one path element
+func pathPatternRegexp(pattern string) *regexp.Regexp {
+	elements := strings.Split(strings.TrimSuffix(pattern, "/"), "/")
+	for i, element := range elements {
+		if element == "*" {
+			elements[i] = "[^/]+"
+			continue
+		}
+
+		elements[i] = regexp.QuoteMeta(element)
+	}
+
+	return regexp.MustCompile("^" + strings.Join(elements, "/") + "$")
+}
+
 // IgnoreWhitespaceChanges disables the detection for whitespace only changes
 func IgnoreWhitespaceChanges(value bool) CompareOption {
 	return func(settings *compareSettings) {
@@ -82,6 +165,69 @@ func IgnoreWhitespaceChanges(value bool) CompareOption {
 	}
 }
 
+// MaxCompareDepth limits the depth of the comparison, where a changed map or
+// list at the given depth is reported as one changed subtree instead of the
+// individual differences inside of it, zero means no limit
+func MaxCompareDepth(depth int) CompareOption {
+	return func(settings *compareSettings) {
+		settings.MaxCompareDepth = depth
+	}
+}
+
+// DetectTypeChanges enables reporting values whose type changed, e.g. from a
+// string to an integer or from a scalar to a map, as a type change instead of
+// a modification
+func DetectTypeChanges(value bool) CompareOption {
+	return func(settings *compareSettings) {
+		settings.DetectTypeChanges = value
+	}
+}
+
+// DetectStyleChanges enables reporting changes that are purely stylistic as
+// a style change instead of a modification, i.e. values that are the same,
+// but use a different quoting, flow or block style, or literal or folded
+// style. Strings with a different content, even if it is only whitespace,
+// are still reported as a modification.
+func DetectStyleChanges(value bool) CompareOption {
+	return func(settings *compareSettings) {
+		settings.DetectStyleChanges = value
+	}
+}
+
+// CoerceTypes enables reporting values of different types that are the same
+// after coercion, e.g. `"10"` and `10`, as a representation change instead of
+// a modification
+func CoerceTypes(value bool) CompareOption {
+	return func(settings *compareSettings) {
+		settings.CoerceTypes = value
+	}
+}
+
+// DetectMapOrderChanges enables the detection of maps that have the same keys,
+// but in a different order, which are reported as an order change of the keys
+func DetectMapOrderChanges(value bool) CompareOption {
+	return func(settings *compareSettings) {
+		settings.DetectMapOrderChanges = value
+	}
+}
+
+// IgnoreTrailingNewlineChanges disables the detection for strings that only
+// differ in trailing newlines, e.g. when one side uses a literal block scalar
+func IgnoreTrailingNewlineChanges(value bool) CompareOption {
+	return func(settings *compareSettings) {
+		settings.IgnoreTrailingNewlineChanges = value
+	}
+}
+
+// WithFloatTolerance treats numbers that differ by at most the given tolerance
+// as equal, e.g. to ignore the noise of floating point calculations in
+// generated files, zero means that numbers have to be exactly the same
+func WithFloatTolerance(epsilon float64) CompareOption {
+	return func(settings *compareSettings) {
+		settings.FloatTolerance = epsilon
+	}
+}
+
 // KubernetesEntityDetection enabled detecting entity identifiers from Kubernetes "kind:" and "metadata:" fields.
 func KubernetesEntityDetection(value bool) CompareOption {
 	return func(settings *compareSettings) {
@@ -96,23 +242,269 @@ func DetectRenames(value bool) CompareOption {
 	}
 }
 
+// RenameThreshold sets the minimum similarity in percent between a removed
+// and an added document for them to be considered a rename, e.g. a resource
+// that moved to another namespace or name, which is then reported as a rename
+// followed by the remaining field differences instead of as a removal and
+// addition
+func RenameThreshold(percent int) CompareOption {
+	return func(settings *compareSettings) {
+		settings.RenameThreshold = percent
+	}
+}
+
+// Concurrency specifies how many pairs of documents are compared at the same
+// time, with zero or less meaning to use the number of available CPUs
+func Concurrency(workers int) CompareOption {
+	return func(settings *compareSettings) {
+		settings.Concurrency = workers
+	}
+}
+
+// Progress describes how far a comparison has come
+type Progress struct {
+	// Documents is the number of documents compared so far
+	Documents int
+
+	// Total is the number of documents to compare, or zero if it is unknown
+	Total int
+
+	// Path refers to the document that was compared last
+	Path *ytbx.Path
+}
+
+// ProgressHandler specifies a function that is called each time a document
+// was compared, calls are never made concurrently
+func ProgressHandler(handler func(Progress)) CompareOption {
+	return func(settings *compareSettings) {
+		settings.ProgressHandler = handler
+	}
+}
+
+// DifferenceHandler specifies a function that is called with each difference
+// as soon as it is known, so that it can be processed before the comparison of
+// all documents is done. The differences are passed in the order of the report
+// and calls are never made concurrently. An error of the handler stops the
+// comparison and is returned. Streams pass differences to their own handler.
+func DifferenceHandler(handler func(Diff) error) CompareOption {
+	return func(settings *compareSettings) {
+		settings.DifferenceHandler = handler
+	}
+}
+
+// Cache keeps subtree hashes and the differences of compared documents
+// between comparisons, so that comparing the same input files again after some
+// documents changed only needs to compare the documents that actually changed.
+// Everything that was not used by the last comparison is dropped, so the
+// cache does not grow over time. The hashes are tied to the parsed nodes,
+// which means input files that did not change should not be parsed again.
+// A cache must not be used by multiple comparisons at the same time and
+// only works as expected if the same compare options are used every time.
+type Cache struct {
+	mutex     sync.Mutex
+	hashes    *sync.Map
+	documents *sync.Map
+}
+
+// NewCache creates a new empty cache for comparisons
+func NewCache() *Cache {
+	return &Cache{}
+}
+
+// WithCache specifies a cache that is shared between comparisons
+func WithCache(cache *Cache) CompareOption {
+	return func(settings *compareSettings) {
+		settings.Cache = cache
+	}
+}
+
+// TraceHandler specifies a function that is called with an explanation for
+// each decision of the comparison, e.g. which identifier is used to match the
+// entries of a list. Since multiple documents can be compared at the same
+// time, calls can be made concurrently.
+func TraceHandler(handler func(path ytbx.Path, message string)) CompareOption {
+	return func(settings *compareSettings) {
+		settings.TraceHandler = handler
+	}
+}
+
+// IgnorePaths excludes the given paths from the comparison, which can be
+// specified in dot-style or go-patch style. Other than filtering the report
+// afterwards, this also ignores map entries that only exist on one side.
+func IgnorePaths(pathStrings ...string) CompareOption {
+	return func(settings *compareSettings) {
+		if settings.IgnoredPaths == nil {
+			settings.IgnoredPaths = map[string]struct{}{}
+		}
+
+		for _, pathString := range pathStrings {
+			settings.IgnoredPaths[normalizedPathString(pathString)] = struct{}{}
+			settings.onResolvedPath(pathString, func(settings *compareSettings, resolved string) {
+				settings.IgnoredPaths[resolved] = struct{}{}
+			})
+		}
+	}
+}
+
+// MapPath declares that the map entry at the from path was renamed to the
+// to path, which can be specified in dot-style or go-patch style. The two
+// subtrees are compared against each other instead of reporting a removal and
+// an addition, in case the from path only exists in the from document and
+// the to path only exists in the to document.
+func MapPath(fromPathString string, toPathString string) CompareOption {
+	return func(settings *compareSettings) {
+		fromPath, fromErr := ytbx.ParsePathStringUnsafe(fromPathString)
+		toPath, toErr := ytbx.ParsePathStringUnsafe(toPathString)
+		if fromErr != nil || toErr != nil || len(fromPath.PathElements) == 0 || len(toPath.PathElements) == 0 {
+			return
+		}
+
+		settings.PathMappings = append(settings.PathMappings, pathMapping{from: fromPath, to: toPath})
+		settings.pathResolvers = append(settings.pathResolvers, func(settings *compareSettings, from []*yamlv3.Node, to []*yamlv3.Node) {
+			resolvedFrom, resolvedTo := resolvePath(fromPathString, from), resolvePath(toPathString, to)
+			if resolvedFrom == nil && resolvedTo == nil {
+				return
+			}
+
+			var mapping = pathMapping{from: fromPath, to: toPath}
+			if resolvedFrom != nil {
+				mapping.from = *resolvedFrom
+			}
+
+			if resolvedTo != nil {
+				mapping.to = *resolvedTo
+			}
+
+			for _, existing := range settings.PathMappings {
+				if existing.from.String() == mapping.from.String() && existing.to.String() == mapping.to.String() {
+					return
+				}
+			}
+
+			settings.PathMappings = append(settings.PathMappings, mapping)
+		})
+	}
+}
+
+// ListIdentifierForPath specifies the field that identifies the entries of
+// the list at the given path, which can be specified in dot-style or go-patch
+// style. Go-patch style paths can use `*` to match any one path element, e.g.
+// `/spec/containers/*/ports`. If not all entries have a unique value for the
+// field, the identifier is detected like for any other list.
+func ListIdentifierForPath(pathString string, field string) CompareOption {
+	return func(settings *compareSettings) {
+		if strings.HasPrefix(pathString, "/") && slices.Contains(strings.Split(pathString, "/"), "*") {
+			settings.PathRegexpListIdentifiers = append(settings.PathRegexpListIdentifiers, regexpListIdentifier{pathPatternRegexp(pathString), []string{field}})
+			return
+		}
+
+		if settings.PathListIdentifiers == nil {
+			settings.PathListIdentifiers = map[string]string{}
+		}
+
+		settings.PathListIdentifiers[normalizedPathString(pathString)] = field
+		settings.onResolvedPath(pathString, func(settings *compareSettings, resolved string) {
+			settings.PathListIdentifiers[resolved] = field
+		})
+	}
+}
+
+// normalizedPathString returns the go-patch style representation of the path
+// string without looking at any document, which means dot-style path elements
+// are assumed to be map keys or list indices
+func normalizedPathString(pathString string) string {
+	if path, err := ytbx.ParsePathStringUnsafe(pathString); err == nil {
+		return path.String()
+	}
+
+	return pathString
+}
+
+// onResolvedPath calls the register function with the go-patch style
+// representation of a dot-style path once it is resolved using the documents
+// to compare, which is required for paths with named list entries, e.g.
+// `spec.containers.web.image` for `/spec/containers/name=web/image`
+func (settings *compareSettings) onResolvedPath(pathString string, register func(settings *compareSettings, resolved string)) {
+	if strings.HasPrefix(pathString, "/") {
+		return
+	}
+
+	settings.pathResolvers = append(settings.pathResolvers, func(settings *compareSettings, from []*yamlv3.Node, to []*yamlv3.Node) {
+		for _, documents := range [][]*yamlv3.Node{from, to} {
+			if path := resolvePath(pathString, documents); path != nil {
+				register(settings, path.String())
+			}
+		}
+	})
+}
+
+// resolvePath returns the dot-style path resolved using the first of the
+// documents it can be resolved in, or nil if it is not a dot-style path or
+// does not refer to a named list entry in any of the documents
+func resolvePath(pathString string, documents []*yamlv3.Node) *ytbx.Path {
+	if strings.HasPrefix(pathString, "/") {
+		return nil
+	}
+
+	for _, document := range documents {
+		if document == nil || isEmptyDocument(document) || (document.Kind == yamlv3.DocumentNode && len(document.Content) == 0) {
+			continue
+		}
+
+		if document.Kind != yamlv3.DocumentNode {
+			document = &yamlv3.Node{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{document}}
+		}
+
+		path, err := ytbx.ParseDotStylePathString(pathString, document)
+		if err != nil {
+			continue
+		}
+
+		if path.String() != normalizedPathString(pathString) {
+			return &path
+		}
+	}
+
+	return nil
+}
+
+// resolvePaths registers the dot-style paths of the settings using the
+// documents to be compared, which has to be done before comparing them
+func (compare *compare) resolvePaths(from []*yamlv3.Node, to []*yamlv3.Node) {
+	for _, resolver := range compare.settings.pathResolvers {
+		resolver(&compare.settings, from, to)
+	}
+}
+
+// ListIdentifierForPathRegexp specifies the fields that identify the entries
+// of all lists with a path matching the regular expression, which is matched
+// against the go-patch style path. Each entry is identified by the first of
+// the fields it has, e.g. `alert` or `record` for Prometheus rules. If not all
+// entries have a unique value, the identifier is detected like for any other
+// list.
+func ListIdentifierForPathRegexp(pattern *regexp.Regexp, fields ...string) CompareOption {
+	return func(settings *compareSettings) {
+		settings.PathRegexpListIdentifiers = append(settings.PathRegexpListIdentifiers, regexpListIdentifier{pattern, fields})
+	}
+}
+
+type regexpListIdentifier struct {
+	pattern *regexp.Regexp
+	fields  []string
+}
+
 // CompareInputFiles is one of the convenience main entry points for comparing
 // objects. In this case the representation of an input file, which might
 // contain multiple documents. It returns a report with the list of differences.
 func CompareInputFiles(from ytbx.InputFile, to ytbx.InputFile, compareOptions ...CompareOption) (Report, error) {
-	// initialize the comparator with the tool defaults
-	cmpr := compare{
-		settings: compareSettings{
-			NonStandardIdentifierGuessCountThreshold: 3,
-			IgnoreOrderChanges:                       false,
-			KubernetesEntityDetection:                true,
-		},
-	}
+	return CompareInputFilesContext(context.Background(), from, to, compareOptions...)
+}
 
-	// apply the optional compare options provided to this function call
-	for _, compareOption := range compareOptions {
-		compareOption(&cmpr.settings)
-	}
+// CompareInputFilesContext is like CompareInputFiles, but stops the comparison
+// with the error of the context as soon as the context is done.
+func CompareInputFilesContext(ctx context.Context, from ytbx.InputFile, to ytbx.InputFile, compareOptions ...CompareOption) (Report, error) {
+	cmpr := newCompare(ctx, compareOptions...)
+	cmpr.resolvePaths(from.Documents, to.Documents)
 
 	// in case Kubernetes mode is enabled, try to compare documents in the YAML
 	// file by their names rather than just by the order of the documents
@@ -146,12 +538,14 @@ func CompareInputFiles(from ytbx.InputFile, to ytbx.InputFile, compareOptions ..
 			from.Documents, from.Names = fromDocs, fromNames
 			to.Documents, to.Names = toDocs, toNames
 
+			cmpr.trace(ytbx.Path{Root: &from}, "matching documents as Kubernetes resources by their kind and name")
+
 			// Compare the document nodes
 			result, err := cmpr.documentNodes(from, to)
 			if err != nil {
 				return Report{}, fmt.Errorf("comparing Kubernetes resources: %w", err)
 			}
-			return Report{from, to, result}, nil
+			return Report{from, to, cmpr.differences(result)}, nil
 		}
 	}
 
@@ -159,28 +553,220 @@ func CompareInputFiles(from ytbx.InputFile, to ytbx.InputFile, compareOptions ..
 		return Report{}, fmt.Errorf("comparing YAMLs with a different number of documents is currently not supported")
 	}
 
-	var result []Diff
+	var pairs = make([]documentPair, len(from.Documents))
 	for idx := range from.Documents {
-		diffs, err := cmpr.objects(
-			ytbx.Path{
+		pairs[idx] = documentPair{
+			path: ytbx.Path{
 				Root:        &from,
 				DocumentIdx: idx,
 			},
-			from.Documents[idx],
-			to.Documents[idx],
-		)
+			from: from.Documents[idx],
+			to:   to.Documents[idx],
+		}
+	}
 
-		if err != nil {
-			return Report{}, err
+	result, err := cmpr.documentPairs(pairs)
+	if err != nil {
+		return Report{}, err
+	}
+
+	return Report{from, to, cmpr.differences(result)}, nil
+}
+
+func newCompare(ctx context.Context, compareOptions ...CompareOption) *compare {
+	// initialize the comparator with the tool defaults
+	cmpr := compare{
+		ctx: ctx,
+		settings: compareSettings{
+			NonStandardIdentifierGuessCountThreshold: 3,
+			IgnoreOrderChanges:                       false,
+			KubernetesEntityDetection:                true,
+			RenameThreshold:                          int(idem.DefaultDetectOptions.RenameScore),
+		},
+	}
+
+	// apply the optional compare options provided to this function call
+	for _, compareOption := range compareOptions {
+		compareOption(&cmpr.settings)
+	}
+
+	// use the entries of the previous comparison and make this comparison
+	// the one whose entries are used next time
+	if cache := cmpr.settings.Cache; cache != nil {
+		cache.mutex.Lock()
+		cmpr.previousHashes, cmpr.previousDocuments = cache.hashes, cache.documents
+		cache.hashes, cache.documents = &cmpr.hashes, &cmpr.documents
+		cache.mutex.Unlock()
+	}
+
+	return &cmpr
+}
+
+type documentPair struct {
+	path ytbx.Path
+	from *yamlv3.Node
+	to   *yamlv3.Node
+}
+
+// documentPairs compares the pairs of documents using a bounded number of
+// concurrent workers, the differences are returned in the order of the pairs
+func (compare *compare) documentPairs(pairs []documentPair) ([]Diff, error) {
+	var workers = compare.settings.Concurrency
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	var results = make([][]Diff, len(pairs))
+	var errs = make([]error, len(pairs))
+	var finished = make([]bool, len(pairs))
+
+	var mutex sync.Mutex
+	var done, next int
+	var handlerErr error
+	var complete = func(idx int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		done++
+		if compare.settings.ProgressHandler != nil {
+			compare.settings.ProgressHandler(Progress{Documents: done, Total: len(pairs), Path: &pairs[idx].path})
 		}
 
-		result = append(result, diffs...)
+		// Pass on the differences of all documents that are finished and have
+		// no unfinished document before them, to keep the order of the report
+		finished[idx] = true
+		for ; next < len(pairs) && finished[next] && errs[next] == nil && handlerErr == nil; next++ {
+			handlerErr = compare.emit(results[next])
+		}
+	}
+
+	var stopped = func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return handlerErr != nil
+	}
+
+	var wg sync.WaitGroup
+	var queue = make(chan int)
+	for i := 0; i < min(workers, len(pairs)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range queue {
+				if !stopped() {
+					results[idx], errs[idx] = compare.documentPair(pairs[idx])
+				}
+
+				complete(idx)
+			}
+		}()
+	}
+
+	for idx := range pairs {
+		queue <- idx
+	}
+
+	close(queue)
+	wg.Wait()
+
+	if handlerErr != nil {
+		return nil, handlerErr
 	}
 
-	return Report{from, to, result}, nil
+	var result []Diff
+	for idx := range pairs {
+		if errs[idx] != nil {
+			return nil, errs[idx]
+		}
+
+		result = append(result, results[idx]...)
+	}
+
+	return result, nil
+}
+
+// documentPair compares a pair of documents, in case a cache is configured,
+// the differences of a pair with the same content are used instead
+func (compare *compare) documentPair(pair documentPair) ([]Diff, error) {
+	if compare.settings.Cache == nil || pair.from == nil || pair.to == nil {
+		return compare.documentObjects(pair)
+	}
+
+	key := [2][sha256.Size]byte{compare.subtreeHash(pair.from), compare.subtreeHash(pair.to)}
+	for _, documents := range []*sync.Map{&compare.documents, compare.previousDocuments} {
+		if documents == nil {
+			continue
+		}
+
+		if cached, ok := documents.Load(key); ok {
+			compare.trace(pair.path, "using the differences of the previous comparison, because the documents did not change")
+			if compare.settings.Visitor != nil {
+				compare.settings.Visitor.Skip(pair.path, pair.from, pair.to, SkipCached)
+			}
+
+			compare.documents.Store(key, cached)
+			return rebaseDiffs(cached.([]Diff), pair.path), nil
+		}
+	}
+
+	result, err := compare.documentObjects(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	compare.documents.Store(key, result)
+	return result, nil
+}
+
+// rebaseDiffs returns a copy of the differences, where the paths refer to
+// the root and document of the given path
+func rebaseDiffs(diffs []Diff, path ytbx.Path) []Diff {
+	var result = make([]Diff, len(diffs))
+	for i, diff := range diffs {
+		rebased := *diff.Path
+		rebased.Root = path.Root
+		rebased.DocumentIdx = path.DocumentIdx
+		result[i] = Diff{Path: &rebased, Details: diff.Details}
+	}
+
+	return result
 }
 
 func (compare *compare) objects(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Diff, error) {
+	if compare.ctx != nil {
+		select {
+		case <-compare.ctx.Done():
+			return nil, compare.ctx.Err()
+
+		default:
+		}
+	}
+
+	if compare.isIgnored(path) {
+		compare.trace(path, "ignoring the path as configured")
+		compare.skipIgnored(path, from, to)
+		return nil, nil
+	}
+
+	if compare.settings.Visitor != nil {
+		compare.settings.Visitor.Enter(path, from, to)
+	}
+
+	// Consult custom comparers first, which take precedence over the default
+	if from != nil && to != nil {
+		if result, handled, err := compare.customComparison(path, from, to); handled || err != nil {
+			if handled {
+				compare.trace(path, "compared using a custom comparer")
+			}
+
+			if handled && compare.settings.Visitor != nil {
+				compare.settings.Visitor.Skip(path, from, to, SkipCustomComparer)
+			}
+
+			return result, err
+		}
+	}
+
 	switch {
 	case from == nil && to == nil:
 		return []Diff{}, nil
@@ -195,15 +781,74 @@ func (compare *compare) objects(path ytbx.Path, from *yamlv3.Node, to *yamlv3.No
 			}},
 		}}, nil
 
+	case from.Value != to.Value && isWithinTolerance(from, to, compare.settings.FloatTolerance):
+		compare.trace(path, "ignoring the change, because the numbers differ by at most the float tolerance")
+		return []Diff{}, nil
+
+	case compare.settings.CoerceTypes && isRepresentationChange(from, to):
+		compare.trace(path, "reporting a representation change, because the values are the same after coercion")
+		return []Diff{{
+			&path,
+			[]Detail{{
+				Kind: REPRESENTATIONCHANGE,
+				From: from,
+				To:   to,
+			}},
+		}}, nil
+
 	case (from.Kind != to.Kind) || (from.Tag != to.Tag):
+		kind := MODIFICATION
+		if compare.settings.DetectTypeChanges {
+			kind = TYPECHANGE
+		}
+
 		return []Diff{{
 			&path,
 			[]Detail{{
-				Kind: MODIFICATION,
+				Kind: kind,
 				From: from,
 				To:   to,
 			}},
 		}}, nil
+
+	case compare.settings.DetectStyleChanges && styleName(from) != styleName(to) && isSameContent(from, to):
+		compare.trace(path, "reporting a style change, because only the style of the value differs")
+		return []Diff{{
+			&path,
+			[]Detail{{
+				Kind: STYLECHANGE,
+				From: from,
+				To:   to,
+			}},
+		}}, nil
+	}
+
+	// Skip subtrees that are identical on both sides
+	if from.Kind == yamlv3.MappingNode || from.Kind == yamlv3.SequenceNode {
+		if compare.subtreeHash(from) == compare.subtreeHash(to) {
+			compare.trace(path, "skipping the subtree, because it is identical in both files")
+			if compare.settings.Visitor != nil {
+				compare.settings.Visitor.Skip(path, from, to, SkipIdentical)
+			}
+
+			return []Diff{}, nil
+		}
+
+		if compare.settings.MaxCompareDepth > 0 && len(path.PathElements) >= compare.settings.MaxCompareDepth {
+			compare.trace(path, "reporting the subtree as changed, because it is at the configured maximum depth")
+			if compare.settings.Visitor != nil {
+				compare.settings.Visitor.Skip(path, from, to, SkipMaxDepth)
+			}
+
+			return []Diff{{
+				&path,
+				[]Detail{{
+					Kind: MODIFICATION,
+					From: from,
+					To:   to,
+				}},
+			}}, nil
+		}
 	}
 
 	return compare.nonNilSameKindNodes(path, from, to)
@@ -296,27 +941,31 @@ func (compare *compare) documentNodes(from, to ytbx.InputFile) ([]Diff, error) {
 
 	var removals []doc
 	var additions []doc
+	var pairs []documentPair
 
 	for _, name := range fromNames {
 		var fromItem = fromLookUpMap[name]
 		if toItem, ok := toLookUpMap[name]; ok {
 			// `from` and `to` contain the same `key` -> require comparison
-			diffs, err := compare.objects(
-				ytbx.Path{Root: &from, DocumentIdx: fromItem.idx},
-				followAlias(fromItem.node),
-				followAlias(toItem.node),
-			)
-			if err != nil {
-				return nil, err
-			}
-
-			result = append(result, diffs...)
+			pairs = append(pairs, documentPair{
+				path: ytbx.Path{Root: &from, DocumentIdx: fromItem.idx},
+				from: followAlias(fromItem.node),
+				to:   followAlias(toItem.node),
+			})
 		} else {
 			// `from` contain the `key`, but `to` does not -> removal
 			removals = append(removals, fromItem)
 		}
 	}
 
+	result, err = compare.documentPairs(pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The differences of the pairs were already passed to the handler
+	var emitted = len(result)
+
 	for _, name := range toNames {
 		var toItem = toLookUpMap[name]
 		if _, ok := fromLookUpMap[name]; !ok {
@@ -348,13 +997,21 @@ func (compare *compare) documentNodes(from, to ytbx.InputFile) ([]Diff, error) {
 	)
 
 	if compare.settings.DetectRenames {
-		if err := idem.DetectRenames(changes, nil); err != nil {
+		if compare.settings.RenameThreshold < 0 || compare.settings.RenameThreshold > 100 {
+			return nil, fmt.Errorf("invalid rename threshold %d, expected a percentage between 0 and 100", compare.settings.RenameThreshold)
+		}
+
+		if err := idem.DetectRenames(changes, &idem.DetectOptions{
+			RenameScore: uint(compare.settings.RenameThreshold),
+			RenameLimit: idem.DefaultDetectOptions.RenameLimit,
+		}); err != nil {
 			return nil, err
 		}
 	}
 
 	// Push rename detection results
 	for _, modified := range changes.ModifiedPairs() {
+		compare.trace(*modified.To.Path, fmt.Sprintf("treating document %s as renamed to %s", modified.From.Name(), modified.To.Name()))
 		diffs, err := compare.objects(
 			*modified.To.Path,
 			followAlias(modified.From.Doc),
@@ -363,6 +1020,17 @@ func (compare *compare) documentNodes(from, to ytbx.InputFile) ([]Diff, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		// The rename itself is reported first, followed by the remaining
+		// differences of the renamed or moved document
+		result = append(result, Diff{
+			Path: &ytbx.Path{Root: modified.To.Path.Root, DocumentIdx: modified.To.Path.DocumentIdx},
+			Details: []Detail{{
+				Kind: RENAME,
+				From: &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: modified.From.Name()},
+				To:   &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: modified.To.Name()},
+			}},
+		})
 		result = append(result, diffs...)
 
 		// Exclude from order change calculation
@@ -398,7 +1066,7 @@ func (compare *compare) documentNodes(from, to ytbx.InputFile) ([]Diff, error) {
 		})
 	}
 
-	if !compare.settings.IgnoreOrderChanges && len(fromNames) == len(toNames) {
+	if !compare.settings.IgnoreOrderChanges && !compare.settings.IgnoreDocumentOrderChanges && len(fromNames) == len(toNames) {
 		for i := range fromNames {
 			if fromNames[i] != toNames[i] {
 				result = append(result, Diff{
@@ -413,9 +1081,72 @@ func (compare *compare) documentNodes(from, to ytbx.InputFile) ([]Diff, error) {
 		}
 	}
 
+	if err := compare.emit(result[emitted:]); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
+// emit passes the differences to the difference handler, if one is set
+func (compare *compare) emit(diffs []Diff) error {
+	if compare.settings.DifferenceHandler == nil {
+		return nil
+	}
+
+	for _, diff := range diffs {
+		if err := compare.settings.DifferenceHandler(diff); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// trace explains a decision of the comparison, if a trace handler is set
+func (compare *compare) trace(path ytbx.Path, message string) {
+	if compare.settings.TraceHandler != nil {
+		compare.settings.TraceHandler(path, message)
+	}
+}
+
+// ignoresOrderChanges returns whether order changes of the list or map at the
+// path are ignored, either for all paths or for this path specifically
+func (compare *compare) ignoresOrderChanges(path ytbx.Path) bool {
+	if compare.settings.IgnoreOrderChanges {
+		return true
+	}
+
+	if len(compare.settings.IgnoreOrderPaths) == 0 {
+		return false
+	}
+
+	pathString := path.String()
+	for _, pattern := range compare.settings.IgnoreOrderPaths {
+		if pattern.MatchString(pathString) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (compare *compare) isIgnored(path ytbx.Path) bool {
+	if len(compare.settings.IgnoredPaths) == 0 {
+		return false
+	}
+
+	_, ok := compare.settings.IgnoredPaths[path.String()]
+	return ok
+}
+
+// skipIgnored notifies the visitor about nodes at an ignored path
+func (compare *compare) skipIgnored(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) {
+	if compare.settings.Visitor != nil {
+		compare.settings.Visitor.Skip(path, from, to, SkipIgnored)
+	}
+}
+
 func (compare *compare) mappingNodes(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Diff, error) {
 	result := make([]Diff, 0)
 	removals := []*yamlv3.Node{}
@@ -437,6 +1168,9 @@ func (compare *compare) mappingNodes(path ytbx.Path, from *yamlv3.Node, to *yaml
 
 			result = append(result, diffs...)
 
+		} else if keyPath := ytbx.NewPathWithNamedElement(path, key.Value); compare.isIgnored(keyPath) {
+			compare.skipIgnored(keyPath, fromItem, nil)
+
 		} else {
 			// `from` contain the `key`, but `to` does not -> removal
 			removals = append(removals, key, fromItem)
@@ -445,7 +1179,14 @@ func (compare *compare) mappingNodes(path ytbx.Path, from *yamlv3.Node, to *yaml
 
 	for i := 0; i < len(to.Content); i += 2 {
 		key, toItem := to.Content[i], to.Content[i+1]
-		if _, ok := findValueByKey(from, key.Value); !ok {
+		if _, ok := findValueByKey(from, key.Value); ok {
+			continue
+		}
+
+		if keyPath := ytbx.NewPathWithNamedElement(path, key.Value); compare.isIgnored(keyPath) {
+			compare.skipIgnored(keyPath, nil, toItem)
+
+		} else {
 			// `to` contains a `key` that `from` does not have -> addition
 			additions = append(additions, key, toItem)
 		}
@@ -453,6 +1194,12 @@ func (compare *compare) mappingNodes(path ytbx.Path, from *yamlv3.Node, to *yaml
 
 	diff := Diff{Path: &path, Details: []Detail{}}
 
+	if len(removals) == 0 && len(additions) == 0 {
+		if orderChange, ok := compare.mapOrderChange(path, from, to); ok {
+			diff.Details = append(diff.Details, orderChange)
+		}
+	}
+
 	if len(removals) > 0 {
 		diff.Details = append(diff.Details,
 			Detail{
@@ -488,28 +1235,91 @@ func (compare *compare) mappingNodes(path ytbx.Path, from *yamlv3.Node, to *yaml
 	return result, nil
 }
 
+// mapOrderChange returns an order change of the keys of two maps with the same
+// keys, if detecting these is configured and the order of the keys differs
+func (compare *compare) mapOrderChange(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) (Detail, bool) {
+	if !compare.settings.DetectMapOrderChanges {
+		return Detail{}, false
+	}
+
+	if compare.ignoresOrderChanges(path) {
+		compare.trace(path, "not looking for order changes of map keys, because ignoring order changes is configured")
+		return Detail{}, false
+	}
+
+	keys := func(mappingNode *yamlv3.Node) []string {
+		var result []string
+		for i := 0; i < len(mappingNode.Content); i += 2 {
+			if key := mappingNode.Content[i].Value; !compare.isIgnored(ytbx.NewPathWithNamedElement(path, key)) {
+				result = append(result, key)
+			}
+		}
+
+		return result
+	}
+
+	fromKeys, toKeys := keys(from), keys(to)
+	if slices.Equal(fromKeys, toKeys) {
+		return Detail{}, false
+	}
+
+	return Detail{
+		Kind: ORDERCHANGE,
+		From: AsSequenceNode(fromKeys...),
+		To:   AsSequenceNode(toKeys...),
+	}, true
+}
+
 func (compare *compare) sequenceNodes(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Diff, error) {
 	// Bail out quickly if there is nothing to check
 	if len(from.Content) == 0 && len(to.Content) == 0 {
 		return []Diff{}, nil
 	}
 
+	// check if an identifier is configured for the path
+	if field, ok := compare.settings.PathListIdentifiers[path.String()]; ok {
+		if hasUniqueField(from, field) && hasUniqueField(to, field) {
+			compare.trace(path, fmt.Sprintf("matching list entries by the identifier %s, which is configured for the path", field))
+			return compare.namedEntryLists(path, &singleField{field}, from, to)
+		}
+
+		compare.trace(path, fmt.Sprintf("not using the configured identifier %s, because not all entries have a unique value for it", field))
+	}
+
+	for _, entry := range compare.settings.PathRegexpListIdentifiers {
+		if !entry.pattern.MatchString(path.String()) {
+			continue
+		}
+
+		identifier := &firstField{entry.fields}
+		if hasUniqueField(from, entry.fields...) && hasUniqueField(to, entry.fields...) {
+			compare.trace(path, fmt.Sprintf("matching list entries by the identifier %s, which is configured for the path pattern", identifier))
+			return compare.namedEntryLists(path, identifier, from, to)
+		}
+
+		compare.trace(path, fmt.Sprintf("not using the configured identifier %s, because not all entries have a unique value for it", identifier))
+	}
+
 	// check if a known identifier (e.g. name, or id) can be used
 	if identifier, err := compare.getIdentifierFromNamedLists(from, to); err == nil {
+		compare.trace(path, fmt.Sprintf("matching list entries by the identifier %s", identifier))
 		return compare.namedEntryLists(path, identifier, from, to)
 	}
 
 	// check if there is a field in all entries that could serve as an identifier
 	if identifier := compare.getNonStandardIdentifierFromNamedLists(from, to); identifier != nil {
+		compare.trace(path, fmt.Sprintf("matching list entries by the identifier %s, which is the only field with unique values in all entries", identifier))
 		return compare.namedEntryLists(path, identifier, from, to)
 	}
 
 	// check if Kubernetes resource fields can be used to identify items
 	if identifier, err := compare.getIdentifierFromKubernetesEntityList(from, to); err == nil {
+		compare.trace(path, fmt.Sprintf("matching list entries as Kubernetes resources by the identifier %s", identifier))
 		return compare.namedEntryLists(path, identifier, from, to)
 	}
 
 	// in any other case, compare lists as simple lists by relying on hashes
+	compare.trace(path, "matching list entries by their content, because there is no identifier that all entries have")
 	return compare.simpleLists(path, from, to)
 }
 
@@ -530,18 +1340,39 @@ func (compare *compare) simpleLists(path ytbx.Path, from *yamlv3.Node, to *yamlv
 		)
 	}
 
-	fromLookup := compare.createLookUpMap(from)
-	toLookup := compare.createLookUpMap(to)
+	if compare.settings.ListAlgorithm != "" && compare.settings.ListAlgorithm != ListDiffMultiset && !compare.ignoresOrderChanges(path) {
+		compare.trace(path, fmt.Sprintf("aligning list entries using the %s algorithm", compare.settings.ListAlgorithm))
+		return compare.alignedLists(path, from, to)
+	}
+
+	// Hash all entries once upfront, since calculating a hash is expensive
+	fromHashes := compare.entryHashes(from)
+	toHashes := compare.entryHashes(to)
 
-	// Fill two lists with the hashes of the entries of each list
-	fromCommon := make([]*yamlv3.Node, 0, fromLength)
-	toCommon := make([]*yamlv3.Node, 0, toLength)
+	fromLookup := createLookUpMap(fromHashes)
+	toLookup := createLookUpMap(toHashes)
+
+	// Fill two lists with the entries (and their hashes) that both lists have,
+	// which are only required to find order changes
+	var fromCommon, toCommon []*yamlv3.Node
+	var fromCommonHashes, toCommonHashes []uint64
+	trackCommon := !compare.ignoresOrderChanges(path)
+	if trackCommon {
+		fromCommon = make([]*yamlv3.Node, 0, fromLength)
+		toCommon = make([]*yamlv3.Node, 0, toLength)
+		fromCommonHashes = make([]uint64, 0, fromLength)
+		toCommonHashes = make([]uint64, 0, toLength)
+	}
+
+	// Keep track of duplicates that were already handled
+	reported := map[uint64]struct{}{}
 
 	for idxPos, fromValue := range from.Content {
-		hash := compare.calcNodeHash(fromValue)
+		hash := fromHashes[idxPos]
 		_, ok := toLookup[hash]
-		if ok {
+		if ok && trackCommon {
 			fromCommon = append(fromCommon, fromValue)
+			fromCommonHashes = append(fromCommonHashes, hash)
 		}
 
 		switch {
@@ -552,7 +1383,8 @@ func (compare *compare) simpleLists(path ytbx.Path, from *yamlv3.Node, to *yamlv
 		case len(fromLookup[hash]) > len(toLookup[hash]):
 			// `from` entry exists in `to` list, but there are duplicates and
 			// the number of duplicates is smaller
-			if !compare.hasEntry(removals, from.Content[idxPos]) {
+			if _, done := reported[hash]; !done {
+				reported[hash] = struct{}{}
 				for i := 0; i < len(fromLookup[hash])-len(toLookup[hash]); i++ {
 					removals = append(removals, from.Content[idxPos])
 				}
@@ -561,10 +1393,11 @@ func (compare *compare) simpleLists(path ytbx.Path, from *yamlv3.Node, to *yamlv
 	}
 
 	for idxPos, toValue := range to.Content {
-		hash := compare.calcNodeHash(toValue)
+		hash := toHashes[idxPos]
 		_, ok := fromLookup[hash]
-		if ok {
+		if ok && trackCommon {
 			toCommon = append(toCommon, toValue)
+			toCommonHashes = append(toCommonHashes, hash)
 		}
 
 		switch {
@@ -575,7 +1408,8 @@ func (compare *compare) simpleLists(path ytbx.Path, from *yamlv3.Node, to *yamlv
 		case len(fromLookup[hash]) < len(toLookup[hash]):
 			// `to` entry exists in `from` list, but there are duplicates and
 			// the number of duplicates is increased
-			if !compare.hasEntry(additions, to.Content[idxPos]) {
+			if _, done := reported[hash]; !done {
+				reported[hash] = struct{}{}
 				for i := 0; i < len(toLookup[hash])-len(fromLookup[hash]); i++ {
 					additions = append(additions, to.Content[idxPos])
 				}
@@ -583,9 +1417,16 @@ func (compare *compare) simpleLists(path ytbx.Path, from *yamlv3.Node, to *yamlv
 		}
 	}
 
+	removals, additions, err := compare.equivalentEntries(path, from, removals, additions)
+	if err != nil {
+		return nil, err
+	}
+
 	var orderChanges []Detail
-	if !compare.settings.IgnoreOrderChanges {
-		orderChanges = compare.findOrderChangesInSimpleList(fromCommon, toCommon)
+	if compare.ignoresOrderChanges(path) {
+		compare.trace(path, "not looking for order changes, because ignoring them is configured")
+	} else {
+		orderChanges = findOrderChangesInSimpleList(fromCommon, toCommon, fromCommonHashes, toCommonHashes)
 	}
 
 	return packChangesAndAddToResult([]Diff{}, path, orderChanges, additions, removals)
@@ -613,7 +1454,7 @@ func (compare *compare) namedEntryLists(path ytbx.Path, identifier listItemIdent
 		if toEntry, err := identifier.FindNodeByName(to, name); err == nil {
 			// `from` and `to` have the same entry identified by identifier and name -> require comparison
 			diffs, err := compare.objects(
-				ytbx.NewPathWithNamedListElement(path, identifier, name),
+				ytbx.NewPathWithNamedListElement(path, identifierKey(identifier, fromEntry), name),
 				followAlias(fromEntry),
 				followAlias(toEntry),
 			)
@@ -647,7 +1488,9 @@ func (compare *compare) namedEntryLists(path ytbx.Path, identifier listItemIdent
 	}
 
 	var orderChanges []Detail
-	if !compare.settings.IgnoreOrderChanges {
+	if compare.ignoresOrderChanges(path) {
+		compare.trace(path, "not looking for order changes, because ignoring them is configured")
+	} else {
 		orderChanges = findOrderChangesInNamedEntryLists(fromNames, toNames)
 	}
 
@@ -659,6 +1502,12 @@ func (compare *compare) nodeValues(path ytbx.Path, from *yamlv3.Node, to *yamlv3
 		// leave and don't report any differences if ignore whitespaces changes is
 		// configured and it is really only a whitespace only change between the strings
 		if compare.settings.IgnoreWhitespaceChanges && isWhitespaceOnlyChange(from.Value, to.Value) {
+			compare.trace(path, "ignoring the change, because it only differs in leading or trailing whitespace")
+			return nil, nil
+		}
+
+		if compare.settings.IgnoreTrailingNewlineChanges && isTrailingNewlineOnlyChange(from.Value, to.Value) {
+			compare.trace(path, "ignoring the change, because it only differs in trailing newlines")
 			return nil, nil
 		}
 
@@ -685,6 +1534,10 @@ func (compare *compare) boolValues(path ytbx.Path, from *yamlv3.Node, to *yamlv3
 		return nil, err
 	}
 	result := make([]Diff, 0)
+	if boolFrom == boolTo && from.Value != to.Value {
+		compare.trace(path, fmt.Sprintf("ignoring the change from %s to %s, because both are the same boolean value", from.Value, to.Value))
+	}
+
 	if boolFrom != boolTo {
 		result = append(result, Diff{
 			&path,
@@ -717,11 +1570,11 @@ func toBool(input string) (bool, error) {
 	return false, fmt.Errorf("not a valid boolean value: '%s'", input)
 }
 
-func (compare *compare) findOrderChangesInSimpleList(fromCommon, toCommon []*yamlv3.Node) []Detail {
+func findOrderChangesInSimpleList(fromCommon, toCommon []*yamlv3.Node, fromHashes, toHashes []uint64) []Detail {
 	// Try to find order changes ...
 	if len(fromCommon) == len(toCommon) {
 		for idx := range fromCommon {
-			if compare.calcNodeHash(fromCommon[idx]) != compare.calcNodeHash(toCommon[idx]) {
+			if fromHashes[idx] != toHashes[idx] {
 				return []Detail{{
 					Kind: ORDERCHANGE,
 					From: &yamlv3.Node{Kind: yamlv3.SequenceNode, Content: fromCommon},
@@ -734,20 +1587,6 @@ func (compare *compare) findOrderChangesInSimpleList(fromCommon, toCommon []*yam
 	return []Detail{}
 }
 
-// hasEntry returns whether the given node is in the provided list. Not exactly
-// a fast or efficient way to verify that a node is already in a list, but
-// given that this should rarely be used it is ok for now.
-func (compare *compare) hasEntry(list []*yamlv3.Node, searchEntry *yamlv3.Node) bool {
-	var searchEntryHash = compare.calcNodeHash(searchEntry)
-	for _, listEntry := range list {
-		if searchEntryHash == compare.calcNodeHash(listEntry) {
-			return true
-		}
-	}
-
-	return false
-}
-
 // AsSequenceNode translates a string list into a SequenceNode
 func AsSequenceNode(list ...string) *yamlv3.Node {
 	result := make([]*yamlv3.Node, len(list))
@@ -912,6 +1751,23 @@ func (compare *compare) getIdentifierFromNamedLists(listA, listB *yamlv3.Node) (
 	return nil, fmt.Errorf("unable to find a key that can serve as an unique identifier")
 }
 
+// hasUniqueField returns whether all entries of the list are maps with a
+// scalar value for the field, which is different for each entry
+func hasUniqueField(sequenceNode *yamlv3.Node, fields ...string) bool {
+	var identifier = firstField{fields}
+	var values = map[string]struct{}{}
+	for _, entry := range sequenceNode.Content {
+		value, err := identifier.Name(entry)
+		if err != nil {
+			return false
+		}
+
+		values[value] = struct{}{}
+	}
+
+	return len(values) == len(sequenceNode.Content)
+}
+
 func (compare *compare) getNonStandardIdentifierFromNamedLists(listA, listB *yamlv3.Node) listItemIdentifier {
 	createKeyCountMap := func(list *yamlv3.Node) map[string]int {
 		tmp := map[string]map[string]struct{}{}
@@ -1000,14 +1856,19 @@ func isEmptyDocument(node *yamlv3.Node) bool {
 	return false
 }
 
-func (compare *compare) createLookUpMap(sequenceNode *yamlv3.Node) map[uint64][]int {
-	result := make(map[uint64][]int, len(sequenceNode.Content))
+// entryHashes returns the hashes of all entries of the sequence node
+func (compare *compare) entryHashes(sequenceNode *yamlv3.Node) []uint64 {
+	result := make([]uint64, len(sequenceNode.Content))
 	for idx, entry := range sequenceNode.Content {
-		hash := compare.calcNodeHash(entry)
-		if _, ok := result[hash]; !ok {
-			result[hash] = []int{}
-		}
+		result[idx] = compare.calcNodeHash(entry)
+	}
+
+	return result
+}
 
+func createLookUpMap(hashes []uint64) map[uint64][]int {
+	result := make(map[uint64][]int, len(hashes))
+	for idx, hash := range hashes {
 		result[hash] = append(result[hash], idx)
 	}
 
@@ -1076,6 +1937,45 @@ func (compare *compare) calcNodeHash(node *yamlv3.Node) (hash uint64) {
 	return hash
 }
 
+// subtreeHash returns a hash of the content of the node including all of its
+// child nodes, which means that two nodes with the same hash have no
+// differences. Hashes are cached, so that every node is only hashed once.
+func (compare *compare) subtreeHash(node *yamlv3.Node) [sha256.Size]byte {
+	if hash, ok := compare.hashes.Load(node); ok {
+		return hash.([sha256.Size]byte)
+	}
+
+	if compare.previousHashes != nil {
+		if hash, ok := compare.previousHashes.Load(node); ok {
+			compare.hashes.Store(node, hash)
+			return hash.([sha256.Size]byte)
+		}
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%d:%s:%d:%s", node.Kind, node.Tag, len(node.Value), node.Value)
+	if compare.settings.DetectStyleChanges {
+		// subtrees that only differ in style are not identical in this case
+		fmt.Fprintf(h, ":%d", node.Style)
+	}
+
+	for _, child := range node.Content {
+		hash := compare.subtreeHash(child)
+		h.Write(hash[:])
+	}
+
+	if node.Kind == yamlv3.AliasNode && node.Alias != nil {
+		hash := compare.subtreeHash(node.Alias)
+		h.Write(hash[:])
+	}
+
+	var result [sha256.Size]byte
+	copy(result[:], h.Sum(nil))
+	compare.hashes.Store(node, result)
+
+	return result
+}
+
 func sortNode(node *yamlv3.Node) {
 	sort.Slice(node.Content, func(i, j int) bool {
 		a, b := node.Content[i], node.Content[j]
@@ -1201,6 +2101,29 @@ func grab(node *yamlv3.Node, pathString string) (*yamlv3.Node, error) {
 	)
 }
 
+// isWithinTolerance returns whether both nodes are numbers, which differ by at
+// most the given tolerance
+func isWithinTolerance(from *yamlv3.Node, to *yamlv3.Node, tolerance float64) bool {
+	if tolerance <= 0 || !isNumber(from) || !isNumber(to) {
+		return false
+	}
+
+	var a, b float64
+	if from.Decode(&a) != nil || to.Decode(&b) != nil {
+		return false
+	}
+
+	return math.Abs(a-b) <= tolerance
+}
+
+func isNumber(node *yamlv3.Node) bool {
+	return node.Kind == yamlv3.ScalarNode && (node.ShortTag() == "!!int" || node.ShortTag() == "!!float")
+}
+
 func isWhitespaceOnlyChange(from string, to string) bool {
 	return strings.Trim(from, " \n") == strings.Trim(to, " \n")
 }
+
+func isTrailingNewlineOnlyChange(from string, to string) bool {
+	return strings.TrimRight(from, "\n") == strings.TrimRight(to, "\n")
+}
@@ -0,0 +1,59 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"strconv"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// isRepresentationChange returns whether two scalars of different types have
+// the same value once they are coerced into a common type, for example the
+// string "10" and the integer 10, or the string "true" and the boolean true
+func isRepresentationChange(from *yamlv3.Node, to *yamlv3.Node) bool {
+	if from == nil || to == nil || from.Kind != yamlv3.ScalarNode || to.Kind != yamlv3.ScalarNode || from.Tag == to.Tag {
+		return false
+	}
+
+	switch {
+	case from.Tag == "!!bool" || to.Tag == "!!bool":
+		fromValue, fromErr := toBool(from.Value)
+		toValue, toErr := toBool(to.Value)
+		return fromErr == nil && toErr == nil && fromValue == toValue
+
+	default:
+		fromValue, fromOk := toNumber(from.Value)
+		toValue, toOk := toNumber(to.Value)
+		return fromOk && toOk && fromValue == toValue
+	}
+}
+
+// toNumber parses the scalar value as a number, supporting the integer
+// notations (e.g. hexadecimal) as well as floating point numbers
+func toNumber(value string) (float64, bool) {
+	if number, err := strconv.ParseInt(value, 0, 64); err == nil {
+		return float64(number), true
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	return number, err == nil
+}
@@ -0,0 +1,196 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"regexp"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Comparer defines the contract for a custom comparison of two nodes, which
+// is consulted before the default comparison for the paths or YAML tags it was
+// registered for, e.g. to compare encrypted values after decrypting them
+type Comparer interface {
+	// Compare returns the details of the differences between the two nodes,
+	// and whether it handled the nodes at all. If the nodes are not handled,
+	// the default comparison is used instead.
+	Compare(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) (details []Detail, handled bool, err error)
+}
+
+// ComparerFunc is an adapter to use a function as a Comparer
+type ComparerFunc func(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Detail, bool, error)
+
+var _ Comparer = ComparerFunc(nil)
+
+// Compare calls the function itself
+func (f ComparerFunc) Compare(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Detail, bool, error) {
+	return f(path, from, to)
+}
+
+// ComparerForPath registers a comparer for the given path, which can be
+// specified in dot-style or go-patch style
+func ComparerForPath(pathString string, comparer Comparer) CompareOption {
+	return func(settings *compareSettings) {
+		if settings.PathComparers == nil {
+			settings.PathComparers = map[string]Comparer{}
+		}
+
+		settings.PathComparers[normalizedPathString(pathString)] = comparer
+		settings.onResolvedPath(pathString, func(settings *compareSettings, resolved string) {
+			settings.PathComparers[resolved] = comparer
+		})
+	}
+}
+
+// ComparerForPathRegexp registers a comparer for all paths that match the
+// given regular expression, which is matched against the go-patch style path
+func ComparerForPathRegexp(pattern *regexp.Regexp, comparer Comparer) CompareOption {
+	return func(settings *compareSettings) {
+		settings.PathRegexpComparers = append(settings.PathRegexpComparers, regexpComparer{pattern, comparer})
+	}
+}
+
+type regexpComparer struct {
+	pattern  *regexp.Regexp
+	comparer Comparer
+}
+
+// ComparerForTag registers a comparer for nodes with the given YAML tag, for
+// example `!vault`, which is used if at least one of the two nodes has the tag
+func ComparerForTag(tag string, comparer Comparer) CompareOption {
+	return func(settings *compareSettings) {
+		if settings.TagComparers == nil {
+			settings.TagComparers = map[string]Comparer{}
+		}
+
+		settings.TagComparers[tag] = comparer
+	}
+}
+
+// customComparison looks up a comparer for the path or the tags of the nodes
+// and uses it, path comparers take precedence over path pattern comparers,
+// which take precedence over tag comparers
+func (compare *compare) customComparison(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Diff, bool, error) {
+	var comparers []Comparer
+	if len(compare.settings.PathComparers) > 0 {
+		if comparer, ok := compare.settings.PathComparers[path.String()]; ok {
+			comparers = append(comparers, comparer)
+		}
+	}
+
+	if len(compare.settings.PathRegexpComparers) > 0 {
+		pathString := path.String()
+		for _, entry := range compare.settings.PathRegexpComparers {
+			if entry.pattern.MatchString(pathString) {
+				comparers = append(comparers, entry.comparer)
+			}
+		}
+	}
+
+	if len(compare.settings.TagComparers) > 0 {
+		for _, tag := range []string{from.Tag, to.Tag} {
+			if comparer, ok := compare.settings.TagComparers[tag]; ok {
+				comparers = append(comparers, comparer)
+			}
+		}
+	}
+
+	for _, comparer := range comparers {
+		details, handled, err := comparer.Compare(path, from, to)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !handled {
+			continue
+		}
+
+		if len(details) == 0 {
+			return []Diff{}, true, nil
+		}
+
+		return []Diff{{&path, details}}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// equivalentEntries removes the pairs of removed and added list entries that
+// a custom comparer considers to be equal, since their hashes differ even
+// though the comparer would not report a difference, e.g. for a placeholder
+// and a concrete value
+func (compare *compare) equivalentEntries(path ytbx.Path, from *yamlv3.Node, removals []*yamlv3.Node, additions []*yamlv3.Node) ([]*yamlv3.Node, []*yamlv3.Node, error) {
+	settings := compare.settings
+	if len(removals) == 0 || len(additions) == 0 || len(settings.PathComparers)+len(settings.PathRegexpComparers)+len(settings.TagComparers) == 0 {
+		return removals, additions, nil
+	}
+
+	var remaining []*yamlv3.Node
+	var matched = make([]bool, len(additions))
+
+	for _, removal := range removals {
+		entryPath := ytbx.NewPathWithIndexedListElement(path, indexOf(from, removal))
+
+		var found bool
+		for i, addition := range additions {
+			if matched[i] {
+				continue
+			}
+
+			result, handled, err := compare.customComparison(entryPath, followAlias(removal), followAlias(addition))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if handled && len(result) == 0 {
+				matched[i], found = true, true
+				break
+			}
+		}
+
+		if !found {
+			remaining = append(remaining, removal)
+		}
+	}
+
+	var unmatched []*yamlv3.Node
+	for i, addition := range additions {
+		if !matched[i] {
+			unmatched = append(unmatched, addition)
+		}
+	}
+
+	return remaining, unmatched, nil
+}
+
+// indexOf returns the index of the entry in the sequence node, or zero if the
+// node is not an entry of the sequence
+func indexOf(sequenceNode *yamlv3.Node, entry *yamlv3.Node) int {
+	for i, node := range sequenceNode.Content {
+		if node == entry {
+			return i
+		}
+	}
+
+	return 0
+}
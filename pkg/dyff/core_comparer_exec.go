@@ -0,0 +1,112 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ExecComparer returns a comparer that delegates the comparison to an
+// external command. The command receives a YAML document with the keys
+// `path`, `from`, and `to` on standard input. It has to exit with status 0 if
+// the values are equal, and with status 1 if they are not. Any other exit
+// status is considered a failure. In case the values are not equal, the
+// command can write a YAML document with the keys `from` and `to` to
+// standard output, which is shown instead of the original values, e.g. the
+// decoded content of a binary value.
+func ExecComparer(name string, args ...string) Comparer {
+	return &execComparer{name: name, args: args}
+}
+
+type execComparer struct {
+	name string
+	args []string
+}
+
+var _ Comparer = &execComparer{}
+
+func (c *execComparer) Compare(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Detail, bool, error) {
+	input, err := yamlv3.Marshal(&yamlv3.Node{
+		Kind: yamlv3.MappingNode,
+		Content: []*yamlv3.Node{
+			{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: "path"},
+			{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: path.String()},
+			{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: "from"},
+			from,
+			{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: "to"},
+			to,
+		},
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to prepare input for comparer %s: %w", c.name, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(c.name, c.args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return nil, true, nil
+
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		break
+
+	default:
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, false, fmt.Errorf("comparer %s failed for path %s: %w: %s", c.name, path.String(), err, msg)
+		}
+
+		return nil, false, fmt.Errorf("comparer %s failed for path %s: %w", c.name, path.String(), err)
+	}
+
+	var detail = Detail{Kind: MODIFICATION, From: from, To: to}
+	if stdout.Len() > 0 {
+		var rendered yamlv3.Node
+		if err := yamlv3.Unmarshal(stdout.Bytes(), &rendered); err != nil {
+			return nil, false, fmt.Errorf("comparer %s returned an invalid rendering for path %s: %w", c.name, path.String(), err)
+		}
+
+		if len(rendered.Content) > 0 && rendered.Content[0].Kind == yamlv3.MappingNode {
+			if value, ok := findValueByKey(rendered.Content[0], "from"); ok {
+				detail.From = value
+			}
+
+			if value, ok := findValueByKey(rendered.Content[0], "to"); ok {
+				detail.To = value
+			}
+		}
+	}
+
+	return []Detail{detail}, true, nil
+}
@@ -0,0 +1,174 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Transformation is a sed-like substitution, which replaces the first match
+// of the pattern in a value, or all matches in case it is global
+type Transformation struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+	Global      bool
+}
+
+// ParseTransformation parses a sed-like substitution expression, for example
+// `s/-[a-f0-9]{8}$//` or `s|v(\d+)|\1|g`, where the character after the s is
+// the delimiter. The replacement supports \1 to \9 for the submatches and &
+// for the whole match.
+func ParseTransformation(expression string) (Transformation, error) {
+	if len(expression) < 2 || expression[0] != 's' {
+		return Transformation{}, fmt.Errorf("invalid transformation %s, expected format is s/<regexp>/<replacement>/[g]", expression)
+	}
+
+	delimiter, size := utf8.DecodeRuneInString(expression[1:])
+	fields := splitByDelimiter(expression[1+size:], delimiter)
+	if len(fields) != 3 || (fields[2] != "" && fields[2] != "g") {
+		return Transformation{}, fmt.Errorf("invalid transformation %s, expected format is s/<regexp>/<replacement>/[g]", expression)
+	}
+
+	pattern, err := regexp.Compile(fields[0])
+	if err != nil {
+		return Transformation{}, fmt.Errorf("invalid transformation %s: %w", expression, err)
+	}
+
+	return Transformation{
+		Pattern:     pattern,
+		Replacement: expandReplacement(fields[1]),
+		Global:      fields[2] == "g",
+	}, nil
+}
+
+// splitByDelimiter splits the text at the delimiter, unless it is escaped
+// using a backslash, in which case the backslash is removed
+func splitByDelimiter(text string, delimiter rune) []string {
+	var fields []string
+	var field strings.Builder
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == delimiter:
+			field.WriteRune(delimiter)
+			i++
+
+		case runes[i] == '\\' && i+1 < len(runes):
+			field.WriteRune(runes[i])
+			field.WriteRune(runes[i+1])
+			i++
+
+		case runes[i] == delimiter:
+			fields = append(fields, field.String())
+			field.Reset()
+
+		default:
+			field.WriteRune(runes[i])
+		}
+	}
+
+	return append(fields, field.String())
+}
+
+// expandReplacement translates a sed replacement into the template syntax
+// of the regexp package, e.g. \1 becomes ${1} and & becomes ${0}
+func expandReplacement(replacement string) string {
+	var result strings.Builder
+
+	runes := []rune(replacement)
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes) && runes[i+1] >= '0' && runes[i+1] <= '9':
+			fmt.Fprintf(&result, "${%c}", runes[i+1])
+			i++
+
+		case runes[i] == '\\' && i+1 < len(runes):
+			result.WriteString(strings.ReplaceAll(string(runes[i+1]), "$", "$$"))
+			i++
+
+		case runes[i] == '&':
+			result.WriteString("${0}")
+
+		case runes[i] == '$':
+			result.WriteString("$$")
+
+		default:
+			result.WriteRune(runes[i])
+		}
+	}
+
+	return result.String()
+}
+
+// Apply returns the value with the substitution applied
+func (t Transformation) Apply(value string) string {
+	if t.Global {
+		return t.Pattern.ReplaceAllString(value, t.Replacement)
+	}
+
+	match := t.Pattern.FindStringSubmatchIndex(value)
+	if match == nil {
+		return value
+	}
+
+	return value[:match[0]] + string(t.Pattern.ExpandString(nil, t.Replacement, value, match)) + value[match[1]:]
+}
+
+// TransformComparer returns a comparer that considers two values to be equal,
+// if they are the same after applying the transformations to both of them,
+// e.g. to remove generated suffixes of names. Otherwise, the default
+// comparison reports the original values.
+func TransformComparer(transformations ...Transformation) Comparer {
+	return &transformComparer{transformations: transformations}
+}
+
+type transformComparer struct {
+	transformations []Transformation
+}
+
+var _ Comparer = &transformComparer{}
+
+func (c *transformComparer) Compare(_ ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Detail, bool, error) {
+	if from.Kind != yamlv3.ScalarNode || to.Kind != yamlv3.ScalarNode || from.Tag != to.Tag {
+		return nil, false, nil
+	}
+
+	if c.transform(from.Value) != c.transform(to.Value) {
+		return nil, false, nil
+	}
+
+	return nil, true, nil
+}
+
+func (c *transformComparer) transform(value string) string {
+	for _, transformation := range c.transformations {
+		value = transformation.Apply(value)
+	}
+
+	return value
+}
@@ -0,0 +1,79 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"strconv"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// leafDifferences returns the number of leaf values that differ between the
+// two subtrees, which are matched by their path only, i.e. list entries are
+// matched by their index
+func leafDifferences(from *yamlv3.Node, to *yamlv3.Node) int {
+	fromLeaves, toLeaves := map[string]string{}, map[string]string{}
+	collectLeaves(from, "", fromLeaves)
+	collectLeaves(to, "", toLeaves)
+
+	var count int
+	for path, fromValue := range fromLeaves {
+		if toValue, ok := toLeaves[path]; !ok || toValue != fromValue {
+			count++
+		}
+	}
+
+	for path := range toLeaves {
+		if _, ok := fromLeaves[path]; !ok {
+			count++
+		}
+	}
+
+	return count
+}
+
+func collectLeaves(node *yamlv3.Node, path string, leaves map[string]string) {
+	node = followAlias(node)
+
+	switch {
+	case node.Kind == yamlv3.DocumentNode && len(node.Content) > 0:
+		collectLeaves(node.Content[0], path, leaves)
+
+	case node.Kind == yamlv3.MappingNode && len(node.Content) > 0:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			collectLeaves(node.Content[i+1], path+"/"+node.Content[i].Value, leaves)
+		}
+
+	case node.Kind == yamlv3.SequenceNode && len(node.Content) > 0:
+		for i, entry := range node.Content {
+			collectLeaves(entry, path+"/"+strconv.Itoa(i), leaves)
+		}
+
+	case node.Kind == yamlv3.MappingNode:
+		leaves[path] = "{}"
+
+	case node.Kind == yamlv3.SequenceNode:
+		leaves[path] = "[]"
+
+	default:
+		leaves[path] = node.Tag + " " + node.Value
+	}
+}
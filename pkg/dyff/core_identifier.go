@@ -81,6 +81,83 @@ func (sf *singleField) String() string {
 
 // --- --- ---
 
+// firstField is an list item identifier that uses the first of the fields
+// an entry has, e.g. 'alert' or 'record' in a list of Prometheus rules
+type firstField struct {
+	IdentifierFieldNames []string
+}
+
+var _ listItemIdentifier = &firstField{}
+
+func (ff *firstField) FindNodeByName(sequenceNode *yamlv3.Node, name string) (*yamlv3.Node, error) {
+	for _, mappingNode := range sequenceNode.Content {
+		nameOfNode, err := ff.Name(mappingNode)
+		if err != nil {
+			return nil, err
+		}
+
+		if nameOfNode == name {
+			return mappingNode, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find mapping entry with name %q", name)
+}
+
+func (ff *firstField) Name(mappingNode *yamlv3.Node) (string, error) {
+	field, ok := ff.field(mappingNode)
+	if !ok {
+		return "", fmt.Errorf("none of the fields %s exists", ff)
+	}
+
+	value, _ := findValueByKey(followAlias(mappingNode), field)
+	if value.Kind != yamlv3.ScalarNode {
+		return "", fmt.Errorf("field %s is not a scalar value", field)
+	}
+
+	return value.Value, nil
+}
+
+// Key returns the field that is used to identify the given entry
+func (ff *firstField) Key(mappingNode *yamlv3.Node) string {
+	if field, ok := ff.field(mappingNode); ok {
+		return field
+	}
+
+	return ff.String()
+}
+
+func (ff *firstField) field(mappingNode *yamlv3.Node) (string, bool) {
+	mappingNode = followAlias(mappingNode)
+	if mappingNode.Kind != yamlv3.MappingNode {
+		return "", false
+	}
+
+	for _, field := range ff.IdentifierFieldNames {
+		if _, ok := findValueByKey(mappingNode, field); ok {
+			return field, true
+		}
+	}
+
+	return "", false
+}
+
+func (ff *firstField) String() string {
+	return strings.Join(ff.IdentifierFieldNames, "|")
+}
+
+// identifierKey returns the key to be used in the path of the given entry,
+// which is the identifier itself unless it uses different fields per entry
+func identifierKey(identifier listItemIdentifier, mappingNode *yamlv3.Node) string {
+	if keyed, ok := identifier.(interface{ Key(*yamlv3.Node) string }); ok {
+		return keyed.Key(mappingNode)
+	}
+
+	return identifier.String()
+}
+
+// --- --- ---
+
 // k8sItemIdentifier is an identifier aiming for Kubernetes items that have an
 // api version, kind, and name field to be used
 type k8sItemIdentifier struct{}
@@ -0,0 +1,388 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ListDiffAlgorithm defines how the entries of two simple lists (lists
+// without an identifier field) are aligned with each other
+type ListDiffAlgorithm string
+
+// Supported list diff algorithms
+const (
+	// ListDiffMultiset compares the entries regardless of their position and
+	// reports changes of the order separately, which is the default
+	ListDiffMultiset ListDiffAlgorithm = "multiset"
+
+	// ListDiffLCS aligns the entries using the longest common subsequence
+	ListDiffLCS ListDiffAlgorithm = "lcs"
+
+	// ListDiffPatience aligns the entries using entries that are unique in
+	// both lists as anchors, and uses LCS in between
+	ListDiffPatience ListDiffAlgorithm = "patience"
+
+	// ListDiffHistogram aligns the entries using the least frequent entries as
+	// anchors, which works well for lists with many duplicate entries
+	ListDiffHistogram ListDiffAlgorithm = "histogram"
+
+	// ListDiffGreedy aligns each entry with the next matching entry, which is
+	// fast, but does not necessarily find the smallest set of changes
+	ListDiffGreedy ListDiffAlgorithm = "greedy"
+)
+
+// histogramMaxOccurrences is the number of occurrences of the least frequent
+// entry at which the histogram algorithm falls back to LCS
+const histogramMaxOccurrences = 64
+
+// ParseListDiffAlgorithm returns the list diff algorithm for the given name
+func ParseListDiffAlgorithm(name string) (ListDiffAlgorithm, error) {
+	switch algorithm := ListDiffAlgorithm(name); algorithm {
+	case ListDiffMultiset, ListDiffLCS, ListDiffPatience, ListDiffHistogram, ListDiffGreedy:
+		return algorithm, nil
+	}
+
+	return "", fmt.Errorf("unknown list diff algorithm %s, supported algorithms are: multiset, lcs, patience, histogram, or greedy", name)
+}
+
+// ListAlgorithm specifies the algorithm used to align the entries of simple
+// lists. Except for the default multiset algorithm, moved entries are
+// reported as a removal and an addition instead of an order change. In case
+// order changes are ignored, the multiset algorithm is always used.
+func ListAlgorithm(algorithm ListDiffAlgorithm) CompareOption {
+	return func(settings *compareSettings) {
+		settings.ListAlgorithm = algorithm
+	}
+}
+
+// alignedLists compares two simple lists by aligning their entries using the
+// configured list diff algorithm, entries that could not be aligned are
+// reported as removals or additions
+func (compare *compare) alignedLists(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Diff, error) {
+	a, b := compare.entryHashes(from), compare.entryHashes(to)
+
+	alignment := &alignment{a: a, b: b}
+	switch compare.settings.ListAlgorithm {
+	case ListDiffLCS:
+		alignment.lcs(0, len(a), 0, len(b))
+
+	case ListDiffPatience:
+		alignment.patience(0, len(a), 0, len(b))
+
+	case ListDiffHistogram:
+		alignment.histogram(0, len(a), 0, len(b))
+
+	case ListDiffGreedy:
+		alignment.greedy()
+
+	default:
+		return nil, fmt.Errorf("unsupported list diff algorithm %s", compare.settings.ListAlgorithm)
+	}
+
+	matchedFrom := make([]bool, len(a))
+	matchedTo := make([]bool, len(b))
+	for _, match := range alignment.matches {
+		matchedFrom[match[0]] = true
+		matchedTo[match[1]] = true
+	}
+
+	removals := make([]*yamlv3.Node, 0)
+	for i, entry := range from.Content {
+		if !matchedFrom[i] {
+			removals = append(removals, entry)
+		}
+	}
+
+	additions := make([]*yamlv3.Node, 0)
+	for i, entry := range to.Content {
+		if !matchedTo[i] {
+			additions = append(additions, entry)
+		}
+	}
+
+	removals, additions, err := compare.equivalentEntries(path, from, removals, additions)
+	if err != nil {
+		return nil, err
+	}
+
+	return packChangesAndAddToResult([]Diff{}, path, nil, additions, removals)
+}
+
+// alignment collects the pairs of matching indices of two lists of hashes
+type alignment struct {
+	a, b    []uint64
+	matches [][2]int
+}
+
+func (al *alignment) match(i, j int) {
+	al.matches = append(al.matches, [2]int{i, j})
+}
+
+// trim matches the common prefix and suffix of the given ranges and returns
+// the remaining ranges
+func (al *alignment) trim(aLo, aHi, bLo, bHi int) (int, int, int, int) {
+	for aLo < aHi && bLo < bHi && al.a[aLo] == al.b[bLo] {
+		al.match(aLo, bLo)
+		aLo, bLo = aLo+1, bLo+1
+	}
+
+	for aLo < aHi && bLo < bHi && al.a[aHi-1] == al.b[bHi-1] {
+		al.match(aHi-1, bHi-1)
+		aHi, bHi = aHi-1, bHi-1
+	}
+
+	return aLo, aHi, bLo, bHi
+}
+
+// lcs aligns the ranges using the longest common subsequence, which is
+// calculated using Hirschberg's algorithm to only require linear space
+func (al *alignment) lcs(aLo, aHi, bLo, bHi int) {
+	aLo, aHi, bLo, bHi = al.trim(aLo, aHi, bLo, bHi)
+	if aLo == aHi || bLo == bHi {
+		return
+	}
+
+	if aHi-aLo == 1 {
+		for j := bLo; j < bHi; j++ {
+			if al.a[aLo] == al.b[j] {
+				al.match(aLo, j)
+				return
+			}
+		}
+
+		return
+	}
+
+	// Split the first range in the middle and find the position in the second
+	// range at which the common subsequences of both halves add up the most
+	mid := aLo + (aHi-aLo)/2
+	forward := al.lcsLengths(aLo, mid, bLo, bHi, false)
+	backward := al.lcsLengths(mid, aHi, bLo, bHi, true)
+
+	split, best := 0, -1
+	for k := 0; k <= bHi-bLo; k++ {
+		if length := forward[k] + backward[bHi-bLo-k]; length > best {
+			split, best = k, length
+		}
+	}
+
+	al.lcs(aLo, mid, bLo, bLo+split)
+	al.lcs(mid, aHi, bLo+split, bHi)
+}
+
+// lcsLengths returns the lengths of the longest common subsequences of the
+// first range with each prefix of the second range (or suffix in reverse),
+// only keeping the last row of the usual table in memory
+func (al *alignment) lcsLengths(aLo, aHi, bLo, bHi int, reverse bool) []int {
+	m := bHi - bLo
+	prev, curr := make([]int, m+1), make([]int, m+1)
+
+	for i := 0; i < aHi-aLo; i++ {
+		x := al.a[aLo+i]
+		if reverse {
+			x = al.a[aHi-1-i]
+		}
+
+		for j := 1; j <= m; j++ {
+			y := al.b[bLo+j-1]
+			if reverse {
+				y = al.b[bHi-j]
+			}
+
+			switch {
+			case x == y:
+				curr[j] = prev[j-1] + 1
+
+			case prev[j] >= curr[j-1]:
+				curr[j] = prev[j]
+
+			default:
+				curr[j] = curr[j-1]
+			}
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev
+}
+
+// patience aligns the ranges using the entries that are unique in both
+// ranges as anchors, the ranges between the anchors are aligned recursively
+func (al *alignment) patience(aLo, aHi, bLo, bHi int) {
+	aLo, aHi, bLo, bHi = al.trim(aLo, aHi, bLo, bHi)
+	if aLo == aHi || bLo == bHi {
+		return
+	}
+
+	type occurrence struct {
+		countA, countB int
+		posA, posB     int
+	}
+
+	occurrences := map[uint64]*occurrence{}
+	for i := aLo; i < aHi; i++ {
+		if _, ok := occurrences[al.a[i]]; !ok {
+			occurrences[al.a[i]] = &occurrence{}
+		}
+
+		occurrences[al.a[i]].countA++
+		occurrences[al.a[i]].posA = i
+	}
+
+	for j := bLo; j < bHi; j++ {
+		if o, ok := occurrences[al.b[j]]; ok {
+			o.countB++
+			o.posB = j
+		}
+	}
+
+	// Unique entries in the order of the first list with their position in
+	// the second list, of which the longest increasing sequence are anchors
+	var candidates [][2]int
+	for i := aLo; i < aHi; i++ {
+		if o := occurrences[al.a[i]]; o.countA == 1 && o.countB == 1 {
+			candidates = append(candidates, [2]int{i, o.posB})
+		}
+	}
+
+	if len(candidates) == 0 {
+		al.lcs(aLo, aHi, bLo, bHi)
+		return
+	}
+
+	for _, anchor := range longestIncreasingSequence(candidates) {
+		al.patience(aLo, anchor[0], bLo, anchor[1])
+		al.match(anchor[0], anchor[1])
+		aLo, bLo = anchor[0]+1, anchor[1]+1
+	}
+
+	al.patience(aLo, aHi, bLo, bHi)
+}
+
+// histogram aligns the ranges using the least frequent common entry as the
+// anchor, which is extended to the longest common region around it, the
+// ranges before and after the region are aligned recursively
+func (al *alignment) histogram(aLo, aHi, bLo, bHi int) {
+	aLo, aHi, bLo, bHi = al.trim(aLo, aHi, bLo, bHi)
+	if aLo == aHi || bLo == bHi {
+		return
+	}
+
+	counts := map[uint64]int{}
+	for i := aLo; i < aHi; i++ {
+		counts[al.a[i]]++
+	}
+
+	var anchorA, anchorB, lowest = -1, -1, 0
+	for j := bLo; j < bHi; j++ {
+		if count, ok := counts[al.b[j]]; ok && (lowest == 0 || count < lowest) {
+			lowest, anchorB = count, j
+		}
+	}
+
+	if anchorB < 0 {
+		return
+	}
+
+	if lowest > histogramMaxOccurrences {
+		al.lcs(aLo, aHi, bLo, bHi)
+		return
+	}
+
+	for i := aLo; i < aHi; i++ {
+		if al.a[i] == al.b[anchorB] {
+			anchorA = i
+			break
+		}
+	}
+
+	// Extend the anchor to the common region around it
+	start, end := 0, 1
+	for anchorA-start-1 >= aLo && anchorB-start-1 >= bLo && al.a[anchorA-start-1] == al.b[anchorB-start-1] {
+		start++
+	}
+
+	for anchorA+end < aHi && anchorB+end < bHi && al.a[anchorA+end] == al.b[anchorB+end] {
+		end++
+	}
+
+	al.histogram(aLo, anchorA-start, bLo, anchorB-start)
+	for k := -start; k < end; k++ {
+		al.match(anchorA+k, anchorB+k)
+	}
+
+	al.histogram(anchorA+end, aHi, anchorB+end, bHi)
+}
+
+// greedy aligns each entry of the first list with the next matching entry of
+// the second list
+func (al *alignment) greedy() {
+	positions := map[uint64][]int{}
+	for j, hash := range al.b {
+		positions[hash] = append(positions[hash], j)
+	}
+
+	next := 0
+	for i, hash := range al.a {
+		candidates := positions[hash]
+		if k := sort.SearchInts(candidates, next); k < len(candidates) {
+			al.match(i, candidates[k])
+			next = candidates[k] + 1
+		}
+	}
+}
+
+// longestIncreasingSequence returns the longest sequence of the pairs, in
+// which the second value is increasing, using patience sorting
+func longestIncreasingSequence(pairs [][2]int) [][2]int {
+	var (
+		tails       []int
+		predecessor = make([]int, len(pairs))
+	)
+
+	for i, pair := range pairs {
+		k := sort.Search(len(tails), func(k int) bool { return pairs[tails[k]][1] >= pair[1] })
+
+		predecessor[i] = -1
+		if k > 0 {
+			predecessor[i] = tails[k-1]
+		}
+
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	result := make([][2]int, len(tails))
+	for i, k := len(tails)-1, tails[len(tails)-1]; i >= 0; i, k = i-1, predecessor[k] {
+		result[i] = pairs[k]
+	}
+
+	return result
+}
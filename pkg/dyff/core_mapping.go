@@ -0,0 +1,139 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// pathMapping is a map entry that was renamed from one path to another
+type pathMapping struct {
+	from ytbx.Path
+	to   ytbx.Path
+}
+
+// documentObjects compares the documents of the pair and then the subtrees
+// of the configured path mappings against each other
+func (compare *compare) documentObjects(pair documentPair) ([]Diff, error) {
+	result, err := compare.objects(pair.path, pair.from, pair.to)
+	if err != nil || len(compare.settings.PathMappings) == 0 || pair.from == nil || pair.to == nil {
+		return result, err
+	}
+
+	for _, mapping := range compare.settings.PathMappings {
+		fromNode, toNode := nodeAtPath(pair.from, mapping.from), nodeAtPath(pair.to, mapping.to)
+		if fromNode == nil || toNode == nil || nodeAtPath(pair.to, mapping.from) != nil || nodeAtPath(pair.from, mapping.to) != nil {
+			continue
+		}
+
+		compare.trace(mapping.to, fmt.Sprintf("comparing with %s, because the path is mapped", mapping.from.String()))
+
+		result = withoutMapEntry(result, mapping.from, REMOVAL)
+		result = withoutMapEntry(result, mapping.to, ADDITION)
+
+		path := ytbx.Path{Root: pair.path.Root, DocumentIdx: pair.path.DocumentIdx, PathElements: mapping.to.PathElements}
+		diffs, err := compare.objects(path, followAlias(fromNode), followAlias(toNode))
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, diffs...)
+	}
+
+	return result, nil
+}
+
+// nodeAtPath returns the node at the path of the document, or nil if there
+// is no such node
+func nodeAtPath(document *yamlv3.Node, path ytbx.Path) *yamlv3.Node {
+	var node *yamlv3.Node
+	var err error
+	if document.Kind == yamlv3.DocumentNode {
+		node, err = ytbx.Grab(document, path.String())
+	} else {
+		node, err = grab(document, path.String())
+	}
+
+	if err != nil {
+		return nil
+	}
+
+	return node
+}
+
+// withoutMapEntry returns the differences without the map entry of the path
+// in the added or removed map entries of its parent
+func withoutMapEntry(diffs []Diff, path ytbx.Path, kind rune) []Diff {
+	last := path.PathElements[len(path.PathElements)-1]
+	if last.Key != "" || last.Name == "" {
+		return diffs
+	}
+
+	parent := ytbx.Path{PathElements: path.PathElements[:len(path.PathElements)-1]}
+
+	var result = make([]Diff, 0, len(diffs))
+	for _, diff := range diffs {
+		if diff.Path == nil || diff.Path.String() != parent.String() {
+			result = append(result, diff)
+			continue
+		}
+
+		var details []Detail
+		for _, detail := range diff.Details {
+			node := detail.To
+			if kind == REMOVAL {
+				node = detail.From
+			}
+
+			if detail.Kind != kind || node == nil || node.Kind != yamlv3.MappingNode {
+				details = append(details, detail)
+				continue
+			}
+
+			var content []*yamlv3.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value != last.Name {
+					content = append(content, node.Content[i], node.Content[i+1])
+				}
+			}
+
+			if len(content) == 0 {
+				continue
+			}
+
+			entries := &yamlv3.Node{Kind: node.Kind, Tag: node.Tag, Content: content}
+			if kind == REMOVAL {
+				details = append(details, Detail{Kind: kind, From: entries, To: detail.To})
+			} else {
+				details = append(details, Detail{Kind: kind, From: detail.From, To: entries})
+			}
+		}
+
+		if len(details) > 0 {
+			result = append(result, Diff{Path: diff.Path, Details: details})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// styleName returns a human readable name of the YAML style of the node
+func styleName(node *yamlv3.Node) string {
+	switch {
+	case node.Style&yamlv3.DoubleQuotedStyle != 0:
+		return "double quoted"
+
+	case node.Style&yamlv3.SingleQuotedStyle != 0:
+		return "single quoted"
+
+	case node.Style&yamlv3.LiteralStyle != 0:
+		return "literal"
+
+	case node.Style&yamlv3.FoldedStyle != 0:
+		return "folded"
+
+	case node.Style&yamlv3.FlowStyle != 0:
+		return "flow"
+
+	case node.Kind == yamlv3.ScalarNode:
+		return "plain"
+
+	default:
+		return "block"
+	}
+}
+
+// isSameContent returns whether both nodes have the same content regardless of
+// the style in which the content is written
+func isSameContent(from *yamlv3.Node, to *yamlv3.Node) bool {
+	if from.Kind != to.Kind || from.Tag != to.Tag || from.Value != to.Value || len(from.Content) != len(to.Content) {
+		return false
+	}
+
+	for i := range from.Content {
+		if !isSameContent(from.Content[i], to.Content[i]) {
+			return false
+		}
+	}
+
+	return true
+}
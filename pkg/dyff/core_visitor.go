@@ -0,0 +1,100 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// SkipReason describes why a pair of nodes was not compared in detail
+type SkipReason string
+
+// Reasons for skipping the detailed comparison of a pair of nodes
+const (
+	// SkipIdentical means both nodes have the same content including all
+	// of their child nodes, so there are no differences
+	SkipIdentical SkipReason = "identical"
+
+	// SkipCached means the differences of a document with the same content
+	// were taken from the cache of a previous comparison
+	SkipCached SkipReason = "cached"
+
+	// SkipCustomComparer means a custom comparer handled the nodes
+	SkipCustomComparer SkipReason = "custom comparer"
+
+	// SkipMaxDepth means the nodes are below the configured maximum depth of
+	// the comparison and are only reported as a changed subtree
+	SkipMaxDepth SkipReason = "max depth"
+
+	// SkipIgnored means the path is excluded from the comparison, in which
+	// case one of the nodes is nil if the path only exists on one side
+	SkipIgnored SkipReason = "ignored"
+)
+
+// Visitor is notified about the steps of a comparison, e.g. to build custom
+// indexes or metrics. Since multiple documents can be compared at the same
+// time, Enter and Skip can be called concurrently, unless the concurrency
+// is set to one. Difference is called for every difference of the result in
+// the order of the report, after the comparison of the documents is done.
+type Visitor interface {
+	// Enter is called for every pair of nodes that is compared
+	Enter(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node)
+
+	// Skip is called for every pair of nodes that is not compared in detail
+	Skip(path ytbx.Path, from *yamlv3.Node, to *yamlv3.Node, reason SkipReason)
+
+	// Difference is called for every difference that was found
+	Difference(diff Diff)
+}
+
+// BaseVisitor implements all functions of the Visitor interface without
+// doing anything, so that it can be embedded to only implement some of them
+type BaseVisitor struct{}
+
+var _ Visitor = BaseVisitor{}
+
+// Enter does nothing
+func (BaseVisitor) Enter(ytbx.Path, *yamlv3.Node, *yamlv3.Node) {}
+
+// Skip does nothing
+func (BaseVisitor) Skip(ytbx.Path, *yamlv3.Node, *yamlv3.Node, SkipReason) {}
+
+// Difference does nothing
+func (BaseVisitor) Difference(Diff) {}
+
+// WithVisitor specifies a visitor that is notified during the comparison
+func WithVisitor(visitor Visitor) CompareOption {
+	return func(settings *compareSettings) {
+		settings.Visitor = visitor
+	}
+}
+
+// differences notifies the visitor about all the differences of the result
+func (compare *compare) differences(diffs []Diff) []Diff {
+	if compare.settings.Visitor != nil {
+		for _, diff := range diffs {
+			compare.settings.Visitor.Difference(diff)
+		}
+	}
+
+	return diffs
+}
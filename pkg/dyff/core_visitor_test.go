@@ -0,0 +1,87 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("Visiting the comparison", func() {
+	It("should notify the visitor about entered, skipped, and different nodes", func() {
+		visitor := &recordingVisitor{}
+		result, err := compare(
+			yml(`{"a": {"x": 1}, "b": {"y": 1}}`),
+			yml(`{"a": {"x": 1}, "b": {"y": 2}}`),
+			dyff.WithVisitor(visitor),
+		)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(1))
+		Expect(visitor.entered).To(ContainElements("/a", "/b", "/b/y"))
+		Expect(visitor.entered).ToNot(ContainElement("/a/x"))
+		Expect(visitor.skipped).To(Equal([]string{"/a (identical)"}))
+		Expect(visitor.differences).To(Equal([]string{"/b/y"}))
+	})
+
+	It("should notify the visitor about ignored paths", func() {
+		visitor := &recordingVisitor{}
+		result, err := compare(
+			yml(`{"a": 1, "status": {"ready": false}, "old": 1, "name": "foo"}`),
+			yml(`{"a": 1, "status": {"ready": true}, "new": 1, "name": "bar"}`),
+			dyff.IgnorePaths("/status", "/old", "/new"),
+			dyff.WithVisitor(visitor),
+		)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(1))
+		Expect(visitor.entered).ToNot(ContainElement("/status"))
+		Expect(visitor.skipped).To(ConsistOf("/status (ignored)", "/old (ignored)", "/new (ignored)"))
+		Expect(visitor.differences).To(Equal([]string{"/name"}))
+	})
+})
+
+type recordingVisitor struct {
+	dyff.BaseVisitor
+
+	entered     []string
+	skipped     []string
+	differences []string
+}
+
+func (v *recordingVisitor) Enter(path ytbx.Path, _ *yamlv3.Node, _ *yamlv3.Node) {
+	v.entered = append(v.entered, path.String())
+}
+
+func (v *recordingVisitor) Skip(path ytbx.Path, _ *yamlv3.Node, _ *yamlv3.Node, reason dyff.SkipReason) {
+	v.skipped = append(v.skipped, fmt.Sprintf("%s (%s)", path.String(), reason))
+}
+
+func (v *recordingVisitor) Difference(diff dyff.Diff) {
+	v.differences = append(v.differences, diff.Path.String())
+}
@@ -0,0 +1,249 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+)
+
+// AgeCommand is the command that is used to decrypt age encrypted files
+var AgeCommand = "age"
+
+// GPGCommand is the command that is used to decrypt PGP encrypted files
+var GPGCommand = "gpg"
+
+// DecryptionKeys are the keys to decrypt encrypted input files, without keys
+// the defaults of the commands are used, e.g. the keys of the gpg-agent
+type DecryptionKeys struct {
+	// AgeIdentities are the identity files that are passed to age, which can
+	// also refer to plugins, e.g. for hardware keys
+	AgeIdentities []string
+
+	// PGPPassphraseFile contains the passphrase of the PGP key, which is used
+	// instead of asking the gpg-agent for it
+	PGPPassphraseFile string
+}
+
+// IsAgeEncrypted returns whether the data is an age encrypted file, either
+// in the binary or in the armored format
+func IsAgeEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("age-encryption.org/v1\n")) ||
+		bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN AGE ENCRYPTED FILE-----"))
+}
+
+// IsPGPEncrypted returns whether the data is a PGP encrypted message, either
+// in the armored format or in the binary format, which starts with a public
+// key or symmetric key encrypted session key packet. For the binary format,
+// the header of the packet is validated, since the first byte alone is also
+// the start of many UTF-8 characters, e.g. `Ü`.
+func IsPGPEncrypted(data []byte) bool {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN PGP MESSAGE-----")) {
+		return true
+	}
+
+	tag, body, ok := pgpPacket(data)
+	if !ok {
+		return false
+	}
+
+	switch tag {
+	case 1: // public key encrypted session key packet
+		switch {
+		case len(body) >= 10 && body[0] == 3:
+			// version, key ID, and the public key algorithm
+			switch body[9] {
+			case 1, 2, 3, 16, 18, 20, 22, 25, 26:
+				return true
+			}
+
+		case len(body) >= 3 && body[0] == 6:
+			return true
+		}
+
+	case 3: // symmetric key encrypted session key packet
+		switch {
+		case len(body) >= 3 && body[0] == 4:
+			// version, symmetric algorithm, and the string-to-key specifier
+			return body[1] >= 1 && body[1] <= 13 && (body[2] == 0 || body[2] == 1 || body[2] == 3 || body[2] == 4)
+
+		case len(body) >= 3 && (body[0] == 5 || body[0] == 6):
+			return true
+		}
+	}
+
+	return false
+}
+
+// pgpPacket returns the tag of the first packet of the data and the start of
+// its body, if the data starts with a valid packet header with a definite
+// length, both in the old and the new packet format
+func pgpPacket(data []byte) (byte, []byte, bool) {
+	if len(data) < 2 || data[0]&0x80 == 0 {
+		return 0, nil, false
+	}
+
+	var tag byte
+	var length, offset int
+	if data[0]&0x40 != 0 {
+		tag = data[0] & 0x3f
+		switch first := int(data[1]); {
+		case first < 192:
+			length, offset = first, 2
+
+		case first < 224 && len(data) >= 3:
+			length, offset = (first-192)<<8+int(data[2])+192, 3
+
+		case first == 255 && len(data) >= 6:
+			length, offset = int(data[2])<<24|int(data[3])<<16|int(data[4])<<8|int(data[5]), 6
+
+		default:
+			// partial body lengths are not used for session key packets
+			return 0, nil, false
+		}
+
+	} else {
+		tag = (data[0] >> 2) & 0x0f
+		switch data[0] & 0x03 {
+		case 0:
+			length, offset = int(data[1]), 2
+
+		case 1:
+			if len(data) < 3 {
+				return 0, nil, false
+			}
+
+			length, offset = int(data[1])<<8|int(data[2]), 3
+
+		case 2:
+			if len(data) < 5 {
+				return 0, nil, false
+			}
+
+			length, offset = int(data[1])<<24|int(data[2])<<16|int(data[3])<<8|int(data[4]), 5
+
+		default:
+			// indeterminate lengths are not used for session key packets
+			return 0, nil, false
+		}
+	}
+
+	// session key packets are small, so anything else is likely not PGP
+	if length < 3 || length > 4096 || offset >= len(data) {
+		return 0, nil, false
+	}
+
+	return tag, data[offset:], true
+}
+
+// DecryptingInputLoader returns a loader for local files that are encrypted
+// with age or PGP. The decryption is done in memory by the `age` or `gpg`
+// command, so that the plain text is never written to disk.
+func DecryptingInputLoader(keys DecryptionKeys) InputLoader {
+	return &decryptingInputLoader{keys: keys}
+}
+
+type decryptingInputLoader struct {
+	keys DecryptionKeys
+}
+
+var (
+	_ InputLoader = &decryptingInputLoader{}
+	_ DataLoader  = &decryptingInputLoader{}
+)
+
+func (l *decryptingInputLoader) Supports(location string) bool {
+	// Reading the header of a pipe would consume it, so only regular files
+	// are checked for encryption
+	if info, err := os.Stat(location); err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+
+	file, err := os.Open(location)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	// the markers of both formats are within the first bytes of the file
+	header := make([]byte, 64)
+	n, _ := io.ReadFull(file, header)
+	return IsAgeEncrypted(header[:n]) || IsPGPEncrypted(header[:n])
+}
+
+func (l *decryptingInputLoader) LoadData(location string) ([]byte, error) {
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, err
+	}
+
+	var name string
+	var args []string
+	switch {
+	case IsAgeEncrypted(data):
+		name, args = AgeCommand, []string{"--decrypt"}
+		for _, identity := range l.keys.AgeIdentities {
+			args = append(args, "--identity", identity)
+		}
+
+	default:
+		name, args = GPGCommand, []string{"--batch", "--quiet", "--decrypt"}
+		if l.keys.PGPPassphraseFile != "" {
+			args = append(args, "--pinentry-mode", "loopback", "--passphrase-file", l.keys.PGPPassphraseFile)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to decrypt %s: %s failed: %w: %s", location, name, err, msg)
+		}
+
+		return nil, fmt.Errorf("failed to decrypt %s: %s failed: %w", location, name, err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (l *decryptingInputLoader) Load(location string) (ytbx.InputFile, error) {
+	data, err := l.LoadData(location)
+	if err != nil {
+		return ytbx.InputFile{}, err
+	}
+
+	documents, err := ytbx.LoadDocuments(data)
+	if err != nil {
+		return ytbx.InputFile{}, fmt.Errorf("decrypted content of %s is invalid: %w", location, err)
+	}
+
+	return ytbx.InputFile{Location: location, Documents: documents}, nil
+}
@@ -0,0 +1,126 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("age and PGP encrypted input", func() {
+	fakeCommand := func(command *string, script string) {
+		location := filepath.Join(GinkgoT().TempDir(), "decrypt")
+		Expect(os.WriteFile(location, []byte("#!/bin/sh\n"+script+"\n"), 0755)).To(Succeed())
+
+		original := *command
+		*command = location
+		DeferCleanup(func() { *command = original })
+	}
+
+	encryptedFile := func(content string) string {
+		location := filepath.Join(GinkgoT().TempDir(), "secrets.enc")
+		Expect(os.WriteFile(location, []byte(content), 0644)).To(Succeed())
+		return location
+	}
+
+	It("should detect age and PGP encrypted files", func() {
+		Expect(dyff.IsAgeEncrypted([]byte("age-encryption.org/v1\n-> X25519 abc\n"))).To(BeTrue())
+		Expect(dyff.IsAgeEncrypted([]byte("-----BEGIN AGE ENCRYPTED FILE-----\nYWdl\n"))).To(BeTrue())
+		Expect(dyff.IsPGPEncrypted([]byte("-----BEGIN PGP MESSAGE-----\n\nhQ==\n"))).To(BeTrue())
+		Expect(dyff.IsPGPEncrypted([]byte{0x8c, 0x0d, 0x04, 0x09, 0x03})).To(BeTrue())
+		Expect(dyff.IsPGPEncrypted([]byte{0xc1, 0x4c, 0x03, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x01})).To(BeTrue())
+
+		Expect(dyff.IsAgeEncrypted([]byte("name: age-encryption.org/v1\n"))).To(BeFalse())
+		Expect(dyff.IsPGPEncrypted([]byte("---\nname: foo\n"))).To(BeFalse())
+		Expect(dyff.IsPGPEncrypted([]byte("Übersicht: foo\n"))).To(BeFalse())
+		Expect(dyff.IsPGPEncrypted([]byte("énoncé: foo\n"))).To(BeFalse())
+		Expect(dyff.IsPGPEncrypted([]byte{0xc1, 0x0d, 0x03})).To(BeFalse())
+	})
+
+	It("should load plain YAML files that start with a non-ASCII character", func() {
+		location := encryptedFile("Übersicht:\n  name: foo\n")
+		Expect(dyff.DecryptingInputLoader(dyff.DecryptionKeys{}).Supports(location)).To(BeFalse())
+
+		inputFile, err := dyff.LoadFile(location)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inputFile.Documents).To(HaveLen(1))
+		Expect(inputFile.Documents[0].Content[0].Content[0].Value).To(Equal("Übersicht"))
+	})
+
+	It("should only support encrypted files", func() {
+		loader := dyff.DecryptingInputLoader(dyff.DecryptionKeys{})
+		Expect(loader.Supports(encryptedFile("age-encryption.org/v1\n"))).To(BeTrue())
+		Expect(loader.Supports(encryptedFile("---\nname: foo\n"))).To(BeFalse())
+		Expect(loader.Supports("https://example.org/secrets.yml")).To(BeFalse())
+	})
+
+	It("should not read from pipes to check whether they are encrypted", func() {
+		reader, writer, err := os.Pipe()
+		Expect(err).ToNot(HaveOccurred())
+		defer reader.Close()
+
+		go func() {
+			defer writer.Close()
+			_, _ = writer.WriteString("foo: bar\n")
+		}()
+
+		location := fmt.Sprintf("/dev/fd/%d", reader.Fd())
+		Expect(dyff.DecryptingInputLoader(dyff.DecryptionKeys{}).Supports(location)).To(BeFalse())
+
+		inputFile, err := dyff.LoadFile(location)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inputFile.Documents).To(HaveLen(1))
+		Expect(inputFile.Documents[0].Content[0].Content[0].Value).To(Equal("foo"))
+	})
+
+	It("should decrypt age encrypted files using the configured identities", func() {
+		fakeCommand(&dyff.AgeCommand, `test "$*" = "--decrypt --identity key.txt" && cat >/dev/null && echo "password: secret"`)
+
+		inputFile, err := dyff.DecryptingInputLoader(dyff.DecryptionKeys{AgeIdentities: []string{"key.txt"}}).Load(encryptedFile("age-encryption.org/v1\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inputFile.Documents).To(HaveLen(1))
+
+		result, err := compare(inputFile.Documents[0].Content[0], yml(`{"password": "secret"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeEmpty())
+	})
+
+	It("should decrypt PGP encrypted files using the configured passphrase", func() {
+		fakeCommand(&dyff.GPGCommand, `test "$*" = "--batch --quiet --decrypt --pinentry-mode loopback --passphrase-file pass.txt" && echo "password: secret"`)
+
+		inputFile, err := dyff.DecryptingInputLoader(dyff.DecryptionKeys{PGPPassphraseFile: "pass.txt"}).Load(encryptedFile("-----BEGIN PGP MESSAGE-----\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inputFile.Documents).To(HaveLen(1))
+	})
+
+	It("should fail if the file cannot be decrypted", func() {
+		fakeCommand(&dyff.GPGCommand, `echo "no secret key" >&2; exit 2`)
+
+		_, err := dyff.DecryptingInputLoader(dyff.DecryptionKeys{}).Load(encryptedFile("-----BEGIN PGP MESSAGE-----\n"))
+		Expect(err).To(MatchError(ContainSubstring("exit status 2: no secret key")))
+	})
+})
@@ -0,0 +1,109 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+)
+
+// HelmCommand is the command that is used to render Helm charts
+var HelmCommand = "helm"
+
+// HelmTemplateOptions are the options to render a Helm chart, which are
+// passed to `helm template`
+type HelmTemplateOptions struct {
+	// ReleaseName is the name of the release, which is usually part of the
+	// names of the rendered resources
+	ReleaseName string
+
+	// Namespace is the namespace of the release
+	Namespace string
+
+	// Version is the version of the chart, if the chart is in a repository
+	Version string
+
+	// ValueFiles are the values files in the order they are applied
+	ValueFiles []string
+
+	// Values are values in the format of the `--set` flag, e.g. `key=value`
+	Values []string
+}
+
+// RenderHelmChart renders the chart, which can be a chart directory, archive,
+// or a chart in a repository, using `helm template` and returns the rendered
+// manifests as an input file with the chart as its location. Empty documents,
+// e.g. of templates that are disabled by the values, are left out.
+func RenderHelmChart(chart string, options HelmTemplateOptions) (ytbx.InputFile, error) {
+	var args = []string{"template", options.ReleaseName, chart}
+	if options.ReleaseName == "" {
+		args = []string{"template", chart, "--generate-name"}
+	}
+
+	if options.Namespace != "" {
+		args = append(args, "--namespace", options.Namespace)
+	}
+
+	if options.Version != "" {
+		args = append(args, "--version", options.Version)
+	}
+
+	for _, valueFile := range options.ValueFiles {
+		args = append(args, "--values", valueFile)
+	}
+
+	for _, value := range options.Values {
+		args = append(args, "--set", value)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(HelmCommand, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return ytbx.InputFile{}, fmt.Errorf("failed to render chart %s, %s failed: %w: %s", chart, HelmCommand, err, msg)
+		}
+
+		return ytbx.InputFile{}, fmt.Errorf("failed to render chart %s, %s failed: %w", chart, HelmCommand, err)
+	}
+
+	documents, err := ytbx.LoadDocuments(stdout.Bytes())
+	if err != nil {
+		return ytbx.InputFile{}, fmt.Errorf("failed to render chart %s, %s returned invalid data: %w", chart, HelmCommand, err)
+	}
+
+	var result = ytbx.InputFile{Location: chart}
+	for _, document := range documents {
+		if len(document.Content) == 0 || isEmptyDocument(document) {
+			continue
+		}
+
+		result.Documents = append(result.Documents, document)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,86 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("Helm charts", func() {
+	fakeHelm := func(script string) string {
+		dir := GinkgoT().TempDir()
+		location := filepath.Join(dir, "helm")
+		Expect(os.WriteFile(location, []byte("#!/bin/sh\n"+script+"\n"), 0755)).To(Succeed())
+
+		original := dyff.HelmCommand
+		dyff.HelmCommand = location
+		DeferCleanup(func() { dyff.HelmCommand = original })
+
+		return dir
+	}
+
+	It("should render the chart using helm template with the given options", func() {
+		dir := fakeHelm(`echo "$@" > "$(dirname "$0")/args"
+cat <<EOF
+---
+# Source: chart/templates/disabled.yaml
+---
+# Source: chart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: release-config
+EOF`)
+
+		inputFile, err := dyff.RenderHelmChart("./chart", dyff.HelmTemplateOptions{
+			ReleaseName: "release",
+			Namespace:   "apps",
+			Version:     "1.2.3",
+			ValueFiles:  []string{"a.yml", "b.yml"},
+			Values:      []string{"image.tag=latest"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inputFile.Location).To(Equal("./chart"))
+		Expect(inputFile.Documents).To(HaveLen(1))
+
+		result, err := compare(inputFile.Documents[0].Content[0], yml(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "release-config"}}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeEmpty())
+
+		args, err := os.ReadFile(filepath.Join(dir, "args"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(args)).To(Equal("template release ./chart --namespace apps --version 1.2.3 --values a.yml --values b.yml --set image.tag=latest\n"))
+	})
+
+	It("should fail if the chart cannot be rendered", func() {
+		fakeHelm(`echo "Error: chart not found" >&2; exit 1`)
+
+		_, err := dyff.RenderHelmChart("./chart", dyff.HelmTemplateOptions{ReleaseName: "release"})
+		Expect(err).To(MatchError(ContainSubstring("failed to render chart ./chart")))
+		Expect(err).To(MatchError(ContainSubstring("exit status 1: Error: chart not found")))
+	})
+})
@@ -0,0 +1,341 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ExtractHelmValues replaces the documents of the input file with the Helm
+// values of the releases defined in there, so that changes in the values are
+// compared as nested structures rather than as part of the whole resource.
+// Supported are Flux `HelmRelease` resources (`spec.values`) and Argo CD
+// `Application` resources (`spec.source.helm.values` and `valuesObject`),
+// where values that are embedded as a string are parsed as YAML.
+//
+// Each release is reduced to its `apiVersion`, `kind`, `metadata` name and
+// namespace, and the `values`, so that the documents can still be matched by
+// their Kubernetes resource name. With resolveValuesFrom, the `valuesFrom`
+// references of a `HelmRelease` are looked up in the ConfigMaps and Secrets
+// of the same input file and merged in the same order Flux uses, otherwise
+// the references are kept as `valuesFrom` next to the values.
+func ExtractHelmValues(inputFile *ytbx.InputFile, resolveValuesFrom bool) error {
+	var documents []*yamlv3.Node
+	for _, document := range inputFile.Documents {
+		node := document
+		if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+			node = node.Content[0]
+		}
+
+		var (
+			values *yamlv3.Node
+			err    error
+		)
+
+		switch {
+		case isHelmRelease(node):
+			values, err = helmReleaseValues(inputFile, node, resolveValuesFrom)
+
+		case isArgoApplication(node):
+			values, err = argoApplicationValues(node)
+
+		default:
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to extract Helm values of %s %s in %s: %w", kindOf(node), nameOf(node), inputFile.Location, err)
+		}
+
+		documents = append(documents, &yamlv3.Node{
+			Kind:    yamlv3.DocumentNode,
+			Content: []*yamlv3.Node{helmValuesDocument(node, values, resolveValuesFrom)},
+		})
+	}
+
+	if len(documents) == 0 {
+		return fmt.Errorf("no HelmRelease or Application documents found in %s", inputFile.Location)
+	}
+
+	inputFile.Documents = documents
+	return nil
+}
+
+func isHelmRelease(node *yamlv3.Node) bool {
+	return kindOf(node) == "HelmRelease"
+}
+
+func isArgoApplication(node *yamlv3.Node) bool {
+	apiVersion, _ := lookupString(node, "apiVersion")
+	return kindOf(node) == "Application" && strings.HasPrefix(apiVersion, "argoproj.io/")
+}
+
+func helmReleaseValues(inputFile *ytbx.InputFile, node *yamlv3.Node, resolveValuesFrom bool) (*yamlv3.Node, error) {
+	result := emptyMapping()
+
+	spec, ok := lookup(node, "spec")
+	if !ok {
+		return result, nil
+	}
+
+	if resolveValuesFrom {
+		if valuesFrom, ok := findValueByKey(spec, "valuesFrom"); ok && valuesFrom.Kind == yamlv3.SequenceNode {
+			namespace, _ := lookupString(node, "metadata", "namespace")
+			for _, reference := range valuesFrom.Content {
+				values, err := referencedValues(inputFile, namespace, reference)
+				if err != nil {
+					return nil, err
+				}
+
+				result = mergeHelmValues(result, values)
+			}
+		}
+	}
+
+	if values, ok := findValueByKey(spec, "values"); ok {
+		parsed, err := parseHelmValues(values)
+		if err != nil {
+			return nil, err
+		}
+
+		result = mergeHelmValues(result, parsed)
+	}
+
+	return result, nil
+}
+
+func argoApplicationValues(node *yamlv3.Node) (*yamlv3.Node, error) {
+	result := emptyMapping()
+
+	var sources []*yamlv3.Node
+	if source, ok := lookup(node, "spec", "source"); ok {
+		sources = append(sources, source)
+	}
+
+	if list, ok := lookup(node, "spec", "sources"); ok && list.Kind == yamlv3.SequenceNode {
+		sources = append(sources, list.Content...)
+	}
+
+	for _, source := range sources {
+		// Argo CD gives the `valuesObject` precedence over the `values` string
+		for _, key := range []string{"values", "valuesObject"} {
+			values, ok := lookup(source, "helm", key)
+			if !ok {
+				continue
+			}
+
+			parsed, err := parseHelmValues(values)
+			if err != nil {
+				return nil, err
+			}
+
+			result = mergeHelmValues(result, parsed)
+		}
+	}
+
+	return result, nil
+}
+
+// referencedValues returns the values of a `valuesFrom` reference, which
+// points to a key of a ConfigMap or Secret in the same namespace
+func referencedValues(inputFile *ytbx.InputFile, namespace string, reference *yamlv3.Node) (*yamlv3.Node, error) {
+	kind, _ := lookupString(reference, "kind")
+	name, _ := lookupString(reference, "name")
+	optional, _ := lookupString(reference, "optional")
+
+	valuesKey, ok := lookupString(reference, "valuesKey")
+	if !ok || valuesKey == "" {
+		valuesKey = "values.yaml"
+	}
+
+	if kind != "ConfigMap" && kind != "Secret" {
+		return nil, fmt.Errorf("unsupported valuesFrom kind %q, supported kinds are ConfigMap and Secret", kind)
+	}
+
+	data, found := referencedData(inputFile, kind, namespace, name, valuesKey)
+	if !found {
+		if optional == "true" {
+			return emptyMapping(), nil
+		}
+
+		return nil, fmt.Errorf("valuesFrom %s %s with key %s not found", kind, name, valuesKey)
+	}
+
+	if targetPath, ok := lookupString(reference, "targetPath"); ok && targetPath != "" {
+		return valuesAtTargetPath(targetPath, strings.TrimSpace(data)), nil
+	}
+
+	return parseHelmValues(stringNode(data))
+}
+
+func referencedData(inputFile *ytbx.InputFile, kind string, namespace string, name string, key string) (string, bool) {
+	for _, document := range inputFile.Documents {
+		node := document
+		if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+			node = node.Content[0]
+		}
+
+		if kindOf(node) != kind || nameOf(node) != name {
+			continue
+		}
+
+		if ns, ok := lookupString(node, "metadata", "namespace"); ok && namespace != "" && ns != namespace {
+			continue
+		}
+
+		if value, ok := lookupString(node, "stringData", key); ok {
+			return value, true
+		}
+
+		value, ok := lookupString(node, "data", key)
+		if !ok {
+			continue
+		}
+
+		if kind == "Secret" {
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				continue
+			}
+
+			value = string(decoded)
+		}
+
+		return value, true
+	}
+
+	return "", false
+}
+
+// valuesAtTargetPath creates the values for a dot separated target path,
+// which is how Flux sets a single value from a ConfigMap or Secret
+func valuesAtTargetPath(targetPath string, value string) *yamlv3.Node {
+	var node = stringNode(value)
+
+	elements := strings.Split(targetPath, ".")
+	for i := len(elements) - 1; i >= 0; i-- {
+		node = &yamlv3.Node{
+			Kind:    yamlv3.MappingNode,
+			Tag:     "!!map",
+			Content: []*yamlv3.Node{stringNode(elements[i]), node},
+		}
+	}
+
+	return node
+}
+
+// parseHelmValues returns the values as a nested structure, which means that
+// values that are embedded as a YAML string are parsed
+func parseHelmValues(values *yamlv3.Node) (*yamlv3.Node, error) {
+	values = followAlias(values)
+	if values.Kind != yamlv3.ScalarNode || values.Tag == "!!null" {
+		return values, nil
+	}
+
+	var document yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(values.Value), &document); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded values: %w", err)
+	}
+
+	if len(document.Content) == 0 {
+		return emptyMapping(), nil
+	}
+
+	return document.Content[0], nil
+}
+
+// mergeHelmValues merges the overlay onto the base the way Helm coalesces
+// values, which is a deep merge of maps where lists are replaced as a whole
+func mergeHelmValues(base *yamlv3.Node, overlay *yamlv3.Node) *yamlv3.Node {
+	m := merger{settings: mergeSettings{ListStrategy: ListReplace}}
+	return m.nodes(base, overlay)
+}
+
+func helmValuesDocument(node *yamlv3.Node, values *yamlv3.Node, resolveValuesFrom bool) *yamlv3.Node {
+	metadata := emptyMapping()
+	for _, key := range []string{"name", "namespace"} {
+		if value, ok := lookup(node, "metadata", key); ok {
+			metadata.Content = append(metadata.Content, stringNode(key), value)
+		}
+	}
+
+	result := emptyMapping()
+	for _, key := range []string{"apiVersion", "kind"} {
+		if value, ok := findValueByKey(node, key); ok {
+			result.Content = append(result.Content, stringNode(key), value)
+		}
+	}
+
+	result.Content = append(result.Content, stringNode("metadata"), metadata)
+
+	if !resolveValuesFrom {
+		if valuesFrom, ok := lookup(node, "spec", "valuesFrom"); ok {
+			result.Content = append(result.Content, stringNode("valuesFrom"), valuesFrom)
+		}
+	}
+
+	result.Content = append(result.Content, stringNode("values"), values)
+	return result
+}
+
+func kindOf(node *yamlv3.Node) string {
+	kind, _ := lookupString(node, "kind")
+	return kind
+}
+
+func nameOf(node *yamlv3.Node) string {
+	name, _ := lookupString(node, "metadata", "name")
+	return name
+}
+
+func lookup(node *yamlv3.Node, keys ...string) (*yamlv3.Node, bool) {
+	for _, key := range keys {
+		node = followAlias(node)
+		if node == nil || node.Kind != yamlv3.MappingNode {
+			return nil, false
+		}
+
+		var ok bool
+		if node, ok = findValueByKey(node, key); !ok {
+			return nil, false
+		}
+	}
+
+	return node, true
+}
+
+func lookupString(node *yamlv3.Node, keys ...string) (string, bool) {
+	value, ok := lookup(node, keys...)
+	if !ok || value.Kind != yamlv3.ScalarNode {
+		return "", false
+	}
+
+	return value.Value, true
+}
+
+func emptyMapping() *yamlv3.Node {
+	return &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+}
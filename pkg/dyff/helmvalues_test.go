@@ -0,0 +1,205 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"github.com/gonvenience/ytbx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("Helm values", func() {
+	expectSame := func(actual *yamlv3.Node, expected string) {
+		result, err := compare(actual, yml(expected))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeEmpty())
+	}
+
+	extract := func(input string, resolveValuesFrom bool) ([]*yamlv3.Node, error) {
+		inputFile := ytbx.InputFile{Location: "input", Documents: multiDoc(input)}
+		if err := dyff.ExtractHelmValues(&inputFile, resolveValuesFrom); err != nil {
+			return nil, err
+		}
+
+		return inputFile.Documents, nil
+	}
+
+	It("should reduce a HelmRelease to its values and keep the references", func() {
+		documents, err := extract(`---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-values
+data:
+  values.yaml: "replicas: 2"
+---
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: app
+  namespace: apps
+spec:
+  interval: 5m
+  valuesFrom:
+  - kind: ConfigMap
+    name: app-values
+  values:
+    image:
+      tag: "1.0"
+`, false)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(documents).To(HaveLen(1))
+		expectSame(documents[0].Content[0], `---
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: app
+  namespace: apps
+valuesFrom:
+- kind: ConfigMap
+  name: app-values
+values:
+  image:
+    tag: "1.0"
+`)
+	})
+
+	It("should merge the values referenced in valuesFrom in order", func() {
+		documents, err := extract(`---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-values
+  namespace: apps
+data:
+  values.yaml: |
+    replicas: 2
+    image:
+      repository: example/app
+      tag: "0.9"
+    args: [--verbose]
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app-secrets
+  namespace: apps
+data:
+  password: c2VjcmV0Cg==
+---
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: app
+  namespace: apps
+spec:
+  valuesFrom:
+  - kind: ConfigMap
+    name: app-values
+  - kind: Secret
+    name: app-secrets
+    valuesKey: password
+    targetPath: auth.password
+  - kind: ConfigMap
+    name: unknown
+    optional: true
+  values:
+    image:
+      tag: "1.0"
+    args: [--debug]
+`, true)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(documents).To(HaveLen(1))
+		expectSame(documents[0].Content[0], `---
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: app
+  namespace: apps
+values:
+  replicas: 2
+  image:
+    repository: example/app
+    tag: "1.0"
+  args: [--debug]
+  auth:
+    password: secret
+`)
+	})
+
+	It("should fail for references that cannot be found", func() {
+		_, err := extract(`---
+apiVersion: helm.toolkit.fluxcd.io/v2
+kind: HelmRelease
+metadata:
+  name: app
+spec:
+  valuesFrom:
+  - kind: ConfigMap
+    name: unknown
+`, true)
+
+		Expect(err).To(MatchError(ContainSubstring("valuesFrom ConfigMap unknown with key values.yaml not found")))
+	})
+
+	It("should parse the embedded values of an Argo CD Application", func() {
+		documents, err := extract(`---
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: app
+spec:
+  source:
+    chart: app
+    helm:
+      values: |
+        replicas: 2
+        image:
+          tag: "1.0"
+      valuesObject:
+        image:
+          tag: "1.1"
+`, false)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(documents).To(HaveLen(1))
+		expectSame(documents[0].Content[0], `---
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: app
+values:
+  replicas: 2
+  image:
+    tag: "1.1"
+`)
+	})
+
+	It("should fail if there are no releases in the input", func() {
+		_, err := extract(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "foo"}}`, false)
+		Expect(err).To(MatchError("no HelmRelease or Application documents found in input"))
+	})
+})
@@ -0,0 +1,265 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// VaultReferencePrefix is the prefix of string values that reference a secret
+// in Vault, for example `vault:secret/data/app#password`
+const VaultReferencePrefix = "vault:"
+
+var placeholderRegexp = regexp.MustCompile(`\(\(\s*([^()\s]+)\s*\)\)`)
+
+// PlaceholderResolver looks up the value of a placeholder, the reference is
+// either the name of a `((placeholder))`, or a complete `vault:` reference
+type PlaceholderResolver interface {
+	Resolve(reference string) (*yamlv3.Node, error)
+}
+
+// PlaceholderResolverFunc is a function that implements PlaceholderResolver
+type PlaceholderResolverFunc func(reference string) (*yamlv3.Node, error)
+
+// Resolve calls the function
+func (f PlaceholderResolverFunc) Resolve(reference string) (*yamlv3.Node, error) {
+	return f(reference)
+}
+
+// InterpolatePlaceholders replaces all `((placeholder))` and `vault:`
+// references in the documents of the input file with the values of the
+// resolver. A string that consists only of a placeholder is replaced with
+// the value as it is, which can also be a map or list. Placeholders inside
+// of a longer string can only be replaced with scalar values.
+func InterpolatePlaceholders(inputFile *ytbx.InputFile, resolver PlaceholderResolver) error {
+	for _, document := range inputFile.Documents {
+		if err := interpolate(document, resolver); err != nil {
+			return fmt.Errorf("failed to interpolate placeholders in %s: %w", inputFile.Location, err)
+		}
+	}
+
+	return nil
+}
+
+// SymbolicPlaceholders is a resolver that keeps placeholders as they are, but
+// in their canonical spelling, e.g. `(( name ))` becomes `((name))`. This way,
+// placeholders are compared by the name of the value they refer to.
+var SymbolicPlaceholders = PlaceholderResolverFunc(func(reference string) (*yamlv3.Node, error) {
+	if strings.HasPrefix(reference, VaultReferencePrefix) {
+		return stringNode(reference), nil
+	}
+
+	return stringNode("((" + reference + "))"), nil
+})
+
+func interpolate(node *yamlv3.Node, resolver PlaceholderResolver) error {
+	if node.Kind != yamlv3.ScalarNode {
+		for _, child := range node.Content {
+			if err := interpolate(child, resolver); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if node.Tag != "!!str" && node.Tag != "" {
+		return nil
+	}
+
+	// The complete value is a reference, which can be replaced by any value
+	var reference string
+	switch {
+	case strings.HasPrefix(node.Value, VaultReferencePrefix):
+		reference = node.Value
+
+	default:
+		if match := placeholderRegexp.FindStringSubmatch(node.Value); match != nil && match[0] == node.Value {
+			reference = match[1]
+		}
+	}
+
+	if reference != "" {
+		value, err := resolver.Resolve(reference)
+		if err != nil {
+			return err
+		}
+
+		*node = *value
+		return nil
+	}
+
+	// Placeholders inside of a string are replaced with the value as text
+	var err error
+	node.Value = placeholderRegexp.ReplaceAllStringFunc(node.Value, func(placeholder string) string {
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+		value, resolveErr := resolver.Resolve(name)
+		switch {
+		case resolveErr != nil:
+			err = resolveErr
+			return placeholder
+
+		case value.Kind != yamlv3.ScalarNode:
+			err = fmt.Errorf("placeholder ((%s)) is used inside of a string, but its value is not a scalar", name)
+			return placeholder
+		}
+
+		return value.Value
+	})
+
+	return err
+}
+
+// VarsFileResolver returns a resolver that looks up the values in a YAML file
+// with the names of the placeholders as keys, like BOSH variable files. The
+// name can refer to nested values by using a dot, e.g. `((cert.ca))`.
+func VarsFileResolver(location string) (PlaceholderResolver, error) {
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read variables from %s: %w", location, err)
+	}
+
+	var document yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse variables from %s: %w", location, err)
+	}
+
+	if len(document.Content) == 0 || document.Content[0].Kind != yamlv3.MappingNode {
+		return nil, fmt.Errorf("variables in %s have to be a map", location)
+	}
+
+	vars := document.Content[0]
+	return PlaceholderResolverFunc(func(reference string) (*yamlv3.Node, error) {
+		if value, ok := findValueByKey(vars, reference); ok {
+			return value, nil
+		}
+
+		var value = vars
+		for _, name := range strings.Split(reference, ".") {
+			var ok bool
+			if value.Kind != yamlv3.MappingNode {
+				return nil, fmt.Errorf("no value for %s in %s", reference, location)
+			}
+
+			if value, ok = findValueByKey(value, name); !ok {
+				return nil, fmt.Errorf("no value for %s in %s", reference, location)
+			}
+		}
+
+		return value, nil
+	}), nil
+}
+
+// ExecPlaceholderResolver returns a resolver that runs an external command
+// with the reference as the last argument, e.g. a wrapper for `credhub get`
+// or `vault kv get`. The command has to write the value as YAML to standard
+// output and exit with status 0.
+func ExecPlaceholderResolver(name string, args ...string) PlaceholderResolver {
+	var cache = map[string]*yamlv3.Node{}
+
+	return PlaceholderResolverFunc(func(reference string) (*yamlv3.Node, error) {
+		if value, ok := cache[reference]; ok {
+			return value, nil
+		}
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command(name, append(append([]string{}, args...), reference)...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("resolver %s failed for %s: %w: %s", name, reference, err, msg)
+			}
+
+			return nil, fmt.Errorf("resolver %s failed for %s: %w", name, reference, err)
+		}
+
+		var document yamlv3.Node
+		if err := yamlv3.Unmarshal(stdout.Bytes(), &document); err != nil {
+			return nil, fmt.Errorf("resolver %s returned an invalid value for %s: %w", name, reference, err)
+		}
+
+		var value = stringNode("")
+		if len(document.Content) > 0 {
+			value = document.Content[0]
+		}
+
+		cache[reference] = value
+		return value, nil
+	})
+}
+
+func stringNode(value string) *yamlv3.Node {
+	return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// PlaceholderWildcards is a comparer that considers a `((placeholder))` to be
+// equal to any concrete value on the other side, for example to compare a
+// template with the deployed result. Placeholders inside of a string only
+// match the text in their place, e.g. `https://((host))` matches
+// `https://example.com`, but not `http://example.com`. A placeholder is still
+// different from another placeholder.
+var PlaceholderWildcards Comparer = ComparerFunc(func(_ ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Detail, bool, error) {
+	if matchesPlaceholders(from, to) || matchesPlaceholders(to, from) {
+		return nil, true, nil
+	}
+
+	return nil, false, nil
+})
+
+func matchesPlaceholders(template *yamlv3.Node, value *yamlv3.Node) bool {
+	if template.Kind != yamlv3.ScalarNode || !placeholderRegexp.MatchString(template.Value) {
+		return false
+	}
+
+	if value.Kind == yamlv3.ScalarNode && placeholderRegexp.MatchString(value.Value) {
+		return false
+	}
+
+	if match := placeholderRegexp.FindString(template.Value); match == template.Value {
+		return true
+	}
+
+	if value.Kind != yamlv3.ScalarNode {
+		return false
+	}
+
+	var pattern strings.Builder
+	var last int
+	for _, loc := range placeholderRegexp.FindAllStringIndex(template.Value, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template.Value[last:loc[0]]))
+		pattern.WriteString("(?s:.*)")
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template.Value[last:]))
+
+	matched, err := regexp.MatchString("^"+pattern.String()+"$", value.Value)
+	return err == nil && matched
+}
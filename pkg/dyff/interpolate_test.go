@@ -0,0 +1,166 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/gonvenience/ytbx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("Placeholder interpolation", func() {
+	interpolate := func(input string, resolver dyff.PlaceholderResolver) (*yamlv3.Node, error) {
+		inputFile := ytbx.InputFile{Location: "input", Documents: multiDoc(input)}
+		if err := dyff.InterpolatePlaceholders(&inputFile, resolver); err != nil {
+			return nil, err
+		}
+
+		return inputFile.Documents[0].Content[0], nil
+	}
+
+	expectSame := func(actual *yamlv3.Node, expected string) {
+		result, err := compare(actual, yml(expected))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeEmpty())
+	}
+
+	It("should replace placeholders and vault references with their values", func() {
+		resolver := dyff.PlaceholderResolverFunc(func(reference string) (*yamlv3.Node, error) {
+			switch reference {
+			case "cert":
+				return yml(`{"ca": "CA", "certificate": "CERT"}`), nil
+
+			case "vault:secret/data/app#password":
+				return yml(`secret`), nil
+
+			default:
+				return yml(reference + "-value"), nil
+			}
+		})
+
+		result, err := interpolate(`{"tls": "((cert))", "password": "vault:secret/data/app#password", "url": "https://(( host )):((port))"}`, resolver)
+		Expect(err).ToNot(HaveOccurred())
+		expectSame(result, `{"tls": {"ca": "CA", "certificate": "CERT"}, "password": "secret", "url": "https://host-value:port-value"}`)
+	})
+
+	It("should fail if a placeholder inside of a string refers to a map", func() {
+		resolver := dyff.PlaceholderResolverFunc(func(string) (*yamlv3.Node, error) {
+			return yml(`{"ca": "CA"}`), nil
+		})
+
+		_, err := interpolate(`{"url": "https://((host))"}`, resolver)
+		Expect(err).To(MatchError("failed to interpolate placeholders in input: placeholder ((host)) is used inside of a string, but its value is not a scalar"))
+	})
+
+	It("should compare placeholders by their name in symbolic mode", func() {
+		result, err := interpolate(`{"a": "(( name ))", "b": "x-((  other ))"}`, dyff.SymbolicPlaceholders)
+		Expect(err).ToNot(HaveOccurred())
+		expectSame(result, `{"a": "((name))", "b": "x-((other))"}`)
+	})
+
+	It("should look up values in a vars file including nested values", func() {
+		location := filepath.Join(GinkgoT().TempDir(), "vars.yml")
+		Expect(os.WriteFile(location, []byte("cert:\n  ca: CA\npassword: secret\n"), 0644)).To(Succeed())
+
+		resolver, err := dyff.VarsFileResolver(location)
+		Expect(err).ToNot(HaveOccurred())
+
+		result, err := interpolate(`{"ca": "((cert.ca))", "password": "((password))"}`, resolver)
+		Expect(err).ToNot(HaveOccurred())
+		expectSame(result, `{"ca": "CA", "password": "secret"}`)
+
+		_, err = interpolate(`{"unknown": "((unknown))"}`, resolver)
+		Expect(err).To(MatchError(fmt.Sprintf("failed to interpolate placeholders in input: no value for unknown in %s", location)))
+	})
+
+	It("should look up values using an external command", func() {
+		resolver := dyff.ExecPlaceholderResolver("sh", "-c", `echo "value of $1"`, "sh")
+
+		result, err := interpolate(`{"password": "((password))"}`, resolver)
+		Expect(err).ToNot(HaveOccurred())
+		expectSame(result, `{"password": "value of password"}`)
+	})
+
+	Context("placeholders as wildcards", func() {
+		It("should consider placeholders equal to any concrete value", func() {
+			result, err := compare(
+				yml(`{"password": "((password))", "tls": "((cert))", "url": "https://((host)):443"}`),
+				yml(`{"password": "secret", "tls": {"ca": "CA"}, "url": "https://example.com:443"}`),
+				dyff.ComparerForPathRegexp(regexp.MustCompile(`^/`), dyff.PlaceholderWildcards),
+			)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should still report placeholders that do not match or differ from each other", func() {
+			result, err := compare(
+				yml(`{"a": "((password))", "url": "https://((host)):443"}`),
+				yml(`{"a": "((other))", "url": "http://example.com:443"}`),
+				dyff.ComparerForPathRegexp(regexp.MustCompile(`^/`), dyff.PlaceholderWildcards),
+			)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(HaveLen(2))
+		})
+
+		It("should consider placeholders in simple lists equal to concrete values", func() {
+			result, err := compare(
+				yml(`{"hosts": ["((primary))", "b.example.com", "((secondary))"]}`),
+				yml(`{"hosts": ["a.example.com", "b.example.com", "c.example.com", "d.example.com"]}`),
+				dyff.ComparerForPathRegexp(regexp.MustCompile(`^/`), dyff.PlaceholderWildcards),
+			)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(HaveLen(1))
+			Expect(result[0].Details).To(HaveLen(1))
+			Expect(result[0].Details[0].Kind).To(Equal(dyff.ADDITION))
+			Expect(result[0].Details[0].To.Content).To(HaveLen(1))
+			Expect(result[0].Details[0].To.Content[0].Value).To(Equal("d.example.com"))
+		})
+
+		It("should consider placeholders in simple lists equal to concrete values with any list algorithm", func() {
+			for _, algorithm := range []dyff.ListDiffAlgorithm{dyff.ListDiffLCS, dyff.ListDiffPatience, dyff.ListDiffHistogram, dyff.ListDiffGreedy} {
+				result, err := compare(
+					yml(`{"hosts": ["((primary))", "b.example.com", "((secondary))"]}`),
+					yml(`{"hosts": ["a.example.com", "b.example.com", "c.example.com", "d.example.com"]}`),
+					dyff.ComparerForPathRegexp(regexp.MustCompile(`^/`), dyff.PlaceholderWildcards),
+					dyff.ListAlgorithm(algorithm),
+				)
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(HaveLen(1), string(algorithm))
+				Expect(result[0].Details).To(HaveLen(1))
+				Expect(result[0].Details[0].Kind).To(Equal(dyff.ADDITION))
+				Expect(result[0].Details[0].To.Content).To(HaveLen(1))
+				Expect(result[0].Details[0].To.Content[0].Value).To(Equal("d.example.com"))
+			}
+		})
+	})
+})
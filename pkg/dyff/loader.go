@@ -0,0 +1,199 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// InputLoader loads input files from locations that are not supported by the
+// default input handling, e.g. an internal configuration store
+type InputLoader interface {
+	// Supports returns whether the loader is responsible for the location
+	Supports(location string) bool
+
+	// Load loads the documents of the location
+	Load(location string) (ytbx.InputFile, error)
+}
+
+// DataLoader is implemented by input loaders that can provide the data of a
+// location before it is parsed, so that it can be parsed in a given format
+// instead of the format that is detected from the content
+type DataLoader interface {
+	// LoadData loads the unparsed data of the location
+	LoadData(location string) ([]byte, error)
+}
+
+var inputLoaders struct {
+	sync.RWMutex
+	loaders []InputLoader
+}
+
+// RegisterInputLoader adds a loader that is used by LoadFile for all
+// locations it supports, loaders registered first take precedence
+func RegisterInputLoader(loader InputLoader) {
+	inputLoaders.Lock()
+	defer inputLoaders.Unlock()
+
+	inputLoaders.loaders = append(inputLoaders.loaders, loader)
+}
+
+// UnregisterInputLoader removes a loader that was registered before, the
+// loader has to be the same value that was passed to RegisterInputLoader
+func UnregisterInputLoader(loader InputLoader) {
+	inputLoaders.Lock()
+	defer inputLoaders.Unlock()
+
+	for i, registered := range inputLoaders.loaders {
+		if registered == loader {
+			inputLoaders.loaders = append(inputLoaders.loaders[:i:i], inputLoaders.loaders[i+1:]...)
+			return
+		}
+	}
+}
+
+// ResetInputLoaders removes all registered loaders
+func ResetInputLoaders() {
+	inputLoaders.Lock()
+	defer inputLoaders.Unlock()
+
+	inputLoaders.loaders = nil
+}
+
+// InputLoaderFor returns the registered loader that supports the location
+func InputLoaderFor(location string) (InputLoader, bool) {
+	inputLoaders.RLock()
+	defer inputLoaders.RUnlock()
+
+	for _, loader := range inputLoaders.loaders {
+		if loader.Supports(location) {
+			return loader, true
+		}
+	}
+
+	return nil, false
+}
+
+// LoadFile loads the input file from the location using the registered
+// loader that supports it, or the default input handling otherwise
+func LoadFile(location string) (ytbx.InputFile, error) {
+	if loader, ok := InputLoaderFor(location); ok {
+		return loader.Load(location)
+	}
+
+	return ytbx.LoadFile(location)
+}
+
+// LoadFiles loads both input files like LoadFile
+func LoadFiles(fromLocation string, toLocation string) (ytbx.InputFile, ytbx.InputFile, error) {
+	from, err := LoadFile(fromLocation)
+	if err != nil {
+		return ytbx.InputFile{}, ytbx.InputFile{}, err
+	}
+
+	to, err := LoadFile(toLocation)
+	if err != nil {
+		return ytbx.InputFile{}, ytbx.InputFile{}, err
+	}
+
+	return from, to, nil
+}
+
+// LoadDocumentsWithFormat parses the data in the given format instead of
+// detecting it from the content, which makes a difference for inputs that
+// are valid in more than one format. Supported formats are yaml, json, and
+// toml.
+func LoadDocumentsWithFormat(data []byte, format string) ([]*yamlv3.Node, error) {
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		return ytbx.LoadYAMLDocuments(data)
+
+	case "json":
+		return ytbx.LoadJSONDocuments(data)
+
+	case "toml":
+		return ytbx.LoadTOMLDocuments(data)
+
+	default:
+		return nil, fmt.Errorf("unknown input format %s, supported formats are yaml, json, or toml", format)
+	}
+}
+
+// ExecInputLoader returns a loader for all locations starting with
+// `<scheme>://`, which runs an external command with the location as the
+// last argument. The command has to write the documents in YAML, JSON, or
+// TOML format to standard output and exit with status 0.
+func ExecInputLoader(scheme string, name string, args ...string) InputLoader {
+	return &execInputLoader{scheme: scheme, name: name, args: args}
+}
+
+type execInputLoader struct {
+	scheme string
+	name   string
+	args   []string
+}
+
+var (
+	_ InputLoader = &execInputLoader{}
+	_ DataLoader  = &execInputLoader{}
+)
+
+func (l *execInputLoader) Supports(location string) bool {
+	return strings.HasPrefix(location, l.scheme+"://")
+}
+
+func (l *execInputLoader) LoadData(location string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(l.name, append(append([]string{}, l.args...), location)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("loader %s failed for %s: %w: %s", l.name, location, err, msg)
+		}
+
+		return nil, fmt.Errorf("loader %s failed for %s: %w", l.name, location, err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (l *execInputLoader) Load(location string) (ytbx.InputFile, error) {
+	data, err := l.LoadData(location)
+	if err != nil {
+		return ytbx.InputFile{}, err
+	}
+
+	documents, err := ytbx.LoadDocuments(data)
+	if err != nil {
+		return ytbx.InputFile{}, fmt.Errorf("loader %s returned invalid data for %s: %w", l.name, location, err)
+	}
+
+	return ytbx.InputFile{Location: location, Documents: documents}, nil
+}
@@ -0,0 +1,93 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"github.com/gonvenience/ytbx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type staticLoader struct{}
+
+func (staticLoader) Supports(location string) bool {
+	return location == "static"
+}
+
+func (staticLoader) Load(location string) (ytbx.InputFile, error) {
+	return ytbx.InputFile{Location: location, Documents: multiDoc(`{"foo": "bar"}`)}, nil
+}
+
+var _ = Describe("Input loaders", func() {
+	AfterEach(func() {
+		dyff.ResetInputLoaders()
+	})
+
+	It("should use a registered loader for the locations it supports", func() {
+		dyff.RegisterInputLoader(staticLoader{})
+
+		inputFile, err := dyff.LoadFile("static")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inputFile.Location).To(Equal("static"))
+		Expect(inputFile.Documents).To(HaveLen(1))
+	})
+
+	It("should use the default input handling for other locations", func() {
+		dyff.RegisterInputLoader(staticLoader{})
+
+		inputFile, err := dyff.LoadFile(assets("examples", "from.yml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inputFile.Location).To(Equal(assets("examples", "from.yml")))
+	})
+
+	It("should only remove the given loader when unregistering", func() {
+		loader := dyff.ExecInputLoader("store", "true")
+		dyff.RegisterInputLoader(staticLoader{})
+		dyff.RegisterInputLoader(loader)
+
+		dyff.UnregisterInputLoader(loader)
+
+		_, ok := dyff.InputLoaderFor("store://config/app")
+		Expect(ok).To(BeFalse())
+
+		_, ok = dyff.InputLoaderFor("static")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should load the documents using an external command", func() {
+		dyff.RegisterInputLoader(dyff.ExecInputLoader("store", "sh", "-c", `printf -- '---\nlocation: %s\n---\nfoo: bar\n' "$1"`, "sh"))
+
+		inputFile, err := dyff.LoadFile("store://config/app")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inputFile.Location).To(Equal("store://config/app"))
+		Expect(inputFile.Documents).To(HaveLen(2))
+		Expect(inputFile.Documents[0].Content[0].Content[1].Value).To(Equal("store://config/app"))
+	})
+
+	It("should fail if the external command fails", func() {
+		dyff.RegisterInputLoader(dyff.ExecInputLoader("store", "sh", "-c", "echo not found >&2; exit 1"))
+
+		_, err := dyff.LoadFile("store://config/app")
+		Expect(err).To(MatchError("loader sh failed for store://config/app: exit status 1: not found"))
+	})
+})
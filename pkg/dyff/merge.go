@@ -0,0 +1,280 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ListMergeStrategy defines how two lists are merged with each other
+type ListMergeStrategy string
+
+// Supported list merge strategies
+const (
+	ListReplace    ListMergeStrategy = "replace"
+	ListAppend     ListMergeStrategy = "append"
+	ListMergeByKey ListMergeStrategy = "merge-by-key"
+)
+
+// MergeOption sets a specific merge setting for merging documents
+type MergeOption func(*mergeSettings)
+
+type mergeSettings struct {
+	ListStrategy ListMergeStrategy
+	ListKey      string
+}
+
+// ListStrategy specifies how lists are merged, with merge by key being the
+// default, which falls back to replace the list in case there is no field
+// that can serve as an identifier for the list entries
+func ListStrategy(strategy ListMergeStrategy) MergeOption {
+	return func(settings *mergeSettings) {
+		settings.ListStrategy = strategy
+	}
+}
+
+// ListKey specifies the field that takes precedence over the usual suspects
+// (name, key, or id) when list entries are merged by key
+func ListKey(fieldName string) MergeOption {
+	return func(settings *mergeSettings) {
+		settings.ListKey = fieldName
+	}
+}
+
+// ParseListMergeStrategy returns the list merge strategy for the given name
+func ParseListMergeStrategy(name string) (ListMergeStrategy, error) {
+	switch strategy := ListMergeStrategy(name); strategy {
+	case ListReplace, ListAppend, ListMergeByKey:
+		return strategy, nil
+	}
+
+	return "", fmt.Errorf("unknown list merge strategy %s, supported strategies are: replace, append, or merge-by-key", name)
+}
+
+// MergeInputFiles performs a deep merge of the overlays onto the base input
+// file, where the documents of the base input file are modified in place.
+// Map keys keep the order of the base with new keys being added at the end.
+// Documents are matched by their Kubernetes resource name if possible and
+// by their index otherwise, with additional documents being appended.
+func MergeInputFiles(base ytbx.InputFile, overlays []ytbx.InputFile, mergeOptions ...MergeOption) (ytbx.InputFile, error) {
+	settings := mergeSettings{ListStrategy: ListMergeByKey}
+	for _, mergeOption := range mergeOptions {
+		mergeOption(&settings)
+	}
+
+	if _, err := ParseListMergeStrategy(string(settings.ListStrategy)); err != nil {
+		return ytbx.InputFile{}, err
+	}
+
+	cmpr := &compare{
+		settings: compareSettings{
+			NonStandardIdentifierGuessCountThreshold: 3,
+			KubernetesEntityDetection:                true,
+		},
+	}
+
+	if settings.ListKey != "" {
+		cmpr.settings.AdditionalIdentifiers = []string{settings.ListKey}
+	}
+
+	merger := merger{settings: settings, compare: cmpr}
+	for _, overlay := range overlays {
+		base.Documents = merger.documents(base.Documents, overlay.Documents)
+	}
+
+	return base, nil
+}
+
+type merger struct {
+	settings mergeSettings
+	compare  *compare
+}
+
+func (m *merger) documents(base []*yamlv3.Node, overlay []*yamlv3.Node) []*yamlv3.Node {
+	names := func(documents []*yamlv3.Node) ([]string, bool) {
+		var result []string
+		for _, document := range documents {
+			if isEmptyDocument(document) {
+				return nil, false
+			}
+
+			name, err := k8sItem.Name(document.Content[0])
+			if err != nil {
+				return nil, false
+			}
+
+			result = append(result, name)
+		}
+
+		return result, true
+	}
+
+	baseNames, baseOK := names(base)
+	overlayNames, overlayOK := names(overlay)
+
+	for i, document := range overlay {
+		idx := -1
+		switch {
+		case baseOK && overlayOK:
+			for j, name := range baseNames {
+				if name == overlayNames[i] {
+					idx = j
+					break
+				}
+			}
+
+		case i < len(base):
+			idx = i
+		}
+
+		switch {
+		case idx < 0:
+			base = append(base, document)
+
+		case isEmptyDocument(base[idx]):
+			base[idx] = document
+
+		case !isEmptyDocument(document):
+			base[idx].Content[0] = m.nodes(base[idx].Content[0], document.Content[0])
+		}
+	}
+
+	return base
+}
+
+func (m *merger) nodes(base *yamlv3.Node, overlay *yamlv3.Node) *yamlv3.Node {
+	base, overlay = resolveAlias(base), resolveAlias(overlay)
+
+	switch {
+	case base == nil:
+		return overlay
+
+	case overlay == nil:
+		return base
+
+	case base.Kind == yamlv3.MappingNode && overlay.Kind == yamlv3.MappingNode:
+		return m.mappings(base, overlay)
+
+	case base.Kind == yamlv3.SequenceNode && overlay.Kind == yamlv3.SequenceNode:
+		return m.sequences(base, overlay)
+	}
+
+	return overlay
+}
+
+func (m *merger) mappings(base *yamlv3.Node, overlay *yamlv3.Node) *yamlv3.Node {
+	for i := 0; i+1 < len(overlay.Content); i += 2 {
+		key, value := overlay.Content[i], overlay.Content[i+1]
+
+		var found bool
+		for j := 0; j+1 < len(base.Content); j += 2 {
+			if base.Content[j].Value == key.Value {
+				base.Content[j+1] = m.nodes(base.Content[j+1], value)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			base.Content = append(base.Content, key, value)
+		}
+	}
+
+	return base
+}
+
+func (m *merger) sequences(base *yamlv3.Node, overlay *yamlv3.Node) *yamlv3.Node {
+	switch m.settings.ListStrategy {
+	case ListAppend:
+		base.Content = append(base.Content, overlay.Content...)
+		return base
+
+	case ListMergeByKey:
+		identifier := m.identifier(base, overlay)
+		if identifier == nil {
+			return overlay
+		}
+
+		// Check all entries before the base list is modified, since the
+		// overlay replaces the list in case one entry has no name
+		names := make([]string, len(overlay.Content))
+		for i, entry := range overlay.Content {
+			name, err := identifier.Name(entry)
+			if err != nil {
+				return overlay
+			}
+
+			names[i] = name
+		}
+
+		for i, entry := range overlay.Content {
+			name := names[i]
+			if existing, err := identifier.FindNodeByName(base, name); err == nil {
+				for i := range base.Content {
+					if base.Content[i] == existing {
+						base.Content[i] = m.nodes(existing, entry)
+					}
+				}
+
+				continue
+			}
+
+			base.Content = append(base.Content, entry)
+		}
+
+		return base
+	}
+
+	return overlay
+}
+
+// identifier returns the identifier to be used to merge the two lists by key,
+// or nil if there is no identifier that works for both lists
+func (m *merger) identifier(base *yamlv3.Node, overlay *yamlv3.Node) listItemIdentifier {
+	if identifier, err := m.compare.getIdentifierFromNamedLists(base, overlay); err == nil {
+		return identifier
+	}
+
+	if identifier := m.compare.getNonStandardIdentifierFromNamedLists(base, overlay); identifier != nil {
+		return identifier
+	}
+
+	if identifier, err := m.compare.getIdentifierFromKubernetesEntityList(base, overlay); err == nil {
+		return identifier
+	}
+
+	return nil
+}
+
+// resolveAlias returns a copy of the node that an alias refers to, so that
+// merging does not modify the anchored node and all other aliases of it
+func resolveAlias(node *yamlv3.Node) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.AliasNode {
+		return node
+	}
+
+	result := copyNode(followAlias(node))
+	result.Anchor = ""
+	return result
+}
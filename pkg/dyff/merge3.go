@@ -0,0 +1,290 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"context"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Conflict is a location that ours and theirs changed in different ways
+// compared to their common base, where a nil node means that there is no
+// value at the location in the respective input file
+type Conflict struct {
+	Path   ytbx.Path
+	Base   *yamlv3.Node
+	Ours   *yamlv3.Node
+	Theirs *yamlv3.Node
+}
+
+// ThreeWayResult is the result of the comparison of two input files with
+// their common base
+type ThreeWayResult struct {
+	// Ours and Theirs are the differences of both input files to the base
+	Ours   Report
+	Theirs Report
+
+	// Merged contains the changes of both input files, where locations with
+	// a conflict keep the value of ours
+	Merged ytbx.InputFile
+
+	// Conflicts are the changes that could not be merged
+	Conflicts []Conflict
+}
+
+// ThreeWayCompare compares ours and theirs with their common base, and merges
+// the changes of both into a new input file, e.g. to merge two branches that
+// changed the same YAML file. Documents are merged by their index, maps by
+// their keys, and lists by the identifier of their entries, or as multisets
+// of entries if there is no identifier. Changes that ours and theirs made in
+// different ways are reported as conflicts. The merged input file shares
+// nodes with the input files, which are not modified.
+func ThreeWayCompare(base ytbx.InputFile, ours ytbx.InputFile, theirs ytbx.InputFile, compareOptions ...CompareOption) (ThreeWayResult, error) {
+	return ThreeWayCompareContext(context.Background(), base, ours, theirs, compareOptions...)
+}
+
+// ThreeWayCompareContext is like ThreeWayCompare, but stops the comparison
+// with the error of the context as soon as the context is done.
+func ThreeWayCompareContext(ctx context.Context, base ytbx.InputFile, ours ytbx.InputFile, theirs ytbx.InputFile, compareOptions ...CompareOption) (ThreeWayResult, error) {
+	oursReport, err := CompareInputFilesContext(ctx, base, ours, compareOptions...)
+	if err != nil {
+		return ThreeWayResult{}, err
+	}
+
+	theirsReport, err := CompareInputFilesContext(ctx, base, theirs, compareOptions...)
+	if err != nil {
+		return ThreeWayResult{}, err
+	}
+
+	var result = ThreeWayResult{
+		Ours:   oursReport,
+		Theirs: theirsReport,
+		Merged: ytbx.InputFile{Location: ours.Location},
+	}
+
+	m := threeWayMerger{compare: newCompare(ctx, compareOptions...)}
+	m.compare.resolvePaths(base.Documents, append(append([]*yamlv3.Node{}, ours.Documents...), theirs.Documents...))
+
+	document := func(documents []*yamlv3.Node, idx int) *yamlv3.Node {
+		if idx >= len(documents) || isEmptyDocument(documents[idx]) {
+			return nil
+		}
+
+		return documents[idx].Content[0]
+	}
+
+	count := max(len(base.Documents), max(len(ours.Documents), len(theirs.Documents)))
+	for i := 0; i < count; i++ {
+		path := ytbx.Path{Root: &result.Merged, DocumentIdx: len(result.Merged.Documents)}
+		node := m.nodes(path, document(base.Documents, i), document(ours.Documents, i), document(theirs.Documents, i))
+		if node == nil {
+			continue
+		}
+
+		result.Merged.Documents = append(result.Merged.Documents, &yamlv3.Node{
+			Kind:    yamlv3.DocumentNode,
+			Content: []*yamlv3.Node{node},
+		})
+	}
+
+	result.Conflicts = m.conflicts
+	return result, nil
+}
+
+type threeWayMerger struct {
+	compare   *compare
+	conflicts []Conflict
+}
+
+// nodes returns the merged node of the base, ours, and theirs node, where nil
+// means that there is no value in the merged result
+func (m *threeWayMerger) nodes(path ytbx.Path, base *yamlv3.Node, ours *yamlv3.Node, theirs *yamlv3.Node) *yamlv3.Node {
+	base, ours, theirs = followAlias(base), followAlias(ours), followAlias(theirs)
+
+	switch {
+	case m.same(ours, theirs), m.same(base, theirs):
+		return ours
+
+	case m.same(base, ours):
+		return theirs
+
+	case isKind(yamlv3.MappingNode, ours, theirs) && (base == nil || base.Kind == yamlv3.MappingNode):
+		return m.mappings(path, base, ours, theirs)
+
+	case isKind(yamlv3.SequenceNode, ours, theirs) && (base == nil || base.Kind == yamlv3.SequenceNode):
+		return m.sequences(path, base, ours, theirs)
+	}
+
+	m.conflicts = append(m.conflicts, Conflict{Path: path, Base: base, Ours: ours, Theirs: theirs})
+	return ours
+}
+
+func (m *threeWayMerger) mappings(path ytbx.Path, base *yamlv3.Node, ours *yamlv3.Node, theirs *yamlv3.Node) *yamlv3.Node {
+	value := func(mappingNode *yamlv3.Node, key string) *yamlv3.Node {
+		if mappingNode == nil {
+			return nil
+		}
+
+		value, _ := findValueByKey(mappingNode, key)
+		return value
+	}
+
+	result := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: ours.Tag, Style: ours.Style}
+	for _, keys := range [][]*yamlv3.Node{ours.Content, theirs.Content} {
+		for i := 0; i+1 < len(keys); i += 2 {
+			key := followAlias(keys[i])
+			if _, ok := findValueByKey(result, key.Value); ok {
+				continue
+			}
+
+			merged := m.nodes(
+				ytbx.NewPathWithNamedElement(path, key.Value),
+				value(base, key.Value),
+				value(ours, key.Value),
+				value(theirs, key.Value),
+			)
+
+			if merged != nil {
+				result.Content = append(result.Content, keys[i], merged)
+			}
+		}
+	}
+
+	return result
+}
+
+func (m *threeWayMerger) sequences(path ytbx.Path, base *yamlv3.Node, ours *yamlv3.Node, theirs *yamlv3.Node) *yamlv3.Node {
+	if base == nil {
+		base = &yamlv3.Node{Kind: yamlv3.SequenceNode}
+	}
+
+	identifier := m.identifier(base, ours, theirs)
+	if identifier == nil {
+		return m.multisets(base, ours, theirs)
+	}
+
+	entry := func(sequenceNode *yamlv3.Node, name string) *yamlv3.Node {
+		entry, err := identifier.FindNodeByName(sequenceNode, name)
+		if err != nil {
+			return nil
+		}
+
+		return entry
+	}
+
+	result := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: ours.Tag, Style: ours.Style}
+	var done = map[string]struct{}{}
+	for _, entries := range [][]*yamlv3.Node{ours.Content, theirs.Content} {
+		for _, listEntry := range entries {
+			name, _ := identifier.Name(followAlias(listEntry))
+			if _, ok := done[name]; ok {
+				continue
+			}
+
+			done[name] = struct{}{}
+			merged := m.nodes(
+				ytbx.NewPathWithNamedListElement(path, identifierKey(identifier, followAlias(listEntry)), name),
+				entry(base, name),
+				entry(ours, name),
+				entry(theirs, name),
+			)
+
+			if merged != nil {
+				result.Content = append(result.Content, merged)
+			}
+		}
+	}
+
+	return result
+}
+
+// multisets merges lists without identifier, where each entry is kept as
+// often as it is in ours, plus the number of times theirs added or removed it
+func (m *threeWayMerger) multisets(base *yamlv3.Node, ours *yamlv3.Node, theirs *yamlv3.Node) *yamlv3.Node {
+	count := func(sequenceNode *yamlv3.Node) map[[32]byte]int {
+		result := map[[32]byte]int{}
+		for _, entry := range sequenceNode.Content {
+			result[m.compare.subtreeHash(followAlias(entry))]++
+		}
+
+		return result
+	}
+
+	baseCount, oursCount, theirsCount := count(base), count(ours), count(theirs)
+
+	result := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: ours.Tag, Style: ours.Style}
+	var added = map[[32]byte]int{}
+	for _, entries := range [][]*yamlv3.Node{ours.Content, theirs.Content} {
+		for _, entry := range entries {
+			hash := m.compare.subtreeHash(followAlias(entry))
+			if added[hash] < oursCount[hash]+theirsCount[hash]-baseCount[hash] {
+				added[hash]++
+				result.Content = append(result.Content, entry)
+			}
+		}
+	}
+
+	return result
+}
+
+// identifier returns the identifier that works for the entries of all three
+// lists, or nil if there is none
+func (m *threeWayMerger) identifier(base *yamlv3.Node, ours *yamlv3.Node, theirs *yamlv3.Node) listItemIdentifier {
+	var identifier listItemIdentifier
+	if candidate, err := m.compare.getIdentifierFromNamedLists(ours, theirs); err == nil {
+		identifier = candidate
+	} else if candidate := m.compare.getNonStandardIdentifierFromNamedLists(ours, theirs); candidate != nil {
+		identifier = candidate
+	} else {
+		return nil
+	}
+
+	for _, sequenceNode := range []*yamlv3.Node{base, ours, theirs} {
+		for _, entry := range sequenceNode.Content {
+			if _, err := identifier.Name(followAlias(entry)); err != nil {
+				return nil
+			}
+		}
+	}
+
+	return identifier
+}
+
+// same returns whether both nodes have the same content, or are both nil
+func (m *threeWayMerger) same(a *yamlv3.Node, b *yamlv3.Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return m.compare.subtreeHash(a) == m.compare.subtreeHash(b)
+}
+
+func isKind(kind yamlv3.Kind, nodes ...*yamlv3.Node) bool {
+	for _, node := range nodes {
+		if node == nil || node.Kind != kind {
+			return false
+		}
+	}
+
+	return true
+}
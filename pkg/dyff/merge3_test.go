@@ -0,0 +1,188 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("Three-way compare", func() {
+	threeWay := func(base string, ours string, theirs string) (string, []dyff.Conflict) {
+		result, err := dyff.ThreeWayCompare(
+			ytbx.InputFile{Documents: multiDoc(base)},
+			ytbx.InputFile{Documents: multiDoc(ours)},
+			ytbx.InputFile{Documents: multiDoc(theirs)},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		var out []byte
+		for _, document := range result.Merged.Documents {
+			data, err := yamlv3.Marshal(document)
+			Expect(err).ToNot(HaveOccurred())
+			out = append(out, data...)
+		}
+
+		return string(out), result.Conflicts
+	}
+
+	It("should merge changes of different keys from both sides", func() {
+		merged, conflicts := threeWay(`
+a: 1
+b: 2
+c: 3
+`, `
+a: 1
+b: 20
+c: 3
+d: 4
+`, `
+a: 10
+b: 2
+e: 5
+`)
+		Expect(conflicts).To(BeEmpty())
+		Expect(merged).To(Equal(`a: 10
+b: 20
+d: 4
+e: 5
+`))
+	})
+
+	It("should report conflicting changes and keep the value of ours", func() {
+		merged, conflicts := threeWay(`
+spec:
+  replicas: 1
+  image: app:1
+`, `
+spec:
+  replicas: 2
+  image: app:1
+`, `
+spec:
+  replicas: 3
+  image: app:2
+`)
+		Expect(merged).To(Equal(`spec:
+    replicas: 2
+    image: app:2
+`))
+
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts[0].Path.String()).To(Equal("/spec/replicas"))
+		Expect(conflicts[0].Base.Value).To(Equal("1"))
+		Expect(conflicts[0].Ours.Value).To(Equal("2"))
+		Expect(conflicts[0].Theirs.Value).To(Equal("3"))
+	})
+
+	It("should report a conflict when one side removes a value the other side changed", func() {
+		_, conflicts := threeWay(`
+a: 1
+b: 2
+`, `
+a: 1
+`, `
+a: 1
+b: 3
+`)
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts[0].Path.String()).To(Equal("/b"))
+		Expect(conflicts[0].Ours).To(BeNil())
+	})
+
+	It("should merge named list entries by their identifier", func() {
+		merged, conflicts := threeWay(`
+list:
+- name: one
+  value: 1
+- name: two
+  value: 2
+`, `
+list:
+- name: one
+  value: 10
+- name: two
+  value: 2
+- name: three
+  value: 3
+`, `
+list:
+- name: one
+  value: 1
+- name: four
+  value: 4
+`)
+		Expect(conflicts).To(BeEmpty())
+		Expect(merged).To(Equal(`list:
+    - name: one
+      value: 10
+    - name: three
+      value: 3
+    - name: four
+      value: 4
+`))
+	})
+
+	It("should merge simple lists by the entries added and removed on both sides", func() {
+		merged, conflicts := threeWay(`
+list: [a, b, c]
+`, `
+list: [a, b, c, d]
+`, `
+list: [b, c, e]
+`)
+		Expect(conflicts).To(BeEmpty())
+		Expect(merged).To(Equal(`list: [b, c, d, e]
+`))
+	})
+
+	It("should provide the reports of both sides", func() {
+		result, err := dyff.ThreeWayCompare(
+			ytbx.InputFile{Documents: multiDoc(`{a: 1, b: 2}`)},
+			ytbx.InputFile{Documents: multiDoc(`{a: 2, b: 2}`)},
+			ytbx.InputFile{Documents: multiDoc(`{a: 1, b: 3}`)},
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Ours.Diffs).To(HaveLen(1))
+		Expect(result.Ours.Diffs[0].Path.String()).To(Equal("/a"))
+		Expect(result.Theirs.Diffs).To(HaveLen(1))
+		Expect(result.Theirs.Diffs[0].Path.String()).To(Equal("/b"))
+	})
+
+	It("should stop when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := dyff.ThreeWayCompareContext(ctx,
+			ytbx.InputFile{Documents: multiDoc(`{a: 1, b: 2}`)},
+			ytbx.InputFile{Documents: multiDoc(`{a: 2, b: 2}`)},
+			ytbx.InputFile{Documents: multiDoc(`{a: 1, b: 3}`)},
+		)
+		Expect(err).To(MatchError(context.Canceled))
+	})
+})
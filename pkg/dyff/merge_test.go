@@ -0,0 +1,212 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("Merge", func() {
+	merge := func(base string, overlay string, mergeOptions ...dyff.MergeOption) string {
+		result, err := dyff.MergeInputFiles(
+			ytbx.InputFile{Documents: multiDoc(base)},
+			[]ytbx.InputFile{{Documents: multiDoc(overlay)}},
+			mergeOptions...,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		var out []byte
+		for _, document := range result.Documents {
+			data, err := yamlv3.Marshal(document)
+			Expect(err).ToNot(HaveOccurred())
+			out = append(out, data...)
+		}
+
+		return string(out)
+	}
+
+	Context("merging maps", func() {
+		It("should merge maps recursively while preserving the order of keys", func() {
+			Expect(merge(`
+b: 1
+a:
+  y: 1
+  x: 1
+`, `
+c: 3
+a:
+  x: 2
+  z: 2
+`)).To(Equal(`b: 1
+a:
+    y: 1
+    x: 2
+    z: 2
+c: 3
+`))
+		})
+	})
+
+	Context("merging aliases", func() {
+		It("should not modify the anchored node when merging into an alias", func() {
+			Expect(merge(`
+defaults: &defaults
+  x: 1
+service:
+  <<: *defaults
+custom: *defaults
+`, `
+custom:
+  x: 2
+`)).To(Equal(`defaults: &defaults
+    x: 1
+service:
+    !!merge <<: *defaults
+custom:
+    x: 2
+`))
+		})
+	})
+
+	Context("merging lists", func() {
+		base := `
+list:
+- name: one
+  value: 1
+- name: two
+`
+		overlay := `
+list:
+- name: two
+  value: 2
+- name: three
+`
+
+		It("should merge lists by key by default", func() {
+			Expect(merge(base, overlay)).To(Equal(`list:
+    - name: one
+      value: 1
+    - name: two
+      value: 2
+    - name: three
+`))
+		})
+
+		It("should replace lists when configured", func() {
+			Expect(merge(base, overlay, dyff.ListStrategy(dyff.ListReplace))).To(Equal(`list:
+    - name: two
+      value: 2
+    - name: three
+`))
+		})
+
+		It("should append lists when configured", func() {
+			Expect(merge(base, overlay, dyff.ListStrategy(dyff.ListAppend))).To(Equal(`list:
+    - name: one
+      value: 1
+    - name: two
+    - name: two
+      value: 2
+    - name: three
+`))
+		})
+
+		It("should use the configured list key", func() {
+			Expect(merge(`
+list:
+- id: a
+  port: 80
+`, `
+list:
+- id: a
+  port: 8080
+`, dyff.ListKey("id"))).To(Equal(`list:
+    - id: a
+      port: 8080
+`))
+		})
+
+		It("should replace simple lists when merging by key", func() {
+			Expect(merge("list: [a, b]", "list: [c]")).To(Equal("list: [c]\n"))
+		})
+
+		It("should not modify the base list when an overlay entry has no name", func() {
+			Expect(merge(base, `
+list:
+- name: two
+  value: 2
+- value: 3
+`)).To(Equal(`list:
+    - name: two
+      value: 2
+    - value: 3
+`))
+		})
+
+		It("should fail for unknown list strategies", func() {
+			_, err := dyff.MergeInputFiles(ytbx.InputFile{}, nil, dyff.ListStrategy("foobar"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("merging Kubernetes resources", func() {
+		It("should merge documents by resource name", func() {
+			Expect(merge(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: one
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: two
+`, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: two
+data:
+  key: other
+`)).To(Equal(`apiVersion: v1
+kind: ConfigMap
+metadata:
+    name: one
+data:
+    key: value
+apiVersion: v1
+kind: ConfigMap
+metadata:
+    name: two
+data:
+    key: other
+`))
+		})
+	})
+})
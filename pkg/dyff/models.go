@@ -29,10 +29,14 @@ import (
 
 // Constants to distinguish between the different kinds of differences
 const (
-	ADDITION     = '+'
-	REMOVAL      = '-'
-	MODIFICATION = '±'
-	ORDERCHANGE  = '⇆'
+	ADDITION             = '+'
+	REMOVAL              = '-'
+	MODIFICATION         = '±'
+	ORDERCHANGE          = '⇆'
+	TYPECHANGE           = '≠'
+	STYLECHANGE          = '≈'
+	REPRESENTATIONCHANGE = '≃'
+	RENAME               = '→'
 	// ILLEGAL      = '✕'
 	// ATTENTION    = '⚠'
 )
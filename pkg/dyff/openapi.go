@@ -0,0 +1,153 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// IsBreakingOpenAPIChange returns whether the difference in an OpenAPI or
+// Swagger specification is likely to break existing clients, which is the
+// case for removed paths, operations, parameters, properties, responses, or
+// enum values, for new required fields and parameters, for changed types or
+// formats, and for narrowed constraints like a lower maximum length. Changes
+// in descriptions, examples, or extensions (`x-`) are never breaking. This is
+// a heuristic that does not differentiate between requests and responses.
+func IsBreakingOpenAPIChange(diff Diff) bool {
+	if diff.Path == nil {
+		return false
+	}
+
+	var elements = diff.Path.PathElements
+	for i, element := range elements {
+		switch name := element.Name; {
+		case element.Key != "":
+			continue
+
+		// A property or parameter can be named like an annotation, for
+		// example a schema property called `description`
+		case i > 0 && isNamedEntriesField(elements[i-1].Name):
+			continue
+
+		case strings.HasPrefix(name, "x-"), name == "description", name == "summary", name == "example", name == "examples", name == "externalDocs":
+			return false
+		}
+	}
+
+	var field string
+	if len(elements) > 0 {
+		field = elements[len(elements)-1].Name
+	}
+
+	for _, detail := range diff.Details {
+		switch detail.Kind {
+		case REMOVAL:
+			// Fewer required fields only relaxes the specification
+			if field != "required" {
+				return true
+			}
+
+		case ADDITION:
+			if field == "required" || hasRequiredEntry(detail.To) {
+				return true
+			}
+
+		case MODIFICATION, TYPECHANGE:
+			if isNarrowingChange(field, detail.From, detail.To) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isNamedEntriesField returns whether the field is a mapping whose keys are
+// user defined names rather than OpenAPI keywords
+func isNamedEntriesField(field string) bool {
+	switch field {
+	case "properties", "patternProperties", "parameters", "responses", "headers", "definitions", "schemas", "examples":
+		return true
+	}
+
+	return false
+}
+
+func isNarrowingChange(field string, from *yamlv3.Node, to *yamlv3.Node) bool {
+	if from == nil || to == nil {
+		return false
+	}
+
+	switch field {
+	case "type", "format", "$ref", "in", "pattern":
+		return true
+
+	case "required":
+		return from.Value != "true" && to.Value == "true"
+
+	case "nullable", "additionalProperties":
+		return from.Value != "false" && to.Value == "false"
+
+	case "maximum", "maxLength", "maxItems", "maxProperties":
+		return compareNumbers(from.Value, to.Value) > 0
+
+	case "minimum", "minLength", "minItems", "minProperties":
+		return compareNumbers(from.Value, to.Value) < 0
+	}
+
+	return false
+}
+
+// hasRequiredEntry returns whether the added list entries contain a required
+// one, e.g. a new required parameter
+func hasRequiredEntry(node *yamlv3.Node) bool {
+	if node == nil || node.Kind != yamlv3.SequenceNode {
+		return false
+	}
+
+	for _, entry := range node.Content {
+		if value, ok := lookupString(entry, "required"); ok && value == "true" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compareNumbers returns -1, 0, or 1 depending on whether the number a is
+// smaller, equal, or larger than b, values that are no numbers are equal
+func compareNumbers(a string, b string) int {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+
+	switch {
+	case errX != nil || errY != nil || x == y:
+		return 0
+
+	case x < y:
+		return -1
+	}
+
+	return 1
+}
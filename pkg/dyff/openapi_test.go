@@ -0,0 +1,52 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("OpenAPI changes", func() {
+	DescribeTable("breaking changes",
+		func(diff dyff.Diff, breaking bool) {
+			Expect(dyff.IsBreakingOpenAPIChange(diff)).To(Equal(breaking))
+		},
+		Entry("removed path", singleDiff("/paths", dyff.REMOVAL, yml(`{"/pets": {}}`), nil), true),
+		Entry("added path", singleDiff("/paths", dyff.ADDITION, nil, yml(`{"/pets": {}}`)), false),
+		Entry("changed type", singleDiff("/components/schemas/Pet/properties/id/type", dyff.MODIFICATION, "integer", "string"), true),
+		Entry("lower maximum", singleDiff("/paths/~1pets/get/parameters/name=limit/schema/maximum", dyff.MODIFICATION, "100", "50"), true),
+		Entry("higher maximum", singleDiff("/paths/~1pets/get/parameters/name=limit/schema/maximum", dyff.MODIFICATION, "50", "100"), false),
+		Entry("higher minimum", singleDiff("/components/schemas/Name/minLength", dyff.MODIFICATION, "1", "3"), true),
+		Entry("new required field", singleDiff("/components/schemas/Pet/required", dyff.ADDITION, nil, []string{"name"}), true),
+		Entry("removed required field", singleDiff("/components/schemas/Pet/required", dyff.REMOVAL, []string{"name"}, nil), false),
+		Entry("new required parameter", singleDiff("/paths/~1pets/get/parameters", dyff.ADDITION, nil, yml(`[{"name": "owner", "required": true}]`)), true),
+		Entry("new optional parameter", singleDiff("/paths/~1pets/get/parameters", dyff.ADDITION, nil, yml(`[{"name": "owner"}]`)), false),
+		Entry("changed description", singleDiff("/paths/~1pets/get/description", dyff.MODIFICATION, "a", "b"), false),
+		Entry("removed example", singleDiff("/components/schemas/Pet/example", dyff.REMOVAL, yml(`{"id": 1}`), nil), false),
+		Entry("removed property called description", singleDiff("/components/schemas/Pet/properties", dyff.REMOVAL, yml(`{"description": {"type": "string"}}`), nil), true),
+		Entry("changed type of property called example", singleDiff("/components/schemas/Pet/properties/example/type", dyff.MODIFICATION, "string", "integer"), true),
+		Entry("changed description of property called summary", singleDiff("/components/schemas/Pet/properties/summary/description", dyff.MODIFICATION, "a", "b"), false),
+		Entry("removed extension", singleDiff("/info/x-logo", dyff.REMOVAL, yml(`{"url": "logo.png"}`), nil), false),
+	)
+})
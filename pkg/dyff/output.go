@@ -21,37 +21,47 @@
 package dyff
 
 import (
-	"github.com/gonvenience/bunt"
+	"bytes"
+	"fmt"
+	"strings"
+
 	"github.com/gonvenience/neat"
-	"github.com/lucasb-eyer/go-colorful"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
-func yamlStringInRedishColors(input interface{}) (string, error) {
-	return neat.NewOutputProcessor(true, true, &map[string]colorful.Color{
-		"keyColor":           bunt.FireBrick,
-		"indentLineColor":    {R: 0.2, G: 0, B: 0},
-		"scalarDefaultColor": bunt.LightCoral,
-		"boolColor":          bunt.LightCoral,
-		"floatColor":         bunt.LightCoral,
-		"intColor":           bunt.LightCoral,
-		"multiLineTextColor": bunt.DarkSalmon,
-		"nullColor":          bunt.Salmon,
-		"emptyStructures":    bunt.LightSalmon,
-		"dashColor":          bunt.FireBrick,
-	}).ToYAML(input)
+func (theme *Theme) yamlStringInRedishColors(input interface{}) (string, error) {
+	return neat.NewOutputProcessor(true, true, &theme.RemovalSchema).ToYAML(input)
+}
+
+func (theme *Theme) yamlStringInGreenishColors(input interface{}) (string, error) {
+	return neat.NewOutputProcessor(true, true, &theme.AdditionSchema).ToYAML(input)
 }
 
-func yamlStringInGreenishColors(input interface{}) (string, error) {
-	return neat.NewOutputProcessor(true, true, &map[string]colorful.Color{
-		"keyColor":           bunt.Green,
-		"indentLineColor":    {R: 0, G: 0.2, B: 0},
-		"scalarDefaultColor": bunt.LimeGreen,
-		"boolColor":          bunt.LimeGreen,
-		"floatColor":         bunt.LimeGreen,
-		"intColor":           bunt.LimeGreen,
-		"multiLineTextColor": bunt.OliveDrab,
-		"nullColor":          bunt.Olive,
-		"emptyStructures":    bunt.DarkOliveGreen,
-		"dashColor":          bunt.Green,
-	}).ToYAML(input)
+// plainYAMLString returns the YAML of the node in its original style without
+// any colors and without a trailing newline, e.g. for reports in other markup
+func plainYAMLString(node *yamlv3.Node) (string, error) {
+	var buf bytes.Buffer
+	encoder := yamlv3.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return "", fmt.Errorf("failed to render value as YAML: %w", err)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// plainPath returns the path of the difference without any colors
+func plainPath(diff Diff, useGoPatchPaths bool) string {
+	switch {
+	case diff.Path == nil:
+		return "(file level)"
+
+	case len(diff.Path.PathElements) == 0:
+		return "(root level)"
+
+	case useGoPatchPaths:
+		return diff.Path.ToGoPatchStyle()
+	}
+
+	return diff.Path.ToDotStyle()
 }
@@ -0,0 +1,401 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// AnnotatedReport is a reporter that renders the documents of the to input
+// file as a whole, while marking all nodes that were added, removed, or
+// modified according to the report. Removed nodes are rendered at the end of
+// the structure they were removed from.
+type AnnotatedReport struct {
+	Report
+	Indent int
+
+	// Theme provides the colors of added, removed, and modified lines, which
+	// are the colors of the dark theme if not set
+	Theme *Theme
+}
+
+type annotatedLine struct {
+	kind   rune
+	indent int
+	text   string
+}
+
+type annotations struct {
+	added        map[*yamlv3.Node]struct{}
+	modified     map[*yamlv3.Node]*yamlv3.Node
+	removed      map[*yamlv3.Node][]*yamlv3.Node
+	orderChanged map[*yamlv3.Node]struct{}
+	removedDocs  []*yamlv3.Node
+}
+
+// WriteReport writes the annotated documents to the provided writer
+func (report *AnnotatedReport) WriteReport(out io.Writer) error {
+	writer := bufio.NewWriter(out)
+	defer writer.Flush()
+
+	if report.Indent <= 0 {
+		report.Indent = 2
+	}
+
+	annotations := report.annotations()
+
+	var lines []annotatedLine
+	for _, document := range report.To.Documents {
+		if len(report.To.Documents) > 1 || len(annotations.removedDocs) > 0 {
+			lines = append(lines, annotatedLine{text: "---"})
+		}
+
+		if len(document.Content) == 0 {
+			continue
+		}
+
+		start := len(lines)
+		lines = append(lines, report.node(annotations, 0, document.Content[0], 0)...)
+		if _, ok := annotations.added[document.Content[0]]; ok {
+			markLines(lines[start:], ADDITION)
+		}
+	}
+
+	for _, document := range annotations.removedDocs {
+		lines = append(lines, annotatedLine{kind: REMOVAL, text: "---"})
+		lines = append(lines, markLines(report.node(annotations, 0, document.Content[0], 0), REMOVAL)...)
+	}
+
+	theme := orDefaultTheme(report.Theme)
+	for _, line := range lines {
+		var marker = " "
+		if line.kind != 0 {
+			marker = string(line.kind)
+		}
+
+		text := strings.TrimRight(fmt.Sprintf("%s %s%s", marker, strings.Repeat(" ", line.indent), line.text), " ")
+		switch line.kind {
+		case ADDITION:
+			text = theme.green(text)
+
+		case REMOVAL:
+			text = theme.red(text)
+
+		case MODIFICATION, ORDERCHANGE:
+			text = theme.yellow(text)
+		}
+
+		_, _ = writer.WriteString(text)
+		_, _ = writer.WriteString("\n")
+	}
+
+	return nil
+}
+
+// annotations collects which nodes of the to documents are affected by the
+// differences of the report
+func (report *AnnotatedReport) annotations() annotations {
+	result := annotations{
+		added:        map[*yamlv3.Node]struct{}{},
+		modified:     map[*yamlv3.Node]*yamlv3.Node{},
+		removed:      map[*yamlv3.Node][]*yamlv3.Node{},
+		orderChanged: map[*yamlv3.Node]struct{}{},
+	}
+
+	for _, diff := range report.Diffs {
+		for _, detail := range diff.Details {
+			switch detail.Kind {
+			case ADDITION:
+				for _, node := range detail.To.Content {
+					result.added[node] = struct{}{}
+				}
+
+			case MODIFICATION, TYPECHANGE, STYLECHANGE, REPRESENTATIONCHANGE:
+				if detail.To != nil {
+					result.modified[detail.To] = detail.From
+				}
+
+			case REMOVAL:
+				if detail.From.Kind == yamlv3.DocumentNode {
+					result.removedDocs = append(result.removedDocs, detail.From)
+					continue
+				}
+
+				if container := report.container(diff.Path); container != nil {
+					result.removed[container] = append(result.removed[container], detail.From.Content...)
+				}
+
+			case ORDERCHANGE:
+				if container := report.container(diff.Path); container != nil {
+					result.orderChanged[container] = struct{}{}
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// container looks up the node in the to documents that the path of a
+// difference points to, which is based on the from documents
+func (report *AnnotatedReport) container(path *ytbx.Path) *yamlv3.Node {
+	if path == nil {
+		return nil
+	}
+
+	document := report.toDocument(path)
+	if document == nil || len(document.Content) == 0 {
+		return nil
+	}
+
+	node, err := grab(document.Content[0], ytbx.Path{PathElements: path.PathElements}.String())
+	if err != nil {
+		return nil
+	}
+
+	return followAlias(node)
+}
+
+// toDocument returns the document in the to input file which corresponds to
+// the document referenced by the path, using the Kubernetes resource name if
+// possible and the document index otherwise
+func (report *AnnotatedReport) toDocument(path *ytbx.Path) *yamlv3.Node {
+	var idx = path.DocumentIdx
+
+	if path.Root != nil && path.Root.Location == report.To.Location && report.From.Location != report.To.Location {
+		if idx < len(report.To.Documents) {
+			return report.To.Documents[idx]
+		}
+
+		return nil
+	}
+
+	if idx < len(report.From.Documents) && len(report.From.Documents[idx].Content) > 0 {
+		if name, err := k8sItem.Name(report.From.Documents[idx].Content[0]); err == nil {
+			for _, document := range report.To.Documents {
+				if len(document.Content) == 0 {
+					continue
+				}
+
+				if toName, err := k8sItem.Name(document.Content[0]); err == nil && toName == name {
+					return document
+				}
+			}
+		}
+	}
+
+	if idx < len(report.To.Documents) {
+		return report.To.Documents[idx]
+	}
+
+	return nil
+}
+
+// node renders the provided node into lines, where the kind is the kind that
+// is inherited from the parent node
+func (report *AnnotatedReport) node(annotations annotations, kind rune, node *yamlv3.Node, indent int) []annotatedLine {
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		if len(node.Content) > 0 {
+			return report.node(annotations, kind, node.Content[0], indent)
+		}
+
+	case yamlv3.MappingNode:
+		return report.mapping(annotations, kind, node, indent)
+
+	case yamlv3.SequenceNode:
+		return report.sequence(annotations, kind, node, indent)
+
+	default:
+		return report.scalar(kind, "", node, indent)
+	}
+
+	return nil
+}
+
+func (report *AnnotatedReport) mapping(annotations annotations, kind rune, node *yamlv3.Node, indent int) []annotatedLine {
+	var lines []annotatedLine
+	if len(node.Content) == 0 && len(annotations.removed[node]) == 0 {
+		return []annotatedLine{{kind: kind, indent: indent, text: "{}"}}
+	}
+
+	var entry = func(kind rune, key *yamlv3.Node, value *yamlv3.Node) {
+		if _, ok := annotations.added[key]; ok {
+			kind = ADDITION
+		}
+
+		if from, ok := annotations.modified[followAlias(value)]; ok && isUnchangedOrReordered(kind) {
+			lines = append(lines, report.keyValue(annotations, REMOVAL, key, from, indent)...)
+			kind = MODIFICATION
+		}
+
+		lines = append(lines, report.keyValue(annotations, kind, key, value, indent)...)
+	}
+
+	for i := 0; i < len(node.Content); i += 2 {
+		entry(kind, node.Content[i], node.Content[i+1])
+	}
+
+	removed := annotations.removed[node]
+	for i := 0; i+1 < len(removed); i += 2 {
+		entry(REMOVAL, removed[i], removed[i+1])
+	}
+
+	return lines
+}
+
+func (report *AnnotatedReport) keyValue(annotations annotations, kind rune, key *yamlv3.Node, value *yamlv3.Node, indent int) []annotatedLine {
+	keyText := scalarText(key)
+	value = followAlias(value)
+
+	switch {
+	case value.Kind == yamlv3.ScalarNode:
+		return report.scalar(kind, keyText+": ", value, indent)
+
+	case value.Kind == yamlv3.MappingNode && len(value.Content) == 0 && len(annotations.removed[value]) == 0:
+		return []annotatedLine{{kind: kind, indent: indent, text: keyText + ": {}"}}
+
+	case value.Kind == yamlv3.SequenceNode && len(value.Content) == 0 && len(annotations.removed[value]) == 0:
+		return []annotatedLine{{kind: kind, indent: indent, text: keyText + ": []"}}
+	}
+
+	return append(
+		[]annotatedLine{{kind: kind, indent: indent, text: keyText + ":"}},
+		report.node(annotations, kind, value, indent+report.Indent)...,
+	)
+}
+
+func (report *AnnotatedReport) sequence(annotations annotations, kind rune, node *yamlv3.Node, indent int) []annotatedLine {
+	var lines []annotatedLine
+	if len(node.Content) == 0 && len(annotations.removed[node]) == 0 {
+		return []annotatedLine{{kind: kind, indent: indent, text: "[]"}}
+	}
+
+	_, orderChanged := annotations.orderChanged[node]
+
+	var entry = func(kind rune, value *yamlv3.Node) {
+		if _, ok := annotations.added[value]; ok {
+			kind = ADDITION
+		}
+
+		if from, ok := annotations.modified[followAlias(value)]; ok && isUnchangedOrReordered(kind) {
+			lines = append(lines, report.sequenceEntry(annotations, REMOVAL, from, indent)...)
+			kind = MODIFICATION
+		}
+
+		if orderChanged && kind == 0 {
+			kind = ORDERCHANGE
+		}
+
+		lines = append(lines, report.sequenceEntry(annotations, kind, value, indent)...)
+	}
+
+	for _, value := range node.Content {
+		entry(kind, value)
+	}
+
+	for _, value := range annotations.removed[node] {
+		entry(REMOVAL, value)
+	}
+
+	return lines
+}
+
+// isUnchangedOrReordered returns whether the inherited kind still allows a
+// modification to be shown, since a reordered entry can be modified as well
+func isUnchangedOrReordered(kind rune) bool {
+	return kind == 0 || kind == ORDERCHANGE
+}
+
+func (report *AnnotatedReport) sequenceEntry(annotations annotations, kind rune, value *yamlv3.Node, indent int) []annotatedLine {
+	lines := report.node(annotations, kind, followAlias(value), indent+report.Indent)
+	if len(lines) == 0 {
+		return []annotatedLine{{kind: kind, indent: indent, text: "-"}}
+	}
+
+	// Place the first line of the entry directly behind the list item dash
+	lines[0].indent = indent
+	lines[0].text = "- " + lines[0].text
+	if lines[0].kind == 0 {
+		lines[0].kind = kind
+	}
+
+	return lines
+}
+
+func (report *AnnotatedReport) scalar(kind rune, prefix string, node *yamlv3.Node, indent int) []annotatedLine {
+	if !strings.Contains(node.Value, "\n") {
+		return []annotatedLine{{kind: kind, indent: indent, text: prefix + scalarText(node)}}
+	}
+
+	var header = "|"
+	var value = node.Value
+	switch {
+	case strings.HasSuffix(value, "\n\n"):
+		header = "|+"
+		value = strings.TrimSuffix(value, "\n")
+
+	case strings.HasSuffix(value, "\n"):
+		value = strings.TrimSuffix(value, "\n")
+
+	default:
+		header = "|-"
+	}
+
+	lines := []annotatedLine{{kind: kind, indent: indent, text: prefix + header}}
+	for _, line := range strings.Split(value, "\n") {
+		lines = append(lines, annotatedLine{kind: kind, indent: indent + report.Indent, text: line})
+	}
+
+	return lines
+}
+
+func scalarText(node *yamlv3.Node) string {
+	if node.Kind == yamlv3.AliasNode {
+		return "*" + node.Value
+	}
+
+	data, err := yamlv3.Marshal(&yamlv3.Node{
+		Kind:  yamlv3.ScalarNode,
+		Tag:   node.Tag,
+		Style: node.Style &^ (yamlv3.LiteralStyle | yamlv3.FoldedStyle),
+		Value: node.Value,
+	})
+	if err != nil {
+		return node.Value
+	}
+
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+func markLines(lines []annotatedLine, kind rune) []annotatedLine {
+	for i := range lines {
+		lines[i].kind = kind
+	}
+
+	return lines
+}
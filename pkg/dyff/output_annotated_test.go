@@ -0,0 +1,140 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gonvenience/bunt"
+
+	"github.com/gonvenience/ytbx"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("annotated report", func() {
+	Context("rendering the whole document with changes", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		annotated := func(from, to string) string {
+			report, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Documents: multiDoc(from)},
+				ytbx.InputFile{Documents: multiDoc(to)},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			var buf bytes.Buffer
+			Expect((&dyff.AnnotatedReport{Report: report}).WriteReport(&buf)).To(Succeed())
+			return buf.String()
+		}
+
+		It("should mark added, removed, and modified nodes", func() {
+			Expect(annotated(`---
+some:
+  name: foo
+  value: 42
+  removed: true
+list:
+- one
+- two
+`, `---
+some:
+  name: foo
+  value: 147
+  added: "yes"
+list:
+- one
+- two
+- three
+`)).To(BeEquivalentTo(`  some:
+    name: foo
+-   value: 42
+±   value: 147
++   added: "yes"
+-   removed: true
+  list:
+    - one
+    - two
++   - three
+`))
+		})
+
+		It("should mark modified values of list entries that were reordered as well", func() {
+			Expect(annotated(`---
+list:
+- name: one
+  value: 1
+- name: two
+  value: 2
+`, `---
+list:
+- name: two
+  value: 3
+- name: one
+  value: 1
+`)).To(BeEquivalentTo(`  list:
+⇆   - name: two
+-     value: 2
+±     value: 3
+⇆   - name: one
+⇆     value: 1
+`))
+		})
+
+		It("should render multiline text and nested structures in sequences", func() {
+			Expect(annotated(`---
+list:
+- name: one
+  text: |
+    some
+    text
+`, `---
+list:
+- name: one
+  text: |
+    some
+    text
+- name: two
+  text: |
+    other
+    text
+`)).To(BeEquivalentTo(`  list:
+    - name: one
+      text: |
+        some
+        text
++   - name: two
++     text: |
++       other
++       text
+`))
+		})
+	})
+})
@@ -39,6 +39,11 @@ const (
 // BriefReport is a reporter that only prints a summary
 type BriefReport struct {
 	Report
+
+	// FromDescription and ToDescription replace the locations of the input
+	// files in the summary
+	FromDescription string
+	ToDescription   string
 }
 
 // WriteReport writes a brief summary to the provided writer
@@ -47,8 +52,12 @@ func (report *BriefReport) WriteReport(out io.Writer) error {
 	defer writer.Flush()
 
 	noOfChanges := bunt.Style(text.Plural(len(report.Diffs), "change"), bunt.Bold())
-	niceFrom := ytbx.HumanReadableLocationInformation(report.From)
-	niceTo := ytbx.HumanReadableLocationInformation(report.To)
+	from, to := report.From, report.To
+	from.Location = describe(report.From, report.FromDescription)
+	to.Location = describe(report.To, report.ToDescription)
+
+	niceFrom := ytbx.HumanReadableLocationInformation(from)
+	niceTo := ytbx.HumanReadableLocationInformation(to)
 
 	var template string
 	switch {
@@ -118,7 +118,7 @@ func (report *DiffSyntaxReport) generateDiffSyntaxDetailOutput(detail Detail) (s
 
 		return report.prefixChangeBlock(detailOutput, REMOVAL), nil
 
-	case MODIFICATION:
+	case MODIFICATION, TYPECHANGE, REPRESENTATIONCHANGE:
 		detailOutput, err := report.generateHumanDetailOutputModification(detail)
 		if err != nil {
 			return "", err
@@ -131,6 +131,20 @@ func (report *DiffSyntaxReport) generateDiffSyntaxDetailOutput(detail Detail) (s
 			return "", err
 		}
 		return report.prefixChangeType(detailOutput), nil
+
+	case STYLECHANGE:
+		detailOutput, err := report.generateHumanDetailOutputStylechange(detail)
+		if err != nil {
+			return "", err
+		}
+		return report.prefixChangeType(detailOutput), nil
+
+	case RENAME:
+		detailOutput, err := report.generateHumanDetailOutputRename(detail)
+		if err != nil {
+			return "", err
+		}
+		return report.prefixChangeType(detailOutput), nil
 	}
 
 	return "", fmt.Errorf("unsupported detail type %c", detail.Kind)
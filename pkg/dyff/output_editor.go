@@ -0,0 +1,160 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gonvenience/text"
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// EditorReport is a reporter that writes one `file:line:col: message` line
+// per difference, which is the format that the problem parsers of editors
+// like Vim, Emacs, or VS Code understand. The location refers to the to
+// input file if possible, and to the from input file otherwise.
+type EditorReport struct {
+	Report
+	UseGoPatchPaths bool
+
+	// SeverityRules optionally adds the severity to each line, which editors
+	// use to highlight the problem accordingly
+	SeverityRules SeverityRules
+}
+
+var _ ReportWriter = &EditorReport{}
+
+// WriteReport writes the differences as editor problem lines to the writer
+func (report *EditorReport) WriteReport(out io.Writer) error {
+	writer := bufio.NewWriter(out)
+	defer writer.Flush()
+
+	for _, diff := range report.Diffs {
+		location, line, column := report.positionOfDiff(diff)
+		if line <= 0 {
+			line, column = 1, 1
+		}
+
+		var messages []string
+		for _, detail := range diff.Details {
+			messages = append(messages, editorMessage(detail))
+		}
+
+		var severity string
+		if report.SeverityRules != nil {
+			severity = report.SeverityRules.Severity(diff).String() + ": "
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s:%d:%d: %s%s: %s\n",
+			location,
+			line,
+			max(column, 1),
+			severity,
+			plainPath(diff, report.UseGoPatchPaths),
+			strings.Join(messages, "; "),
+		)
+	}
+
+	return nil
+}
+
+// positionOfDiff returns the location, line, and column of the difference,
+// preferring the to input file
+func (report *EditorReport) positionOfDiff(diff Diff) (string, int, int) {
+	side := func(inputFile ytbx.InputFile, nodeOf func(Detail) *yamlv3.Node) (int, int) {
+		for _, detail := range diff.Details {
+			if line, column := positionOf(nodeOf(detail)); line > 0 && detail.Kind != ORDERCHANGE {
+				return line, column
+			}
+		}
+
+		return positionOf(nodeOfPath(inputFile, diff.Path))
+	}
+
+	if line, column := side(report.To, func(detail Detail) *yamlv3.Node { return detail.To }); line > 0 {
+		return report.To.Location, line, column
+	}
+
+	if line, column := side(report.From, func(detail Detail) *yamlv3.Node { return detail.From }); line > 0 {
+		return report.From.Location, line, column
+	}
+
+	return report.To.Location, 0, 0
+}
+
+func editorMessage(detail Detail) string {
+	switch detail.Kind {
+	case ADDITION:
+		return fmt.Sprintf("%s added", entries(detail.To))
+
+	case REMOVAL:
+		return fmt.Sprintf("%s removed", entries(detail.From))
+
+	case ORDERCHANGE:
+		return "order changed"
+
+	case STYLECHANGE:
+		return fmt.Sprintf("style change from %s to %s", styleName(detail.From), styleName(detail.To))
+
+	case REPRESENTATIONCHANGE:
+		return fmt.Sprintf("representation change from %s to %s", humanReadableType(detail.From), humanReadableType(detail.To))
+
+	case RENAME:
+		return fmt.Sprintf("document %s from %s to %s", renameDescription(detail), detail.From.Value, detail.To.Value)
+
+	case MODIFICATION, TYPECHANGE:
+		fromType, toType := humanReadableType(detail.From), humanReadableType(detail.To)
+		switch {
+		case fromType != toType:
+			return fmt.Sprintf("type change from %s to %s", fromType, toType)
+
+		case isShortScalar(detail.From) && isShortScalar(detail.To):
+			return fmt.Sprintf("value change from %s to %s", detail.From.Value, detail.To.Value)
+		}
+
+		return "value change"
+	}
+
+	return string(detail.Kind)
+}
+
+func entries(node *yamlv3.Node) string {
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		return text.Plural(len(node.Content), "document")
+
+	case yamlv3.SequenceNode:
+		return text.Plural(len(node.Content), "list entry", "list entries")
+
+	case yamlv3.MappingNode:
+		return text.Plural(len(node.Content)/2, "map entry", "map entries")
+	}
+
+	return "one value"
+}
+
+func isShortScalar(node *yamlv3.Node) bool {
+	return node.Kind == yamlv3.ScalarNode && len(node.Value) <= 64 && !strings.ContainsAny(node.Value, "\n\r")
+}
@@ -0,0 +1,60 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"bytes"
+
+	"github.com/gonvenience/ytbx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("editor report", func() {
+	It("should write one problem line per difference", func() {
+		from := ytbx.InputFile{Location: "from.yml", Documents: multiDoc("---\nname: app\nconfig:\n  a: 1\n  b: two\nlist: [x]\n")}
+		to := ytbx.InputFile{Location: "to.yml", Documents: multiDoc("---\nname: app\nconfig:\n  a: 2\n  b: 2\n")}
+
+		report, err := dyff.CompareInputFiles(from, to)
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		Expect((&dyff.EditorReport{Report: report}).WriteReport(&buf)).To(Succeed())
+		Expect(buf.String()).To(Equal(`from.yml:6:1: (root level): one map entry removed
+to.yml:4:6: config.a: value change from 1 to 2
+to.yml:5:6: config.b: type change from string to int
+`))
+	})
+
+	It("should use Go-Patch style paths if configured", func() {
+		from := ytbx.InputFile{Location: "from.yml", Documents: multiDoc(`{"a": {"b": 1}}`)}
+		to := ytbx.InputFile{Location: "to.yml", Documents: multiDoc(`{"a": {"b": 2}}`)}
+
+		report, err := dyff.CompareInputFiles(from, to)
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		Expect((&dyff.EditorReport{Report: report, UseGoPatchPaths: true}).WriteReport(&buf)).To(Succeed())
+		Expect(buf.String()).To(Equal("to.yml:1:13: /a/b: value change from 1 to 2\n"))
+	})
+})
@@ -0,0 +1,164 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"regexp"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// elisionMarker is the key used for omitted map entries, which is rendered as
+// a map entry without value and has its colon removed afterwards
+const elisionMarker = "…"
+
+var elisionMarkerLine = regexp.MustCompile(elisionMarker + `:((?:\x1b\[[\d;]*m)*) ?(\n|$)`)
+
+// elidedDetails returns the details of a difference where list entries that
+// were removed and added only show the changed map entries and their parent
+// keys, with unchanged map entries being replaced with an elision marker.
+// Entries are paired by their position. If no entry can be trimmed, the
+// details are returned unchanged.
+func elidedDetails(details []Detail) []Detail {
+	var removal, addition int
+	var removed, added *yamlv3.Node
+	for i, detail := range details {
+		switch {
+		case detail.Kind == REMOVAL && detail.From != nil && detail.From.Kind == yamlv3.SequenceNode:
+			removal, removed = i, detail.From
+
+		case detail.Kind == ADDITION && detail.To != nil && detail.To.Kind == yamlv3.SequenceNode:
+			addition, added = i, detail.To
+		}
+	}
+
+	if removed == nil || added == nil || len(removed.Content) != len(added.Content) {
+		return details
+	}
+
+	fromList := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: removed.Tag}
+	toList := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: added.Tag}
+
+	var trimmed bool
+	for i := range removed.Content {
+		from, to, elided := elideUnchanged(followAlias(removed.Content[i]), followAlias(added.Content[i]), true)
+		trimmed = trimmed || elided
+
+		fromList.Content = append(fromList.Content, from)
+		toList.Content = append(toList.Content, to)
+	}
+
+	if !trimmed {
+		return details
+	}
+
+	result := make([]Detail, len(details))
+	copy(result, details)
+	result[removal].From = fromList
+	result[addition].To = toList
+
+	return result
+}
+
+// elideUnchanged returns copies of two maps that only contain the entries that
+// differ, including an elision marker in place of omitted entries, and whether
+// entries were omitted. Other nodes are returned as they are. An unchanged first
+// entry with a scalar value can be kept to show which list entry it is.
+func elideUnchanged(from *yamlv3.Node, to *yamlv3.Node, keepFirst bool) (*yamlv3.Node, *yamlv3.Node, bool) {
+	if from.Kind != yamlv3.MappingNode || to.Kind != yamlv3.MappingNode {
+		return from, to, false
+	}
+
+	fromResult := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: from.Tag}
+	toResult := &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: to.Tag}
+
+	var elided bool
+	for i := 0; i < len(from.Content); i += 2 {
+		key, fromValue := from.Content[i], followAlias(from.Content[i+1])
+
+		toValue, ok := findValueByKey(to, key.Value)
+		switch {
+		case !ok:
+			fromResult.Content = append(fromResult.Content, key, fromValue)
+
+		case i == 0 && keepFirst && fromValue.Kind == yamlv3.ScalarNode && equalNodes(fromValue, toValue):
+			fromResult.Content = append(fromResult.Content, key, fromValue)
+			toResult.Content = append(toResult.Content, key, toValue)
+
+		case equalNodes(fromValue, toValue):
+			elided = true
+
+		default:
+			fromValue, toValue, _ = elideUnchanged(fromValue, toValue, false)
+			fromResult.Content = append(fromResult.Content, key, fromValue)
+			toResult.Content = append(toResult.Content, key, toValue)
+		}
+	}
+
+	for i := 0; i < len(to.Content); i += 2 {
+		if _, ok := findValueByKey(from, to.Content[i].Value); !ok {
+			toResult.Content = append(toResult.Content, to.Content[i], followAlias(to.Content[i+1]))
+		}
+	}
+
+	// Entries that do not share a single unchanged value are not trimmed
+	if !elided {
+		return from, to, false
+	}
+
+	marker := func() []*yamlv3.Node {
+		return []*yamlv3.Node{
+			{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: elisionMarker},
+			{Kind: yamlv3.ScalarNode, Tag: "!!null"},
+		}
+	}
+
+	fromResult.Content = append(fromResult.Content, marker()...)
+	toResult.Content = append(toResult.Content, marker()...)
+
+	return fromResult, toResult, true
+}
+
+// equalNodes returns whether both nodes have the same content
+func equalNodes(a *yamlv3.Node, b *yamlv3.Node) bool {
+	a, b = followAlias(a), followAlias(b)
+	if a.Kind != b.Kind || len(a.Content) != len(b.Content) {
+		return false
+	}
+
+	if a.Kind == yamlv3.ScalarNode && (a.ShortTag() != b.ShortTag() || a.Value != b.Value) {
+		return false
+	}
+
+	for i := range a.Content {
+		if !equalNodes(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// removeElisionMarkers turns the map entries used as elision markers in the
+// rendered text into plain markers
+func removeElisionMarkers(text string) string {
+	return elisionMarkerLine.ReplaceAllString(text, elisionMarker+"$1$2")
+}
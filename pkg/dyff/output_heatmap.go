@@ -0,0 +1,140 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+)
+
+// DefaultHeatmapWidth is the width of the longest bar in the heatmap
+const DefaultHeatmapWidth = 40
+
+// HeatmapReport is a reporter that shows an overview of where the differences
+// are, with a bar per top-level key, or per document in case there are more
+// than one, which length and color depends on the number of differences
+type HeatmapReport struct {
+	Report
+	UseGoPatchPaths bool
+
+	// Width is the width of the longest bar, which is the DefaultHeatmapWidth
+	// if not set
+	Width int
+
+	// Theme provides the colors that the bars are blended from, which are the
+	// colors of the dark theme if not set
+	Theme *Theme
+}
+
+type heatmapRow struct {
+	name  string
+	count int
+}
+
+// WriteReport writes the heatmap to the provided writer
+func (report *HeatmapReport) WriteReport(out io.Writer) error {
+	writer := bufio.NewWriter(out)
+	defer writer.Flush()
+
+	rows := report.rows()
+
+	var max int
+	for _, row := range rows {
+		if row.count > max {
+			max = row.count
+		}
+	}
+
+	width := report.Width
+	if width <= 0 {
+		width = DefaultHeatmapWidth
+	}
+
+	theme := orDefaultTheme(report.Theme)
+	var names, bars, counts []string
+	for _, row := range rows {
+		// round up, so that every row with a difference has a visible bar
+		length := (row.count*width + max - 1) / max
+		ratio := float64(row.count) / float64(max)
+
+		names = append(names, row.name)
+		bars = append(bars, colored(
+			theme.Modification.BlendLab(theme.Removal, ratio).Clamped(),
+			"%s", strings.Repeat("█", length),
+		))
+		counts = append(counts, fmt.Sprintf("%d", row.count))
+	}
+
+	if len(rows) > 0 {
+		_, _ = writer.WriteString(CreateTableStyleString(" ", 0,
+			strings.Join(names, "\n"),
+			strings.Join(bars, "\n"),
+			strings.Join(counts, "\n"),
+		))
+		_, _ = writer.WriteString("\n")
+	}
+
+	// Finish with one last newline so that we do not end next to the prompt
+	_, _ = writer.WriteString("\n")
+	return nil
+}
+
+// rows returns the number of differences per top-level key, or per document
+// in case there is more than one, in the order of the differences
+func (report *HeatmapReport) rows() []heatmapRow {
+	var perDocument = len(report.From.Documents) > 1 || len(report.To.Documents) > 1
+
+	var result []heatmapRow
+	var lookup = map[string]int{}
+	for _, diff := range report.Diffs {
+		var name string
+		switch {
+		case diff.Path == nil:
+			name = pathToString(nil, report.UseGoPatchPaths, false)
+
+		case perDocument:
+			name = diff.Path.RootDescription()
+
+		default:
+			path := ytbx.Path{PathElements: diff.Path.PathElements}
+			if len(path.PathElements) > 1 {
+				path.PathElements = path.PathElements[:1]
+			}
+
+			name = pathToString(&path, report.UseGoPatchPaths, false)
+		}
+
+		idx, ok := lookup[name]
+		if !ok {
+			idx = len(result)
+			lookup[name] = idx
+			result = append(result, heatmapRow{name: name})
+		}
+
+		result[idx].count++
+	}
+
+	return result
+}
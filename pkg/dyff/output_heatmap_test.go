@@ -0,0 +1,78 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"bytes"
+
+	. "github.com/gonvenience/bunt"
+	"github.com/gonvenience/ytbx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("heatmap report", func() {
+	BeforeEach(func() {
+		SetColorSettings(OFF, OFF)
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	var writeHeatmap = func(from string, to string) string {
+		report, err := dyff.CompareInputFiles(
+			ytbx.InputFile{Documents: multiDoc(from)},
+			ytbx.InputFile{Documents: multiDoc(to)},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		Expect((&dyff.HeatmapReport{Report: report, Width: 8}).WriteReport(&buf)).To(Succeed())
+		return buf.String()
+	}
+
+	It("should show a bar per top-level key", func() {
+		Expect(writeHeatmap(
+			"---\nspec: {a: 1, b: 1, c: 1, d: 1}\nname: foo\nkeep: true\n",
+			"---\nspec: {a: 2, b: 2, c: 2, d: 2}\nname: bar\nkeep: true\n",
+		)).To(Equal(`spec ████████ 4
+name ██       1
+
+`))
+	})
+
+	It("should show a bar per document in case there is more than one", func() {
+		Expect(writeHeatmap(
+			"---\na: 1\nb: 1\n---\nc: 1\n",
+			"---\na: 2\nb: 2\n---\nc: 2\n",
+		)).To(Equal(`document #1 ████████ 2
+document #2 ████     1
+
+`))
+	})
+
+	It("should show nothing if there are no differences", func() {
+		Expect(writeHeatmap("---\na: 1\n", "---\na: 1\n")).To(Equal("\n"))
+	})
+})
@@ -0,0 +1,314 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// HTMLReport is a reporter that writes the report as a standalone HTML page,
+// e.g. to be attached to the artifacts of a CI pipeline. The differences are
+// grouped in collapsible sections per document, and each difference has an
+// anchor, so that it can be linked directly.
+type HTMLReport struct {
+	Report
+	UseGoPatchPaths bool
+
+	// Title is the title of the page, which is based on the locations of
+	// the input files if not set
+	Title string
+}
+
+var _ ReportWriter = &HTMLReport{}
+
+type htmlDocument struct {
+	Name  string
+	Diffs []htmlDiff
+}
+
+type htmlDiff struct {
+	Anchor  string
+	Link    template.URL
+	Path    string
+	Details []htmlDetail
+}
+
+type htmlDetail struct {
+	Kind   string
+	Symbol string
+	From   template.HTML
+	To     template.HTML
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #24292f; }
+details { margin-bottom: 1em; border: 1px solid #d0d7de; border-radius: 6px; }
+summary { padding: 0.5em 1em; background: #f6f8fa; cursor: pointer; font-weight: bold; }
+section { padding: 0.5em 1em; border-top: 1px solid #d0d7de; }
+section > h3 { margin: 0.25em 0; font-family: monospace; font-size: 1em; }
+section > h3 > a { color: inherit; text-decoration: none; }
+.detail { margin: 0.5em 0; }
+.kind { font-weight: bold; }
+.kind-addition { color: #1a7f37; }
+.kind-removal { color: #cf222e; }
+.kind-modification, .kind-type-change, .kind-style-change, .kind-order-change, .kind-rename { color: #9a6700; }
+.values { display: flex; gap: 1em; }
+pre { flex: 1; margin: 0.25em 0; padding: 0.5em; overflow-x: auto; border-radius: 6px; }
+pre.from { background: #ffebe9; }
+pre.to { background: #dafbe1; }
+.key { color: #0550ae; }
+.string { color: #0a3069; }
+.number, .literal { color: #8250df; }
+.comment { color: #6e7781; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<p>between <code>{{ .From }}</code> and <code>{{ .To }}</code>: {{ .Summary }}</p>
+{{- range .Documents }}
+<details open>
+<summary>{{ .Name }}</summary>
+{{- range .Diffs }}
+<section id="{{ .Anchor }}">
+<h3><a href="{{ .Link }}">{{ .Path }}</a></h3>
+{{- range .Details }}
+<div class="detail">
+<span class="kind kind-{{ .Kind }}">{{ .Symbol }} {{ .Kind }}</span>
+<div class="values">
+{{- if .From }}
+<pre class="from">{{ .From }}</pre>
+{{- end }}
+{{- if .To }}
+<pre class="to">{{ .To }}</pre>
+{{- end }}
+</div>
+</div>
+{{- end }}
+</section>
+{{- end }}
+</details>
+{{- end }}
+</body>
+</html>
+`))
+
+// WriteReport writes the report as a HTML page to the provided writer
+func (report *HTMLReport) WriteReport(out io.Writer) error {
+	title := report.Title
+	if title == "" {
+		title = fmt.Sprintf("dyff between %s and %s", report.From.Location, report.To.Location)
+	}
+
+	documents, err := report.documents()
+	if err != nil {
+		return err
+	}
+
+	var summary = "no differences"
+	switch count := len(report.Diffs); count {
+	case 0:
+	case 1:
+		summary = "one difference"
+	default:
+		summary = fmt.Sprintf("%d differences", count)
+	}
+
+	return htmlReportTemplate.Execute(out, map[string]any{
+		"Title":     title,
+		"From":      report.From.Location,
+		"To":        report.To.Location,
+		"Summary":   summary,
+		"Documents": documents,
+	})
+}
+
+// documents returns the differences grouped by document in the order of the
+// differences, where differences of the input files as a whole come first
+func (report *HTMLReport) documents() ([]htmlDocument, error) {
+	var result []htmlDocument
+	var lookup = map[int]int{}
+	var anchors = map[string]int{}
+	for _, diff := range report.Diffs {
+		var documentIdx, name = -1, "(file level)"
+		if diff.Path != nil {
+			documentIdx, name = diff.Path.DocumentIdx, diff.Path.RootDescription()
+		}
+
+		idx, ok := lookup[documentIdx]
+		if !ok {
+			idx = len(result)
+			lookup[documentIdx] = idx
+			result = append(result, htmlDocument{Name: name})
+		}
+
+		entry := htmlDiff{Path: plainPath(diff, report.UseGoPatchPaths), Anchor: htmlAnchor(diff)}
+		if anchors[entry.Anchor]++; anchors[entry.Anchor] > 1 {
+			entry.Anchor = fmt.Sprintf("%s-%d", entry.Anchor, anchors[entry.Anchor])
+		}
+
+		for _, detail := range diff.Details {
+			from, err := highlightedYAML(detail.From)
+			if err != nil {
+				return nil, err
+			}
+
+			to, err := highlightedYAML(detail.To)
+			if err != nil {
+				return nil, err
+			}
+
+			entry.Details = append(entry.Details, htmlDetail{
+				Kind:   KindName(detail.Kind),
+				Symbol: string(detail.Kind),
+				From:   from,
+				To:     to,
+			})
+		}
+
+		// the fragment keeps the slashes of the path, which do not need to be
+		// escaped in links
+		entry.Link = template.URL((&url.URL{Fragment: entry.Anchor}).String())
+		result[idx].Diffs = append(result[idx].Diffs, entry)
+	}
+
+	return result, nil
+}
+
+// htmlAnchor returns the anchor of the difference, which is based on the
+// document index and the path, e.g. `document-1/spec/replicas`
+func htmlAnchor(diff Diff) string {
+	if diff.Path == nil {
+		return "file-level"
+	}
+
+	path := diff.Path.ToGoPatchStyle()
+	if len(diff.Path.PathElements) == 0 {
+		path = ""
+	}
+
+	return fmt.Sprintf("document-%d%s", diff.Path.DocumentIdx+1, strings.Join(strings.Fields(path), "-"))
+}
+
+var (
+	htmlYAMLKeyLine    = regexp.MustCompile(`^(\s*(?:-\s+)*)("[^"]*"|'[^']*'|[^\s#'"-][^:#]*?|-[^\s:#][^:#]*?)(:)(\s.*)?$`)
+	htmlYAMLEntryLine  = regexp.MustCompile(`^(\s*(?:-\s+)+)(.*)$`)
+	htmlYAMLNumber     = regexp.MustCompile(`^[-+]?(\d[\d_]*(\.\d*)?|\.\d+)([eE][-+]?\d+)?$`)
+	htmlYAMLLiteral    = regexp.MustCompile(`^(true|false|True|False|TRUE|FALSE|null|Null|NULL|~|[|>][-+]?)$`)
+	htmlYAMLFlowTokens = regexp.MustCompile(`[\[\]{},]|\s+|"(?:[^"\\]|\\.)*"|'[^']*'|[^\[\]{},\s][^\[\]{},]*`)
+)
+
+// highlightedYAML returns the YAML of the node as HTML, where keys and
+// values are in spans with a class of their type to be styled
+func highlightedYAML(node *yamlv3.Node) (template.HTML, error) {
+	if node == nil {
+		return "", nil
+	}
+
+	text, err := plainYAMLString(node)
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		lines = append(lines, highlightedYAMLLine(line))
+	}
+
+	// the escaped text and the fixed spans are safe to be used as HTML
+	return template.HTML(strings.Join(lines, "\n")), nil
+}
+
+func highlightedYAMLLine(line string) string {
+	if match := htmlYAMLKeyLine.FindStringSubmatch(line); match != nil {
+		return html.EscapeString(match[1]) +
+			htmlSpan("key", match[2]) +
+			html.EscapeString(match[3]) +
+			highlightedYAMLValue(match[4])
+	}
+
+	if match := htmlYAMLEntryLine.FindStringSubmatch(line); match != nil {
+		return html.EscapeString(match[1]) + highlightedYAMLValue(match[2])
+	}
+
+	return highlightedYAMLValue(line)
+}
+
+func highlightedYAMLValue(value string) string {
+	trimmed := strings.TrimSpace(value)
+	prefix := value[:strings.Index(value, trimmed)]
+
+	switch {
+	case trimmed == "":
+		return html.EscapeString(value)
+
+	case strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{"):
+		return html.EscapeString(prefix) + highlightedYAMLFlow(trimmed)
+
+	case strings.HasPrefix(trimmed, "#"):
+		return html.EscapeString(prefix) + htmlSpan("comment", trimmed)
+
+	case htmlYAMLNumber.MatchString(trimmed):
+		return html.EscapeString(prefix) + htmlSpan("number", trimmed)
+
+	case htmlYAMLLiteral.MatchString(trimmed):
+		return html.EscapeString(prefix) + htmlSpan("literal", trimmed)
+	}
+
+	return html.EscapeString(prefix) + htmlSpan("string", trimmed)
+}
+
+// highlightedYAMLFlow highlights the keys and values of a flow style list or
+// map, e.g. `{name: foo, ports: [80, 443]}`
+func highlightedYAMLFlow(value string) string {
+	var result strings.Builder
+	for _, token := range htmlYAMLFlowTokens.FindAllString(value, -1) {
+		switch {
+		case strings.TrimSpace(token) == "", len(token) == 1 && strings.ContainsAny(token, "[]{},"):
+			result.WriteString(html.EscapeString(token))
+
+		case strings.Contains(token, ": ") && !strings.HasPrefix(token, `"`) && !strings.HasPrefix(token, "'"):
+			key, rest, _ := strings.Cut(token, ":")
+			result.WriteString(htmlSpan("key", key) + ":" + highlightedYAMLValue(rest))
+
+		default:
+			result.WriteString(highlightedYAMLValue(token))
+		}
+	}
+
+	return result.String()
+}
+
+func htmlSpan(class string, text string) string {
+	return fmt.Sprintf(`<span class="%s">%s</span>`, class, html.EscapeString(text))
+}
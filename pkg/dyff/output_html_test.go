@@ -0,0 +1,75 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"bytes"
+
+	"github.com/gonvenience/ytbx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("HTML report", func() {
+	var writeHTML = func(from string, to string) string {
+		report, err := dyff.CompareInputFiles(
+			ytbx.InputFile{Location: "from.yml", Documents: multiDoc(from)},
+			ytbx.InputFile{Location: "to.yml", Documents: multiDoc(to)},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		Expect((&dyff.HTMLReport{Report: report}).WriteReport(&buf)).To(Succeed())
+		return buf.String()
+	}
+
+	It("should write a standalone page with a section per document", func() {
+		out := writeHTML(
+			"---\nname: foo\n---\nspec: {replicas: 1}\n",
+			"---\nname: bar\n---\nspec: {replicas: 2}\n",
+		)
+
+		Expect(out).To(HavePrefix("<!DOCTYPE html>"))
+		Expect(out).To(ContainSubstring("<title>dyff between from.yml and to.yml</title>"))
+		Expect(out).To(ContainSubstring("<summary>document #1</summary>"))
+		Expect(out).To(ContainSubstring("<summary>document #2</summary>"))
+		Expect(out).To(ContainSubstring(`<section id="document-2/spec/replicas">`))
+		Expect(out).To(ContainSubstring(`<h3><a href="#document-2/spec/replicas">spec.replicas</a></h3>`))
+	})
+
+	It("should highlight the from and to values", func() {
+		out := writeHTML("---\nname: foo\n", "---\nname: foo\nlabels: {app: web, replicas: 3}\n")
+
+		Expect(out).To(ContainSubstring(`<span class="kind kind-addition">&#43; addition</span>`))
+		Expect(out).To(ContainSubstring(`<pre class="to"><span class="key">labels</span>:`))
+		Expect(out).To(ContainSubstring(`<span class="key">replicas</span>: <span class="number">3</span>`))
+		Expect(out).ToNot(ContainSubstring(`<pre class="from">`))
+	})
+
+	It("should escape values", func() {
+		out := writeHTML("---\nscript: <b>foo</b>\n", "---\nscript: <i>bar</i>\n")
+
+		Expect(out).To(ContainSubstring(`<span class="string">&lt;i&gt;bar&lt;/i&gt;</span>`))
+		Expect(out).ToNot(ContainSubstring("<i>bar</i>"))
+	})
+})
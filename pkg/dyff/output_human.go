@@ -35,7 +35,6 @@ import (
 
 	"github.com/gonvenience/bunt"
 	"github.com/gonvenience/neat"
-	"github.com/gonvenience/term"
 	"github.com/gonvenience/text"
 	"github.com/gonvenience/ytbx"
 	"github.com/lucasb-eyer/go-colorful"
@@ -60,10 +59,81 @@ type HumanReport struct {
 	OmitHeader            bool
 	UseGoPatchPaths       bool
 	PrefixMultiline       bool
+
+	// ShowLineNumbers adds the line numbers of the difference in the from and
+	// to input file next to the path, e.g. `(from: a.yml:42, to: b.yml:57)`
+	ShowLineNumbers bool
+
+	// ShowValueTypes adds the YAML tag to changed values of different types,
+	// e.g. `!!str "42"` and `!!int 42`
+	ShowValueTypes bool
+
+	// ElideUnchanged only shows the changed map entries of list entries that
+	// are reported as removed and added, with `…` for the unchanged entries
+	ElideUnchanged bool
+
+	// SourceContextLines shows the given number of lines of the original input
+	// file around each difference, which is only possible for local files
+	SourceContextLines int
+
+	// sources caches the lines of the input files for the source context
+	sources map[string][]string
+
+	// Columns overrides the detected width of the terminal, which is used to
+	// decide whether text blocks fit next to each other and to wrap lines
+	Columns int
+
+	// WrapLines wraps lines that are longer than the available columns, so
+	// that the wrapped text keeps the indent of the line
+	WrapLines bool
+
+	// FromDescription and ToDescription replace the locations of the input
+	// files in the report, e.g. to show a name instead of a temporary file
+	FromDescription string
+	ToDescription   string
+
+	// HyperlinkTemplate enables OSC 8 hyperlinks for file names and paths of
+	// differences, see DefaultHyperlinkTemplate for supported placeholders
+	HyperlinkTemplate string
+
+	// MaxDiffs limits the number of differences that are shown per document,
+	// the number of differences that are not shown is mentioned at the end
+	MaxDiffs int
+
+	// MaxDiffsHint is shown next to the number of differences that are not
+	// shown, e.g. to explain how to see all of them
+	MaxDiffsHint string
+
+	// LargestSubtrees adds a section before the differences, which lists the
+	// given number of subtrees with the most differences
+	LargestSubtrees int
+
+	// CollapseRepeated shows differences with the same change at paths with
+	// the same last element only once, together with the list of their paths
+	CollapseRepeated bool
+
+	// SeverityRules optionally adds the severity next to the path of each
+	// difference, where critical differences are emphasized
+	SeverityRules SeverityRules
+
+	// GroupBy returns the name of the group of a difference, differences of
+	// the same group are shown together below a heading with the name, and
+	// differences without a group are shown first
+	GroupBy func(diff Diff) string
+
+	// Theme is the set of colors used to render the report, the dark theme is
+	// used if it is not set
+	Theme *Theme
 }
 
 // WriteReport writes a human readable report to the provided writer
 func (report *HumanReport) WriteReport(out io.Writer) error {
+	if report.WrapLines {
+		wrapper := &lineWrapper{out: out, columns: report.columns()}
+		defer wrapper.Flush()
+		out = wrapper
+	}
+
 	writer := bufio.NewWriter(out)
 	defer writer.Flush()
 
@@ -79,8 +149,8 @@ func (report *HumanReport) WriteReport(out io.Writer) error {
  \__,_|\__, |_| |_|   returned %s
         |___/
 `,
-			ytbx.HumanReadableLocationInformation(report.From),
-			ytbx.HumanReadableLocationInformation(report.To),
+			report.linkedLocationInformation(report.From, report.FromDescription),
+			report.linkedLocationInformation(report.To, report.ToDescription),
 			bunt.Style(text.Plural(len(report.Diffs), "difference"), bunt.Bold()))
 
 		_, _ = writer.WriteString(bunt.Style(
@@ -88,13 +158,13 @@ func (report *HumanReport) WriteReport(out io.Writer) error {
 			bunt.ForegroundFunc(func(x int, _ int, _ rune) *colorful.Color {
 				switch {
 				case x < 7:
-					return &colorful.Color{R: .45, G: .71, B: .30}
+					return &report.theme().Header[0]
 
 				case x < 13:
-					return &colorful.Color{R: .79, G: .76, B: .38}
+					return &report.theme().Header[1]
 
 				case x < 21:
-					return &colorful.Color{R: .65, G: .17, B: .17}
+					return &report.theme().Header[2]
 				}
 
 				return nil
@@ -102,11 +172,62 @@ func (report *HumanReport) WriteReport(out io.Writer) error {
 		))
 	}
 
+	if report.LargestSubtrees > 0 {
+		_, _ = writer.WriteString(report.largestSubtreesSection(showPathRoot))
+	}
+
+	var groups = report.groups()
+	var repeated *repeatedChanges
+	if report.CollapseRepeated {
+		var diffs []Diff
+		for _, group := range groups {
+			diffs = append(diffs, group.diffs...)
+		}
+
+		repeated = newRepeatedChanges(diffs)
+	}
+
 	// Loop over the diff and generate each report into the buffer
-	for _, diff := range report.Diffs {
-		if err := report.generateHumanDiffOutput(writer, diff, report.UseGoPatchPaths, showPathRoot); err != nil {
-			return err
+	var limit = newDiffLimit(report.MaxDiffs)
+	var idx = -1
+	for _, group := range groups {
+		if group.name != "" {
+			_, _ = writer.WriteString(fmt.Sprintf("\n%s  %s\n",
+				bunt.Style("▶ "+group.name, bunt.Bold()),
+				report.theme().dimgray("(%s)", text.Plural(len(group.diffs), "difference"))))
+		}
+
+		for _, diff := range group.diffs {
+			idx++
+			if repeated != nil && repeated.isRepetition(idx, diff) {
+				continue
+			}
+
+			if !limit.allow(diff) {
+				continue
+			}
+
+			if err := report.generateHumanDiffOutput(writer, diff, report.UseGoPatchPaths, showPathRoot); err != nil {
+				return err
+			}
+
+			if repeated != nil {
+				_, _ = writer.WriteString(report.repetitions(repeated, idx, showPathRoot))
+			}
+		}
+	}
+
+	for _, overflow := range limit.overflows() {
+		var message = fmt.Sprintf("… and %s", text.Plural(overflow.count, "more difference"))
+		if showPathRoot && overflow.path != nil {
+			message += fmt.Sprintf(" in %s", overflow.path.RootDescription())
+		}
+
+		if report.MaxDiffsHint != "" {
+			message += fmt.Sprintf(" (%s)", report.MaxDiffsHint)
 		}
+
+		_, _ = writer.WriteString("\n" + report.theme().dimgray("%s", message) + "\n")
 	}
 
 	// Finish with one last newline so that we do not end next to the prompt
@@ -114,20 +235,220 @@ func (report *HumanReport) WriteReport(out io.Writer) error {
 	return nil
 }
 
+type diffGroup struct {
+	name  string
+	diffs []Diff
+}
+
+// GroupByPath returns a function to be used as GroupBy of the human report,
+// which groups the differences by the first path elements up to the given
+// depth, e.g. a depth of one groups them by their top-level key
+func GroupByPath(depth int, useGoPatchPaths bool) func(diff Diff) string {
+	return func(diff Diff) string {
+		path := ytbx.Path{PathElements: diff.Path.PathElements}
+		if len(path.PathElements) > depth {
+			path.PathElements = path.PathElements[:depth]
+		}
+
+		if len(path.PathElements) == 0 {
+			return ""
+		}
+
+		if useGoPatchPaths {
+			return path.ToGoPatchStyle()
+		}
+
+		return path.ToDotStyle()
+	}
+}
+
+// GroupByPathPrefix returns a function to be used as GroupBy of the human
+// report, which groups the differences by the first of the given paths that
+// their path starts with, e.g. by the change roots that were compared
+func GroupByPathPrefix(prefixes []ytbx.Path, useGoPatchPaths bool) func(diff Diff) string {
+	return func(diff Diff) string {
+		for _, prefix := range prefixes {
+			if !hasPathPrefix(diff.Path.PathElements, prefix.PathElements) {
+				continue
+			}
+
+			if useGoPatchPaths {
+				return prefix.ToGoPatchStyle()
+			}
+
+			return prefix.ToDotStyle()
+		}
+
+		return ""
+	}
+}
+
+func hasPathPrefix(elements []ytbx.PathElement, prefix []ytbx.PathElement) bool {
+	if len(elements) < len(prefix) {
+		return false
+	}
+
+	for i := range prefix {
+		if elements[i] != prefix[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GroupByDocument is to be used as GroupBy of the human report and groups
+// the differences by their document, e.g. by Kubernetes resource
+func GroupByDocument(diff Diff) string {
+	if diff.Path == nil || diff.Path.Root == nil {
+		return ""
+	}
+
+	return diff.Path.RootDescription()
+}
+
+// groups returns the differences in the order of their groups, where the
+// groups are sorted by their first difference
+func (report *HumanReport) groups() []diffGroup {
+	if report.GroupBy == nil {
+		return []diffGroup{{diffs: report.Diffs}}
+	}
+
+	var result = []diffGroup{{}}
+	var lookup = map[string]int{"": 0}
+	for _, diff := range report.Diffs {
+		// Differences on file level, e.g. document order changes, have no path
+		var name string
+		if diff.Path != nil {
+			name = report.GroupBy(diff)
+		}
+
+		idx, ok := lookup[name]
+		if !ok {
+			idx = len(result)
+			lookup[name] = idx
+			result = append(result, diffGroup{name: name})
+		}
+
+		result[idx].diffs = append(result[idx].diffs, diff)
+	}
+
+	return result
+}
+
+// WriteDiff writes the human readable output of a single difference, which
+// can be used to write differences one by one as soon as they are found. The
+// document of the path is always shown, since the number of documents is not
+// necessarily known at that point.
+func (report *HumanReport) WriteDiff(out io.Writer, diff Diff) error {
+	if report.WrapLines {
+		wrapper := &lineWrapper{out: out, columns: report.columns()}
+		defer wrapper.Flush()
+		out = wrapper
+	}
+
+	writer := bufio.NewWriter(out)
+	defer writer.Flush()
+
+	return report.generateHumanDiffOutput(writer, diff, report.UseGoPatchPaths, true)
+}
+
+// describe returns the description of the input file if there is one, or
+// otherwise its location
+func describe(inputFile ytbx.InputFile, description string) string {
+	if description != "" {
+		return description
+	}
+
+	return inputFile.Location
+}
+
+// linkedLocationInformation returns the human readable location information
+// of the input file, which is a hyperlink to the file if enabled
+func (report *HumanReport) linkedLocationInformation(inputFile ytbx.InputFile, description string) string {
+	described := inputFile
+	described.Location = describe(inputFile, description)
+
+	info := ytbx.HumanReadableLocationInformation(described)
+	if report.HyperlinkTemplate == "" {
+		return info
+	}
+
+	return hyperlink(expandHyperlinkTemplate(report.HyperlinkTemplate, inputFile.Location, 0), info)
+}
+
+// linkedPath returns the provided path text as a hyperlink pointing to the
+// line of the difference in the input file if hyperlinks are enabled
+func (report *HumanReport) linkedPath(diff Diff, text string) string {
+	if report.HyperlinkTemplate == "" {
+		return text
+	}
+
+	location, line := report.locationOfDiff(diff)
+	return hyperlink(expandHyperlinkTemplate(report.HyperlinkTemplate, location, line), text)
+}
+
+// lineNumbers returns the line numbers of the difference in both input files
+// to be shown next to the path, or an empty string if they are unknown
+func (report *HumanReport) lineNumbers(diff Diff) string {
+	fromLine, toLine := report.linesOfDiff(diff)
+
+	var locations []string
+	if fromLine > 0 {
+		locations = append(locations, fmt.Sprintf("from: %s:%d", describe(report.From, report.FromDescription), fromLine))
+	}
+
+	if toLine > 0 {
+		locations = append(locations, fmt.Sprintf("to: %s:%d", describe(report.To, report.ToDescription), toLine))
+	}
+
+	if len(locations) == 0 {
+		return ""
+	}
+
+	return report.theme().dimgray("  (%s)", strings.Join(locations, ", "))
+}
+
 // generateHumanDiffOutput creates a human readable report of the provided diff and writes this into the given bytes buffer. There is an optional flag to indicate whether the document index (which documents of the input file) should be included in the report of the path of the difference.
 func (report *HumanReport) generateHumanDiffOutput(output stringWriter, diff Diff, useGoPatchPaths bool, showPathRoot bool) error {
 	_, _ = output.WriteString("\n")
-	_, _ = output.WriteString(pathToString(diff.Path, useGoPatchPaths, showPathRoot))
+	_, _ = output.WriteString(report.linkedPath(diff, pathToString(diff.Path, useGoPatchPaths, showPathRoot)))
+	if report.ShowLineNumbers {
+		_, _ = output.WriteString(report.lineNumbers(diff))
+	}
+	if mappings := report.indexMappings(diff); len(mappings) > 0 {
+		_, _ = output.WriteString(report.theme().dimgray("  (%s)", strings.Join(mappings, ", ")))
+	}
+	if report.SeverityRules != nil {
+		_, _ = output.WriteString("  " + report.severityLabel(report.SeverityRules.Severity(diff)))
+	}
 	_, _ = output.WriteString("\n")
 
-	blocks := make([]string, len(diff.Details))
-	for i, detail := range diff.Details {
-		generatedOutput, err := report.generateHumanDetailOutput(detail)
+	details := diff.Details
+	if report.ElideUnchanged {
+		details = elidedDetails(details)
+	}
+
+	blocks := make([]string, len(details))
+	for i, detail := range details {
+		var generatedOutput string
+		var err error
+		switch {
+		case detail.Kind == ORDERCHANGE && isMappingAt(report.From, diff.Path):
+			generatedOutput, err = report.orderChangeOutput("map order changed", detail)
+
+		default:
+			generatedOutput, err = report.generateHumanDetailOutput(detail)
+		}
+
 		if err != nil {
 			return err
 		}
 
 		blocks[i] = generatedOutput
+		if report.ElideUnchanged {
+			blocks[i] = removeElisionMarkers(generatedOutput)
+		}
 	}
 
 	// For the use case in which only a path-less diff is suppose to be printed,
@@ -138,9 +459,32 @@ func (report *HumanReport) generateHumanDiffOutput(output stringWriter, diff Dif
 	}
 
 	report.writeTextBlocks(output, indent, blocks...)
+
+	if report.SourceContextLines > 0 {
+		_, _ = output.WriteString(report.sourceContext(diff))
+	}
+
 	return nil
 }
 
+// severityLabel returns the severity in a color that matches its importance
+func (report *HumanReport) theme() *Theme {
+	return orDefaultTheme(report.Theme)
+}
+
+func (report *HumanReport) severityLabel(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return bold("%s", report.theme().red("‼ %s", severity))
+
+	case SeverityWarning:
+		return report.theme().yellow("! %s", severity)
+
+	default:
+		return report.theme().dimgray("%s", severity)
+	}
+}
+
 // generateHumanDetailOutput only serves as a dispatcher to call the correct sub function for the respective type of change
 func (report *HumanReport) generateHumanDetailOutput(detail Detail) (string, error) {
 	switch detail.Kind {
@@ -150,11 +494,17 @@ func (report *HumanReport) generateHumanDetailOutput(detail Detail) (string, err
 	case REMOVAL:
 		return report.generateHumanDetailOutputRemoval(detail)
 
-	case MODIFICATION:
+	case MODIFICATION, TYPECHANGE, REPRESENTATIONCHANGE:
 		return report.generateHumanDetailOutputModification(detail)
 
 	case ORDERCHANGE:
 		return report.generateHumanDetailOutputOrderchange(detail)
+
+	case STYLECHANGE:
+		return report.generateHumanDetailOutputStylechange(detail)
+
+	case RENAME:
+		return report.generateHumanDetailOutputRename(detail)
 	}
 
 	return "", fmt.Errorf("unsupported detail type %c", detail.Kind)
@@ -165,26 +515,26 @@ func (report *HumanReport) generateHumanDetailOutputAddition(detail Detail) (str
 
 	switch detail.To.Kind {
 	case yamlv3.DocumentNode:
-		_, _ = fmt.Fprint(&output, yellow("%c %s added:\n",
+		_, _ = fmt.Fprint(&output, report.theme().yellow("%c %s added:\n",
 			ADDITION,
 			text.Plural(len(detail.To.Content), "document"),
 		))
 
 	case yamlv3.SequenceNode:
-		_, _ = output.WriteString(yellow("%c %s added:\n",
+		_, _ = output.WriteString(report.theme().yellow("%c %s added:\n",
 			ADDITION,
 			text.Plural(len(detail.To.Content), "list entry", "list entries"),
 		))
 
 	case yamlv3.MappingNode:
-		_, _ = output.WriteString(yellow("%c %s added:\n",
+		_, _ = output.WriteString(report.theme().yellow("%c %s added:\n",
 			ADDITION,
 			text.Plural(len(detail.To.Content)/2, "map entry", "map entries"),
 		))
 	}
 
 	ytbx.RestructureObject(detail.To)
-	yamlOutput, err := yamlStringInGreenishColors(detail.To)
+	yamlOutput, err := report.theme().yamlStringInGreenishColors(detail.To)
 	if err != nil {
 		return "", err
 	}
@@ -199,22 +549,22 @@ func (report *HumanReport) generateHumanDetailOutputRemoval(detail Detail) (stri
 
 	switch detail.From.Kind {
 	case yamlv3.DocumentNode:
-		_, _ = fmt.Fprint(&output, yellow("%c %s removed:\n",
+		_, _ = fmt.Fprint(&output, report.theme().yellow("%c %s removed:\n",
 			REMOVAL,
 			text.Plural(len(detail.From.Content), "document"),
 		))
 
 	case yamlv3.SequenceNode:
 		text := text.Plural(len(detail.From.Content), "list entry", "list entries")
-		_, _ = output.WriteString(yellow("%c %s removed:\n", REMOVAL, text))
+		_, _ = output.WriteString(report.theme().yellow("%c %s removed:\n", REMOVAL, text))
 
 	case yamlv3.MappingNode:
 		text := text.Plural(len(detail.From.Content)/2, "map entry", "map entries")
-		_, _ = output.WriteString(yellow("%c %s removed:\n", REMOVAL, text))
+		_, _ = output.WriteString(report.theme().yellow("%c %s removed:\n", REMOVAL, text))
 	}
 
 	ytbx.RestructureObject(detail.From)
-	yamlOutput, err := yamlStringInRedishColors(detail.From)
+	yamlOutput, err := report.theme().yamlStringInRedishColors(detail.From)
 	if err != nil {
 		return "", err
 	}
@@ -238,6 +588,13 @@ func (report *HumanReport) generateHumanDetailOutputModification(detail Detail)
 			detail.To.Value,
 		)
 
+	case fromType == toType && (fromType == "map" || fromType == "list"):
+		// only the case for subtrees at the maximum depth of the comparison
+		_, _ = output.WriteString(report.theme().yellow("%c subtree changed (%s)\n",
+			MODIFICATION,
+			text.Plural(leafDifferences(detail.From, detail.To), "leaf difference"),
+		))
+
 	case fromType == "binary" && toType == "binary":
 		from, err := base64.StdEncoding.DecodeString(detail.From.Value)
 		if err != nil {
@@ -249,29 +606,37 @@ func (report *HumanReport) generateHumanDetailOutputModification(detail Detail)
 			return "", err
 		}
 
-		_, _ = output.WriteString(yellow("%c content change\n", MODIFICATION))
+		_, _ = output.WriteString(report.theme().yellow("%c content change\n", MODIFICATION))
 		if report.PrefixMultiline {
 			report.writeTextBlocks(&output, 0,
-				red("%s", createStringWithContinuousPrefix("- ", hex.Dump(from), report.Indent)),
-				green("%s", createStringWithContinuousPrefix("+ ", hex.Dump(to), report.Indent)),
+				report.theme().red("%s", createStringWithContinuousPrefix("- ", hex.Dump(from), report.Indent)),
+				report.theme().green("%s", createStringWithContinuousPrefix("+ ", hex.Dump(to), report.Indent)),
 			)
 		} else {
 			report.writeTextBlocks(&output, 0,
-				red("%s", createStringWithPrefix("- ", hex.Dump(from), report.Indent)),
-				green("%s", createStringWithPrefix("+ ", hex.Dump(to), report.Indent)),
+				report.theme().red("%s", createStringWithPrefix("- ", hex.Dump(from), report.Indent)),
+				report.theme().green("%s", createStringWithPrefix("+ ", hex.Dump(to), report.Indent)),
 			)
 		}
 
 	default:
-		if fromType != toType {
-			_, _ = output.WriteString(yellow("%c type change from %s to %s\n",
-				MODIFICATION,
+		switch {
+		case detail.Kind == REPRESENTATIONCHANGE:
+			_, _ = output.WriteString(report.theme().yellow("%c representation change from %s to %s\n",
+				detail.Kind,
 				italic(fromType),
 				italic(toType),
 			))
 
-		} else {
-			_, _ = output.WriteString(yellow("%c value change\n",
+		case fromType != toType:
+			_, _ = output.WriteString(report.theme().yellow("%c type change from %s to %s\n",
+				detail.Kind,
+				italic(fromType),
+				italic(toType),
+			))
+
+		default:
+			_, _ = output.WriteString(report.theme().yellow("%c value change\n",
 				MODIFICATION,
 			))
 		}
@@ -286,17 +651,87 @@ func (report *HumanReport) generateHumanDetailOutputModification(detail Detail)
 			return "", err
 		}
 
-		_, _ = output.WriteString(red("%s", createStringWithPrefix("- ", strings.TrimRight(from, "\n"), report.Indent)))
-		_, _ = output.WriteString(green("%s", createStringWithPrefix("+ ", strings.TrimRight(to, "\n"), report.Indent)))
+		if report.ShowValueTypes {
+			from, to = withTag(detail.From, from), withTag(detail.To, to)
+		}
+
+		_, _ = output.WriteString(report.theme().red("%s", createStringWithPrefix("- ", strings.TrimRight(from, "\n"), report.Indent)))
+		_, _ = output.WriteString(report.theme().green("%s", createStringWithPrefix("+ ", strings.TrimRight(to, "\n"), report.Indent)))
 	}
 
 	return output.String(), nil
 }
 
+// withTag prefixes the text of a scalar value with the YAML tag of the node,
+// e.g. `!!int 42`, so that values of different types can be told apart
+func withTag(node *yamlv3.Node, text string) string {
+	if node == nil || node.Kind != yamlv3.ScalarNode {
+		return text
+	}
+
+	return node.ShortTag() + " " + text
+}
+
+// generateHumanDetailOutputStylechange creates the output of a style change,
+// which uses a dimmed heading to set it apart from changes of the content
+func (report *HumanReport) generateHumanDetailOutputStylechange(detail Detail) (string, error) {
+	var output bytes.Buffer
+
+	_, _ = output.WriteString(report.theme().dimgray("%c style change from %s to %s\n",
+		STYLECHANGE,
+		italic(styleName(detail.From)),
+		italic(styleName(detail.To)),
+	))
+
+	from, err := styledYAMLString(detail.From)
+	if err != nil {
+		return "", err
+	}
+
+	to, err := styledYAMLString(detail.To)
+	if err != nil {
+		return "", err
+	}
+
+	_, _ = output.WriteString(report.theme().red("%s", createStringWithPrefix("- ", strings.TrimRight(from, "\n"), report.Indent)))
+	_, _ = output.WriteString(report.theme().green("%s", createStringWithPrefix("+ ", strings.TrimRight(to, "\n"), report.Indent)))
+
+	return output.String(), nil
+}
+
+// generateHumanDetailOutputRename creates the output of a renamed or moved
+// document with its previous and its new name
+func (report *HumanReport) generateHumanDetailOutputRename(detail Detail) (string, error) {
+	var output bytes.Buffer
+
+	_, _ = output.WriteString(report.theme().yellow("%c document %s\n", RENAME, renameDescription(detail)))
+	_, _ = output.WriteString(report.theme().red("%s", createStringWithPrefix("- ", detail.From.Value, report.Indent)))
+	_, _ = output.WriteString(report.theme().green("%s", createStringWithPrefix("+ ", detail.To.Value, report.Indent)))
+
+	return output.String(), nil
+}
+
+// renameDescription returns whether a document was moved, i.e. it kept its
+// name in another namespace, or whether it was renamed
+func renameDescription(detail Detail) string {
+	name := func(value string) string { return value[strings.LastIndex(value, "/")+1:] }
+	if name(detail.From.Value) == name(detail.To.Value) {
+		return "moved"
+	}
+
+	return "renamed"
+}
+
 func (report *HumanReport) generateHumanDetailOutputOrderchange(detail Detail) (string, error) {
+	return report.orderChangeOutput("order changed", detail)
+}
+
+// orderChangeOutput creates the output of an order change using the provided
+// label, which differs for the reordered keys of a map
+func (report *HumanReport) orderChangeOutput(label string, detail Detail) (string, error) {
 	var output bytes.Buffer
 
-	_, _ = output.WriteString(yellow("%c order changed\n", ORDERCHANGE))
+	_, _ = output.WriteString(report.theme().yellow("%c %s\n", ORDERCHANGE, label))
 	switch detail.From.Kind {
 	case yamlv3.SequenceNode:
 		asStringList := func(sequenceNode *yamlv3.Node) ([]string, error) {
@@ -326,17 +761,17 @@ func (report *HumanReport) generateHumanDetailOutputOrderchange(detail Detail) (
 
 		const singleLineSeparator = ", "
 
-		threshold := term.GetTerminalWidth() / 2
+		threshold := report.columns() / 2
 		fromSingleLineLength := stringArrayLen(from) + ((len(from) - 1) * plainTextLength(singleLineSeparator))
 		toStringleLineLength := stringArrayLen(to) + ((len(to) - 1) * plainTextLength(singleLineSeparator))
 		if estimatedLength := max(fromSingleLineLength, toStringleLineLength); estimatedLength < threshold {
-			_, _ = output.WriteString(red(strings.Repeat(" ", report.Indent)+"- %s\n", strings.Join(from, singleLineSeparator)))
-			_, _ = output.WriteString(green(strings.Repeat(" ", report.Indent)+"+ %s\n", strings.Join(to, singleLineSeparator)))
+			_, _ = output.WriteString(report.theme().red(strings.Repeat(" ", report.Indent)+"- %s\n", strings.Join(from, singleLineSeparator)))
+			_, _ = output.WriteString(report.theme().green(strings.Repeat(" ", report.Indent)+"+ %s\n", strings.Join(to, singleLineSeparator)))
 
 		} else {
 			_, _ = output.WriteString(CreateTableStyleString(" ", 2,
-				red("%s", strings.Join(from, "\n")),
-				green("%s", strings.Join(to, "\n"))))
+				report.theme().red("%s", strings.Join(from, "\n")),
+				report.theme().green("%s", strings.Join(to, "\n"))))
 		}
 	}
 
@@ -348,14 +783,21 @@ func (report *HumanReport) writeStringDiff(output stringWriter, from string, to
 
 	switch {
 	case err == nil:
-		_, _ = output.WriteString(yellow("%c certificate change\n", MODIFICATION))
+		_, _ = output.WriteString(report.theme().yellow("%c certificate change\n", MODIFICATION))
 		_, _ = output.WriteString(report.highlightByLine(fromCertText, toCertText))
 
+	case isInvisibleCharacterChange(from, to):
+		_, _ = output.WriteString(report.theme().yellow("%c invisible character change ⚠\n", MODIFICATION))
+		report.writeTextBlocks(output, 0,
+			report.theme().red("%s", createStringWithPrefix("- ", escapeInvisibleCharacters(from), report.Indent)),
+			report.theme().green("%s", createStringWithPrefix("+ ", escapeInvisibleCharacters(to), report.Indent)),
+		)
+
 	case isWhitespaceOnlyChange(from, to):
-		_, _ = output.WriteString(yellow("%c whitespace only change\n", MODIFICATION))
+		_, _ = output.WriteString(report.theme().yellow("%c whitespace only change\n", MODIFICATION))
 		report.writeTextBlocks(output, 0,
-			red("%s", createStringWithPrefix("- ", showWhitespaceCharacters(from), report.Indent)),
-			green("%s", createStringWithPrefix("+ ", showWhitespaceCharacters(to), report.Indent)),
+			report.theme().red("%s", createStringWithPrefix("- ", showWhitespaceCharacters(from), report.Indent)),
+			report.theme().green("%s", createStringWithPrefix("+ ", showWhitespaceCharacters(to), report.Indent)),
 		)
 
 	case isMultiLine(from, to):
@@ -373,11 +815,11 @@ func (report *HumanReport) writeStringDiff(output stringWriter, from string, to
 			// color and format each diff by type
 			switch d.Type {
 			case diffmatchpatch.DiffInsert:
-				fmt.Fprint(&buf, green(createStringWithContinuousPrefix("+ ", d.Text, report.Indent)))
+				fmt.Fprint(&buf, report.theme().green(createStringWithContinuousPrefix("+ ", d.Text, report.Indent)))
 				ins++
 
 			case diffmatchpatch.DiffDelete:
-				fmt.Fprint(&buf, red(createStringWithContinuousPrefix("- ", d.Text, report.Indent)))
+				fmt.Fprint(&buf, report.theme().red(createStringWithContinuousPrefix("- ", d.Text, report.Indent)))
 				del++
 
 			case diffmatchpatch.DiffEqual:
@@ -402,25 +844,25 @@ func (report *HumanReport) writeStringDiff(output stringWriter, from string, to
 						text.Plural((upper-lower), "line"),
 						strings.Join(lines[upper:], "\n"))
 				}
-				fmt.Fprint(&buf, dimgray(createStringWithContinuousPrefix("  ", val, report.Indent)))
+				fmt.Fprint(&buf, report.theme().dimgray(createStringWithContinuousPrefix("  ", val, report.Indent)))
 			}
 		}
 		_, _ = output.WriteString(
-			yellow("%c value change in multiline text (%s, %s)\n",
+			report.theme().yellow("%c value change in multiline text (%s, %s)\n",
 				MODIFICATION, text.Plural(ins, "insert"), text.Plural(del, "deletion")))
 		_, _ = output.WriteString(buf.String())
 		_, _ = output.WriteString("\n")
 
 	case isMinorChange(from, to, report.MinorChangeThreshold):
-		_, _ = output.WriteString(yellow("%c value change\n", MODIFICATION))
+		_, _ = output.WriteString(report.theme().yellow("%c value change\n", MODIFICATION))
 		diffs := diffmatchpatch.New().DiffMain(from, to, false)
-		_, _ = output.WriteString(highlightRemovals(diffs, report.Indent))
-		_, _ = output.WriteString(highlightAdditions(diffs, report.Indent))
+		_, _ = output.WriteString(report.highlightRemovals(diffs, report.Indent))
+		_, _ = output.WriteString(report.highlightAdditions(diffs, report.Indent))
 
 	default:
-		_, _ = output.WriteString(yellow("%c value change\n", MODIFICATION))
-		_, _ = output.WriteString(red("%s", createStringWithPrefix("- ", from, report.Indent)))
-		_, _ = output.WriteString(green("%s", createStringWithPrefix("+ ", to, report.Indent)))
+		_, _ = output.WriteString(report.theme().yellow("%c value change\n", MODIFICATION))
+		_, _ = output.WriteString(report.theme().red("%s", createStringWithPrefix("- ", from, report.Indent)))
+		_, _ = output.WriteString(report.theme().green("%s", createStringWithPrefix("+ ", to, report.Indent)))
 	}
 }
 
@@ -433,29 +875,29 @@ func (report *HumanReport) highlightByLine(from, to string) string {
 	if len(fromLines) == len(toLines) {
 		for i := range fromLines {
 			if fromLines[i] != toLines[i] {
-				fromLines[i] = red(fromLines[i])
-				toLines[i] = green(toLines[i])
+				fromLines[i] = report.theme().red(fromLines[i])
+				toLines[i] = report.theme().green(toLines[i])
 
 			} else {
-				fromLines[i] = lightred(fromLines[i])
-				toLines[i] = lightgreen(toLines[i])
+				fromLines[i] = report.theme().lightred(fromLines[i])
+				toLines[i] = report.theme().lightgreen(toLines[i])
 			}
 		}
 
 		if report.PrefixMultiline {
 			report.writeTextBlocks(&buf, 0,
-				createStringWithContinuousPrefix(red("- "), strings.Join(fromLines, "\n"), report.Indent),
-				createStringWithContinuousPrefix(green("+ "), strings.Join(toLines, "\n"), report.Indent))
+				createStringWithContinuousPrefix(report.theme().red("- "), strings.Join(fromLines, "\n"), report.Indent),
+				createStringWithContinuousPrefix(report.theme().green("+ "), strings.Join(toLines, "\n"), report.Indent))
 		} else {
 			report.writeTextBlocks(&buf, 0,
-				createStringWithPrefix(red("- "), strings.Join(fromLines, "\n"), report.Indent),
-				createStringWithPrefix(green("+ "), strings.Join(toLines, "\n"), report.Indent))
+				createStringWithPrefix(report.theme().red("- "), strings.Join(fromLines, "\n"), report.Indent),
+				createStringWithPrefix(report.theme().green("+ "), strings.Join(toLines, "\n"), report.Indent))
 		}
 
 	} else {
 		report.writeTextBlocks(&buf, 0,
-			red("%s", createStringWithPrefix("- ", from, report.Indent)),
-			green("%s", createStringWithPrefix("+ ", to, report.Indent)),
+			report.theme().red("%s", createStringWithPrefix("- ", from, report.Indent)),
+			report.theme().green("%s", createStringWithPrefix("+ ", to, report.Indent)),
 		)
 	}
 
@@ -493,17 +935,17 @@ func humanReadableType(node *yamlv3.Node) string {
 	panic(fmt.Errorf("unknown and therefore unsupported kind %v", node.Kind))
 }
 
-func highlightRemovals(diffs []diffmatchpatch.Diff, indent int) string {
+func (report *HumanReport) highlightRemovals(diffs []diffmatchpatch.Diff, indent int) string {
 	var buf bytes.Buffer
 
-	buf.WriteString(red("%s- ", strings.Repeat(" ", indent)))
+	buf.WriteString(report.theme().red("%s- ", strings.Repeat(" ", indent)))
 	for _, part := range diffs {
 		switch part.Type {
 		case diffmatchpatch.DiffEqual:
-			buf.WriteString(lightred("%s", part.Text))
+			buf.WriteString(report.theme().lightred("%s", part.Text))
 
 		case diffmatchpatch.DiffDelete:
-			buf.WriteString(bold("%s", red("%s", part.Text)))
+			buf.WriteString(bold("%s", report.theme().red("%s", part.Text)))
 		}
 	}
 
@@ -511,17 +953,17 @@ func highlightRemovals(diffs []diffmatchpatch.Diff, indent int) string {
 	return buf.String()
 }
 
-func highlightAdditions(diffs []diffmatchpatch.Diff, indent int) string {
+func (report *HumanReport) highlightAdditions(diffs []diffmatchpatch.Diff, indent int) string {
 	var buf bytes.Buffer
 
-	buf.WriteString(green("%s+ ", strings.Repeat(" ", indent)))
+	buf.WriteString(report.theme().green("%s+ ", strings.Repeat(" ", indent)))
 	for _, part := range diffs {
 		switch part.Type {
 		case diffmatchpatch.DiffEqual:
-			buf.WriteString(lightgreen("%s", part.Text))
+			buf.WriteString(report.theme().lightgreen("%s", part.Text))
 
 		case diffmatchpatch.DiffInsert:
-			buf.WriteString(bold("%s", green("%s", part.Text)))
+			buf.WriteString(bold("%s", report.theme().green("%s", part.Text)))
 		}
 	}
 
@@ -620,6 +1062,17 @@ func yamlString(input interface{}) (string, error) {
 	return neat.NewOutputProcessor(false, true, nil).ToYAML(input)
 }
 
+// styledYAMLString returns the YAML of the node in its original style, e.g.
+// with quotes or in flow style, so that a style change becomes visible
+func styledYAMLString(node *yamlv3.Node) (string, error) {
+	data, err := yamlv3.Marshal(node)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
 func isMinorChange(from string, to string, minorChangeThreshold float64) bool {
 	levenshteinDistance := levenshtein.DistanceForStrings([]rune(from), []rune(to), levenshtein.DefaultOptions)
 
@@ -676,7 +1129,7 @@ func createStringWithPrefix(prefix string, obj interface{}, indent int) string {
 }
 
 func plainTextLength(text string) int {
-	return utf8.RuneCountInString(bunt.RemoveAllEscapeSequences(text))
+	return utf8.RuneCountInString(bunt.RemoveAllEscapeSequences(hyperlinkSequence.ReplaceAllString(text, "")))
 }
 
 func stringArrayLen(list []string) int {
@@ -706,7 +1159,7 @@ func (report *HumanReport) writeTextBlocks(buf stringWriter, indent int, blocks
 	}
 
 	// In case the line with blocks next to each other would surpass the terminal width, fall back to the no-table-style
-	if report.NoTableStyle || theoreticalMaxLineLength > term.GetTerminalWidth() {
+	if report.NoTableStyle || theoreticalMaxLineLength > report.columns() {
 		for _, block := range blocks {
 			lines := strings.Split(block, "\n")
 			for _, line := range lines {
@@ -821,3 +1274,18 @@ func styledDotStylePath(path *ytbx.Path) string {
 
 	return strings.Join(sections, ".")
 }
+
+// isMappingAt returns whether the node at the path in the input file is a map
+func isMappingAt(inputFile ytbx.InputFile, path *ytbx.Path) bool {
+	if path == nil {
+		return false
+	}
+
+	document := documentOf(inputFile, path)
+	if document == nil || len(document.Content) == 0 {
+		return false
+	}
+
+	node, err := ytbx.Grab(document, path.String())
+	return err == nil && node.Kind == yamlv3.MappingNode
+}
@@ -0,0 +1,106 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gonvenience/text"
+	"github.com/gonvenience/ytbx"
+)
+
+// repeatedChanges keeps track of differences with the same change, i.e. the
+// same details at paths with the same last element, e.g. the same label that
+// was added to many resources
+type repeatedChanges struct {
+	first map[string]int
+	paths map[int][]*ytbx.Path
+}
+
+func newRepeatedChanges(diffs []Diff) *repeatedChanges {
+	var result = &repeatedChanges{
+		first: map[string]int{},
+		paths: map[int][]*ytbx.Path{},
+	}
+
+	for i, diff := range diffs {
+		key, ok := repeatedChangeKey(diff)
+		if !ok {
+			continue
+		}
+
+		if idx, ok := result.first[key]; ok {
+			result.paths[idx] = append(result.paths[idx], diff.Path)
+			continue
+		}
+
+		result.first[key] = i
+	}
+
+	return result
+}
+
+// isRepetition returns whether the difference is the same change as one of
+// the previous differences, which means it is shown together with it
+func (repeated *repeatedChanges) isRepetition(idx int, diff Diff) bool {
+	key, ok := repeatedChangeKey(diff)
+	if !ok {
+		return false
+	}
+
+	return repeated.first[key] != idx
+}
+
+func repeatedChangeKey(diff Diff) (string, bool) {
+	if diff.Path == nil || len(diff.Path.PathElements) == 0 {
+		return "", false
+	}
+
+	var key strings.Builder
+	last := diff.Path.PathElements[len(diff.Path.PathElements)-1]
+	_, _ = fmt.Fprintf(&key, "%s=%s\n", last.Key, last.Name)
+	writeDetails(&key, diff.Details)
+
+	return key.String(), true
+}
+
+// repetitions returns the list of other paths with the same change as the
+// difference at the given index
+func (report *HumanReport) repetitions(repeated *repeatedChanges, idx int, showPathRoot bool) string {
+	paths := repeated.paths[idx]
+	if len(paths) == 0 {
+		return ""
+	}
+
+	var output strings.Builder
+	_, _ = output.WriteString(strings.Repeat(" ", report.Indent))
+	_, _ = output.WriteString(report.theme().dimgray("same change at %s:", text.Plural(len(paths), "more path")))
+	_, _ = output.WriteString("\n")
+
+	for _, path := range paths {
+		_, _ = output.WriteString(strings.Repeat(" ", 2*report.Indent))
+		_, _ = output.WriteString(pathToString(path, report.UseGoPatchPaths, showPathRoot))
+		_, _ = output.WriteString("\n")
+	}
+
+	return output.String()
+}
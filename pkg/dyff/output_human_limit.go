@@ -0,0 +1,92 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"github.com/gonvenience/ytbx"
+)
+
+// diffLimit keeps track of the number of differences per document, so that
+// only the first differences of each document are shown
+type diffLimit struct {
+	max     int
+	shown   map[documentKey]int
+	omitted map[documentKey]*diffOverflow
+	order   []documentKey
+}
+
+// documentKey identifies a document, since reports with multiple input files
+// (i.e. directories or merged reports) have the same indexes more than once
+type documentKey struct {
+	location string
+	idx      int
+}
+
+// diffOverflow is the number of differences of a document that are not shown
+type diffOverflow struct {
+	path  *ytbx.Path
+	count int
+}
+
+func newDiffLimit(max int) *diffLimit {
+	return &diffLimit{
+		max:     max,
+		shown:   map[documentKey]int{},
+		omitted: map[documentKey]*diffOverflow{},
+	}
+}
+
+// allow returns whether the difference is to be shown, which is always the
+// case without a limit and for differences without a path
+func (limit *diffLimit) allow(diff Diff) bool {
+	if limit.max <= 0 || diff.Path == nil {
+		return true
+	}
+
+	key := documentKey{idx: diff.Path.DocumentIdx}
+	if diff.Path.Root != nil {
+		key.location = diff.Path.Root.Location
+	}
+
+	if limit.shown[key] < limit.max {
+		limit.shown[key]++
+		return true
+	}
+
+	if _, ok := limit.omitted[key]; !ok {
+		limit.omitted[key] = &diffOverflow{path: diff.Path}
+		limit.order = append(limit.order, key)
+	}
+
+	limit.omitted[key].count++
+	return false
+}
+
+// overflows returns the documents with differences that are not shown, in
+// the order in which they appear in the report
+func (limit *diffLimit) overflows() []diffOverflow {
+	var result []diffOverflow
+	for _, key := range limit.order {
+		result = append(result, *limit.omitted[key])
+	}
+
+	return result
+}
@@ -0,0 +1,128 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/text"
+	"github.com/gonvenience/ytbx"
+)
+
+// subtreeCount is the number of differences contained in a subtree
+type subtreeCount struct {
+	path  ytbx.Path
+	count int
+	first int
+}
+
+// largestSubtrees returns the given number of subtrees with the most
+// differences. A subtree is left out in favor of one of its child subtrees,
+// if all of its differences are contained in that child subtree. Subtrees with
+// the same number of differences are in the order of the report.
+func largestSubtrees(diffs []Diff, n int) []subtreeCount {
+	var counts = map[string]*subtreeCount{}
+	for i, diff := range diffs {
+		if diff.Path == nil {
+			continue
+		}
+
+		for depth := 1; depth <= len(diff.Path.PathElements); depth++ {
+			path := ytbx.Path{
+				Root:         diff.Path.Root,
+				DocumentIdx:  diff.Path.DocumentIdx,
+				PathElements: diff.Path.PathElements[:depth],
+			}
+
+			key := subtreeKey(path)
+			if _, ok := counts[key]; !ok {
+				counts[key] = &subtreeCount{path: path, first: i}
+			}
+
+			counts[key].count++
+		}
+	}
+
+	var redundant = map[string]struct{}{}
+	for _, subtree := range counts {
+		if len(subtree.path.PathElements) < 2 {
+			continue
+		}
+
+		parent := subtree.path
+		parent.PathElements = parent.PathElements[:len(parent.PathElements)-1]
+		if counts[subtreeKey(parent)].count == subtree.count {
+			redundant[subtreeKey(parent)] = struct{}{}
+		}
+	}
+
+	var result []subtreeCount
+	for key, subtree := range counts {
+		if _, ok := redundant[key]; !ok {
+			result = append(result, *subtree)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].count != result[j].count {
+			return result[i].count > result[j].count
+		}
+
+		if result[i].first != result[j].first {
+			return result[i].first < result[j].first
+		}
+
+		return len(result[i].path.PathElements) < len(result[j].path.PathElements)
+	})
+
+	if len(result) > n {
+		result = result[:n]
+	}
+
+	return result
+}
+
+func subtreeKey(path ytbx.Path) string {
+	return fmt.Sprintf("%d:%s", path.DocumentIdx, path.ToGoPatchStyle())
+}
+
+// largestSubtreesSection returns the section of the report that lists the
+// subtrees with the most differences
+func (report *HumanReport) largestSubtreesSection(showPathRoot bool) string {
+	subtrees := largestSubtrees(report.Diffs, report.LargestSubtrees)
+	if len(subtrees) == 0 {
+		return ""
+	}
+
+	var paths, counts []string
+	for _, subtree := range subtrees {
+		paths = append(paths, pathToString(&subtree.path, report.UseGoPatchPaths, showPathRoot))
+		counts = append(counts, report.theme().dimgray("(%s)", text.Plural(subtree.count, "difference")))
+	}
+
+	return fmt.Sprintf("\n%s\n%s\n",
+		bunt.Style("▶ largest changed subtrees", bunt.Bold()),
+		CreateTableStyleString("", 2, strings.Join(paths, "\n"), strings.Join(counts, "\n")),
+	)
+}
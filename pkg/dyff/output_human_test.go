@@ -21,7 +21,11 @@
 package dyff_test
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -73,6 +77,232 @@ some.yaml.structure.test
     - 12
     + 12
 
+`))
+		})
+
+		It("should show the types of changed values if configured", func() {
+			report, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Documents: multiDoc("---\nport: \"8080\"\nenabled: true\n")},
+				ytbx.InputFile{Documents: multiDoc("---\nport: 8080\nenabled: false\n")},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, ShowValueTypes: true}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("\nport\n  ± type change from string to int\n    - !!str 8080\n    + !!int 8080\n"))
+			Expect(buf.String()).To(ContainSubstring("\nenabled\n  ± value change\n    - !!bool true\n    + !!bool false\n"))
+		})
+
+		It("should show the number of leaf differences of subtrees at the maximum depth", func() {
+			report, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Documents: multiDoc("---\nspec:\n  image: x:1\n  ports: [80, 443]\n  env: {A: \"1\"}\n")},
+				ytbx.InputFile{Documents: multiDoc("---\nspec:\n  image: x:2\n  ports: [80]\n  env: {A: \"1\", B: \"2\"}\n")},
+				dyff.MaxCompareDepth(1),
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(Equal("\nspec\n  ± subtree changed (three leaf differences)\n\n"))
+		})
+
+		It("should report values that are the same after coercion as a representation change if configured", func() {
+			report, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Documents: multiDoc("---\nreplicas: \"3\"\nenabled: \"true\"\nratio: \"1.0\"\nport: \"8080\"\n")},
+				ytbx.InputFile{Documents: multiDoc("---\nreplicas: 3\nenabled: true\nratio: 1\nport: 8081\n")},
+				dyff.CoerceTypes(true),
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("\nreplicas\n  ≃ representation change from string to int\n"))
+			Expect(buf.String()).To(ContainSubstring("\nenabled\n  ≃ representation change from string to bool\n"))
+			Expect(buf.String()).To(ContainSubstring("\nratio\n  ≃ representation change from string to int\n"))
+			Expect(buf.String()).To(ContainSubstring("\nport\n  ± type change from string to int\n"))
+		})
+
+		It("should only show the configured number of differences per document", func() {
+			report, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Documents: multiDoc("---\na: 1\nb: 1\nc: 1\nd: 1\n---\nx: 1\n")},
+				ytbx.InputFile{Documents: multiDoc("---\na: 2\nb: 2\nc: 2\nd: 2\n---\nx: 2\n")},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, MaxDiffs: 2, MaxDiffsHint: "use a higher limit"}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("\nb  (document #1)\n"))
+			Expect(buf.String()).ToNot(ContainSubstring("\nc  (document #1)\n"))
+			Expect(buf.String()).To(ContainSubstring("\nx  (document #2)\n"))
+			Expect(buf.String()).To(HaveSuffix("\n… and two more differences in document #1 (use a higher limit)\n\n"))
+		})
+
+		It("should limit the differences of documents with the same index in different files separately", func() {
+			one, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Location: "one.yml", Documents: multiDoc("---\na: 1\nb: 1\nc: 1\n")},
+				ytbx.InputFile{Location: "one.yml", Documents: multiDoc("---\na: 2\nb: 2\nc: 2\n")},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			two, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Location: "two.yml", Documents: multiDoc("---\nx: 1\ny: 1\nz: 1\n")},
+				ytbx.InputFile{Location: "two.yml", Documents: multiDoc("---\nx: 2\ny: 2\nz: 2\n")},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			report := dyff.Report{From: one.From, To: one.To, Diffs: append(one.Diffs, two.Diffs...)}
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, MaxDiffs: 2}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("\nx\n"))
+			Expect(buf.String()).To(ContainSubstring("\ny\n"))
+			Expect(buf.String()).To(HaveSuffix("\n… and one more difference\n\n… and one more difference\n\n"))
+		})
+
+		It("should show invisible and confusable characters in escaped notation", func() {
+			report, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Documents: multiDoc("---\nzero: \"foo\\u200bbar\"\nnbsp: \"1\\u00a0000\"\nhomoglyph: \"p\\u0430ypal\"\nnewline: \"x\\r\\ny\"\n")},
+				ytbx.InputFile{Documents: multiDoc("---\nzero: foobar\nnbsp: 1 000\nhomoglyph: paypal\nnewline: \"x\\ny\"\n")},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, NoTableStyle: true}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("\nzero\n  ± invisible character change ⚠\n    - foo\\u200bbar\n"))
+			Expect(buf.String()).To(ContainSubstring("\nnbsp\n  ± invisible character change ⚠\n    - 1\\u00a0000\n"))
+			Expect(buf.String()).To(ContainSubstring("\nhomoglyph\n  ± invisible character change ⚠\n    - p\\u0430ypal\n"))
+			Expect(buf.String()).To(ContainSubstring("\nnewline\n  ± invisible character change ⚠\n    - x\\r\\n\n      y\n"))
+			Expect(buf.String()).To(ContainSubstring("    + x\\n\n      y\n"))
+		})
+
+		It("should show the same change at many paths only once if configured", func() {
+			report, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Documents: multiDoc("---\na: {labels: {app: x}}\nb: {labels: {app: x}}\nc: {labels: {app: z}}\n")},
+				ytbx.InputFile{Documents: multiDoc("---\na: {labels: {app: y}}\nb: {labels: {app: y}}\nc: {labels: {app: y}}\n")},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, CollapseRepeated: true}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(Equal(`
+a.labels.app
+  ± value change
+    - x
+    + y
+  same change at one more path:
+    b.labels.app
+
+c.labels.app
+  ± value change
+    - z
+    + y
+
+`))
+		})
+
+		It("should list the subtrees with the most differences if configured", func() {
+			report, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Documents: multiDoc("---\nspec:\n  template:\n    env: {A: \"1\", B: \"1\"}\nmetadata:\n  labels: {a: \"1\"}\n  name: foo\n")},
+				ytbx.InputFile{Documents: multiDoc("---\nspec:\n  template:\n    env: {A: \"2\", B: \"2\"}\nmetadata:\n  labels: {a: \"2\"}\n  name: bar\n")},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, LargestSubtrees: 2}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(HavePrefix(`
+▶ largest changed subtrees
+  spec.template.env  (two differences)
+  metadata           (two differences)
+
+`))
+		})
+
+		It("should only show the changed parts of list entries that were removed and added if configured", func() {
+			report, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Documents: multiDoc("---\nsteps:\n- run: make lint\n- run: make test\n  env:\n    GOFLAGS: -mod=vendor\n    GOOS: linux\n  timeout: 10m\n")},
+				ytbx.InputFile{Documents: multiDoc("---\nsteps:\n- run: make lint\n- run: make test\n  env:\n    GOFLAGS: -mod=mod\n    GOOS: linux\n  timeout: 10m\n  shell: bash\n")},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, NoTableStyle: true, ElideUnchanged: true}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`
+  - one list entry removed:
+    - run: "make test"
+      env:
+        GOFLAGS: "-mod=vendor"
+        …
+      …
+`))
+			Expect(buf.String()).To(ContainSubstring(`
+  + one list entry added:
+    - run: "make test"
+      env:
+        GOFLAGS: "-mod=mod"
+        …
+      shell: bash
+      …
+`))
+		})
+
+		It("should only show the changed lines of a large multiline text with some context", func() {
+			var fromLines, toLines []string
+			for i := 1; i <= 20; i++ {
+				fromLines = append(fromLines, fmt.Sprintf("echo line %d", i))
+				toLines = append(toLines, fmt.Sprintf("echo line %d", i))
+			}
+			toLines[9] = "echo changed line"
+
+			reporter := dyff.HumanReport{
+				Report: dyff.Report{Diffs: []dyff.Diff{singleDiff("/script", dyff.MODIFICATION,
+					strings.Join(fromLines, "\n")+"\n",
+					strings.Join(toLines, "\n")+"\n",
+				)}},
+				Indent:                2,
+				MultilineContextLines: 2,
+				OmitHeader:            true,
+			}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(BeEquivalentTo(`
+script
+  ± value change in multiline text (one insert, one deletion)
+      echo line 1
+      echo line 2
+
+      [five lines unchanged)]
+
+      echo line 8
+      echo line 9
+    - echo line 10
+    + echo changed line
+      echo line 11
+      echo line 12
+
+      [six lines unchanged)]
+
+      echo line 19
+      echo line 20
+
+
 `))
 		})
 
@@ -152,6 +382,354 @@ input: |+
 		})
 	})
 
+	Context("grouping differences", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should show the differences of a group together below a heading", func() {
+			reporter := dyff.HumanReport{
+				Report: dyff.Report{Diffs: []dyff.Diff{
+					singleDiff("/a/x", dyff.MODIFICATION, "1", "2"),
+					singleDiff("/top", dyff.MODIFICATION, "1", "2"),
+					singleDiff("/b/y", dyff.MODIFICATION, "1", "2"),
+					singleDiff("/a/z", dyff.MODIFICATION, "1", "2"),
+				}},
+				Indent:     2,
+				OmitHeader: true,
+				GroupBy: func(diff dyff.Diff) string {
+					if len(diff.Path.PathElements) > 1 {
+						return diff.Path.PathElements[0].Name
+					}
+
+					return ""
+				},
+			}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchRegexp(`(?s)^\ntop\n.*\n▶ a  \(two differences\)\n\na\.x\n.*\na\.z\n.*\n▶ b  \(one difference\)\n\nb\.y\n`))
+		})
+
+		It("should group the differences by their path up to the given depth", func() {
+			reporter := dyff.HumanReport{
+				Report: dyff.Report{Diffs: []dyff.Diff{
+					singleDiff("/spec/template/image", dyff.MODIFICATION, "app:1", "app:2"),
+					singleDiff("/spec/replicas", dyff.MODIFICATION, 1, 2),
+					singleDiff("/spec/template/port", dyff.MODIFICATION, 80, 8080),
+					singleDiff("/metadata/name", dyff.MODIFICATION, "a", "b"),
+				}},
+				Indent:     2,
+				OmitHeader: true,
+				GroupBy:    dyff.GroupByPath(2, false),
+			}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchRegexp(`(?s)^\n▶ spec\.template  \(two differences\)\n\nspec\.template\.image\n.*\nspec\.template\.port\n.*\n▶ spec\.replicas  \(one difference\)\n.*\n▶ metadata\.name  \(one difference\)\n`))
+
+			reporter.GroupBy = dyff.GroupByPath(1, true)
+			buf.Reset()
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchRegexp(`(?s)^\n▶ /spec  \(three differences\)\n.*\n▶ /metadata  \(one difference\)\n`))
+		})
+
+		It("should group the differences by the path they start with", func() {
+			reporter := dyff.HumanReport{
+				Report: dyff.Report{Diffs: []dyff.Diff{
+					singleDiff("/spec/template/image", dyff.MODIFICATION, "app:1", "app:2"),
+					singleDiff("/metadata/name", dyff.MODIFICATION, "a", "b"),
+					singleDiff("/spec/replicas", dyff.MODIFICATION, 1, 2),
+				}},
+				Indent:     2,
+				OmitHeader: true,
+				GroupBy:    dyff.GroupByPathPrefix([]ytbx.Path{*path("/spec/template"), *path("/spec")}, false),
+			}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(MatchRegexp(`(?s)^\nmetadata\.name\n.*\n▶ spec\.template  \(one difference\)\n\nspec\.template\.image\n.*\n▶ spec  \(one difference\)\n\nspec\.replicas\n`))
+		})
+	})
+
+	Context("line numbers", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should show the line numbers of the difference in both input files", func() {
+			from := ytbx.InputFile{Location: "from.yml", Documents: multiDoc("---\nname: app\nconfig:\n  a: 1\n  b: 2\n")}
+			to := ytbx.InputFile{Location: "to.yml", Documents: multiDoc("---\nname: app\n\nconfig:\n  a: 1\n  b: 3\n  c: 4\n")}
+
+			report, err := dyff.CompareInputFiles(from, to)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, ShowLineNumbers: true}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("\nconfig  (from: from.yml:4, to: to.yml:7)\n"))
+			Expect(buf.String()).To(ContainSubstring("\nconfig.b  (from: from.yml:5, to: to.yml:6)\n"))
+		})
+	})
+
+	Context("source descriptions", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should use the descriptions instead of the locations of the input files", func() {
+			from := ytbx.InputFile{Location: "/tmp/live-123.yml", Documents: multiDoc("---\nname: app\nversion: 1\n")}
+			to := ytbx.InputFile{Location: "/tmp/desired-456.yml", Documents: multiDoc("---\nname: app\nversion: 2\n")}
+
+			report, err := dyff.CompareInputFiles(from, to)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{
+				Report:          report,
+				Indent:          2,
+				ShowLineNumbers: true,
+				FromDescription: "live state",
+				ToDescription:   "desired state",
+			}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("between live state\n"))
+			Expect(buf.String()).To(ContainSubstring("and desired state\n"))
+			Expect(buf.String()).To(ContainSubstring("\nversion  (from: live state:3, to: desired state:3)\n"))
+			Expect(buf.String()).ToNot(ContainSubstring("/tmp/"))
+		})
+
+		It("should use the descriptions in the brief report", func() {
+			reporter := dyff.BriefReport{
+				Report: dyff.Report{
+					From:  ytbx.InputFile{Location: "/tmp/live-123.yml"},
+					To:    ytbx.InputFile{Location: "/tmp/desired-456.yml"},
+					Diffs: []dyff.Diff{singleDiff("/version", dyff.MODIFICATION, 1, 2)},
+				},
+				FromDescription: "live state",
+				ToDescription:   "desired state",
+			}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(Equal("one change detected between live state and desired state\n\n"))
+		})
+	})
+
+	Context("index mapping", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should show the old and new index of list entries that moved and changed", func() {
+			from := ytbx.InputFile{Location: "from.yml", Documents: multiDoc(`---
+containers:
+- name: app
+  image: app:1
+- name: proxy
+  image: proxy:1
+- name: sidecar
+  image: sidecar:1
+`)}
+
+			to := ytbx.InputFile{Location: "to.yml", Documents: multiDoc(`---
+containers:
+- name: sidecar
+  image: sidecar:2
+- name: app
+  image: app:2
+- name: proxy
+  image: proxy:1
+`)}
+
+			report, err := dyff.CompareInputFiles(from, to, dyff.IgnoreOrderChanges(true))
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("\ncontainers.app.image  (containers[0→1] name=app)\n"))
+			Expect(buf.String()).To(ContainSubstring("\ncontainers.sidecar.image  (containers[2→0] name=sidecar)\n"))
+		})
+
+		It("should find the index of moved entries in documents that moved as well", func() {
+			from := ytbx.InputFile{Location: "from.yml", Documents: multiDoc(`---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: one
+spec:
+  containers:
+  - name: app
+    image: app:1
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: two
+spec:
+  containers:
+  - name: app
+    image: app:1
+  - name: sidecar
+    image: sidecar:1
+`)}
+
+			to := ytbx.InputFile{Location: "to.yml", Documents: multiDoc(`---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: two
+spec:
+  containers:
+  - name: sidecar
+    image: sidecar:2
+  - name: app
+    image: app:1
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: one
+spec:
+  containers:
+  - name: app
+    image: app:1
+`)}
+
+			report, err := dyff.CompareInputFiles(from, to, dyff.IgnoreOrderChanges(true))
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("spec.containers.sidecar.image  (v1/Pod/two)  (containers[1→0] name=sidecar)\n"))
+		})
+	})
+
+	Context("wrapping lines", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should wrap long lines at the configured columns keeping the indent", func() {
+			reporter := dyff.HumanReport{
+				Report: dyff.Report{Diffs: []dyff.Diff{singleDiff("/description", dyff.MODIFICATION,
+					"a short description",
+					"a much longer description that does not fit into the configured columns",
+				)}},
+				Indent:     2,
+				OmitHeader: true,
+				Columns:    30,
+				WrapLines:  true,
+			}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(BeEquivalentTo("\ndescription\n" +
+				"  ± value change\n" +
+				"    - a short description\n" +
+				"    + a much longer\n" +
+				"      description that does\n" +
+				"      not fit into the\n" +
+				"      configured columns\n" +
+				"  \n\n"))
+		})
+
+		It("should not wrap lines unless configured", func() {
+			reporter := dyff.HumanReport{
+				Report: dyff.Report{Diffs: []dyff.Diff{singleDiff("/description", dyff.MODIFICATION,
+					"a short description",
+					"a much longer description that does not fit into the configured columns",
+				)}},
+				Indent:     2,
+				OmitHeader: true,
+				Columns:    30,
+			}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("\n    + a much longer description that does not fit into the configured columns\n"))
+		})
+
+		It("should use the configured columns to decide whether blocks fit next to each other", func() {
+			report, err := dyff.CompareInputFiles(
+				ytbx.InputFile{Documents: multiDoc("---\nlist: [one, two]\n")},
+				ytbx.InputFile{Documents: multiDoc("---\nlist: [three, four]\n")},
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			narrow := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, Columns: 20}
+			wide := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, Columns: 200}
+
+			var narrowBuf, wideBuf bytes.Buffer
+			Expect(narrow.WriteReport(&narrowBuf)).To(Succeed())
+			Expect(wide.WriteReport(&wideBuf)).To(Succeed())
+			Expect(strings.Count(narrowBuf.String(), "\n")).To(BeNumerically(">", strings.Count(wideBuf.String(), "\n")))
+		})
+	})
+
+	Context("source context", func() {
+		BeforeEach(func() {
+			SetColorSettings(OFF, OFF)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should show the lines of the original input file around a difference", func() {
+			dir := GinkgoT().TempDir()
+			fromLocation, toLocation := filepath.Join(dir, "from.yml"), filepath.Join(dir, "to.yml")
+			Expect(os.WriteFile(fromLocation, []byte("---\nname: app\nconfig:\n  a: 1\n  b: 2\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(toLocation, []byte("---\nname: app\nconfig:\n  a: 1\n  # comment\n  b: 3\n"), 0644)).To(Succeed())
+
+			from, err := ytbx.LoadFile(fromLocation)
+			Expect(err).ToNot(HaveOccurred())
+
+			to, err := ytbx.LoadFile(toLocation)
+			Expect(err).ToNot(HaveOccurred())
+
+			report, err := dyff.CompareInputFiles(from, to)
+			Expect(err).ToNot(HaveOccurred())
+
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, SourceContextLines: 1}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(HaveSuffix(fmt.Sprintf(`
+  %s
+    5 │   # comment
+  > 6 │   b: 3
+
+`, toLocation)))
+		})
+	})
+
 	Context("nicely colored human readable differences", func() {
 		BeforeEach(func() {
 			SetColorSettings(ON, ON)
@@ -199,6 +777,23 @@ variables.ROUTER_TLS_PEM.options
 
 `)))
 		})
+
+		It("should render paths as hyperlinks to remote locations when enabled", func() {
+			reporter := dyff.HumanReport{
+				Report: dyff.Report{
+					From:  ytbx.InputFile{Location: "https://example.org/from.yml"},
+					To:    ytbx.InputFile{Location: "https://example.org/to.yml"},
+					Diffs: []dyff.Diff{singleDiff("/some/path", dyff.MODIFICATION, "foo", "bar")},
+				},
+				Indent:            2,
+				OmitHeader:        true,
+				HyperlinkTemplate: dyff.DefaultHyperlinkTemplate,
+			}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(HavePrefix("\n\x1b]8;;https://example.org/to.yml\x1b\\"))
+		})
 	})
 
 	Context("human friendly multiline text differences", func() {
@@ -265,4 +860,24 @@ variables.ROUTER_TLS_PEM.options
 			)
 		})
 	})
+
+	Context("themes", func() {
+		BeforeEach(func() {
+			SetColorSettings(ON, ON)
+		})
+
+		AfterEach(func() {
+			SetColorSettings(AUTO, AUTO)
+		})
+
+		It("should use the theme of the report instead of the dark theme", func() {
+			report := dyff.Report{Diffs: []dyff.Diff{singleDiff("/some/value", dyff.MODIFICATION, "foo", "bar")}}
+			reporter := dyff.HumanReport{Report: report, Indent: 2, OmitHeader: true, Theme: &dyff.LightTheme}
+
+			var buf bytes.Buffer
+			Expect(reporter.WriteReport(&buf)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring("\x1b[38;2;154;103;0m"))
+			Expect(buf.String()).ToNot(ContainSubstring("\x1b[38;2;199;196;63m"))
+		})
+	})
 })
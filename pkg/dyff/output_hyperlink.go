@@ -0,0 +1,199 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// DefaultHyperlinkTemplate is the template used for hyperlinks to local
+// files, where the line number is passed as the URL fragment
+const DefaultHyperlinkTemplate = "file://{host}{file}#{line}"
+
+var (
+	hyperlinkSequence = regexp.MustCompile("\x1b]8;[^;\x1b]*;[^\x1b]*\x1b\\\\")
+	unknownLineNumber = regexp.MustCompile(`(#L|#|:)?\{line\}`)
+)
+
+// hyperlink wraps the text into an OSC 8 hyperlink escape sequence, which
+// terminals with hyperlink support render as a clickable link
+func hyperlink(target string, text string) string {
+	if target == "" {
+		return text
+	}
+
+	return "\x1b]8;;" + target + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// expandHyperlinkTemplate creates the link target for the given input file
+// location and line number. The template supports the placeholders {host},
+// {file} (absolute path), {location} (path as provided), and {line}. Only
+// local files and URLs can be linked, all other locations return an empty
+// link target.
+func expandHyperlinkTemplate(template string, location string, line int) string {
+	if location == "" || ytbx.IsStdin(location) {
+		return ""
+	}
+
+	if _, err := os.Stat(location); err != nil {
+		if uri, err := url.ParseRequestURI(location); err == nil && uri.Scheme != "" {
+			return location
+		}
+
+		return ""
+	}
+
+	if template == "" {
+		template = DefaultHyperlinkTemplate
+	}
+
+	abs, err := filepath.Abs(location)
+	if err != nil {
+		abs = location
+	}
+
+	host, _ := os.Hostname()
+
+	if line <= 0 {
+		template = unknownLineNumber.ReplaceAllString(template, "")
+	}
+
+	return strings.NewReplacer(
+		"{host}", host,
+		"{file}", filepath.ToSlash(abs),
+		"{location}", location,
+		"{line}", strconv.Itoa(line),
+	).Replace(template)
+}
+
+// lineOf returns the line number of the given node, or of its first child
+// node in case the node was created during the comparison
+func lineOf(node *yamlv3.Node) int {
+	line, _ := positionOf(node)
+	return line
+}
+
+// positionOf returns the line and column of the given node, or of its first
+// child node in case the node was created during the comparison
+func positionOf(node *yamlv3.Node) (int, int) {
+	switch {
+	case node == nil:
+		return 0, 0
+
+	case node.Line > 0:
+		return node.Line, node.Column
+
+	case len(node.Content) > 0:
+		return positionOf(node.Content[0])
+	}
+
+	return 0, 0
+}
+
+// locationOfDiff returns the input file location and line number that are
+// best suited to show where the difference is, preferring the to side
+func (r Report) locationOfDiff(diff Diff) (string, int) {
+	for _, detail := range diff.Details {
+		if line := lineOf(detail.To); line > 0 && detail.Kind != ORDERCHANGE {
+			return r.To.Location, line
+		}
+	}
+
+	for _, detail := range diff.Details {
+		if line := lineOf(detail.From); line > 0 && detail.Kind != ORDERCHANGE {
+			return r.From.Location, line
+		}
+	}
+
+	return r.To.Location, 0
+}
+
+// linesOfDiff returns the line numbers of the difference in the from and to
+// input file, or zero for a side where the line number is unknown. For added
+// or removed entries, this is the line of the parent on the other side.
+func (r Report) linesOfDiff(diff Diff) (int, int) {
+	var fromLine, toLine int
+	for _, detail := range diff.Details {
+		if detail.Kind == ORDERCHANGE {
+			continue
+		}
+
+		if line := lineOf(detail.From); fromLine == 0 && line > 0 {
+			fromLine = line
+		}
+
+		if line := lineOf(detail.To); toLine == 0 && line > 0 {
+			toLine = line
+		}
+	}
+
+	if fromLine == 0 {
+		fromLine = lineOfPath(r.From, diff.Path)
+	}
+
+	if toLine == 0 {
+		toLine = lineOfPath(r.To, diff.Path)
+	}
+
+	return fromLine, toLine
+}
+
+// lineOfPath returns the line number of the node at the path in the input
+// file, if it is clear which document of the input file the path refers to
+func lineOfPath(inputFile ytbx.InputFile, path *ytbx.Path) int {
+	return lineOf(nodeOfPath(inputFile, path))
+}
+
+// nodeOfPath returns the node at the path in the input file, or nil if it is
+// not clear which document of the input file the path refers to
+func nodeOfPath(inputFile ytbx.InputFile, path *ytbx.Path) *yamlv3.Node {
+	if path == nil || len(path.PathElements) == 0 {
+		return nil
+	}
+
+	var idx int
+	switch {
+	case path.Root != nil && path.Root.Location == inputFile.Location:
+		idx = path.DocumentIdx
+
+	case len(inputFile.Documents) != 1:
+		return nil
+	}
+
+	if idx < 0 || idx >= len(inputFile.Documents) {
+		return nil
+	}
+
+	node, err := ytbx.Grab(inputFile.Documents[idx], path.String())
+	if err != nil {
+		return nil
+	}
+
+	return node
+}
@@ -0,0 +1,191 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// indexMappings returns a description for each named list entry in the path
+// of the difference that has a different position in the from and to input
+// file, e.g. `containers[2→0] name=sidecar`
+func (r Report) indexMappings(diff Diff) []string {
+	if diff.Path == nil || len(diff.Path.PathElements) == 0 {
+		return nil
+	}
+
+	var fromNodes, toNodes []*yamlv3.Node
+	for _, detail := range diff.Details {
+		fromNodes = append(fromNodes, detail.From)
+		toNodes = append(toNodes, detail.To)
+	}
+
+	fromIndices := entryIndices(documentOf(r.From, diff.Path, fromNodes...), diff.Path.PathElements)
+	toIndices := entryIndices(documentOf(r.To, diff.Path, toNodes...), diff.Path.PathElements)
+
+	var result []string
+	for i, element := range diff.Path.PathElements {
+		if fromIndices[i] < 0 || toIndices[i] < 0 || fromIndices[i] == toIndices[i] {
+			continue
+		}
+
+		var list string
+		if i > 0 {
+			list = diff.Path.PathElements[i-1].Name
+		}
+
+		result = append(result, fmt.Sprintf("%s[%d→%d] %s=%s",
+			list,
+			fromIndices[i],
+			toIndices[i],
+			element.Key,
+			element.Name,
+		))
+	}
+
+	return result
+}
+
+// documentOf returns the document of the input file the path refers to, which
+// is either clear from the path itself, or the document containing one of the
+// given nodes of the difference
+func documentOf(inputFile ytbx.InputFile, path *ytbx.Path, nodes ...*yamlv3.Node) *yamlv3.Node {
+	switch {
+	case path.Root != nil && path.Root.Location == inputFile.Location:
+		if path.DocumentIdx >= 0 && path.DocumentIdx < len(inputFile.Documents) {
+			return inputFile.Documents[path.DocumentIdx]
+		}
+
+		return nil
+
+	case len(inputFile.Documents) == 1:
+		return inputFile.Documents[0]
+	}
+
+	for _, document := range inputFile.Documents {
+		for _, node := range nodes {
+			if node != nil && containsNode(document, node) {
+				return document
+			}
+		}
+	}
+
+	return nil
+}
+
+// containsNode returns whether the node is part of the tree of the root node
+func containsNode(root *yamlv3.Node, node *yamlv3.Node) bool {
+	if root == node {
+		return true
+	}
+
+	for _, child := range root.Content {
+		if containsNode(child, node) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// entryIndices follows the path elements in the given document and returns
+// the position of each named list entry in its list, or -1 for path elements
+// that are no named list entries or that cannot be found
+func entryIndices(document *yamlv3.Node, elements []ytbx.PathElement) []int {
+	result := make([]int, len(elements))
+	for i := range result {
+		result[i] = -1
+	}
+
+	node := document
+	for i, element := range elements {
+		if node = followAlias(node); node == nil {
+			break
+		}
+
+		if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+			node = followAlias(node.Content[0])
+		}
+
+		switch {
+		case element.Key != "" && element.Name != "":
+			if node.Kind != yamlv3.SequenceNode {
+				return result
+			}
+
+			result[i] = entryIndex(node, element.Key, element.Name)
+			if result[i] < 0 {
+				return result
+			}
+
+			node = node.Content[result[i]]
+
+		case element.Name != "":
+			if node.Kind != yamlv3.MappingNode {
+				return result
+			}
+
+			value, ok := findValueByKey(node, element.Name)
+			if !ok {
+				return result
+			}
+
+			node = value
+
+		default:
+			if node.Kind != yamlv3.SequenceNode || element.Idx < 0 || element.Idx >= len(node.Content) {
+				return result
+			}
+
+			node = node.Content[element.Idx]
+		}
+	}
+
+	return result
+}
+
+// entryIndex returns the position of the list entry with the given identifier
+// key and name, or -1 if there is no such entry
+func entryIndex(sequenceNode *yamlv3.Node, key string, name string) int {
+	for i, entry := range sequenceNode.Content {
+		entry = followAlias(entry)
+		if entry.Kind != yamlv3.MappingNode {
+			continue
+		}
+
+		if key == k8sItem.String() {
+			if entryName, err := k8sItem.Name(entry); err == nil && entryName == name {
+				return i
+			}
+
+			continue
+		}
+
+		if value, ok := findValueByKey(entry, key); ok && value.Value == name {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,117 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// confusables maps characters that look like ASCII letters, e.g. the
+// Cyrillic `а`, to the ASCII letter they are likely confused with
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's',
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O', 'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'І': 'I',
+	// Greek
+	'ο': 'o', 'ν': 'v', 'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Χ': 'X', 'Υ': 'Y',
+}
+
+// isInvisibleCharacterChange returns whether two different strings look the
+// same, because they only differ in invisible characters like zero-width
+// spaces, in the kind of space or line break, or in confusable characters
+func isInvisibleCharacterChange(from string, to string) bool {
+	return from != to && visibleSkeleton(from) == visibleSkeleton(to)
+}
+
+// visibleSkeleton returns the text as it looks like, i.e. without invisible
+// characters, with all spaces and line breaks normalized, and with the ASCII
+// letters instead of confusable characters
+func visibleSkeleton(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var result strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\r':
+			_, _ = result.WriteRune('\n')
+
+		case r == '\n':
+			_, _ = result.WriteRune(r)
+
+		case unicode.IsSpace(r):
+			_, _ = result.WriteRune(' ')
+
+		case isInvisible(r):
+			continue
+
+		default:
+			if ascii, ok := confusables[r]; ok {
+				r = ascii
+			}
+
+			_, _ = result.WriteRune(r)
+		}
+	}
+
+	return result.String()
+}
+
+// isInvisible returns whether the character is not shown, e.g. a zero-width
+// space, a control character, a byte order mark, or a Hangul filler
+func isInvisible(r rune) bool {
+	return unicode.Is(unicode.Cf, r) || unicode.IsControl(r) || r == '\u115f' || r == '\u1160' || r == '\u3164'
+}
+
+// escapeInvisibleCharacters returns the text with all invisible, unusual
+// whitespace, and confusable characters in escaped notation, e.g. `\u200b`
+func escapeInvisibleCharacters(text string) string {
+	var result strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\r':
+			_, _ = result.WriteString(bold(`\r`))
+
+		case r == '\t':
+			_, _ = result.WriteString(bold(`\t`))
+
+		case r == '\n':
+			_, _ = result.WriteString(bold(`\n`) + "\n")
+
+		case r == ' ':
+			_, _ = result.WriteRune(r)
+
+		case unicode.IsSpace(r) || isInvisible(r):
+			_, _ = result.WriteString(bold("%s", fmt.Sprintf(`\u%04x`, r)))
+
+		default:
+			if _, ok := confusables[r]; ok {
+				_, _ = result.WriteString(bold("%s", fmt.Sprintf(`\u%04x`, r)))
+				continue
+			}
+
+			_, _ = result.WriteRune(r)
+		}
+	}
+
+	return result.String()
+}
@@ -0,0 +1,423 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ReportSchemaVersion is the version of the schema of serialized reports.
+// Reports with the same major version are compatible: New fields can be
+// added, but existing fields are never removed, renamed, or change their
+// meaning. Consumers should therefore ignore fields they do not know.
+//
+// Version history:
+//   - 1.1 adds the type-change kind of differences
+//   - 1.2 adds the style-change kind of differences
+//   - 1.3 adds the fingerprint of differences
+//   - 1.4 adds the optional severity of differences
+//   - 1.5 adds the representation-change kind of differences
+//   - 1.6 adds the document flag of details, which marks whole documents
+//   - 1.7 adds the rename kind of differences
+const ReportSchemaVersion = "1.7"
+
+// ReportSchema is the JSON schema of serialized reports
+//
+//go:embed report.schema.json
+var ReportSchema string
+
+// SerializedReport is the representation of a report for other tools
+type SerializedReport struct {
+	SchemaVersion string           `json:"schema_version"`
+	From          SerializedInput  `json:"from"`
+	To            SerializedInput  `json:"to"`
+	Diffs         []SerializedDiff `json:"diffs"`
+}
+
+// SerializedInput describes one of the compared input files
+type SerializedInput struct {
+	Location  string `json:"location"`
+	Note      string `json:"note,omitempty"`
+	Documents int    `json:"documents"`
+}
+
+// SerializedDiff is one difference, the path and document fields are omitted
+// for differences of the input files as a whole, e.g. the order of documents
+type SerializedDiff struct {
+	Path          string             `json:"path,omitempty"`
+	DocumentIndex *int               `json:"document_index,omitempty"`
+	DocumentName  string             `json:"document_name,omitempty"`
+	Fingerprint   string             `json:"fingerprint"`
+	Severity      string             `json:"severity,omitempty"`
+	Details       []SerializedDetail `json:"details"`
+}
+
+// SerializedDetail is one detail of a difference, where `from` is null for
+// additions and `to` is null for removals. Document is set if a whole
+// document was added or removed.
+type SerializedDetail struct {
+	Kind     string `json:"kind"`
+	From     any    `json:"from"`
+	To       any    `json:"to"`
+	Document bool   `json:"document,omitempty"`
+}
+
+// JSONReport is a reporter that writes the report as JSON, which follows
+// the versioned report schema
+type JSONReport struct {
+	Report
+
+	// SeverityRules optionally adds the severity to each difference
+	SeverityRules SeverityRules
+}
+
+var _ ReportWriter = &JSONReport{}
+
+// WriteReport writes the serialized report as JSON to the provided writer
+func (report *JSONReport) WriteReport(out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	serialized := report.Serialize()
+	if report.SeverityRules != nil {
+		for i, diff := range report.Diffs {
+			serialized.Diffs[i].Severity = report.SeverityRules.Severity(diff).String()
+		}
+	}
+
+	return encoder.Encode(serialized)
+}
+
+// Serialize returns the representation of the report for other tools
+func (r Report) Serialize() SerializedReport {
+	var result = SerializedReport{
+		SchemaVersion: ReportSchemaVersion,
+		From:          serializeInput(r.From),
+		To:            serializeInput(r.To),
+		Diffs:         make([]SerializedDiff, 0, len(r.Diffs)),
+	}
+
+	for _, diff := range r.Diffs {
+		result.Diffs = append(result.Diffs, diff.Serialize())
+	}
+
+	return result
+}
+
+// Serialize returns the representation of the difference for other tools
+func (diff Diff) Serialize() SerializedDiff {
+	var serialized = SerializedDiff{
+		Fingerprint: diff.Fingerprint(),
+		Details:     make([]SerializedDetail, 0, len(diff.Details)),
+	}
+
+	if diff.Path != nil {
+		documentIndex := diff.Path.DocumentIdx
+		serialized.Path = diff.Path.String()
+		serialized.DocumentIndex = &documentIndex
+
+		if diff.Path.Root != nil && documentIndex < len(diff.Path.Root.Names) {
+			serialized.DocumentName = diff.Path.Root.Names[documentIndex]
+		}
+	}
+
+	for _, detail := range diff.Details {
+		serialized.Details = append(serialized.Details, detail.Serialize())
+	}
+
+	return serialized
+}
+
+// Serialize returns the representation of the detail for other tools
+func (detail Detail) Serialize() SerializedDetail {
+	return SerializedDetail{
+		Kind:     KindName(detail.Kind),
+		From:     serializeNode(detail.From),
+		To:       serializeNode(detail.To),
+		Document: isDocumentNode(detail.From) || isDocumentNode(detail.To),
+	}
+}
+
+// MarshalJSON writes the report in the same format as the JSON reporter
+func (r Report) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Serialize())
+}
+
+// MarshalJSON writes the difference in the same format as the differences
+// of the JSON reporter
+func (diff Diff) MarshalJSON() ([]byte, error) {
+	return json.Marshal(diff.Serialize())
+}
+
+// MarshalJSON writes the detail in the same format as the details of the
+// JSON reporter
+func (detail Detail) MarshalJSON() ([]byte, error) {
+	return json.Marshal(detail.Serialize())
+}
+
+func serializeInput(inputFile ytbx.InputFile) SerializedInput {
+	return SerializedInput{
+		Location:  inputFile.Location,
+		Note:      inputFile.Note,
+		Documents: len(inputFile.Documents),
+	}
+}
+
+// KindName returns the name of the kind of difference that is used in the
+// JSON report, e.g. `addition`
+func KindName(kind rune) string {
+	switch kind {
+	case ADDITION:
+		return "addition"
+
+	case REMOVAL:
+		return "removal"
+
+	case MODIFICATION:
+		return "modification"
+
+	case ORDERCHANGE:
+		return "order-change"
+
+	case TYPECHANGE:
+		return "type-change"
+
+	case STYLECHANGE:
+		return "style-change"
+
+	case REPRESENTATIONCHANGE:
+		return "representation-change"
+
+	case RENAME:
+		return "rename"
+
+	default:
+		return string(kind)
+	}
+}
+
+// serializeNode returns the value of the node as a type that is supported by
+// JSON, values that cannot be represented are used as strings
+func serializeNode(node *yamlv3.Node) any {
+	if node == nil {
+		return nil
+	}
+
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+
+		return serializeNode(node.Content[0])
+
+	case yamlv3.AliasNode:
+		return serializeNode(node.Alias)
+
+	case yamlv3.MappingNode:
+		result := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			result[followAlias(node.Content[i]).Value] = serializeNode(node.Content[i+1])
+		}
+
+		return result
+
+	case yamlv3.SequenceNode:
+		result := make([]any, 0, len(node.Content))
+		for _, entry := range node.Content {
+			result = append(result, serializeNode(entry))
+		}
+
+		return result
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return nil
+
+	case "!!bool":
+		if value, err := toBool(node.Value); err == nil {
+			return value
+		}
+
+	case "!!int", "!!float":
+		var value any
+		if err := node.Decode(&value); err == nil {
+			if data, err := json.Marshal(value); err == nil {
+				return json.Number(data)
+			}
+		}
+	}
+
+	return node.Value
+}
+
+// ParseJSONReport reads a report that was written by the JSON reporter, for
+// example by another dyff process, so that it can be rendered locally. Values
+// keep the order of their keys, but not their original style or comments. The
+// input files of the report only describe the compared files, which means
+// they have empty documents with the names used by the differences.
+func ParseJSONReport(data []byte) (Report, error) {
+	var serialized struct {
+		SchemaVersion string          `json:"schema_version"`
+		From          SerializedInput `json:"from"`
+		To            SerializedInput `json:"to"`
+		Diffs         []struct {
+			Path          string `json:"path"`
+			DocumentIndex *int   `json:"document_index"`
+			DocumentName  string `json:"document_name"`
+			Details       []struct {
+				Kind     string          `json:"kind"`
+				From     json.RawMessage `json:"from"`
+				To       json.RawMessage `json:"to"`
+				Document bool            `json:"document"`
+			} `json:"details"`
+		} `json:"diffs"`
+	}
+
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return Report{}, fmt.Errorf("failed to parse JSON report: %w", err)
+	}
+
+	if major, _, _ := strings.Cut(serialized.SchemaVersion, "."); major != strings.Split(ReportSchemaVersion, ".")[0] {
+		return Report{}, fmt.Errorf("unsupported report schema version %q, expected version %s", serialized.SchemaVersion, ReportSchemaVersion)
+	}
+
+	var from, to = deserializeInput(serialized.From), deserializeInput(serialized.To)
+	var diffs []Diff
+	for _, entry := range serialized.Diffs {
+		var diff Diff
+		if entry.DocumentIndex != nil {
+			path, err := ytbx.ParseGoPatchStylePathString(entry.Path)
+			if err != nil {
+				return Report{}, fmt.Errorf("failed to parse path %s of JSON report: %w", entry.Path, err)
+			}
+
+			// Added documents only exist in the to input file
+			var root = from
+			if len(entry.Details) == 1 && entry.Details[0].Document && entry.Details[0].Kind == KindName(ADDITION) {
+				root = to
+			}
+
+			path.Root, path.DocumentIdx = root, *entry.DocumentIndex
+			if entry.DocumentName != "" && path.DocumentIdx >= 0 && path.DocumentIdx < len(root.Documents) {
+				if root.Names == nil {
+					root.Names = make([]string, len(root.Documents))
+				}
+
+				root.Names[path.DocumentIdx] = entry.DocumentName
+			}
+
+			diff.Path = &path
+		}
+
+		for _, detail := range entry.Details {
+			kind, err := ParseKindName(detail.Kind)
+			if err != nil {
+				return Report{}, err
+			}
+
+			fromNode, err := deserializeNode(detail.From, kind != ADDITION)
+			if err != nil {
+				return Report{}, err
+			}
+
+			toNode, err := deserializeNode(detail.To, kind != REMOVAL)
+			if err != nil {
+				return Report{}, err
+			}
+
+			if detail.Document {
+				fromNode, toNode = asDocumentNode(fromNode), asDocumentNode(toNode)
+			}
+
+			diff.Details = append(diff.Details, Detail{Kind: kind, From: fromNode, To: toNode})
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	// The names are only known once all differences are read
+	return Report{From: *from, To: *to, Diffs: diffs}, nil
+}
+
+func deserializeInput(input SerializedInput) *ytbx.InputFile {
+	var documents = make([]*yamlv3.Node, input.Documents)
+	for i := range documents {
+		documents[i] = &yamlv3.Node{Kind: yamlv3.DocumentNode}
+	}
+
+	return &ytbx.InputFile{
+		Location:  input.Location,
+		Note:      input.Note,
+		Documents: documents,
+	}
+}
+
+func isDocumentNode(node *yamlv3.Node) bool {
+	return node != nil && node.Kind == yamlv3.DocumentNode
+}
+
+func asDocumentNode(node *yamlv3.Node) *yamlv3.Node {
+	if node == nil {
+		return nil
+	}
+
+	return &yamlv3.Node{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{node}}
+}
+
+// deserializeNode returns the node of a serialized value, where null is only
+// a value if the kind of difference has a value on this side
+func deserializeNode(data json.RawMessage, hasValue bool) (*yamlv3.Node, error) {
+	if len(data) == 0 || (!hasValue && string(data) == "null") {
+		return nil, nil
+	}
+
+	// JSON is YAML, which keeps the order of the keys in the node
+	var document yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse value of JSON report: %w", err)
+	}
+
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	var node = document.Content[0]
+	resetStyle(node)
+	return node, nil
+}
+
+// resetStyle removes the JSON style and positions of the parsed nodes, so
+// that values are rendered like any other YAML value
+func resetStyle(node *yamlv3.Node) {
+	node.Style, node.Line, node.Column = 0, 0, 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gonvenience/ytbx"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("JSON report", func() {
+	var writeJSON = func(from string, to string, compareOptions ...dyff.CompareOption) string {
+		report, err := dyff.CompareInputFiles(
+			ytbx.InputFile{Location: "from.yml", Documents: multiDoc(from)},
+			ytbx.InputFile{Location: "to.yml", Documents: multiDoc(to)},
+			compareOptions...,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		Expect((&dyff.JSONReport{Report: report}).WriteReport(&buf)).To(Succeed())
+		return buf.String()
+	}
+
+	It("should write the report in the versioned format", func() {
+		out := writeJSON(`---
+name: foo
+list: [a, b]
+version: 1
+`, `---
+name: bar
+list: [a, c]
+version: 1.5
+`)
+
+		var result dyff.SerializedReport
+		Expect(json.Unmarshal([]byte(out), &result)).To(Succeed())
+		Expect(result.SchemaVersion).To(Equal(dyff.ReportSchemaVersion))
+		Expect(result.From).To(Equal(dyff.SerializedInput{Location: "from.yml", Documents: 1}))
+		Expect(result.Diffs).To(HaveLen(3))
+
+		Expect(result.Diffs[0].Path).To(Equal("/name"))
+		Expect(*result.Diffs[0].DocumentIndex).To(Equal(0))
+		Expect(result.Diffs[0].Details).To(Equal([]dyff.SerializedDetail{{Kind: "modification", From: "foo", To: "bar"}}))
+
+		Expect(result.Diffs[1].Path).To(Equal("/list"))
+		Expect(result.Diffs[1].Details).To(ConsistOf(
+			dyff.SerializedDetail{Kind: "removal", From: []any{"b"}},
+			dyff.SerializedDetail{Kind: "addition", To: []any{"c"}},
+		))
+
+		Expect(result.Diffs[2].Details).To(Equal([]dyff.SerializedDetail{{Kind: "modification", From: 1.0, To: 1.5}}))
+	})
+
+	It("should write type changes as their own kind of difference", func() {
+		out := writeJSON(`{"port": "8080"}`, `{"port": 8080}`, dyff.DetectTypeChanges(true))
+
+		var result dyff.SerializedReport
+		Expect(json.Unmarshal([]byte(out), &result)).To(Succeed())
+		Expect(result.Diffs).To(HaveLen(1))
+		Expect(result.Diffs[0].Details).To(Equal([]dyff.SerializedDetail{{Kind: "type-change", From: "8080", To: 8080.0}}))
+	})
+
+	It("should write stable fingerprints of the differences", func() {
+		var fingerprints = func(from string, to string) []string {
+			var result dyff.SerializedReport
+			Expect(json.Unmarshal([]byte(writeJSON(from, to)), &result)).To(Succeed())
+
+			var fingerprints []string
+			for _, diff := range result.Diffs {
+				Expect(diff.Fingerprint).To(MatchRegexp("^[0-9a-f]{16}$"))
+				fingerprints = append(fingerprints, diff.Fingerprint)
+			}
+
+			return fingerprints
+		}
+
+		original := fingerprints("---\nname: foo\nspec: {a: 1, b: 2}\n", "---\nname: bar\nspec: {a: 1, b: 3}\n")
+		Expect(original).To(HaveLen(2))
+		Expect(original[0]).ToNot(Equal(original[1]))
+
+		// same differences in another order and with another formatting
+		Expect(fingerprints("---\nspec:\n  b: 2\n  a: 1\nname: 'foo'\n", "---\nspec:\n  b: 3\n  a: 1\nname: \"bar\"\n")).To(ConsistOf(original))
+
+		// other values result in other fingerprints
+		Expect(fingerprints("---\nname: foo\n", "---\nname: baz\n")).ToNot(ContainElement(original[0]))
+	})
+
+	It("should write the severity of the differences if configured", func() {
+		report, err := dyff.CompareInputFiles(
+			ytbx.InputFile{Location: "from.yml", Documents: multiDoc(`{"replicas": 1, "name": "foo"}`)},
+			ytbx.InputFile{Location: "to.yml", Documents: multiDoc(`{"replicas": 2, "name": "bar"}`)},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		rules, err := dyff.LoadSeverityRules([]byte("- {path: ^/replicas$, severity: critical}"))
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		Expect((&dyff.JSONReport{Report: report, SeverityRules: rules}).WriteReport(&buf)).To(Succeed())
+
+		var result dyff.SerializedReport
+		Expect(json.Unmarshal(buf.Bytes(), &result)).To(Succeed())
+		Expect(result.Diffs).To(HaveLen(2))
+		Expect(result.Diffs[0].Severity).To(Equal("critical"))
+		Expect(result.Diffs[1].Severity).To(Equal("info"))
+	})
+
+	It("should write style changes as their own kind of difference", func() {
+		out := writeJSON(`name: "foo"`, "name: foo", dyff.DetectStyleChanges(true))
+
+		var result dyff.SerializedReport
+		Expect(json.Unmarshal([]byte(out), &result)).To(Succeed())
+		Expect(result.Diffs).To(HaveLen(1))
+		Expect(result.Diffs[0].Details).To(Equal([]dyff.SerializedDetail{{Kind: "style-change", From: "foo", To: "foo"}}))
+	})
+
+	It("should write reports that are valid according to the schema", func() {
+		schemaDocument, err := jsonschema.UnmarshalJSON(strings.NewReader(dyff.ReportSchema))
+		Expect(err).ToNot(HaveOccurred())
+
+		compiler := jsonschema.NewCompiler()
+		Expect(compiler.AddResource("report.schema.json", schemaDocument)).To(Succeed())
+
+		schema, err := compiler.Compile("report.schema.json")
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, out := range []string{
+			writeJSON(`{"foo": "bar"}`, `{"foo": "bar"}`),
+			writeJSON(`{"foo": {"bar": [1, 2]}, "null": null}`, `{"foo": {"bar": [2, 1]}, "null": true}`),
+			writeJSON(`{"port": "8080"}`, `{"port": 8080}`, dyff.DetectTypeChanges(true)),
+			writeJSON(`name: "foo"`, "name: foo", dyff.DetectStyleChanges(true)),
+		} {
+			value, err := jsonschema.UnmarshalJSON(strings.NewReader(out))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(schema.Validate(value)).To(Succeed())
+		}
+	})
+
+	It("should change the schema version whenever the schema changes", func() {
+		// A changed schema requires a new schema version, add the version
+		// with the checksum of the changed schema to this list
+		var checksums = map[string]string{
+			"1.5": "7501dccd0b7bb6bde577dc226be595dda59564105a3c0f0b82a68f3b263c22f0",
+			"1.6": "be6685f00f4b6cc6d16af987bb1535f055ce4423f338ae952a00dda71b20db22",
+			"1.7": "271dc00ae0b26dd00004f6b9dffa6b5ff9f0d664a0d49c935bd0eee2aa023d20",
+		}
+
+		Expect(fmt.Sprintf("%x", sha256.Sum256([]byte(dyff.ReportSchema)))).To(Equal(checksums[dyff.ReportSchemaVersion]))
+	})
+
+	It("should parse a written report so that it can be rendered again", func() {
+		out := writeJSON(`{"foo": {"bar": [1, 2]}, "null": null, "list": [{"name": "one"}]}`, `{"foo": {"bar": [2, 1]}, "null": true, "list": [{"name": "one"}, {"name": "two", "z": 1, "a": 2}]}`)
+
+		report, err := dyff.ParseJSONReport([]byte(out))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Diffs).To(HaveLen(3))
+
+		var buf bytes.Buffer
+		Expect((&dyff.JSONReport{Report: report}).WriteReport(&buf)).To(Succeed())
+		Expect(buf.String()).To(MatchJSON(out))
+	})
+
+	It("should fail to parse a report of an unsupported schema version", func() {
+		_, err := dyff.ParseJSONReport([]byte(`{"schema_version": "2.0", "diffs": []}`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should marshal reports, differences, and details like the JSON reporter", func() {
+		report, err := dyff.CompareInputFiles(
+			ytbx.InputFile{Location: "from.yml", Documents: multiDoc("---\nname: foo\nlist: [a]\n")},
+			ytbx.InputFile{Location: "to.yml", Documents: multiDoc("---\nname: bar\nlist: [a, b]\n")},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		Expect((&dyff.JSONReport{Report: report}).WriteReport(&buf)).To(Succeed())
+
+		data, err := json.Marshal(report)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(MatchJSON(buf.Bytes()))
+
+		data, err = json.Marshal(report.Diffs[0])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(MatchJSON(fmt.Sprintf(`{
+  "path": "/name",
+  "document_index": 0,
+  "fingerprint": %q,
+  "details": [{"kind": "modification", "from": "foo", "to": "bar"}]
+}`, report.Diffs[0].Fingerprint())))
+
+		data, err = json.Marshal(report.Diffs[1].Details)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(MatchJSON(`[{"kind": "addition", "from": null, "to": ["b"]}]`))
+	})
+})
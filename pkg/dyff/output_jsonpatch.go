@@ -0,0 +1,399 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// PatchOperation is one operation of a JSON Patch (RFC 6902), where the path
+// is a JSON Pointer (RFC 6901) into the from input file
+type PatchOperation struct {
+	Op    string
+	Path  string
+	Value any
+}
+
+// MarshalJSON writes the operation, with a value only if the operation has one
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	if op.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{op.Op, op.Path})
+	}
+
+	return json.Marshal(struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}{op.Op, op.Path, op.Value})
+}
+
+// PatchReport is a reporter that writes the report as a JSON Patch, which
+// turns the from input file into the to input file when applied, e.g. using
+// `kubectl patch --type json`. Since a JSON Patch applies to one document,
+// both input files must contain exactly one document.
+type PatchReport struct {
+	Report
+}
+
+var _ ReportWriter = &PatchReport{}
+
+// WriteReport writes the JSON Patch to the provided writer
+func (report *PatchReport) WriteReport(out io.Writer) error {
+	operations, err := report.Operations()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(operations)
+}
+
+// Operations returns the operations of the JSON Patch in the order in which
+// they have to be applied: List entries are removed last, starting with the
+// highest index, so that the indices of all other operations stay valid, and
+// added list entries are appended to the list if they are at the end of the
+// list in the to input file. Otherwise, the list is replaced as a whole, like
+// lists with a changed order. Style changes are left out, since they do not
+// change any value.
+func (r Report) Operations() ([]PatchOperation, error) {
+	var operations = []PatchOperation{}
+	if len(r.Diffs) == 0 {
+		return operations, nil
+	}
+
+	if len(r.From.Documents) != 1 || len(r.To.Documents) != 1 {
+		return nil, fmt.Errorf("a JSON Patch can only describe the differences of input files with one document each, but there are %d and %d documents", len(r.From.Documents), len(r.To.Documents))
+	}
+
+	var from, to = r.From.Documents[0], r.To.Documents[0]
+
+	// Lists with a changed order or with entries added in between existing
+	// entries are replaced as a whole, including all other differences inside
+	// of them
+	var replacedLists []string
+	for _, diff := range r.Diffs {
+		if diff.Path == nil {
+			continue
+		}
+
+		replace, err := replacesList(to, diff)
+		if err != nil {
+			return nil, err
+		}
+
+		if replace {
+			replacedLists = append(replacedLists, diff.Path.String())
+		}
+	}
+
+	var replaced = map[string]struct{}{}
+
+	var removals []PatchOperation
+	for _, diff := range r.Diffs {
+		if diff.Path == nil {
+			return nil, fmt.Errorf("a JSON Patch cannot describe differences between the documents of the input files")
+		}
+
+		if isBelowAny(diff.Path.String(), replacedLists) {
+			continue
+		}
+
+		pointer, node, err := jsonPointer(from, diff.Path.PathElements)
+		if err != nil {
+			return nil, err
+		}
+
+		if isAny(diff.Path.String(), replacedLists) {
+			if _, ok := replaced[pointer]; ok {
+				continue
+			}
+
+			_, toNode, err := jsonPointer(to, diff.Path.PathElements)
+			if err != nil {
+				return nil, err
+			}
+
+			replaced[pointer] = struct{}{}
+			operations = append(operations, PatchOperation{Op: "replace", Path: pointer, Value: serializeNode(toNode)})
+			continue
+		}
+
+		for _, detail := range diff.Details {
+			switch detail.Kind {
+			case MODIFICATION, TYPECHANGE, REPRESENTATIONCHANGE:
+				operations = append(operations, PatchOperation{Op: "replace", Path: pointer, Value: serializeNode(detail.To)})
+
+			case RENAME:
+				// The changed names are part of the remaining differences
+				continue
+
+			case ADDITION:
+				added, err := patchEntries(detail.To, pointer, func(entry *yamlv3.Node) (string, error) { return "-", nil })
+				if err != nil {
+					return nil, err
+				}
+
+				for _, entry := range added {
+					operations = append(operations, PatchOperation{Op: "add", Path: entry.Path, Value: entry.Value})
+				}
+
+			case REMOVAL:
+				var used = map[int]struct{}{}
+				removed, err := patchEntries(detail.From, pointer, func(entry *yamlv3.Node) (string, error) {
+					idx := listIndex(node, entry, used)
+					if idx < 0 {
+						return "", fmt.Errorf("failed to find removed list entry in %s", diff.Path.String())
+					}
+
+					used[idx] = struct{}{}
+					return strconv.Itoa(idx), nil
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				for _, entry := range removed {
+					removals = append(removals, PatchOperation{Op: "remove", Path: entry.Path})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(removals, func(i, j int) bool {
+		return comparePointers(removals[i].Path, removals[j].Path) > 0
+	})
+
+	return append(operations, removals...), nil
+}
+
+// patchEntries returns an operation with path and value for each entry of a
+// map or list that was added or removed, list entries use the token that
+// the provided function returns
+func patchEntries(node *yamlv3.Node, pointer string, listToken func(*yamlv3.Node) (string, error)) ([]PatchOperation, error) {
+	var result []PatchOperation
+	switch node = followAlias(node); node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			result = append(result, PatchOperation{
+				Path:  pointer + "/" + escapePointerToken(followAlias(node.Content[i]).Value),
+				Value: serializeNode(node.Content[i+1]),
+			})
+		}
+
+	case yamlv3.SequenceNode:
+		for _, entry := range node.Content {
+			token, err := listToken(entry)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, PatchOperation{
+				Path:  pointer + "/" + token,
+				Value: serializeNode(entry),
+			})
+		}
+
+	default:
+		return nil, fmt.Errorf("a JSON Patch cannot describe added or removed documents")
+	}
+
+	return result, nil
+}
+
+// jsonPointer returns the JSON Pointer of the path elements in the given
+// document, where named list entries are replaced by their index, and the
+// node the path points to
+func jsonPointer(document *yamlv3.Node, elements []ytbx.PathElement) (string, *yamlv3.Node, error) {
+	var tokens []string
+	var node = followAlias(document)
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		node = followAlias(node.Content[0])
+	}
+
+	for _, element := range elements {
+		switch {
+		case element.Key != "" && element.Name != "":
+			idx := -1
+			if node.Kind == yamlv3.SequenceNode {
+				idx = entryIndex(node, element.Key, element.Name)
+			}
+
+			if idx < 0 {
+				return "", nil, fmt.Errorf("failed to find list entry %s=%s", element.Key, element.Name)
+			}
+
+			tokens = append(tokens, strconv.Itoa(idx))
+			node = followAlias(node.Content[idx])
+
+		case element.Name != "":
+			var value *yamlv3.Node
+			var ok bool
+			if node.Kind == yamlv3.MappingNode {
+				value, ok = findValueByKey(node, element.Name)
+			}
+
+			if !ok {
+				return "", nil, fmt.Errorf("failed to find map entry %s", element.Name)
+			}
+
+			tokens = append(tokens, escapePointerToken(element.Name))
+			node = value
+
+		default:
+			if node.Kind != yamlv3.SequenceNode || element.Idx < 0 || element.Idx >= len(node.Content) {
+				return "", nil, fmt.Errorf("failed to find list entry #%d", element.Idx)
+			}
+
+			tokens = append(tokens, strconv.Itoa(element.Idx))
+			node = followAlias(node.Content[element.Idx])
+		}
+	}
+
+	if len(tokens) == 0 {
+		return "", node, nil
+	}
+
+	return "/" + strings.Join(tokens, "/"), node, nil
+}
+
+// listIndex returns the index of the entry in the list, which is either the
+// node itself or the first unused entry with the same value
+func listIndex(list *yamlv3.Node, entry *yamlv3.Node, used map[int]struct{}) int {
+	if list.Kind != yamlv3.SequenceNode {
+		return -1
+	}
+
+	for i, candidate := range list.Content {
+		if _, ok := used[i]; !ok && candidate == entry {
+			return i
+		}
+	}
+
+	for i, candidate := range list.Content {
+		if _, ok := used[i]; !ok && reflect.DeepEqual(serializeNode(candidate), serializeNode(entry)) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// comparePointers compares two JSON Pointers token by token, where list
+// indices are compared by their number
+func comparePointers(a, b string) int {
+	tokensA, tokensB := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(tokensA) && i < len(tokensB); i++ {
+		numA, errA := strconv.Atoi(tokensA[i])
+		numB, errB := strconv.Atoi(tokensB[i])
+
+		switch {
+		case errA == nil && errB == nil && numA != numB:
+			return numA - numB
+
+		case tokensA[i] != tokensB[i]:
+			return strings.Compare(tokensA[i], tokensB[i])
+		}
+	}
+
+	return len(tokensA) - len(tokensB)
+}
+
+// replacesList returns whether the list of the difference has to be replaced
+// as a whole, which is the case for a changed order, or for added entries
+// that are not at the end of the list in the to document, since appending
+// them would result in a different order
+func replacesList(to *yamlv3.Node, diff Diff) (bool, error) {
+	if hasOrderChange(diff) {
+		return true, nil
+	}
+
+	for _, detail := range diff.Details {
+		if detail.Kind != ADDITION || followAlias(detail.To).Kind != yamlv3.SequenceNode {
+			continue
+		}
+
+		_, toNode, err := jsonPointer(to, diff.Path.PathElements)
+		if err != nil {
+			return false, err
+		}
+
+		added := followAlias(detail.To).Content
+		if toNode.Kind != yamlv3.SequenceNode || len(added) > len(toNode.Content) {
+			return true, nil
+		}
+
+		tail := toNode.Content[len(toNode.Content)-len(added):]
+		for i := range added {
+			if tail[i] != added[i] && !reflect.DeepEqual(serializeNode(tail[i]), serializeNode(added[i])) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func hasOrderChange(diff Diff) bool {
+	for _, detail := range diff.Details {
+		if detail.Kind == ORDERCHANGE {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAny(path string, paths []string) bool {
+	for _, candidate := range paths {
+		if path == candidate {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isBelowAny(path string, parents []string) bool {
+	for _, parent := range parents {
+		if path != parent && strings.HasPrefix(path, strings.TrimSuffix(parent, "/")+"/") {
+			return true
+		}
+	}
+
+	return false
+}
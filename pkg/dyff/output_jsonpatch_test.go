@@ -0,0 +1,226 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("JSON Patch report", func() {
+	var compare = func(from string, to string) dyff.Report {
+		report, err := dyff.CompareInputFiles(
+			ytbx.InputFile{Location: "from.yml", Documents: multiDoc(from)},
+			ytbx.InputFile{Location: "to.yml", Documents: multiDoc(to)},
+		)
+		Expect(err).ToNot(HaveOccurred())
+		return report
+	}
+
+	var writePatch = func(from string, to string) string {
+		var buf bytes.Buffer
+		Expect((&dyff.PatchReport{Report: compare(from, to)}).WriteReport(&buf)).To(Succeed())
+		return buf.String()
+	}
+
+	It("should write the differences as JSON Patch operations", func() {
+		Expect(writePatch(`---
+name: foo
+meta/data: {a: 1, b: 2}
+list:
+- name: one
+  value: 1
+- name: two
+  value: 2
+- name: three
+  value: 3
+`, `---
+name: bar
+meta/data: {a: 1, c: 3}
+list:
+- name: one
+  value: 1
+- name: three
+  value: 4
+- name: four
+  value: 4
+`)).To(MatchJSON(`[
+  {"op": "replace", "path": "/name", "value": "bar"},
+  {"op": "add", "path": "/meta~1data/c", "value": 3},
+  {"op": "add", "path": "/list/-", "value": {"name": "four", "value": 4}},
+  {"op": "replace", "path": "/list/2/value", "value": 4},
+  {"op": "remove", "path": "/meta~1data/b"},
+  {"op": "remove", "path": "/list/1"}
+]`))
+	})
+
+	It("should replace lists with entries added in between existing entries", func() {
+		Expect(writePatch("---\nargs: [a, c]\n", "---\nargs: [a, b, c]\n")).To(MatchJSON(`[
+  {"op": "replace", "path": "/args", "value": ["a", "b", "c"]}
+]`))
+	})
+
+	It("should create a patch that turns the from document into the to document", func() {
+		for _, input := range [][2]string{
+			{"---\nargs: [a, c]\n", "---\nargs: [a, b, c]\n"},
+			{"---\nargs: [a, c]\n", "---\nargs: [b, a, c, d]\n"},
+			{"---\nargs: [a, b, c, d]\n", "---\nargs: [a, x, d, y]\n"},
+			{"---\nlist: [{name: one, value: 1}, {name: three, value: 3}]\n", "---\nlist: [{name: one, value: 1}, {name: two, value: 2}, {name: three, value: 4}]\n"},
+			{"---\nmap: {list: [1, 2], key: value}\n", "---\nmap: {list: [0, 1, 2, 3], other: value}\n"},
+		} {
+			from, to := input[0], input[1]
+			Expect(applyPatch(from, writePatch(from, to))).To(Equal(jsonValue(to)), "patch of %q to %q", from, to)
+		}
+	})
+
+	It("should remove list entries starting with the highest index", func() {
+		Expect(writePatch("---\nlist: [a, b, c, d]\n", "---\nlist: [a, c]\n")).To(MatchJSON(`[
+  {"op": "remove", "path": "/list/3"},
+  {"op": "remove", "path": "/list/1"}
+]`))
+	})
+
+	It("should replace lists with a changed order as a whole", func() {
+		Expect(writePatch(`---
+list:
+- name: one
+  value: 1
+- name: two
+  value: 2
+`, `---
+list:
+- name: two
+  value: 3
+- name: one
+  value: 1
+`)).To(MatchJSON(`[
+  {"op": "replace", "path": "/list", "value": [{"name": "two", "value": 3}, {"name": "one", "value": 1}]}
+]`))
+	})
+
+	It("should write an empty patch if there are no differences", func() {
+		Expect(writePatch("---\nfoo: bar\n", "---\nfoo: bar\n")).To(MatchJSON(`[]`))
+	})
+
+	It("should fail for input files with more than one document", func() {
+		_, err := compare("---\na: 1\n---\nb: 2\n", "---\na: 2\n---\nb: 2\n").Operations()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("input files with one document each"))
+	})
+})
+
+// jsonValue returns the value of the YAML document as it is represented in
+// JSON, so that it can be compared with the result of a JSON Patch
+func jsonValue(document string) any {
+	var value any
+	Expect(yamlv3.Unmarshal([]byte(document), &value)).To(Succeed())
+
+	data, err := json.Marshal(value)
+	Expect(err).ToNot(HaveOccurred())
+
+	var result any
+	Expect(json.Unmarshal(data, &result)).To(Succeed())
+	return result
+}
+
+// applyPatch applies the JSON Patch to the YAML document, it supports the
+// operations and paths that are used by the JSON Patch report
+func applyPatch(document string, patch string) any {
+	var operations []struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}
+
+	Expect(json.Unmarshal([]byte(patch), &operations)).To(Succeed())
+
+	var root = jsonValue(document)
+	for _, operation := range operations {
+		tokens := strings.Split(operation.Path, "/")[1:]
+		for i := range tokens {
+			tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[i])
+		}
+
+		root = applyOperation(root, tokens, operation.Op, operation.Value)
+	}
+
+	return root
+}
+
+func applyOperation(node any, tokens []string, op string, value any) any {
+	if len(tokens) == 0 {
+		return value
+	}
+
+	last := len(tokens) == 1
+	switch node := node.(type) {
+	case map[string]any:
+		switch {
+		case !last:
+			node[tokens[0]] = applyOperation(node[tokens[0]], tokens[1:], op, value)
+
+		case op == "remove":
+			delete(node, tokens[0])
+
+		default:
+			node[tokens[0]] = value
+		}
+
+		return node
+
+	case []any:
+		if tokens[0] == "-" {
+			return append(node, value)
+		}
+
+		idx, err := strconv.Atoi(tokens[0])
+		Expect(err).ToNot(HaveOccurred())
+
+		switch {
+		case !last:
+			node[idx] = applyOperation(node[idx], tokens[1:], op, value)
+			return node
+
+		case op == "remove":
+			return append(node[:idx], node[idx+1:]...)
+
+		case op == "add":
+			return append(node[:idx], append([]any{value}, node[idx:]...)...)
+
+		default:
+			node[idx] = value
+			return node
+		}
+	}
+
+	Fail("JSON Patch path does not exist")
+	return nil
+}
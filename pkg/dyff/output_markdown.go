@@ -0,0 +1,196 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/gonvenience/text"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// MarkdownReport is a reporter that writes the report as GitHub flavored
+// Markdown, e.g. to be posted as a pull request comment. It starts with a
+// table of the number of changes per document, followed by the differences
+// in diff code blocks.
+type MarkdownReport struct {
+	Report
+	UseGoPatchPaths bool
+
+	// Title is the heading of the report, which is based on the locations of
+	// the input files if not set
+	Title string
+}
+
+var _ ReportWriter = &MarkdownReport{}
+
+type markdownDocument struct {
+	name  string
+	diffs []Diff
+	kinds map[rune]int
+}
+
+var backtickRuns = regexp.MustCompile("`+")
+
+// WriteReport writes the report as Markdown to the provided writer
+func (report *MarkdownReport) WriteReport(out io.Writer) error {
+	writer := bufio.NewWriter(out)
+	defer writer.Flush()
+
+	title := report.Title
+	if title == "" {
+		title = fmt.Sprintf("dyff between %s and %s", markdownCode(report.From.Location), markdownCode(report.To.Location))
+	}
+
+	_, _ = fmt.Fprintf(writer, "### %s\n\n", title)
+
+	if len(report.Diffs) == 0 {
+		_, _ = writer.WriteString("No differences found.\n")
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(writer, "Found %s.\n\n", text.Plural(len(report.Diffs), "difference"))
+
+	documents := report.documents()
+
+	_, _ = writer.WriteString("| Document | Additions | Removals | Modifications | Order changes |\n")
+	_, _ = writer.WriteString("| :--- | ---: | ---: | ---: | ---: |\n")
+	for _, document := range documents {
+		_, _ = fmt.Fprintf(writer, "| %s | %d | %d | %d | %d |\n",
+			markdownTableCell(document.name),
+			document.kinds[ADDITION],
+			document.kinds[REMOVAL],
+			document.kinds[MODIFICATION]+document.kinds[TYPECHANGE]+document.kinds[STYLECHANGE]+document.kinds[REPRESENTATIONCHANGE]+document.kinds[RENAME],
+			document.kinds[ORDERCHANGE],
+		)
+	}
+
+	for _, document := range documents {
+		_, _ = fmt.Fprintf(writer, "\n#### %s\n", document.name)
+
+		for _, diff := range document.diffs {
+			_, _ = fmt.Fprintf(writer, "\n%s\n", markdownCode(plainPath(diff, report.UseGoPatchPaths)))
+
+			for _, detail := range diff.Details {
+				block, err := markdownDiffBlock(detail)
+				if err != nil {
+					return err
+				}
+
+				_, _ = fmt.Fprintf(writer, "\n%s:\n\n%s\n", editorMessage(detail), block)
+			}
+		}
+	}
+
+	return nil
+}
+
+// documents returns the differences grouped by document in the order of the
+// differences, together with the number of details per kind
+func (report *MarkdownReport) documents() []*markdownDocument {
+	var result []*markdownDocument
+	var lookup = map[int]*markdownDocument{}
+	for _, diff := range report.Diffs {
+		var documentIdx, name = -1, "(file level)"
+		if diff.Path != nil {
+			documentIdx, name = diff.Path.DocumentIdx, diff.Path.RootDescription()
+		}
+
+		document, ok := lookup[documentIdx]
+		if !ok {
+			document = &markdownDocument{name: name, kinds: map[rune]int{}}
+			lookup[documentIdx] = document
+			result = append(result, document)
+		}
+
+		document.diffs = append(document.diffs, diff)
+		for _, detail := range diff.Details {
+			document.kinds[detail.Kind]++
+		}
+	}
+
+	return result
+}
+
+// markdownDiffBlock returns a diff code block with the removed lines of the
+// from value and the added lines of the to value of the detail
+func markdownDiffBlock(detail Detail) (string, error) {
+	var lines []string
+	for _, side := range []struct {
+		prefix string
+		node   *yamlv3.Node
+	}{
+		{"- ", detail.From},
+		{"+ ", detail.To},
+	} {
+		if side.node == nil {
+			continue
+		}
+
+		value, err := plainYAMLString(side.node)
+		if err != nil {
+			return "", err
+		}
+
+		for _, line := range strings.Split(value, "\n") {
+			lines = append(lines, side.prefix+line)
+		}
+	}
+
+	body := strings.Join(lines, "\n")
+	fence := markdownFence(body)
+	return fmt.Sprintf("%sdiff\n%s\n%s", fence, body, fence), nil
+}
+
+// markdownFence returns a code fence that is longer than any run of
+// backticks in the text, so that the text cannot end the code block
+func markdownFence(text string) string {
+	var length = 3
+	for _, run := range backtickRuns.FindAllString(text, -1) {
+		length = max(length, len(run)+1)
+	}
+
+	return strings.Repeat("`", length)
+}
+
+// markdownCode returns the text as inline code, which uses more backticks
+// than the text contains
+func markdownCode(text string) string {
+	var length = 1
+	for _, run := range backtickRuns.FindAllString(text, -1) {
+		length = max(length, len(run)+1)
+	}
+
+	delimiter := strings.Repeat("`", length)
+	if length > 1 {
+		return delimiter + " " + text + " " + delimiter
+	}
+
+	return delimiter + text + delimiter
+}
+
+func markdownTableCell(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
@@ -0,0 +1,90 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"bytes"
+
+	"github.com/gonvenience/ytbx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("Markdown report", func() {
+	var writeMarkdown = func(from string, to string) string {
+		report, err := dyff.CompareInputFiles(
+			ytbx.InputFile{Location: "from.yml", Documents: multiDoc(from)},
+			ytbx.InputFile{Location: "to.yml", Documents: multiDoc(to)},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		var buf bytes.Buffer
+		Expect((&dyff.MarkdownReport{Report: report}).WriteReport(&buf)).To(Succeed())
+		return buf.String()
+	}
+
+	It("should write a summary table and the differences in diff code blocks", func() {
+		Expect(writeMarkdown(
+			"---\nname: foo\n---\nspec: {replicas: 1}\n",
+			"---\nname: foo\nlabels: {app: web}\n---\nspec: {replicas: 2}\n",
+		)).To(Equal("### dyff between `from.yml` and `to.yml`" + `
+
+Found two differences.
+
+| Document | Additions | Removals | Modifications | Order changes |
+| :--- | ---: | ---: | ---: | ---: |
+| document #1 | 1 | 0 | 0 | 0 |
+| document #2 | 0 | 0 | 1 | 0 |
+
+#### document #1
+
+` + "`(root level)`" + `
+
+one map entry added:
+
+` + "```diff" + `
++ labels: {app: web}
+` + "```" + `
+
+#### document #2
+
+` + "`spec.replicas`" + `
+
+value change from 1 to 2:
+
+` + "```diff" + `
+- 1
++ 2
+` + "```" + `
+`))
+	})
+
+	It("should use longer code fences for values with backticks", func() {
+		out := writeMarkdown("---\ntext: foo\n", "---\ntext: \"```\"\n")
+		Expect(out).To(ContainSubstring("````diff\n- foo\n+ \"```\"\n````\n"))
+	})
+
+	It("should state that there are no differences", func() {
+		Expect(writeMarkdown("---\na: 1\n", "---\na: 1\n")).To(Equal("### dyff between `from.yml` and `to.yml`\n\nNo differences found.\n"))
+	})
+})
@@ -0,0 +1,108 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+)
+
+// sourceContext returns the lines of the original input file around the
+// difference, preferring the to input file, with the line of the difference
+// being marked. If the input file is not a local file or the line of the
+// difference is unknown, there is no context.
+func (report *HumanReport) sourceContext(diff Diff) string {
+	fromLine, toLine := report.linesOfDiff(diff)
+
+	description, line := describe(report.To, report.ToDescription), toLine
+	lines := report.sourceLines(report.To.Location)
+	if lines == nil || line <= 0 {
+		description, line = describe(report.From, report.FromDescription), fromLine
+		lines = report.sourceLines(report.From.Location)
+	}
+
+	if lines == nil || line <= 0 || line > len(lines) {
+		return ""
+	}
+
+	lower := max(line-report.SourceContextLines, 1)
+	upper := min(line+report.SourceContextLines, len(lines))
+	width := len(strconv.Itoa(upper))
+
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("\n")
+	_, _ = buf.WriteString(strings.Repeat(" ", report.Indent))
+	_, _ = buf.WriteString(report.theme().dimgray("%s", description))
+	_, _ = buf.WriteString("\n")
+
+	for i := lower; i <= upper; i++ {
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+
+		text := fmt.Sprintf("%s %*d │ %s", marker, width, i, lines[i-1])
+		if i != line {
+			text = report.theme().dimgray("%s", text)
+		}
+
+		_, _ = buf.WriteString(strings.Repeat(" ", report.Indent))
+		_, _ = buf.WriteString(text)
+		_, _ = buf.WriteString("\n")
+	}
+
+	return buf.String()
+}
+
+// sourceLines returns the lines of the local input file, or nil if the
+// location is not a readable regular file
+func (report *HumanReport) sourceLines(location string) []string {
+	if location == "" || ytbx.IsStdin(location) {
+		return nil
+	}
+
+	if lines, ok := report.sources[location]; ok {
+		return lines
+	}
+
+	if report.sources == nil {
+		report.sources = map[string][]string{}
+	}
+
+	// Pipes, e.g. of a process substitution, cannot be read a second time
+	var lines []string
+	if info, err := os.Stat(location); err != nil || !info.Mode().IsRegular() {
+		report.sources[location] = lines
+		return lines
+	}
+
+	if data, err := os.ReadFile(location); err == nil {
+		lines = strings.Split(strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"), "\n")
+	}
+
+	report.sources[location] = lines
+	return lines
+}
@@ -0,0 +1,204 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/gonvenience/neat"
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ChangeTableReport is a reporter that summarizes the differences in a table
+// with one row per (Kubernetes) resource, similar to the change table of the
+// Carvel kapp tool, optionally followed by the detailed differences
+type ChangeTableReport struct {
+	ShowDetails bool
+	HumanReport
+}
+
+type changeTableRow struct {
+	namespace string
+	name      string
+	kind      string
+	op        string
+	changes   int
+}
+
+// WriteReport writes the change table to the provided writer
+func (report *ChangeTableReport) WriteReport(out io.Writer) error {
+	writer := bufio.NewWriter(out)
+	defer writer.Flush()
+
+	rows, orderChanged := report.rows()
+
+	var ops = map[string]int{}
+	var table = [][]string{{bold("Namespace"), bold("Name"), bold("Kind"), bold("Op"), bold("Changes")}}
+	for _, row := range rows {
+		ops[row.op]++
+
+		var op, changes = row.op, "-"
+		switch row.op {
+		case "create":
+			op = report.theme().green(op)
+
+		case "delete":
+			op = report.theme().red(op)
+
+		case "update":
+			op = report.theme().yellow(op)
+			changes = strconv.Itoa(row.changes)
+		}
+
+		table = append(table, []string{row.namespace, row.name, row.kind, op, changes})
+	}
+
+	if len(rows) > 0 {
+		out, err := neat.Table(table, neat.AlignRight(4))
+		if err != nil {
+			return err
+		}
+
+		_, _ = writer.WriteString(out)
+		_, _ = writer.WriteString("\n")
+	}
+
+	_, _ = writer.WriteString(fmt.Sprintf("Op: %d create, %d delete, %d update\n", ops["create"], ops["delete"], ops["update"]))
+	if orderChanged {
+		_, _ = writer.WriteString("Order of documents changed\n")
+	}
+
+	if report.ShowDetails && len(report.Diffs) > 0 {
+		details := report.HumanReport
+		details.OmitHeader = true
+
+		// Flush the table before the details are written to the same output
+		_ = writer.Flush()
+		return details.WriteReport(out)
+	}
+
+	return nil
+}
+
+// rows returns one row per resource in the order of the differences, and
+// whether the order of the documents changed
+func (report *ChangeTableReport) rows() ([]*changeTableRow, bool) {
+	var (
+		rows         []*changeTableRow
+		byKey        = map[string]*changeTableRow{}
+		orderChanged bool
+	)
+
+	for _, diff := range report.Diffs {
+		if diff.Path == nil || diff.Path.Root == nil {
+			orderChanged = true
+			continue
+		}
+
+		op, document := "update", report.document(diff.Path)
+		if len(diff.Path.PathElements) == 0 && len(diff.Details) == 1 {
+			switch detail := diff.Details[0]; {
+			case detail.Kind == ADDITION && detail.To != nil && detail.To.Kind == yamlv3.DocumentNode:
+				op, document = "create", documentRoot(detail.To)
+
+			case detail.Kind == REMOVAL && detail.From != nil && detail.From.Kind == yamlv3.DocumentNode:
+				op, document = "delete", documentRoot(detail.From)
+			}
+		}
+
+		row := &changeTableRow{namespace: "-", name: "-", kind: "-", op: op}
+		if isEmptyNode(document) {
+			row.kind = "(empty)"
+		}
+
+		if value, ok := lookupString(document, "metadata", "namespace"); ok {
+			row.namespace = value
+		}
+
+		if value, ok := lookupString(document, "kind"); ok {
+			row.kind = value
+		}
+
+		if value, ok := lookupString(document, "metadata", "name"); ok {
+			row.name = value
+		} else {
+			row.name = fmt.Sprintf("document #%d", diff.Path.DocumentIdx+1)
+		}
+
+		apiVersion, _ := lookupString(document, "apiVersion")
+		key := fmt.Sprintf("%s/%s/%s/%s/%s", apiVersion, row.kind, row.namespace, row.name, op)
+		// Each difference counts once, even if it has multiple details, e.g.
+		// a type change together with a value change at the same path
+		if existing, ok := byKey[key]; ok {
+			existing.changes++
+			continue
+		}
+
+		row.changes = 1
+		byKey[key] = row
+		rows = append(rows, row)
+	}
+
+	return rows, orderChanged
+}
+
+func (report *ChangeTableReport) document(path *ytbx.Path) *yamlv3.Node {
+	if path.DocumentIdx < 0 || path.DocumentIdx >= len(path.Root.Documents) {
+		return nil
+	}
+
+	return documentRoot(path.Root.Documents[path.DocumentIdx])
+}
+
+// documentRoot returns the root node of the document, or nil if the document
+// has no content at all
+func documentRoot(document *yamlv3.Node) *yamlv3.Node {
+	if document.Kind != yamlv3.DocumentNode {
+		return document
+	}
+
+	if len(document.Content) > 0 {
+		return document.Content[0]
+	}
+
+	return nil
+}
+
+// isEmptyNode returns whether the node is missing, null, or an empty map or
+// list, i.e. a document that has neither a kind nor a name
+func isEmptyNode(node *yamlv3.Node) bool {
+	switch {
+	case node == nil:
+		return true
+
+	case node.Kind == yamlv3.ScalarNode:
+		return node.Tag == "!!null"
+
+	case node.Kind == yamlv3.MappingNode, node.Kind == yamlv3.SequenceNode:
+		return len(node.Content) == 0
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"bytes"
+
+	. "github.com/gonvenience/bunt"
+	"github.com/gonvenience/ytbx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("change table report", func() {
+	BeforeEach(func() {
+		SetColorSettings(OFF, OFF)
+	})
+
+	AfterEach(func() {
+		SetColorSettings(AUTO, AUTO)
+	})
+
+	report := func() dyff.Report {
+		from := ytbx.InputFile{Location: "from", Documents: multiDoc(`---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: config, namespace: apps}
+data: {a: "1", b: "2"}
+---
+apiVersion: v1
+kind: Service
+metadata: {name: app, namespace: apps}
+spec: {port: 80}
+`)}
+
+		to := ytbx.InputFile{Location: "to", Documents: multiDoc(`---
+apiVersion: v1
+kind: ConfigMap
+metadata: {name: config, namespace: apps}
+data: {a: "3", b: "4"}
+---
+apiVersion: v1
+kind: Service
+metadata: {name: app, namespace: apps}
+spec: {port: 80}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata: {name: app, namespace: apps}
+spec: {replicas: 1}
+`)}
+
+		report, err := dyff.CompareInputFiles(from, to)
+		Expect(err).ToNot(HaveOccurred())
+		return report
+	}
+
+	It("should show one row per resource with the operation and number of changes", func() {
+		reporter := dyff.ChangeTableReport{HumanReport: dyff.HumanReport{Report: report()}}
+
+		var buf bytes.Buffer
+		Expect(reporter.WriteReport(&buf)).To(Succeed())
+		Expect(buf.String()).To(Equal(`Namespace Name   Kind       Op     Changes
+apps      config ConfigMap  update       2
+apps      app    Deployment create       -
+
+Op: 1 create, 0 delete, 1 update
+`))
+	})
+
+	It("should count a difference with multiple details once", func() {
+		from := ytbx.InputFile{Location: "from", Documents: multiDoc("---\napiVersion: v1\nkind: ConfigMap\nmetadata: {name: config}\ndata: {a: \"1\"}\n")}
+		to := ytbx.InputFile{Location: "to", Documents: multiDoc("---\napiVersion: v1\nkind: ConfigMap\nmetadata: {name: config}\ndata: {a: \"2\"}\n")}
+
+		path := &ytbx.Path{Root: &from, PathElements: []ytbx.PathElement{{Idx: -1, Name: "data"}, {Idx: -1, Name: "a"}}}
+		reporter := dyff.ChangeTableReport{HumanReport: dyff.HumanReport{Report: dyff.Report{
+			From: from,
+			To:   to,
+			Diffs: []dyff.Diff{{Path: path, Details: []dyff.Detail{
+				{Kind: dyff.TYPECHANGE, From: from.Documents[0], To: to.Documents[0]},
+				{Kind: dyff.MODIFICATION, From: from.Documents[0], To: to.Documents[0]},
+			}}},
+		}}}
+
+		var buf bytes.Buffer
+		Expect(reporter.WriteReport(&buf)).To(Succeed())
+		Expect(buf.String()).To(Equal(`Namespace Name   Kind      Op     Changes
+-         config ConfigMap update       1
+
+Op: 0 create, 0 delete, 1 update
+`))
+	})
+
+	It("should show the detailed differences on demand", func() {
+		reporter := dyff.ChangeTableReport{
+			ShowDetails: true,
+			HumanReport: dyff.HumanReport{Report: report(), Indent: 2},
+		}
+
+		var buf bytes.Buffer
+		Expect(reporter.WriteReport(&buf)).To(Succeed())
+		Expect(buf.String()).To(MatchRegexp(`(?s)^Namespace.*\nOp: 1 create, 0 delete, 1 update\n\ndata\.a .*\ndata\.b .*one document added`))
+	})
+
+	It("should show documents without content or with empty collections", func() {
+		to := ytbx.InputFile{Location: "to", Documents: []*yamlv3.Node{
+			{Kind: yamlv3.DocumentNode},
+			{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{{Kind: yamlv3.MappingNode, Tag: "!!map"}}},
+		}}
+
+		reporter := dyff.ChangeTableReport{HumanReport: dyff.HumanReport{Report: dyff.Report{
+			To: to,
+			Diffs: []dyff.Diff{
+				{Path: &ytbx.Path{Root: &to, DocumentIdx: 0}, Details: []dyff.Detail{{Kind: dyff.ADDITION, To: to.Documents[0]}}},
+				{Path: &ytbx.Path{Root: &to, DocumentIdx: 1}, Details: []dyff.Detail{{Kind: dyff.ADDITION, To: to.Documents[1]}}},
+			},
+		}}}
+
+		var buf bytes.Buffer
+		Expect(reporter.WriteReport(&buf)).To(Succeed())
+		Expect(buf.String()).To(Equal(`Namespace Name        Kind    Op     Changes
+-         document #1 (empty) create       -
+-         document #2 (empty) create       -
+
+Op: 2 create, 0 delete, 0 update
+`))
+	})
+})
@@ -0,0 +1,153 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/gonvenience/bunt"
+	"github.com/gonvenience/term"
+)
+
+// columns returns the number of columns available for the report, which is
+// the configured number of columns or the width of the terminal
+func (report *HumanReport) columns() int {
+	if report.Columns > 0 {
+		return report.Columns
+	}
+
+	return term.GetTerminalWidth()
+}
+
+// lineWrapper is a writer that wraps lines longer than the given number of
+// columns before writing them to the underlying writer
+type lineWrapper struct {
+	out     io.Writer
+	columns int
+	line    bytes.Buffer
+}
+
+func (w *lineWrapper) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b != '\n' {
+			_ = w.line.WriteByte(b)
+			continue
+		}
+
+		if err := w.writeLine(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the remaining text of an unfinished line
+func (w *lineWrapper) Flush() error {
+	if w.line.Len() == 0 {
+		return nil
+	}
+
+	return w.writeLine(false)
+}
+
+func (w *lineWrapper) writeLine(newline bool) error {
+	lines := wrapLine(w.line.String(), w.columns)
+	w.line.Reset()
+
+	text := strings.Join(lines, "\n")
+	if newline {
+		text += "\n"
+	}
+
+	_, err := io.WriteString(w.out, text)
+	return err
+}
+
+// wrapLine splits a line that is longer than the given number of columns into
+// multiple lines, preferably at spaces. The wrapped lines are indented to
+// start below the text of the first line. Colors of the text are kept, lines
+// with hyperlinks or unknown escape sequences are not wrapped.
+func wrapLine(line string, columns int) []string {
+	if columns <= 0 || plainTextLength(line) <= columns || hyperlinkSequence.MatchString(line) {
+		return []string{line}
+	}
+
+	parsed, err := bunt.ParseString(line)
+	if err != nil {
+		return []string{line}
+	}
+
+	plain := []rune(bunt.RemoveAllEscapeSequences(line))
+	if len(plain) != len(*parsed) {
+		return []string{line}
+	}
+
+	// Wrapped lines start below the text, not below the change type prefix
+	indent := len(plain) - len([]rune(strings.TrimLeft(string(plain), " ")))
+	for _, prefix := range []string{"- ", "+ ", "± ", "⇆ "} {
+		if strings.HasPrefix(string(plain[indent:]), prefix) {
+			indent += len([]rune(prefix))
+			break
+		}
+	}
+
+	if 2*indent >= columns {
+		indent = 0
+	}
+
+	var result []string
+	for start, first := 0, true; start < len(plain); first = false {
+		available, prefix := columns, ""
+		if !first {
+			available, prefix = columns-indent, strings.Repeat(" ", indent)
+		}
+
+		end := len(plain)
+		if end-start > available {
+			end = start + available
+
+			// Prefer to break at the last space that is not part of the indent
+			minimum := start
+			if first {
+				minimum += indent
+			}
+
+			for i := end; i > minimum; i-- {
+				if plain[i] == ' ' {
+					end = i
+					break
+				}
+			}
+		}
+
+		result = append(result, prefix+bunt.String((*parsed)[start:end]).String())
+
+		start = end
+		for start < len(plain) && plain[start] == ' ' {
+			start++
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,109 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// NormalizePromQL returns the PromQL expression with normalized whitespace,
+// so that expressions that only differ in their formatting are the same: line
+// breaks and indentation are collapsed to a single space, spaces around
+// brackets, commas, and operators are removed, and comments are dropped.
+// String literals are kept as they are.
+func NormalizePromQL(expr string) string {
+	var (
+		result  strings.Builder
+		last    rune
+		space   bool
+		quote   rune
+		escaped bool
+		comment bool
+	)
+
+	isPunctuation := func(r rune) bool {
+		return strings.ContainsRune("(){}[],+-*/%^=!<>~", r)
+	}
+
+	write := func(r rune) {
+		if space && last != 0 && !isPunctuation(last) && !isPunctuation(r) {
+			result.WriteRune(' ')
+		}
+
+		result.WriteRune(r)
+		last, space = r, false
+	}
+
+	for _, r := range expr {
+		switch {
+		case comment:
+			if r == '\n' {
+				comment, space = false, true
+			}
+
+		case quote != 0:
+			result.WriteRune(r)
+			switch {
+			case escaped:
+				escaped = false
+
+			case r == '\\' && quote != '`':
+				escaped = true
+
+			case r == quote:
+				quote = 0
+			}
+
+		case r == '#':
+			comment = true
+
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			space = true
+
+		case r == '"' || r == '\'' || r == '`':
+			write(r)
+			quote = r
+
+		default:
+			write(r)
+		}
+	}
+
+	return result.String()
+}
+
+// PromQLWhitespace is a comparer for PromQL expressions, which considers two
+// expressions to be equal if they only differ in their formatting, see
+// NormalizePromQL for details
+var PromQLWhitespace Comparer = ComparerFunc(func(_ ytbx.Path, from *yamlv3.Node, to *yamlv3.Node) ([]Detail, bool, error) {
+	if from.Kind != yamlv3.ScalarNode || to.Kind != yamlv3.ScalarNode {
+		return nil, false, nil
+	}
+
+	if NormalizePromQL(from.Value) == NormalizePromQL(to.Value) {
+		return nil, true, nil
+	}
+
+	return []Detail{{Kind: MODIFICATION, From: from, To: to}}, true, nil
+})
@@ -0,0 +1,49 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("PromQL expressions", func() {
+	It("should normalize the whitespace of expressions", func() {
+		Expect(dyff.NormalizePromQL("sum by (job) (\n  rate(errors_total[5m])\n)")).To(Equal("sum by(job)(rate(errors_total[5m]))"))
+		Expect(dyff.NormalizePromQL("up   ==   0\nand on (instance)\n  absent(foo)")).To(Equal("up==0 and on(instance)absent(foo)"))
+		Expect(dyff.NormalizePromQL("foo{job = \"a  b\"} # comment\n> 1")).To(Equal(`foo{job="a  b"}>1`))
+		Expect(dyff.NormalizePromQL(`foo{job="a\"  #b"}`)).To(Equal(`foo{job="a\"  #b"}`))
+	})
+
+	It("should only report expressions that differ in more than their formatting", func() {
+		result, err := compare(
+			yml(`{"expr": "sum by (job) (rate(x[5m]))", "other": "sum by (job) (rate(x[5m]))"}`),
+			yml(`{"expr": "sum by(job)(rate(x[5m]))", "other": "sum by(job)(rate(x[5m]))"}`),
+			dyff.ComparerForPath("/expr", dyff.PromQLWhitespace),
+		)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Path.String()).To(Equal("/other"))
+	})
+})
@@ -2,8 +2,10 @@ package dyff
 
 import (
 	"regexp"
+	"sort"
 
 	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
 func (r Report) filter(hasPath func(*ytbx.Path) bool) (result Report) {
@@ -108,7 +110,7 @@ func (r Report) IgnoreValueChanges() (result Report) {
 	for _, diff := range r.Diffs {
 		var hasValChange = false
 		for _, detail := range diff.Details {
-			if detail.Kind == MODIFICATION {
+			if detail.Kind == MODIFICATION || detail.Kind == TYPECHANGE || detail.Kind == STYLECHANGE || detail.Kind == REPRESENTATIONCHANGE {
 				hasValChange = true
 				break
 			}
@@ -121,3 +123,187 @@ func (r Report) IgnoreValueChanges() (result Report) {
 
 	return result	
 }
+
+// Invert returns a new report with the roles of from and to exchanged, which
+// means that additions become removals and vice versa. The paths refer to the
+// documents of the new from input file afterwards.
+func (r Report) Invert() (result Report) {
+	result = Report{
+		From: r.To,
+		To:   r.From,
+	}
+
+	// paths refer to the from input file, unless the document only exists in
+	// the to input file, which is the same for the inverted report
+	var from, to = result.From, result.To
+	var rebase = func(path *ytbx.Path) *ytbx.Path {
+		if path == nil {
+			return nil
+		}
+
+		rebased := *path
+		rebased.Root = &from
+
+		// paths of added documents already refer to the new from input file
+		if path.Root == nil || sameDocuments(path.Root.Documents, r.To.Documents) {
+			return &rebased
+		}
+
+		if path.DocumentIdx < len(path.Root.Names) {
+			name := path.Root.Names[path.DocumentIdx]
+			rebased.Root = &to
+			for idx := range from.Names {
+				if from.Names[idx] == name {
+					rebased.Root, rebased.DocumentIdx = &from, idx
+					break
+				}
+			}
+		}
+
+		return &rebased
+	}
+
+	var rank = func(kind rune) int {
+		switch kind {
+		case ORDERCHANGE:
+			return 0
+
+		case REMOVAL:
+			return 1
+
+		case ADDITION:
+			return 2
+		}
+
+		return 3
+	}
+
+	for _, diff := range r.Diffs {
+		details := make([]Detail, len(diff.Details))
+		for i, detail := range diff.Details {
+			kind := detail.Kind
+			switch kind {
+			case ADDITION:
+				kind = REMOVAL
+
+			case REMOVAL:
+				kind = ADDITION
+			}
+
+			details[i] = Detail{From: detail.To, To: detail.From, Kind: kind}
+		}
+
+		// keep the usual order of removals being listed before additions
+		sort.SliceStable(details, func(i, j int) bool {
+			return rank(details[i].Kind) < rank(details[j].Kind)
+		})
+
+		result.Diffs = append(result.Diffs, Diff{Path: rebase(diff.Path), Details: details})
+	}
+
+	return result
+}
+
+// WithInputFiles returns a new report with the given input files, where the
+// paths of the differences refer to the respective new input file. This is
+// meant for reports that only have placeholder documents, for example the
+// ones read using ParseJSONReport, and input files with the same documents.
+// Document names of the report are kept if the input files have none.
+func (r Report) WithInputFiles(from ytbx.InputFile, to ytbx.InputFile) (result Report) {
+	if len(from.Names) == 0 && len(r.From.Names) == len(from.Documents) {
+		from.Names = r.From.Names
+	}
+
+	if len(to.Names) == 0 && len(r.To.Names) == len(to.Documents) {
+		to.Names = r.To.Names
+	}
+
+	result = Report{From: from, To: to}
+	for _, diff := range r.Diffs {
+		if diff.Path != nil {
+			path := *diff.Path
+			switch {
+			case path.Root != nil && sameDocuments(path.Root.Documents, r.To.Documents) && !sameDocuments(path.Root.Documents, r.From.Documents):
+				path.Root = &result.To
+
+			default:
+				path.Root = &result.From
+			}
+
+			diff.Path = &path
+		}
+
+		result.Diffs = append(result.Diffs, diff)
+	}
+
+	return result
+}
+
+// sameDocuments returns whether both lists share the same documents
+func sameDocuments(a []*yamlv3.Node, b []*yamlv3.Node) bool {
+	return len(a) > 0 && len(a) == len(b) && a[0] == b[0]
+}
+
+// AdditionsOnly returns a new report with only the additions, which shows what
+// is new in the to input file
+func (r Report) AdditionsOnly() (result Report) {
+	return r.onlyKind(ADDITION)
+}
+
+// RemovalsOnly returns a new report with only the removals, which shows what
+// would be lost going from the from input file to the to input file
+func (r Report) RemovalsOnly() (result Report) {
+	return r.onlyKind(REMOVAL)
+}
+
+// TypeChangesOnly returns a new report with only the type changes, which
+// requires the detection of type changes during the comparison
+func (r Report) TypeChangesOnly() (result Report) {
+	return r.onlyKind(TYPECHANGE)
+}
+
+// IgnoreStyleChanges returns a new report without the style changes, which
+// requires the detection of style changes during the comparison
+func (r Report) IgnoreStyleChanges() (result Report) {
+	result = Report{
+		From: r.From,
+		To:   r.To,
+	}
+
+	for _, diff := range r.Diffs {
+		var details []Detail
+		for _, detail := range diff.Details {
+			if detail.Kind != STYLECHANGE {
+				details = append(details, detail)
+			}
+		}
+
+		if len(details) > 0 {
+			result.Diffs = append(result.Diffs, Diff{Path: diff.Path, Details: details})
+		}
+	}
+
+	return result
+}
+
+func (r Report) onlyKind(kind rune) (result Report) {
+	result = Report{
+		From: r.From,
+		To:   r.To,
+	}
+
+	for _, diff := range r.Diffs {
+		var details []Detail
+		for _, detail := range diff.Details {
+			if detail.Kind == kind {
+				details = append(details, detail)
+			}
+		}
+
+		if len(details) > 0 {
+			result.Diffs = append(result.Diffs, Diff{Path: diff.Path, Details: details})
+		}
+	}
+
+	return result
+}
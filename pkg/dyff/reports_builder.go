@@ -0,0 +1,168 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// NewDetail creates a detail of the given kind and validates that the values
+// fit the kind: Additions only have a `to` value, removals only have a `from`
+// value, order changes have both values as lists, and modifications have at
+// least one of the two values. For additions and removals, the value is the
+// map or list containing the added or removed entries, e.g. a list with
+// the entries that were added to a list.
+func NewDetail(kind rune, from *yamlv3.Node, to *yamlv3.Node) (Detail, error) {
+	detail := Detail{Kind: kind, From: from, To: to}
+	return detail, detail.validate()
+}
+
+func (detail Detail) validate() error {
+	switch detail.Kind {
+	case ADDITION:
+		if detail.From != nil || detail.To == nil {
+			return fmt.Errorf("an addition requires a to value, but no from value")
+		}
+
+	case REMOVAL:
+		if detail.From == nil || detail.To != nil {
+			return fmt.Errorf("a removal requires a from value, but no to value")
+		}
+
+	case MODIFICATION:
+		if detail.From == nil && detail.To == nil {
+			return fmt.Errorf("a modification requires at least a from or a to value")
+		}
+
+	case ORDERCHANGE:
+		if !isSequence(detail.From) || !isSequence(detail.To) {
+			return fmt.Errorf("an order change requires lists as from and to values")
+		}
+
+	case TYPECHANGE:
+		if detail.From == nil || detail.To == nil {
+			return fmt.Errorf("a type change requires a from and a to value")
+		}
+
+	case STYLECHANGE:
+		if detail.From == nil || detail.To == nil {
+			return fmt.Errorf("a style change requires a from and a to value")
+		}
+
+	case REPRESENTATIONCHANGE:
+		if detail.From == nil || detail.To == nil {
+			return fmt.Errorf("a representation change requires a from and a to value")
+		}
+
+	case RENAME:
+		if detail.From == nil || detail.To == nil {
+			return fmt.Errorf("a rename requires a from and a to value")
+		}
+
+	default:
+		return fmt.Errorf("unknown kind of difference %q", detail.Kind)
+	}
+
+	return nil
+}
+
+func isSequence(node *yamlv3.Node) bool {
+	return node != nil && followAlias(node).Kind == yamlv3.SequenceNode
+}
+
+// ReportBuilder creates a report from differences that were determined
+// elsewhere, so that the report writers can be used to render them
+type ReportBuilder struct {
+	from  *ytbx.InputFile
+	to    *ytbx.InputFile
+	diffs []Diff
+	errs  []error
+}
+
+// NewReportBuilder creates a report builder for differences between the
+// given input files, the documents of the input files are optional and only
+// used to check and resolve the paths of the differences
+func NewReportBuilder(from ytbx.InputFile, to ytbx.InputFile) *ReportBuilder {
+	return &ReportBuilder{from: &from, to: &to}
+}
+
+// AddDiff adds a difference with the given details at the path of the given
+// document (starting with zero). The path can be in dot-style or go-patch
+// style, with `/` referring to the document as a whole. Invalid paths or
+// details are reported by Build.
+func (builder *ReportBuilder) AddDiff(documentIdx int, pathString string, details ...Detail) *ReportBuilder {
+	path, err := builder.path(documentIdx, pathString)
+	if err != nil {
+		builder.errs = append(builder.errs, fmt.Errorf("invalid difference at %s: %w", pathString, err))
+		return builder
+	}
+
+	if len(details) == 0 {
+		builder.errs = append(builder.errs, fmt.Errorf("invalid difference at %s: at least one detail is required", pathString))
+		return builder
+	}
+
+	for _, detail := range details {
+		if err := detail.validate(); err != nil {
+			builder.errs = append(builder.errs, fmt.Errorf("invalid difference at %s: %w", pathString, err))
+			return builder
+		}
+	}
+
+	builder.diffs = append(builder.diffs, Diff{Path: &path, Details: details})
+	return builder
+}
+
+func (builder *ReportBuilder) path(documentIdx int, pathString string) (ytbx.Path, error) {
+	if documentIdx < 0 || (len(builder.from.Documents) > 0 && documentIdx >= len(builder.from.Documents)) {
+		return ytbx.Path{}, fmt.Errorf("there is no document with index %d", documentIdx)
+	}
+
+	var path ytbx.Path
+	var err error
+	if documentIdx < len(builder.from.Documents) {
+		path, err = ytbx.ParsePathString(pathString, builder.from.Documents[documentIdx])
+	} else {
+		path, err = ytbx.ParsePathStringUnsafe(pathString)
+	}
+
+	if err != nil {
+		return ytbx.Path{}, err
+	}
+
+	path.Root = builder.from
+	path.DocumentIdx = documentIdx
+	return path, nil
+}
+
+// Build returns the report with all differences that were added, or an error
+// if one of them was invalid
+func (builder *ReportBuilder) Build() (Report, error) {
+	if len(builder.errs) > 0 {
+		return Report{}, errors.Join(builder.errs...)
+	}
+
+	return Report{From: *builder.from, To: *builder.to, Diffs: builder.diffs}, nil
+}
@@ -0,0 +1,141 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ValueClass defines a class of value changes that are usually noise
+type ValueClass string
+
+// Supported value classes
+const (
+	// ValueClassNull are changes between different notations of null, e.g.
+	// `~` and `null`, or an empty value
+	ValueClassNull ValueClass = "null"
+
+	// ValueClassWhitespace are changes of strings that only differ in leading
+	// or trailing whitespace
+	ValueClassWhitespace ValueClass = "whitespace"
+
+	// ValueClassQuoting are changes of values with the same text, where only
+	// one of them is a quoted string, e.g. `"8080"` and `8080`
+	ValueClassQuoting ValueClass = "quoting"
+
+	// ValueClassComment are changes of multiline strings that only differ in
+	// comment lines, e.g. of an embedded script or configuration file
+	ValueClassComment ValueClass = "comment"
+)
+
+// ParseValueClass returns the value class for the given name
+func ParseValueClass(name string) (ValueClass, error) {
+	switch class := ValueClass(name); class {
+	case ValueClassNull, ValueClassWhitespace, ValueClassQuoting, ValueClassComment:
+		return class, nil
+	}
+
+	return "", fmt.Errorf("unknown value class %s, supported classes are: null, whitespace, quoting, or comment", name)
+}
+
+// ExcludeValueClasses returns a new report without the value changes that
+// belong to one of the given value classes
+func (r Report) ExcludeValueClasses(classes ...ValueClass) (result Report) {
+	if len(classes) == 0 {
+		return r
+	}
+
+	result = Report{
+		From: r.From,
+		To:   r.To,
+	}
+
+	for _, diff := range r.Diffs {
+		var details []Detail
+		for _, detail := range diff.Details {
+			if (detail.Kind != MODIFICATION && detail.Kind != TYPECHANGE && detail.Kind != STYLECHANGE && detail.Kind != REPRESENTATIONCHANGE) || !isOfValueClass(detail, classes) {
+				details = append(details, detail)
+			}
+		}
+
+		if len(details) > 0 {
+			result.Diffs = append(result.Diffs, Diff{Path: diff.Path, Details: details})
+		}
+	}
+
+	return result
+}
+
+func isOfValueClass(detail Detail, classes []ValueClass) bool {
+	var isNull = func(node *yamlv3.Node) bool {
+		return node == nil || (node.Kind == yamlv3.ScalarNode && node.Tag == "!!null")
+	}
+
+	var isString = func(node *yamlv3.Node) bool {
+		return node != nil && node.Kind == yamlv3.ScalarNode && node.Tag == "!!str"
+	}
+
+	var isQuoted = func(node *yamlv3.Node) bool {
+		return isString(node) && node.Style&(yamlv3.SingleQuotedStyle|yamlv3.DoubleQuotedStyle) != 0
+	}
+
+	for _, class := range classes {
+		switch class {
+		case ValueClassNull:
+			if isNull(detail.From) && isNull(detail.To) {
+				return true
+			}
+
+		case ValueClassWhitespace:
+			if isString(detail.From) && isString(detail.To) && isWhitespaceOnlyChange(detail.From.Value, detail.To.Value) {
+				return true
+			}
+
+		case ValueClassQuoting:
+			if detail.From != nil && detail.To != nil && detail.From.Value == detail.To.Value && isQuoted(detail.From) != isQuoted(detail.To) {
+				return true
+			}
+
+		case ValueClassComment:
+			if isString(detail.From) && isString(detail.To) && withoutCommentLines(detail.From.Value) == withoutCommentLines(detail.To.Value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// withoutCommentLines returns the text without the lines that only contain
+// a comment, i.e. lines starting with a hash character
+func withoutCommentLines(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
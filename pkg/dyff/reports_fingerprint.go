@@ -0,0 +1,63 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Fingerprint returns a stable identifier of the difference, which is based
+// on the document, the path, and the kinds and values of the details. It
+// does not depend on the location of the input files, the order of the
+// differences, or the formatting of the values, so that the same difference
+// has the same fingerprint in other runs, e.g. to suppress known differences.
+func (diff Diff) Fingerprint() string {
+	hash := sha256.New()
+
+	switch {
+	case diff.Path == nil:
+		_, _ = fmt.Fprintln(hash, "(file level)")
+
+	default:
+		// document names, e.g. of Kubernetes resources, are more stable than
+		// the index of the document, which is only used without a name
+		_, _ = fmt.Fprintln(hash, diff.Path.RootDescription())
+		_, _ = fmt.Fprintln(hash, diff.Path.String())
+	}
+
+	writeDetails(hash, diff.Details)
+
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// writeDetails writes the kinds and normalized values of the details, which
+// are the same for the same change regardless of the formatting
+func writeDetails(out io.Writer, details []Detail) {
+	for _, detail := range details {
+		from, _ := json.Marshal(serializeNode(detail.From))
+		to, _ := json.Marshal(serializeNode(detail.To))
+		_, _ = fmt.Fprintf(out, "%s:%s:%s\n", KindName(detail.Kind), from, to)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+)
+
+// MergeReports combines the reports of multiple pairs of input files into one
+// report, e.g. when the files of two directories are compared one by one. The
+// documents of the from and to input files are concatenated in the order of
+// the reports. The differences keep their paths, which means they still refer
+// to the original input file and document index they are from. To keep track
+// of the input files the differences are from, the names of the documents of
+// the combined and the original input files start with the location of their
+// input file, e.g. `deploy/app.yml: v1/ConfigMap/config`. Differences of input
+// files as a whole, e.g. the order of documents, have no path and are kept as
+// they are.
+func MergeReports(reports ...Report) Report {
+	var result Report
+	var fromLocations, toLocations []string
+	var named = map[*ytbx.InputFile]struct{}{}
+	for _, report := range reports {
+		appendDocuments(&result.From, report.From)
+		appendDocuments(&result.To, report.To)
+		fromLocations = appendLocation(fromLocations, report.From.Location)
+		toLocations = appendLocation(toLocations, report.To.Location)
+
+		for _, diff := range report.Diffs {
+			if diff.Path != nil && diff.Path.Root != nil {
+				if _, ok := named[diff.Path.Root]; !ok {
+					named[diff.Path.Root] = struct{}{}
+					diff.Path.Root.Names = documentNames(*diff.Path.Root)
+				}
+			}
+
+			result.Diffs = append(result.Diffs, diff)
+		}
+	}
+
+	result.From.Location = strings.Join(fromLocations, ", ")
+	result.To.Location = strings.Join(toLocations, ", ")
+	return result
+}
+
+// Merge returns a report with the differences of both reports, see
+// MergeReports, which should be used to merge more than two reports at once,
+// since the names of the documents of a merged report already start with the
+// location of their input file
+func (r Report) Merge(other Report) Report {
+	return MergeReports(r, other)
+}
+
+// appendDocuments appends the documents of the input file, which are named
+// after the location of the input file and their original name
+func appendDocuments(target *ytbx.InputFile, inputFile ytbx.InputFile) {
+	target.Documents = append(target.Documents, inputFile.Documents...)
+	target.Names = append(target.Names, documentNames(inputFile)...)
+}
+
+// documentNames returns the names of the documents of the input file, which
+// start with the location of the input file, unless they already do
+func documentNames(inputFile ytbx.InputFile) []string {
+	var names = make([]string, len(inputFile.Documents))
+	for idx := range inputFile.Documents {
+		var name string
+		if idx < len(inputFile.Names) {
+			name = inputFile.Names[idx]
+		}
+
+		switch {
+		case name == "" && len(inputFile.Documents) == 1:
+			name = inputFile.Location
+
+		case name == "":
+			name = fmt.Sprintf("%s: document #%d", inputFile.Location, idx+1)
+
+		case name == inputFile.Location, strings.HasPrefix(name, inputFile.Location+": "):
+			// already named after the location of the input file
+
+		default:
+			name = fmt.Sprintf("%s: %s", inputFile.Location, name)
+		}
+
+		names[idx] = name
+	}
+
+	return names
+}
+
+func appendLocation(locations []string, location string) []string {
+	for _, existing := range locations {
+		if existing == location {
+			return locations
+		}
+	}
+
+	return append(locations, location)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// CommonPath returns the deepest path that all differences have in common,
+// where each difference keeps at least the last element of its own path. It
+// returns nil if there is no such path below the root of the document.
+func (r Report) CommonPath() *ytbx.Path {
+	var common []ytbx.PathElement
+	for i, diff := range r.Diffs {
+		if diff.Path == nil || diff.Path.DocumentIdx != r.Diffs[0].Path.DocumentIdx {
+			return nil
+		}
+
+		elements := diff.Path.PathElements
+		if len(elements) > 0 {
+			elements = elements[:len(elements)-1]
+		}
+
+		if i == 0 {
+			common = elements
+			continue
+		}
+
+		var n int
+		for n < len(common) && n < len(elements) && common[n] == elements[n] {
+			n++
+		}
+
+		common = common[:n]
+	}
+
+	if len(common) == 0 {
+		return nil
+	}
+
+	return &ytbx.Path{
+		Root:         r.Diffs[0].Path.Root,
+		DocumentIdx:  r.Diffs[0].Path.DocumentIdx,
+		PathElements: common,
+	}
+}
+
+// AutoChangeRoot returns a new report with the root of both input files
+// changed to the common path of all differences, so that the paths of the
+// differences no longer repeat it. Like ChangeRoot, this is only possible if
+// both input files contain exactly one document, otherwise the report is
+// returned unchanged.
+func (r Report) AutoChangeRoot(useGoPatchPaths bool) Report {
+	if len(r.From.Documents) != 1 || len(r.To.Documents) != 1 {
+		return r
+	}
+
+	root := r.CommonPath()
+	if root == nil {
+		return r
+	}
+
+	fromNode, err := ytbx.Grab(r.From.Documents[0], root.String())
+	if err != nil {
+		return r
+	}
+
+	toNode, err := ytbx.Grab(r.To.Documents[0], root.String())
+	if err != nil {
+		return r
+	}
+
+	var from, to = r.From, r.To
+	from.Documents = []*yamlv3.Node{{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{fromNode}}}
+	to.Documents = []*yamlv3.Node{{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{toNode}}}
+
+	// The root could have been changed before, which the new root is relative to
+	var note = fmt.Sprintf("YAML root was changed to %s", pathToString(root, useGoPatchPaths, false))
+	if from.Note != "" {
+		note = fmt.Sprintf("%s and then to %s", from.Note, pathToString(root, useGoPatchPaths, false))
+	}
+
+	from.Note, to.Note = note, note
+
+	var diffs = make([]Diff, len(r.Diffs))
+	for i, diff := range r.Diffs {
+		diffs[i] = Diff{
+			Path: &ytbx.Path{
+				Root:         &from,
+				PathElements: diff.Path.PathElements[len(root.PathElements):],
+			},
+			Details: diff.Details,
+		}
+	}
+
+	return Report{From: from, To: to, Diffs: diffs}
+}
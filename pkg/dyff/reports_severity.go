@@ -0,0 +1,170 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Severity is the importance of a difference
+type Severity int
+
+// Supported severities, a difference without a matching rule is of info
+// severity
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+func (severity Severity) String() string {
+	switch severity {
+	case SeverityWarning:
+		return "warning"
+
+	case SeverityCritical:
+		return "critical"
+
+	default:
+		return "info"
+	}
+}
+
+// ParseSeverity returns the severity of the given name
+func ParseSeverity(name string) (Severity, error) {
+	for _, severity := range []Severity{SeverityInfo, SeverityWarning, SeverityCritical} {
+		if strings.EqualFold(name, severity.String()) {
+			return severity, nil
+		}
+	}
+
+	return SeverityInfo, fmt.Errorf("unknown severity %s, supported severities are: info, warning, or critical", name)
+}
+
+// SeverityRule assigns a severity to the differences with a path that matches
+// the pattern, and optionally with a detail of the given kind
+type SeverityRule struct {
+	Pattern  *regexp.Regexp
+	Kind     rune
+	Severity Severity
+}
+
+// SeverityRules is a list of rules, where the first matching rule defines
+// the severity of a difference
+type SeverityRules []SeverityRule
+
+// LoadSeverityRules reads severity rules from YAML, which is a list of rules
+// with a path regular expression, an optional kind, and the severity, e.g.
+// `[{path: ^/spec/replicas$, kind: modification, severity: critical}]`
+func LoadSeverityRules(data []byte) (SeverityRules, error) {
+	var config []struct {
+		Path     string `yaml:"path"`
+		Kind     string `yaml:"kind"`
+		Severity string `yaml:"severity"`
+	}
+
+	if err := yamlv3.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse severity rules: %w", err)
+	}
+
+	var result SeverityRules
+	for _, entry := range config {
+		pattern, err := regexp.Compile(entry.Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path pattern %s of severity rule: %w", entry.Path, err)
+		}
+
+		severity, err := ParseSeverity(entry.Severity)
+		if err != nil {
+			return nil, err
+		}
+
+		var kind rune
+		if entry.Kind != "" {
+			if kind, err = ParseKindName(entry.Kind); err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, SeverityRule{Pattern: pattern, Kind: kind, Severity: severity})
+	}
+
+	return result, nil
+}
+
+// Severity returns the severity of the difference according to the first
+// matching rule, or info severity if no rule matches
+func (rules SeverityRules) Severity(diff Diff) Severity {
+	var path string
+	if diff.Path != nil {
+		path = diff.Path.String()
+	}
+
+	for _, rule := range rules {
+		if rule.Pattern.MatchString(path) && rule.matchesKind(diff) {
+			return rule.Severity
+		}
+	}
+
+	return SeverityInfo
+}
+
+// Highest returns the highest severity of all differences of the report
+func (rules SeverityRules) Highest(report Report) Severity {
+	var result = SeverityInfo
+	for _, diff := range report.Diffs {
+		if severity := rules.Severity(diff); severity > result {
+			result = severity
+		}
+	}
+
+	return result
+}
+
+func (rule SeverityRule) matchesKind(diff Diff) bool {
+	if rule.Kind == 0 {
+		return true
+	}
+
+	for _, detail := range diff.Details {
+		if detail.Kind == rule.Kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseKindName returns the kind of difference of the name that is used in
+// the JSON report, e.g. `addition`
+func ParseKindName(name string) (rune, error) {
+	for _, kind := range []rune{ADDITION, REMOVAL, MODIFICATION, ORDERCHANGE, TYPECHANGE, STYLECHANGE, REPRESENTATIONCHANGE, RENAME} {
+		if strings.EqualFold(name, KindName(kind)) {
+			return kind, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown kind of difference %s, supported kinds are: addition, removal, modification, order-change, type-change, style-change, representation-change, or rename", name)
+}
@@ -0,0 +1,62 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import "slices"
+
+// ReportSummary is the number of differences of a report, and the number of
+// details of these differences per kind of difference
+type ReportSummary struct {
+	Differences int
+	Kinds       map[rune]int
+}
+
+// Summary returns the number of differences and their details per kind
+func (r Report) Summary() ReportSummary {
+	var summary = ReportSummary{
+		Differences: len(r.Diffs),
+		Kinds:       map[rune]int{},
+	}
+
+	for _, diff := range r.Diffs {
+		for _, detail := range diff.Details {
+			summary.Kinds[detail.Kind]++
+		}
+	}
+
+	return summary
+}
+
+// Count returns the number of details of the given kind
+func (s ReportSummary) Count(kind rune) int {
+	return s.Kinds[kind]
+}
+
+// OnlyKinds returns whether there are no details other than of the given kinds
+func (s ReportSummary) OnlyKinds(kinds ...rune) bool {
+	for kind, n := range s.Kinds {
+		if n > 0 && !slices.Contains(kinds, kind) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,248 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// IncludeTag is the YAML tag that marks a string as the location of a file,
+// which content is supposed to be included in place of the string
+const IncludeTag = "!include"
+
+type resolver struct {
+	files map[string]*yamlv3.Node
+	stack []string
+}
+
+// ResolveReferences replaces JSON references (`$ref`) and `!include` tags in
+// all documents of the input file with the content they refer to. References
+// can point into the same document (`#/components/schemas/Pet`), or to other
+// files (`pet.yaml#/Pet`), which are looked up relative to the input file.
+// Recursive references are kept as they are, since they cannot be resolved.
+func ResolveReferences(inputFile *ytbx.InputFile) error {
+	r := &resolver{files: map[string]*yamlv3.Node{}}
+
+	for _, document := range inputFile.Documents {
+		if err := r.resolve(document, inputFile.Location, document); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *resolver) resolve(node *yamlv3.Node, location string, document *yamlv3.Node) error {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		if ref, ok := referenceOf(node); ok {
+			return r.replace(node, location, document, ref)
+		}
+
+	case yamlv3.ScalarNode:
+		if node.Tag == IncludeTag {
+			return r.replace(node, location, document, node.Value)
+		}
+	}
+
+	for _, child := range node.Content {
+		if err := r.resolve(child, location, document); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replace overwrites the node with a resolved copy of the referenced node
+func (r *resolver) replace(node *yamlv3.Node, location string, document *yamlv3.Node, ref string) error {
+	fileRef, pointer, _ := strings.Cut(ref, "#")
+
+	if fileRef != "" {
+		var err error
+		location = relativeLocation(location, fileRef)
+		if document, err = r.load(location); err != nil {
+			return fmt.Errorf("failed to resolve reference %s: %w", ref, err)
+		}
+	}
+
+	var id = location + "#" + pointer
+	for _, entry := range r.stack {
+		if entry == id {
+			return nil
+		}
+	}
+
+	target, err := lookupPointer(document, pointer)
+	if err != nil {
+		return fmt.Errorf("failed to resolve reference %s: %w", ref, err)
+	}
+
+	result := copyNode(target)
+
+	r.stack = append(r.stack, id)
+	defer func() { r.stack = r.stack[:len(r.stack)-1] }()
+
+	if err := r.resolve(result, location, document); err != nil {
+		return err
+	}
+
+	// Keys next to the reference take precedence over the referenced keys
+	if node.Kind == yamlv3.MappingNode && result.Kind == yamlv3.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			if key := node.Content[i].Value; key != "$ref" {
+				setMappingValue(result, node.Content[i], node.Content[i+1])
+			}
+		}
+	}
+
+	*node = *result
+	return nil
+}
+
+func (r *resolver) load(location string) (*yamlv3.Node, error) {
+	if document, ok := r.files[location]; ok {
+		return document, nil
+	}
+
+	inputFile, err := LoadFile(location)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputFile.Documents) != 1 {
+		return nil, fmt.Errorf("%s contains %d documents, but only files with one document can be referenced", location, len(inputFile.Documents))
+	}
+
+	r.files[location] = inputFile.Documents[0]
+	return inputFile.Documents[0], nil
+}
+
+func referenceOf(node *yamlv3.Node) (string, bool) {
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value == "$ref" && value.Kind == yamlv3.ScalarNode {
+			return value.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// relativeLocation returns the location of the referenced file, which is
+// relative to the location of the referencing file unless it is absolute
+func relativeLocation(base string, ref string) string {
+	if _, err := url.ParseRequestURI(ref); err == nil && strings.Contains(ref, "://") {
+		return ref
+	}
+
+	if baseURL, err := url.ParseRequestURI(base); err == nil && strings.Contains(base, "://") {
+		if refURL, err := url.Parse(ref); err == nil {
+			return baseURL.ResolveReference(refURL).String()
+		}
+	}
+
+	if filepath.IsAbs(ref) || ytbx.IsStdin(base) {
+		return ref
+	}
+
+	return filepath.Join(filepath.Dir(base), ref)
+}
+
+// lookupPointer returns the node the JSON pointer (RFC 6901) refers to
+func lookupPointer(document *yamlv3.Node, pointer string) (*yamlv3.Node, error) {
+	node := document
+	if node.Kind == yamlv3.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil, fmt.Errorf("no content found in document")
+		}
+
+		node = node.Content[0]
+	}
+
+	if pointer == "" || pointer == "/" {
+		return node, nil
+	}
+
+	var unescape = strings.NewReplacer("~1", "/", "~0", "~")
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = unescape.Replace(token)
+		if decoded, err := url.PathUnescape(token); err == nil {
+			token = decoded
+		}
+
+		node = followAlias(node)
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			value, ok := findValueByKey(node, token)
+			if !ok {
+				return nil, fmt.Errorf("no key '%s' found in map", token)
+			}
+
+			node = value
+
+		case yamlv3.SequenceNode:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(node.Content) {
+				return nil, fmt.Errorf("no entry with index '%s' found in list", token)
+			}
+
+			node = node.Content[idx]
+
+		default:
+			return nil, fmt.Errorf("cannot look up '%s' in a scalar value", token)
+		}
+	}
+
+	return node, nil
+}
+
+func setMappingValue(mapping *yamlv3.Node, key *yamlv3.Node, value *yamlv3.Node) {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key.Value {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content, key, value)
+}
+
+func copyNode(node *yamlv3.Node) *yamlv3.Node {
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		return copyNode(node.Content[0])
+	}
+
+	result := *node
+	result.Content = make([]*yamlv3.Node, len(node.Content))
+	for i, child := range node.Content {
+		result.Content[i] = copyNode(child)
+	}
+
+	return &result
+}
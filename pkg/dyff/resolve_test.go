@@ -0,0 +1,146 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+type emptyLoader struct{}
+
+func (emptyLoader) Supports(location string) bool {
+	return strings.HasPrefix(location, "empty://")
+}
+
+func (emptyLoader) Load(location string) (ytbx.InputFile, error) {
+	return ytbx.InputFile{Location: location, Documents: []*yamlv3.Node{{Kind: yamlv3.DocumentNode}}}, nil
+}
+
+var _ = Describe("Resolve references", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "dyff-resolve")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+		return path
+	}
+
+	resolve := func(location string) string {
+		inputFile, err := ytbx.LoadFile(location)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dyff.ResolveReferences(&inputFile)).To(Succeed())
+
+		data, err := yamlv3.Marshal(inputFile.Documents[0])
+		Expect(err).ToNot(HaveOccurred())
+		return string(data)
+	}
+
+	It("should resolve references within the same document", func() {
+		Expect(resolve(writeFile("spec.yml", `
+paths:
+  /pets:
+    schema: {$ref: "#/components/schemas/Pet"}
+components:
+  schemas:
+    Pet: {type: object}
+`))).To(Equal(`paths:
+    /pets:
+        schema: {type: object}
+components:
+    schemas:
+        Pet: {type: object}
+`))
+	})
+
+	It("should resolve references to other files and include tags", func() {
+		writeFile("pet.yml", `
+Pet:
+  type: object
+  properties: {name: {$ref: "#/Name"}}
+Name: {type: string}
+`)
+		writeFile("tags.yml", `- a
+- b
+`)
+
+		Expect(resolve(writeFile("spec.yml", `
+schema:
+  $ref: pet.yml#/Pet
+  description: a pet
+tags: !include tags.yml
+`))).To(Equal(`schema:
+    type: object
+    properties: {name: {type: string}}
+    description: a pet
+tags:
+    - a
+    - b
+`))
+	})
+
+	It("should keep recursive references as they are", func() {
+		Expect(resolve(writeFile("spec.yml", `
+Node:
+  children: {items: {$ref: "#/Node"}}
+`))).To(Equal(`Node:
+    children: {items: {children: {items: {$ref: "#/Node"}}}}
+`))
+	})
+
+	It("should fail when the referenced path does not exist", func() {
+		inputFile, err := ytbx.LoadFile(writeFile("spec.yml", `schema: {$ref: "#/missing"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dyff.ResolveReferences(&inputFile)).To(MatchError("failed to resolve reference #/missing: no key 'missing' found in map"))
+	})
+
+	It("should fail when the referenced document is empty", func() {
+		dyff.RegisterInputLoader(emptyLoader{})
+		DeferCleanup(dyff.ResetInputLoaders)
+
+		inputFile, err := ytbx.LoadFile(writeFile("spec.yml", `schema: {$ref: "empty://pet#/Pet"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dyff.ResolveReferences(&inputFile)).To(MatchError("failed to resolve reference empty://pet#/Pet: no content found in document"))
+
+		inputFile, err = ytbx.LoadFile(writeFile("spec.yml", `schema: !include empty://pet`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dyff.ResolveReferences(&inputFile)).To(MatchError("failed to resolve reference empty://pet: no content found in document"))
+	})
+})
@@ -0,0 +1,105 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// SOPSCommand is the command that is used to decrypt SOPS encrypted documents
+var SOPSCommand = "sops"
+
+// IsSOPSEncrypted returns whether the document was encrypted with SOPS, which
+// is the case if it contains the `sops` metadata with a message authentication
+// code on the top level
+func IsSOPSEncrypted(document *yamlv3.Node) bool {
+	node := document
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	if node.Kind != yamlv3.MappingNode {
+		return false
+	}
+
+	metadata, ok := findValueByKey(node, "sops")
+	if !ok || metadata.Kind != yamlv3.MappingNode {
+		return false
+	}
+
+	_, ok = findValueByKey(metadata, "mac")
+	return ok
+}
+
+// DecryptSOPS replaces all SOPS encrypted documents of the input file with
+// their decrypted content. The decryption is done in memory by the `sops`
+// command, so that all key services configured for it can be used, e.g. age,
+// PGP, or cloud key management services.
+func DecryptSOPS(inputFile *ytbx.InputFile) error {
+	for idx, document := range inputFile.Documents {
+		if !IsSOPSEncrypted(document) {
+			continue
+		}
+
+		decrypted, err := decryptSOPS(document)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt document #%d of %s: %w", idx+1, inputFile.Location, err)
+		}
+
+		inputFile.Documents[idx] = decrypted
+	}
+
+	return nil
+}
+
+func decryptSOPS(document *yamlv3.Node) (*yamlv3.Node, error) {
+	input, err := yamlv3.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(SOPSCommand, "--decrypt", "--input-type", "yaml", "--output-type", "yaml", "/dev/stdin")
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", SOPSCommand, err, msg)
+		}
+
+		return nil, fmt.Errorf("%s failed: %w", SOPSCommand, err)
+	}
+
+	var decrypted yamlv3.Node
+	if err := yamlv3.Unmarshal(stdout.Bytes(), &decrypted); err != nil {
+		return nil, fmt.Errorf("%s returned invalid data: %w", SOPSCommand, err)
+	}
+
+	return &decrypted, nil
+}
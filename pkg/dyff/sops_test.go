@@ -0,0 +1,79 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/gonvenience/ytbx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("SOPS encrypted input", func() {
+	var encrypted = `---
+password: ENC[AES256_GCM,data:b3Bh,iv:aXY=,tag:dGFn,type:str]
+sops:
+  mac: ENC[AES256_GCM,data:bWFj,iv:aXY=,tag:dGFn,type:str]
+  version: 3.9.0
+`
+
+	fakeSOPS := func(script string) {
+		location := filepath.Join(GinkgoT().TempDir(), "sops")
+		Expect(os.WriteFile(location, []byte("#!/bin/sh\n"+script+"\n"), 0755)).To(Succeed())
+
+		original := dyff.SOPSCommand
+		dyff.SOPSCommand = location
+		DeferCleanup(func() { dyff.SOPSCommand = original })
+	}
+
+	It("should detect SOPS encrypted documents", func() {
+		Expect(dyff.IsSOPSEncrypted(multiDoc(encrypted)[0])).To(BeTrue())
+		Expect(dyff.IsSOPSEncrypted(multiDoc(`{"sops": "not metadata"}`)[0])).To(BeFalse())
+		Expect(dyff.IsSOPSEncrypted(multiDoc(`["sops"]`)[0])).To(BeFalse())
+	})
+
+	It("should replace encrypted documents with the decrypted content", func() {
+		fakeSOPS(`echo "password: secret"`)
+
+		inputFile := ytbx.InputFile{Location: "secrets.yml", Documents: multiDoc(encrypted, `{"plain": "text"}`)}
+		Expect(dyff.DecryptSOPS(&inputFile)).To(Succeed())
+
+		result, err := compare(inputFile.Documents[0].Content[0], yml(`{"password": "secret"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeEmpty())
+
+		result, err = compare(inputFile.Documents[1].Content[0], yml(`{"plain": "text"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeEmpty())
+	})
+
+	It("should fail if the document cannot be decrypted", func() {
+		fakeSOPS(`echo "no key found" >&2; exit 128`)
+
+		inputFile := ytbx.InputFile{Location: "secrets.yml", Documents: multiDoc(encrypted)}
+		Expect(dyff.DecryptSOPS(&inputFile)).To(MatchError(ContainSubstring("failed to decrypt document #1 of secrets.yml")))
+		Expect(dyff.DecryptSOPS(&inputFile)).To(MatchError(ContainSubstring("exit status 128: no key found")))
+	})
+})
@@ -0,0 +1,448 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var spruceOperatorRegexp = regexp.MustCompile(`^\(\(\s*([a-z][a-z0-9_-]*)(?:\s+(.*?))?\s*\)\)$`)
+
+// spruceOperators are the operators known to spruce, strings that look like
+// operators but use other names are not touched
+var spruceOperators = map[string]struct{}{
+	"awsparam":          {},
+	"awssecret":         {},
+	"base64":            {},
+	"base64-decode":     {},
+	"calc":              {},
+	"cartesian-product": {},
+	"concat":            {},
+	"defer":             {},
+	"empty":             {},
+	"file":              {},
+	"grab":              {},
+	"inject":            {},
+	"ips":               {},
+	"join":              {},
+	"keys":              {},
+	"load":              {},
+	"negate":            {},
+	"param":             {},
+	"prune":             {},
+	"shuffle":           {},
+	"sort":              {},
+	"static_ips":        {},
+	"stringify":         {},
+	"vault":             {},
+	"vault-try":         {},
+}
+
+// maxSpruceOperatorDepth limits how many operators are evaluated to get one
+// value, which only happens for operators referring to each other in a loop
+const maxSpruceOperatorDepth = 64
+
+// IsSpruceOperator returns whether the value is a spruce operator expression
+// like `(( grab meta.name ))`, and if so, the name of the operator
+func IsSpruceOperator(value string) (string, bool) {
+	match := spruceOperatorRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return "", false
+	}
+
+	if _, ok := spruceOperators[match[1]]; !ok {
+		return "", false
+	}
+
+	return match[1], true
+}
+
+// NormalizeSpruceOperators rewrites all spruce operator expressions in the
+// documents of the input file to a canonical spelling, so that operators are
+// compared symbolically without differences in whitespace
+func NormalizeSpruceOperators(inputFile *ytbx.InputFile) {
+	for _, document := range inputFile.Documents {
+		walkScalars(document, func(node *yamlv3.Node) {
+			if expr, err := parseSpruceOperator(node.Value); err == nil {
+				node.Value = expr.String()
+			}
+		})
+	}
+}
+
+// EvaluateSpruceOperators replaces the spruce operators `grab`, `concat`,
+// `join`, and `empty` in the documents of the input file with their results,
+// including alternatives with `||`, and removes values marked with `prune`.
+// References are looked up in the same document. Other operators depend on
+// external systems or on the merge of multiple files, these are kept in
+// their canonical spelling, see NormalizeSpruceOperators.
+func EvaluateSpruceOperators(inputFile *ytbx.InputFile) error {
+	for _, document := range inputFile.Documents {
+		evaluator := &spruceEvaluator{root: document}
+		if err := evaluator.walk(document); err != nil {
+			return fmt.Errorf("failed to evaluate spruce operators in %s: %w", inputFile.Location, err)
+		}
+	}
+
+	return nil
+}
+
+func walkScalars(node *yamlv3.Node, f func(node *yamlv3.Node)) {
+	if node.Kind == yamlv3.ScalarNode {
+		f(node)
+		return
+	}
+
+	for _, child := range node.Content {
+		walkScalars(child, f)
+	}
+}
+
+// spruceExpression is a parsed spruce operator, each argument is a list of
+// alternatives separated by `||`
+type spruceExpression struct {
+	operator string
+	args     [][]spruceToken
+}
+
+type spruceToken struct {
+	value  string
+	quoted bool
+}
+
+func (t spruceToken) String() string {
+	if t.quoted {
+		return strconv.Quote(t.value)
+	}
+
+	return t.value
+}
+
+func (e spruceExpression) String() string {
+	var parts = []string{e.operator}
+	for _, alternatives := range e.args {
+		var tokens []string
+		for _, token := range alternatives {
+			tokens = append(tokens, token.String())
+		}
+
+		parts = append(parts, strings.Join(tokens, " || "))
+	}
+
+	return "(( " + strings.Join(parts, " ") + " ))"
+}
+
+func parseSpruceOperator(value string) (spruceExpression, error) {
+	operator, ok := IsSpruceOperator(value)
+	if !ok {
+		return spruceExpression{}, fmt.Errorf("%s is not a spruce operator", value)
+	}
+
+	tokens, err := tokenizeSpruceArguments(spruceOperatorRegexp.FindStringSubmatch(value)[2])
+	if err != nil {
+		return spruceExpression{}, fmt.Errorf("invalid arguments in %s: %w", value, err)
+	}
+
+	var expr = spruceExpression{operator: operator}
+	var alternatives []spruceToken
+	var or bool
+	for _, token := range tokens {
+		switch {
+		case !token.quoted && token.value == "||":
+			if len(alternatives) == 0 || or {
+				return spruceExpression{}, fmt.Errorf("invalid arguments in %s: missing value for ||", value)
+			}
+
+			or = true
+
+		case or:
+			alternatives = append(alternatives, token)
+			or = false
+
+		default:
+			if len(alternatives) > 0 {
+				expr.args = append(expr.args, alternatives)
+			}
+
+			alternatives = []spruceToken{token}
+		}
+	}
+
+	if or {
+		return spruceExpression{}, fmt.Errorf("invalid arguments in %s: missing value for ||", value)
+	}
+
+	if len(alternatives) > 0 {
+		expr.args = append(expr.args, alternatives)
+	}
+
+	return expr, nil
+}
+
+func tokenizeSpruceArguments(input string) ([]spruceToken, error) {
+	var tokens []spruceToken
+	var runes = []rune(input)
+
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == ' ' || runes[i] == '\t':
+			continue
+
+		case runes[i] == '"':
+			var value strings.Builder
+			var closed bool
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					value.WriteRune(runes[i])
+					continue
+				}
+
+				if runes[i] == '"' {
+					closed = true
+					break
+				}
+
+				value.WriteRune(runes[i])
+			}
+
+			if !closed {
+				return nil, fmt.Errorf("unterminated string")
+			}
+
+			tokens = append(tokens, spruceToken{value: value.String(), quoted: true})
+
+		default:
+			start := i
+			for i < len(runes) && runes[i] != ' ' && runes[i] != '\t' && runes[i] != '"' {
+				i++
+			}
+
+			tokens = append(tokens, spruceToken{value: string(runes[start:i])})
+			i--
+		}
+	}
+
+	return tokens, nil
+}
+
+type spruceEvaluator struct {
+	root  *yamlv3.Node
+	depth int
+}
+
+func (e *spruceEvaluator) walk(node *yamlv3.Node) error {
+	switch node.Kind {
+	case yamlv3.ScalarNode:
+		value, err := e.evaluate(node)
+		if err != nil {
+			return err
+		}
+
+		*node = *value
+
+	case yamlv3.MappingNode:
+		var content []*yamlv3.Node
+		for i := 0; i < len(node.Content); i += 2 {
+			if operator, ok := IsSpruceOperator(node.Content[i+1].Value); ok && operator == "prune" {
+				continue
+			}
+
+			if err := e.walk(node.Content[i+1]); err != nil {
+				return err
+			}
+
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+
+		node.Content = content
+
+	default:
+		for _, child := range node.Content {
+			if err := e.walk(child); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// evaluate returns the result of the operator in the node, or the node itself
+// if it is no operator or an operator that cannot be evaluated
+func (e *spruceEvaluator) evaluate(node *yamlv3.Node) (*yamlv3.Node, error) {
+	if node.Kind != yamlv3.ScalarNode {
+		return node, nil
+	}
+
+	expr, err := parseSpruceOperator(node.Value)
+	if err != nil {
+		return node, nil
+	}
+
+	if e.depth++; e.depth > maxSpruceOperatorDepth {
+		return nil, fmt.Errorf("too many nested operators evaluating %s, operators probably refer to each other", node.Value)
+	}
+	defer func() { e.depth-- }()
+
+	switch expr.operator {
+	case "empty":
+		return spruceEmpty(expr)
+
+	case "grab", "concat", "join":
+		// Operators with arguments that can refer to other values
+
+	default:
+		return stringNode(expr.String()), nil
+	}
+
+	var args []*yamlv3.Node
+	for _, alternatives := range expr.args {
+		arg, err := e.argument(alternatives)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate %s: %w", node.Value, err)
+		}
+
+		args = append(args, arg)
+	}
+
+	switch expr.operator {
+	case "concat":
+		var result strings.Builder
+		for _, arg := range args {
+			if arg.Kind != yamlv3.ScalarNode {
+				return nil, fmt.Errorf("failed to evaluate %s: only scalar values can be concatenated", node.Value)
+			}
+
+			result.WriteString(arg.Value)
+		}
+
+		return stringNode(result.String()), nil
+
+	case "join":
+		if len(args) == 0 || args[0].Kind != yamlv3.ScalarNode {
+			return nil, fmt.Errorf("failed to evaluate %s: the first argument has to be the separator", node.Value)
+		}
+
+		var values []string
+		for _, arg := range flattenSpruceArguments(args[1:]) {
+			if arg.Kind != yamlv3.ScalarNode {
+				return nil, fmt.Errorf("failed to evaluate %s: only scalar values can be joined", node.Value)
+			}
+
+			values = append(values, arg.Value)
+		}
+
+		return stringNode(strings.Join(values, args[0].Value)), nil
+
+	default:
+		return spruceGrab(args), nil
+	}
+}
+
+func spruceEmpty(expr spruceExpression) (*yamlv3.Node, error) {
+	if len(expr.args) != 1 {
+		return nil, fmt.Errorf("failed to evaluate %s: expected one type argument", expr)
+	}
+
+	switch expr.args[0][0].value {
+	case "hash", "map":
+		return &yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}, nil
+
+	case "array", "list":
+		return &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq"}, nil
+
+	case "string":
+		return stringNode(""), nil
+
+	default:
+		return nil, fmt.Errorf("failed to evaluate %s: unknown type %s", expr, expr.args[0][0].value)
+	}
+}
+
+// argument returns the value of the first alternative that can be resolved
+func (e *spruceEvaluator) argument(alternatives []spruceToken) (*yamlv3.Node, error) {
+	var err error
+	for _, token := range alternatives {
+		var value *yamlv3.Node
+		if value, err = e.token(token); err == nil {
+			return value, nil
+		}
+	}
+
+	return nil, err
+}
+
+func (e *spruceEvaluator) token(token spruceToken) (*yamlv3.Node, error) {
+	if token.quoted {
+		return stringNode(token.value), nil
+	}
+
+	switch token.value {
+	case "nil", "null", "~":
+		return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!null", Value: "~"}, nil
+	}
+
+	if _, err := strconv.ParseFloat(token.value, 64); err == nil {
+		var literal yamlv3.Node
+		if err := yamlv3.Unmarshal([]byte(token.value), &literal); err == nil && len(literal.Content) > 0 {
+			return literal.Content[0], nil
+		}
+	}
+
+	value, err := ytbx.Grab(e.root, token.value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve reference %s: %w", token.value, err)
+	}
+
+	return e.evaluate(value)
+}
+
+// spruceGrab returns a single value as it is, and multiple values as a list
+func spruceGrab(args []*yamlv3.Node) *yamlv3.Node {
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	return &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq", Content: flattenSpruceArguments(args)}
+}
+
+// flattenSpruceArguments returns the values of all arguments, where the
+// entries of lists are used instead of the lists themselves
+func flattenSpruceArguments(args []*yamlv3.Node) []*yamlv3.Node {
+	var result []*yamlv3.Node
+	for _, arg := range args {
+		if arg.Kind == yamlv3.SequenceNode {
+			result = append(result, arg.Content...)
+			continue
+		}
+
+		result = append(result, arg)
+	}
+
+	return result
+}
@@ -0,0 +1,105 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"github.com/gonvenience/ytbx"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("Spruce operators", func() {
+	expectSame := func(actual *yamlv3.Node, expected string) {
+		result, err := compare(actual, yml(expected))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(BeEmpty())
+	}
+
+	evaluate := func(input string) (*yamlv3.Node, error) {
+		inputFile := ytbx.InputFile{Location: "input", Documents: multiDoc(input)}
+		if err := dyff.EvaluateSpruceOperators(&inputFile); err != nil {
+			return nil, err
+		}
+
+		return inputFile.Documents[0].Content[0], nil
+	}
+
+	It("should recognize known operators only", func() {
+		operator, ok := dyff.IsSpruceOperator("(( grab meta.name ))")
+		Expect(ok).To(BeTrue())
+		Expect(operator).To(Equal("grab"))
+
+		_, ok = dyff.IsSpruceOperator("((placeholder))")
+		Expect(ok).To(BeFalse())
+
+		_, ok = dyff.IsSpruceOperator("(( unknown meta.name ))")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should normalize the spelling of operators for a symbolic comparison", func() {
+		inputFile := ytbx.InputFile{Documents: multiDoc(`{"a": "((grab   meta.name))", "b": "((  concat \"x\"  meta.y ||  \"z\" ))"}`)}
+		dyff.NormalizeSpruceOperators(&inputFile)
+		expectSame(inputFile.Documents[0].Content[0], `{"a": "(( grab meta.name ))", "b": "(( concat \"x\" meta.y || \"z\" ))"}`)
+	})
+
+	It("should evaluate grab, concat, join, empty, and prune", func() {
+		result, err := evaluate(`---
+meta:
+  name: app
+  zones: [z1, z2]
+  secret: (( prune ))
+name: (( grab meta.name ))
+url: (( concat "https://" meta.name ".example.com" ))
+zones: (( join ", " meta.zones "z3" ))
+fallback: (( grab meta.unknown || "default" ))
+chained: (( grab name ))
+labels: (( empty hash ))
+other: (( vault "secret/app:password" ))
+`)
+
+		Expect(err).ToNot(HaveOccurred())
+		expectSame(result, `---
+meta:
+  name: app
+  zones: [z1, z2]
+name: app
+url: https://app.example.com
+zones: z1, z2, z3
+fallback: default
+chained: app
+labels: {}
+other: (( vault "secret/app:password" ))
+`)
+	})
+
+	It("should fail for references that cannot be resolved", func() {
+		_, err := evaluate(`{"name": "(( grab meta.unknown ))"}`)
+		Expect(err).To(MatchError(ContainSubstring("failed to resolve reference meta.unknown")))
+	})
+
+	It("should fail for operators that refer to each other", func() {
+		_, err := evaluate(`{"a": "(( grab b ))", "b": "(( grab a ))"}`)
+		Expect(err).To(MatchError(ContainSubstring("too many nested operators")))
+	})
+})
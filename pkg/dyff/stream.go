@@ -0,0 +1,260 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// CompareStreams compares the documents of two multi-document inputs, where
+// only one document of each input is decoded at a time. Each difference is
+// passed to the handler as soon as it is found, which means the inputs never
+// have to be loaded completely into memory. Kubernetes resources are matched
+// by their identity (API version, kind, namespace, and name), so only
+// resources that have no match yet are kept in memory until their match is
+// read. All other documents are compared by their order. Documents that only
+// exist in one of the inputs are reported as additions or removals once both
+// inputs are read completely.
+func CompareStreams(fromLocation string, fromReader io.Reader, toLocation string, toReader io.Reader, handler func(Diff) error, compareOptions ...CompareOption) error {
+	return CompareStreamsContext(context.Background(), fromLocation, fromReader, toLocation, toReader, handler, compareOptions...)
+}
+
+// CompareStreamsContext is like CompareStreams, but stops the comparison with
+// the error of the context as soon as the context is done.
+func CompareStreamsContext(ctx context.Context, fromLocation string, fromReader io.Reader, toLocation string, toReader io.Reader, handler func(Diff) error, compareOptions ...CompareOption) error {
+	var (
+		cmpr        = newCompare(ctx, compareOptions...)
+		from        = &ytbx.InputFile{Location: fromLocation}
+		to          = &ytbx.InputFile{Location: toLocation}
+		fromDecoder = yamlv3.NewDecoder(fromReader)
+		toDecoder   = yamlv3.NewDecoder(toReader)
+		fromPending = newPendingDocuments()
+		toPending   = newPendingDocuments()
+	)
+
+	var emit = func(diffs []Diff) error {
+		for _, diff := range cmpr.differences(diffs) {
+			if err := handler(diff); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	var compareDocuments = func(fromDocument streamDocument, toDocument streamDocument) error {
+		cmpr.resolvePaths([]*yamlv3.Node{fromDocument.node}, []*yamlv3.Node{toDocument.node})
+		diffs, err := cmpr.objects(ytbx.Path{Root: from, DocumentIdx: fromDocument.idx}, fromDocument.node, toDocument.node)
+		if err != nil {
+			return err
+		}
+
+		// Cached hashes are only useful for the documents just compared
+		cmpr.hashes.Clear()
+		return emit(diffs)
+	}
+
+	var fromDone, toDone bool
+	for idx := 0; !fromDone || !toDone; idx++ {
+		var fromDocument, toDocument *yamlv3.Node
+		var err error
+		if !fromDone {
+			if fromDocument, err = nextDocument(fromDecoder, fromLocation, idx); err != nil {
+				return err
+			}
+
+			fromDone = fromDocument == nil
+		}
+
+		if !toDone {
+			if toDocument, err = nextDocument(toDecoder, toLocation, idx); err != nil {
+				return err
+			}
+
+			toDone = toDocument == nil
+		}
+
+		if fromDocument != nil {
+			document := streamDocument{idx: idx, node: fromDocument, name: cmpr.resourceName(fromDocument)}
+			setDocumentName(from, document)
+			if match, ok := toPending.take(document.name); ok {
+				if err := compareDocuments(document, match); err != nil {
+					return err
+				}
+			} else {
+				fromPending.add(document)
+			}
+		}
+
+		if toDocument != nil {
+			document := streamDocument{idx: idx, node: toDocument, name: cmpr.resourceName(toDocument)}
+			setDocumentName(to, document)
+			if match, ok := fromPending.take(document.name); ok {
+				if err := compareDocuments(match, document); err != nil {
+					return err
+				}
+			} else {
+				toPending.add(document)
+			}
+		}
+
+		if cmpr.settings.ProgressHandler != nil && (fromDocument != nil || toDocument != nil) {
+			cmpr.settings.ProgressHandler(Progress{Documents: idx + 1, Path: &ytbx.Path{Root: from, DocumentIdx: idx}})
+		}
+	}
+
+	for _, document := range fromPending.remaining() {
+		if err := emit([]Diff{{
+			Path:    &ytbx.Path{Root: from, DocumentIdx: document.idx},
+			Details: []Detail{{Kind: REMOVAL, From: document.node}},
+		}}); err != nil {
+			return err
+		}
+	}
+
+	for _, document := range toPending.remaining() {
+		if err := emit([]Diff{{
+			Path:    &ytbx.Path{Root: to, DocumentIdx: document.idx},
+			Details: []Detail{{Kind: ADDITION, To: document.node}},
+		}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// streamDocument is a document read from a stream with its index, and its
+// name if it is a Kubernetes resource
+type streamDocument struct {
+	idx  int
+	node *yamlv3.Node
+	name string
+}
+
+// resourceName returns the name of the document if it is a Kubernetes
+// resource and Kubernetes resources are matched by their name, or an empty
+// string otherwise
+func (compare *compare) resourceName(document *yamlv3.Node) string {
+	if !compare.settings.KubernetesEntityDetection || isEmptyDocument(document) || len(document.Content) == 0 {
+		return ""
+	}
+
+	name, err := k8sItem.Name(document.Content[0])
+	if err != nil {
+		return ""
+	}
+
+	return name
+}
+
+// setDocumentName keeps the name of a Kubernetes resource in the input file,
+// so that differences refer to the resource instead of the document index
+func setDocumentName(inputFile *ytbx.InputFile, document streamDocument) {
+	if document.name == "" {
+		return
+	}
+
+	for len(inputFile.Names) <= document.idx {
+		inputFile.Names = append(inputFile.Names, "")
+	}
+
+	inputFile.Names[document.idx] = document.name
+}
+
+// pendingDocuments are the documents of one stream that have no match in the
+// other stream yet, Kubernetes resources are matched by name and all other
+// documents in the order they were read
+type pendingDocuments struct {
+	named     map[string][]streamDocument
+	unnamed   []streamDocument
+	documents []streamDocument
+}
+
+func newPendingDocuments() *pendingDocuments {
+	return &pendingDocuments{named: map[string][]streamDocument{}}
+}
+
+func (p *pendingDocuments) add(document streamDocument) {
+	if document.name != "" {
+		p.named[document.name] = append(p.named[document.name], document)
+	} else {
+		p.unnamed = append(p.unnamed, document)
+	}
+
+	p.documents = append(p.documents, document)
+}
+
+// take removes and returns the pending document matching the name, which is
+// the first pending document without name for an empty name
+func (p *pendingDocuments) take(name string) (streamDocument, bool) {
+	var document streamDocument
+	switch {
+	case name != "":
+		if len(p.named[name]) == 0 {
+			return streamDocument{}, false
+		}
+
+		document, p.named[name] = p.named[name][0], p.named[name][1:]
+
+	case len(p.unnamed) > 0:
+		document, p.unnamed = p.unnamed[0], p.unnamed[1:]
+
+	default:
+		return streamDocument{}, false
+	}
+
+	for i := range p.documents {
+		if p.documents[i].idx == document.idx {
+			p.documents = append(p.documents[:i], p.documents[i+1:]...)
+			break
+		}
+	}
+
+	return document, true
+}
+
+// remaining returns the documents without match in the order they were read
+func (p *pendingDocuments) remaining() []streamDocument {
+	return p.documents
+}
+
+// nextDocument decodes the next document, or returns nil if there are no more
+// documents. Empty documents are returned as well, so that the indexes of the
+// documents match the ones of the input files when they are loaded completely.
+func nextDocument(decoder *yamlv3.Decoder, location string, idx int) (*yamlv3.Node, error) {
+	var document yamlv3.Node
+	if err := decoder.Decode(&document); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to decode document #%d of %s: %w", idx+1, ytbx.HumanReadableLocation(location), err)
+	}
+
+	return &document, nil
+}
@@ -0,0 +1,463 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gonvenience/ytbx"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// DefaultListStreamWindow is the default number of list entries per input
+// that are kept in memory while looking for the matching entry of the other
+// input, see ListStreamWindow
+const DefaultListStreamWindow = 1000
+
+// ListStreamWindow sets the number of list entries per input that are kept in
+// memory while comparing list streams. An entry without a matching entry in
+// the other input within this window is reported as removed or added.
+func ListStreamWindow(size int) CompareOption {
+	return func(settings *compareSettings) {
+		settings.ListStreamWindow = size
+	}
+}
+
+// CompareListStreams compares two inputs that each consist of one document
+// with a list at the top level, for example a huge JSON array, where only one
+// list entry of each input is decoded at a time. Like with CompareStreams,
+// each difference is passed to the handler as soon as it is found.
+//
+// Entries with an identifier (e.g. name) are matched by it, where only a
+// limited number of entries is kept in memory while looking for the match,
+// see ListStreamWindow. Entries without an identifier are compared by their
+// index. Since the lists are never loaded completely, order changes are not
+// reported, and aliases can only refer to anchors of the same entry.
+func CompareListStreams(fromLocation string, fromReader io.Reader, toLocation string, toReader io.Reader, handler func(Diff) error, compareOptions ...CompareOption) error {
+	return CompareListStreamsContext(context.Background(), fromLocation, fromReader, toLocation, toReader, handler, compareOptions...)
+}
+
+// CompareListStreamsContext is like CompareListStreams, but stops the
+// comparison with the error of the context as soon as the context is done.
+func CompareListStreamsContext(ctx context.Context, fromLocation string, fromReader io.Reader, toLocation string, toReader io.Reader, handler func(Diff) error, compareOptions ...CompareOption) error {
+	var (
+		cmpr       = newCompare(ctx, compareOptions...)
+		from       = &ytbx.InputFile{Location: fromLocation}
+		fromList   = newListEntryReader(fromReader, fromLocation)
+		toList     = newListEntryReader(toReader, toLocation)
+		listStream = &listStream{compare: cmpr, path: ytbx.Path{Root: from}, handler: handler}
+	)
+
+	listStream.window = cmpr.settings.ListStreamWindow
+	if listStream.window <= 0 {
+		listStream.window = DefaultListStreamWindow
+	}
+
+	for idx := 0; ; idx++ {
+		fromEntry, err := fromList.next()
+		if err != nil {
+			return err
+		}
+
+		toEntry, err := toList.next()
+		if err != nil {
+			return err
+		}
+
+		if fromEntry == nil && toEntry == nil {
+			return listStream.flush()
+		}
+
+		if err := listStream.compareEntries(idx, fromEntry, toEntry); err != nil {
+			return err
+		}
+
+		if cmpr.settings.ProgressHandler != nil {
+			path := ytbx.NewPathWithIndexedListElement(listStream.path, idx)
+			cmpr.settings.ProgressHandler(Progress{Documents: idx + 1, Path: &path})
+		}
+
+		// Cached hashes are only useful for the entries just compared
+		cmpr.hashes.Clear()
+	}
+}
+
+// listStream keeps the state of the comparison of two list streams, which
+// are the entries that still wait for their match in the other list
+type listStream struct {
+	compare    *compare
+	path       ytbx.Path
+	handler    func(Diff) error
+	window     int
+	identifier listItemIdentifier
+	detected   bool
+
+	pendingFrom *pendingEntries
+	pendingTo   *pendingEntries
+}
+
+// compareEntries compares the entries with the same index of both lists, or
+// the entries with the same name if the lists have an identifier
+func (stream *listStream) compareEntries(idx int, fromEntry *yamlv3.Node, toEntry *yamlv3.Node) error {
+	if !stream.detected && fromEntry != nil && toEntry != nil {
+		stream.detected = true
+		stream.pendingFrom, stream.pendingTo = newPendingEntries(), newPendingEntries()
+
+		single := func(entry *yamlv3.Node) *yamlv3.Node {
+			return &yamlv3.Node{Kind: yamlv3.SequenceNode, Content: []*yamlv3.Node{entry}}
+		}
+
+		if identifier, err := stream.compare.getIdentifierFromNamedLists(single(fromEntry), single(toEntry)); err == nil {
+			stream.identifier = identifier
+		}
+	}
+
+	if stream.identifier == nil {
+		switch {
+		case toEntry == nil:
+			return stream.report(nil, []*yamlv3.Node{fromEntry})
+
+		case fromEntry == nil:
+			return stream.report([]*yamlv3.Node{toEntry}, nil)
+		}
+
+		diffs, err := stream.compare.objects(ytbx.NewPathWithIndexedListElement(stream.path, idx), fromEntry, toEntry)
+		if err != nil {
+			return err
+		}
+
+		return stream.handle(diffs)
+	}
+
+	if fromEntry != nil {
+		if err := stream.match(fromEntry, stream.pendingTo, stream.pendingFrom, true); err != nil {
+			return err
+		}
+	}
+
+	if toEntry != nil {
+		if err := stream.match(toEntry, stream.pendingFrom, stream.pendingTo, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// match compares the entry with the pending entry of the other list that has
+// the same name, or adds it to the pending entries of its own list
+func (stream *listStream) match(entry *yamlv3.Node, other *pendingEntries, own *pendingEntries, isFrom bool) error {
+	name, err := stream.identifier.Name(entry)
+	if err != nil {
+		return fmt.Errorf("failed to identify name: %w", err)
+	}
+
+	if match, ok := other.remove(name); ok {
+		fromEntry, toEntry := entry, match
+		if !isFrom {
+			fromEntry, toEntry = match, entry
+		}
+
+		diffs, err := stream.compare.objects(
+			ytbx.NewPathWithNamedListElement(stream.path, identifierKey(stream.identifier, fromEntry), name),
+			fromEntry,
+			toEntry,
+		)
+		if err != nil {
+			return err
+		}
+
+		return stream.handle(diffs)
+	}
+
+	// an entry with the same name of the same list cannot be matched anymore
+	var evicted []*yamlv3.Node
+	if previous, ok := own.remove(name); ok {
+		evicted = append(evicted, previous)
+	}
+
+	own.add(name, entry)
+	for own.len() > stream.window {
+		evicted = append(evicted, own.removeOldest())
+	}
+
+	if isFrom {
+		return stream.report(nil, evicted)
+	}
+
+	return stream.report(evicted, nil)
+}
+
+// flush reports all pending entries as removed or added
+func (stream *listStream) flush() error {
+	if stream.identifier == nil {
+		return nil
+	}
+
+	var removals, additions []*yamlv3.Node
+	for stream.pendingFrom.len() > 0 {
+		removals = append(removals, stream.pendingFrom.removeOldest())
+	}
+
+	for stream.pendingTo.len() > 0 {
+		additions = append(additions, stream.pendingTo.removeOldest())
+	}
+
+	return stream.report(additions, removals)
+}
+
+func (stream *listStream) report(additions []*yamlv3.Node, removals []*yamlv3.Node) error {
+	if len(additions) == 0 && len(removals) == 0 {
+		return nil
+	}
+
+	diffs, err := packChangesAndAddToResult(nil, stream.path, nil, additions, removals)
+	if err != nil {
+		return err
+	}
+
+	return stream.handle(diffs)
+}
+
+func (stream *listStream) handle(diffs []Diff) error {
+	for _, diff := range stream.compare.differences(diffs) {
+		if err := stream.handler(diff); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pendingEntries are list entries by name in the order they were read
+type pendingEntries struct {
+	order  *list.List
+	byName map[string]*list.Element
+}
+
+type pendingEntry struct {
+	name  string
+	entry *yamlv3.Node
+}
+
+func newPendingEntries() *pendingEntries {
+	return &pendingEntries{order: list.New(), byName: map[string]*list.Element{}}
+}
+
+func (p *pendingEntries) len() int {
+	return p.order.Len()
+}
+
+func (p *pendingEntries) add(name string, entry *yamlv3.Node) {
+	p.byName[name] = p.order.PushBack(pendingEntry{name: name, entry: entry})
+}
+
+func (p *pendingEntries) remove(name string) (*yamlv3.Node, bool) {
+	element, ok := p.byName[name]
+	if !ok {
+		return nil, false
+	}
+
+	delete(p.byName, name)
+	return p.order.Remove(element).(pendingEntry).entry, true
+}
+
+func (p *pendingEntries) removeOldest() *yamlv3.Node {
+	pending := p.order.Remove(p.order.Front()).(pendingEntry)
+	delete(p.byName, pending.name)
+	return pending.entry
+}
+
+// listEntryReader reads the entries of a list at the top level of the input
+// one at a time, which is either a JSON array or a YAML block sequence
+type listEntryReader struct {
+	location string
+	reader   *bufio.Reader
+	started  bool
+	done     bool
+	idx      int
+
+	// json is used for JSON arrays, and nil for YAML
+	json *json.Decoder
+
+	// indent is the indentation of the entries of a YAML block sequence, and
+	// line is the first line of the next entry
+	indent string
+	line   string
+}
+
+func newListEntryReader(reader io.Reader, location string) *listEntryReader {
+	return &listEntryReader{location: location, reader: bufio.NewReader(reader)}
+}
+
+// next returns the next list entry, or nil if there are no more entries
+func (r *listEntryReader) next() (*yamlv3.Node, error) {
+	if r.done {
+		return nil, nil
+	}
+
+	if !r.started {
+		r.started = true
+		if err := r.start(); err != nil {
+			return nil, err
+		}
+
+		if r.done {
+			return nil, nil
+		}
+	}
+
+	var data []byte
+	var err error
+	if r.json != nil {
+		data, err = r.nextJSON()
+	} else {
+		data, err = r.nextYAML()
+	}
+
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	r.idx++
+
+	var document yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &document); err != nil {
+		return nil, r.errorf("failed to decode list entry #%d: %w", r.idx, err)
+	}
+
+	switch {
+	case r.json != nil && len(document.Content) == 1:
+		return document.Content[0], nil
+
+	case r.json == nil && len(document.Content) == 1 && document.Content[0].Kind == yamlv3.SequenceNode && len(document.Content[0].Content) == 1:
+		return document.Content[0].Content[0], nil
+	}
+
+	return nil, r.errorf("failed to decode list entry #%d", r.idx)
+}
+
+// start skips everything before the list, and decides whether it is a JSON
+// array or a YAML block sequence
+func (r *listEntryReader) start() error {
+	for {
+		line, err := r.reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return r.errorf("failed to read: %w", err)
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" && errors.Is(err, io.EOF):
+			r.done = true
+			return nil
+
+		case trimmed == "", strings.HasPrefix(trimmed, "#"), strings.HasPrefix(trimmed, "%"), trimmed == "---":
+			continue
+
+		case strings.HasPrefix(trimmed, "["):
+			// the JSON decoder continues with the rest of the first line
+			rest := strings.TrimLeft(line, " \t")
+			r.json = json.NewDecoder(io.MultiReader(strings.NewReader(rest), r.reader))
+			if _, err := r.json.Token(); err != nil {
+				return r.errorf("failed to read JSON array: %w", err)
+			}
+
+			return nil
+
+		case isBlockSequenceEntry(trimmed):
+			r.indent = line[:len(line)-len(strings.TrimLeft(line, " "))]
+			r.line = line
+			return nil
+		}
+
+		return r.errorf("input does not have a list at the top level")
+	}
+}
+
+func (r *listEntryReader) nextJSON() ([]byte, error) {
+	if !r.json.More() {
+		r.done = true
+		if _, err := r.json.Token(); err != nil {
+			return nil, r.errorf("failed to read JSON array: %w", err)
+		}
+
+		return nil, nil
+	}
+
+	var entry json.RawMessage
+	if err := r.json.Decode(&entry); err != nil {
+		return nil, r.errorf("failed to decode list entry #%d: %w", r.idx+1, err)
+	}
+
+	return entry, nil
+}
+
+// nextYAML returns the lines of the next entry, which ends with the start of
+// the next entry with the same indentation, or the end of the document
+func (r *listEntryReader) nextYAML() ([]byte, error) {
+	if r.line == "" {
+		r.done = true
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(strings.TrimPrefix(r.line, r.indent))
+	r.line = ""
+
+	for {
+		line, err := r.reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, r.errorf("failed to read: %w", err)
+		}
+
+		switch trimmed := strings.TrimRight(line, "\r\n"); {
+		case trimmed == "---" || trimmed == "...":
+			return nil, r.errorf("stream of list entries only supports input with one document")
+
+		case strings.HasPrefix(trimmed, r.indent) && isBlockSequenceEntry(trimmed[len(r.indent):]):
+			r.line = line
+			return buf.Bytes(), nil
+		}
+
+		buf.WriteString(strings.TrimPrefix(line, r.indent))
+		if errors.Is(err, io.EOF) {
+			return buf.Bytes(), nil
+		}
+	}
+}
+
+func (r *listEntryReader) errorf(format string, a ...any) error {
+	return fmt.Errorf("%s: %w", ytbx.HumanReadableLocation(r.location), fmt.Errorf(format, a...))
+}
+
+// isBlockSequenceEntry returns whether the line starts a YAML list entry
+func isBlockSequenceEntry(line string) bool {
+	return line == "-" || strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "-\t") || strings.HasPrefix(line, "-\n") || strings.HasPrefix(line, "-\r")
+}
@@ -0,0 +1,219 @@
+// Copyright © 2026 The Homeport Team
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dyff_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/homeport/dyff/pkg/dyff"
+)
+
+var _ = Describe("Compare streams", func() {
+	compareStreams := func(from string, to string, compareOptions ...dyff.CompareOption) ([]dyff.Diff, error) {
+		var diffs []dyff.Diff
+		err := dyff.CompareStreams("from", strings.NewReader(from), "to", strings.NewReader(to), func(diff dyff.Diff) error {
+			diffs = append(diffs, diff)
+			return nil
+		}, compareOptions...)
+
+		return diffs, err
+	}
+
+	It("should compare the documents by their index", func() {
+		diffs, err := compareStreams("---\na: 1\n---\nb: foo\n", "---\na: 2\n---\nb: foo\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diffs).To(HaveLen(1))
+		Expect(diffs[0].Path.DocumentIdx).To(Equal(0))
+		Expect(diffs[0].Details).To(HaveLen(1))
+		Expect(diffs[0].Details[0].Kind).To(Equal(dyff.MODIFICATION))
+	})
+
+	It("should report documents that only exist in one of the inputs", func() {
+		diffs, err := compareStreams("---\na: 1\n---\nb: 2\n---\nc: 3\n", "---\na: 1\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diffs).To(HaveLen(2))
+		Expect(diffs[0].Path.DocumentIdx).To(Equal(1))
+		Expect(diffs[0].Details[0].Kind).To(Equal(dyff.REMOVAL))
+		Expect(diffs[1].Path.DocumentIdx).To(Equal(2))
+		Expect(diffs[1].Details[0].Kind).To(Equal(dyff.REMOVAL))
+
+		diffs, err = compareStreams("---\na: 1\n", "---\na: 1\n---\nb: 2\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diffs).To(HaveLen(1))
+		Expect(diffs[0].Details[0].Kind).To(Equal(dyff.ADDITION))
+	})
+
+	It("should keep the index of documents after empty documents", func() {
+		diffs, err := compareStreams("---\na: 1\n---\n---\nc: 1\n", "---\na: 1\n---\nb: 1\n---\nc: 2\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diffs).To(HaveLen(2))
+		Expect(diffs[0].Path.DocumentIdx).To(Equal(1))
+		Expect(diffs[1].Path.DocumentIdx).To(Equal(2))
+		Expect(diffs[1].Path.String()).To(Equal("/c"))
+	})
+
+	It("should match Kubernetes resources by their identity instead of their index", func() {
+		diffs, err := compareStreams(`---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: one
+data:
+  key: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: two
+data:
+  key: b
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: gone
+`, `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: two
+data:
+  key: b
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: new
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: one
+data:
+  key: c
+`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diffs).To(HaveLen(3))
+
+		Expect(diffs[0].Path.String()).To(Equal("/data/key"))
+		Expect(diffs[0].Path.DocumentIdx).To(Equal(0))
+		Expect(diffs[0].Path.RootDescription()).To(Equal("v1/ConfigMap/one"))
+		Expect(diffs[0].Details[0].Kind).To(Equal(dyff.MODIFICATION))
+
+		Expect(diffs[1].Path.RootDescription()).To(Equal("v1/ConfigMap/gone"))
+		Expect(diffs[1].Details[0].Kind).To(Equal(dyff.REMOVAL))
+
+		Expect(diffs[2].Path.RootDescription()).To(Equal("v1/ConfigMap/new"))
+		Expect(diffs[2].Details[0].Kind).To(Equal(dyff.ADDITION))
+	})
+
+	It("should stop as soon as the handler returns an error", func() {
+		var calls int
+		err := dyff.CompareStreams("from", strings.NewReader("---\na: 1\n---\nb: 1\n"), "to", strings.NewReader("---\na: 2\n---\nb: 2\n"), func(_ dyff.Diff) error {
+			calls++
+			return fmt.Errorf("stop")
+		})
+
+		Expect(err).To(MatchError("stop"))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("should fail when a document cannot be decoded", func() {
+		_, err := compareStreams("---\na: 1\n---\nb: [\n", "---\na: 1\n---\nb: 2\n")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("failed to decode document #2 of from"))
+	})
+})
+
+var _ = Describe("Compare list streams", func() {
+	compareListStreams := func(from string, to string, compareOptions ...dyff.CompareOption) ([]dyff.Diff, error) {
+		var diffs []dyff.Diff
+		err := dyff.CompareListStreams("from", strings.NewReader(from), "to", strings.NewReader(to), func(diff dyff.Diff) error {
+			diffs = append(diffs, diff)
+			return nil
+		}, compareOptions...)
+
+		return diffs, err
+	}
+
+	It("should compare the entries of YAML lists by their name", func() {
+		diffs, err := compareListStreams(`# comment
+- name: a
+  value: 1
+- name: b
+  value: 1
+- name: c
+`, `---
+- name: b
+  value: 2
+- name: a
+  value: 1
+- name: d
+`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diffs).To(HaveLen(2))
+		Expect(diffs[0].Path.String()).To(Equal("/name=b/value"))
+		Expect(diffs[0].Details[0].Kind).To(Equal(dyff.MODIFICATION))
+
+		Expect(diffs[1].Path.String()).To(Equal("/"))
+		Expect(diffs[1].Details).To(HaveLen(2))
+		Expect(diffs[1].Details[0].Kind).To(Equal(dyff.REMOVAL))
+		Expect(diffs[1].Details[0].From.Content[0].Content[1].Value).To(Equal("c"))
+		Expect(diffs[1].Details[1].Kind).To(Equal(dyff.ADDITION))
+		Expect(diffs[1].Details[1].To.Content[0].Content[1].Value).To(Equal("d"))
+	})
+
+	It("should compare the entries of JSON arrays by their index", func() {
+		diffs, err := compareListStreams(`[1, {"a": "x"}, 3]`, "[\n  1,\n  {\"a\": \"y\"}\n]\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diffs).To(HaveLen(2))
+		Expect(diffs[0].Path.String()).To(Equal("/1/a"))
+		Expect(diffs[0].Details[0].Kind).To(Equal(dyff.MODIFICATION))
+		Expect(diffs[1].Details[0].Kind).To(Equal(dyff.REMOVAL))
+	})
+
+	It("should only look for matching entries within the window", func() {
+		from := "- name: a\n- name: b\n- name: c\n"
+		to := "- name: c\n- name: a\n- name: b\n"
+
+		diffs, err := compareListStreams(from, to)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diffs).To(BeEmpty())
+
+		diffs, err = compareListStreams(from, to, dyff.ListStreamWindow(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diffs).ToNot(BeEmpty())
+	})
+
+	It("should fail when the input does not have a list at the top level", func() {
+		_, err := compareListStreams("a: 1\n", "- a\n")
+		Expect(err).To(MatchError("from: input does not have a list at the top level"))
+	})
+
+	It("should fail when the input has more than one document", func() {
+		_, err := compareListStreams("- a\n---\n- b\n", "- a\n")
+		Expect(err).To(MatchError("from: stream of list entries only supports input with one document"))
+	})
+})